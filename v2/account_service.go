@@ -3,6 +3,8 @@ package binance
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"math/big"
 )
 
 // GetAccountService get account info
@@ -42,6 +44,16 @@ type Account struct {
 	AccountType      string    `json:"accountType"`
 	Balances         []Balance `json:"balances"`
 	Permissions      []string  `json:"permissions"`
+
+	CommissionRates CommissionRates `json:"commissionRates"`
+}
+
+// CommissionRates define the maker, taker, buyer and seller commission rates, as decimal strings
+type CommissionRates struct {
+	Maker  string `json:"maker"`
+	Taker  string `json:"taker"`
+	Buyer  string `json:"buyer"`
+	Seller string `json:"seller"`
 }
 
 // Balance define user balance of your account
@@ -173,3 +185,169 @@ type SnapshotPositions struct {
 	Symbol           string `json:"symbol"`
 	UnRealizedProfit string `json:"unRealizedProfit"`
 }
+
+// GetAccountCommissionService get the commission rates of the account on a symbol
+type GetAccountCommissionService struct {
+	c      *Client
+	symbol string
+}
+
+// Symbol set symbol
+func (s *GetAccountCommissionService) Symbol(symbol string) *GetAccountCommissionService {
+	s.symbol = symbol
+	return s
+}
+
+// Do send request
+func (s *GetAccountCommissionService) Do(ctx context.Context, opts ...RequestOption) (res *AccountCommission, err error) {
+	r := &request{
+		method:   "GET",
+		endpoint: "/api/v3/account/commission",
+		secType:  secTypeSigned,
+	}
+	r.setParam("symbol", s.symbol)
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(AccountCommission)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// AccountCommission define the commission rates of the account on a symbol
+type AccountCommission struct {
+	Symbol             string             `json:"symbol"`
+	StandardCommission CommissionRates    `json:"standardCommission"`
+	TaxCommission      CommissionRates    `json:"taxCommission"`
+	Discount           CommissionDiscount `json:"discount"`
+}
+
+// CommissionDiscount define the discount of the standard commission when it is paid with DiscountAsset
+type CommissionDiscount struct {
+	EnabledForAccount bool   `json:"enabledForAccount"`
+	EnabledForSymbol  bool   `json:"enabledForSymbol"`
+	DiscountAsset     string `json:"discountAsset"`
+	Discount          string `json:"discount"`
+}
+
+// EffectiveRates return the commission rates paid on the symbol, the sum of the standard and tax
+// commissions. When payWithDiscountAsset is set and the discount is enabled for the account and the
+// symbol, the standard commission is multiplied by the discount, e.g. 0.75 when paying with BNB.
+func (a *AccountCommission) EffectiveRates(payWithDiscountAsset bool) (*CommissionRates, error) {
+	discount := big.NewRat(1, 1)
+	if payWithDiscountAsset && a.Discount.EnabledForAccount && a.Discount.EnabledForSymbol {
+		if _, ok := discount.SetString(a.Discount.Discount); !ok {
+			return nil, fmt.Errorf("invalid commission discount %q", a.Discount.Discount)
+		}
+	}
+	rate := func(standard, tax string) (string, error) {
+		res := new(big.Rat)
+		for i, v := range []string{standard, tax} {
+			if v == "" {
+				continue
+			}
+			r, ok := new(big.Rat).SetString(v)
+			if !ok {
+				return "", fmt.Errorf("invalid commission rate %q", v)
+			}
+			if i == 0 {
+				r.Mul(r, discount)
+			}
+			res.Add(res, r)
+		}
+		return res.FloatString(8), nil
+	}
+	res := new(CommissionRates)
+	var err error
+	if res.Maker, err = rate(a.StandardCommission.Maker, a.TaxCommission.Maker); err != nil {
+		return nil, err
+	}
+	if res.Taker, err = rate(a.StandardCommission.Taker, a.TaxCommission.Taker); err != nil {
+		return nil, err
+	}
+	if res.Buyer, err = rate(a.StandardCommission.Buyer, a.TaxCommission.Buyer); err != nil {
+		return nil, err
+	}
+	if res.Seller, err = rate(a.StandardCommission.Seller, a.TaxCommission.Seller); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ListTradeFeeService list the trade fees of the account
+type ListTradeFeeService struct {
+	c      *Client
+	symbol *string
+}
+
+// Symbol set symbol
+func (s *ListTradeFeeService) Symbol(symbol string) *ListTradeFeeService {
+	s.symbol = &symbol
+	return s
+}
+
+// Do send request
+func (s *ListTradeFeeService) Do(ctx context.Context, opts ...RequestOption) (res []*TradeFee, err error) {
+	r := &request{
+		method:   "GET",
+		endpoint: "/sapi/v1/asset/tradeFee",
+		secType:  secTypeSigned,
+	}
+	if s.symbol != nil {
+		r.setParam("symbol", *s.symbol)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*TradeFee{}, err
+	}
+	res = make([]*TradeFee, 0)
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return []*TradeFee{}, err
+	}
+	return res, nil
+}
+
+// TradeFee define the trade fee of a symbol
+type TradeFee struct {
+	Symbol          string `json:"symbol"`
+	MakerCommission string `json:"makerCommission"`
+	TakerCommission string `json:"takerCommission"`
+}
+
+// GetOrderRateLimitService get the current order count usage of the account for all intervals
+type GetOrderRateLimitService struct {
+	c *Client
+}
+
+// Do send request
+func (s *GetOrderRateLimitService) Do(ctx context.Context, opts ...RequestOption) (res []*OrderRateLimit, err error) {
+	r := &request{
+		method:   "GET",
+		endpoint: "/api/v3/rateLimit/order",
+		secType:  secTypeSigned,
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*OrderRateLimit{}, err
+	}
+	res = make([]*OrderRateLimit, 0)
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return []*OrderRateLimit{}, err
+	}
+	return res, nil
+}
+
+// OrderRateLimit define an order rate limit and its current usage
+type OrderRateLimit struct {
+	RateLimitType string `json:"rateLimitType"`
+	Interval      string `json:"interval"`
+	IntervalNum   int64  `json:"intervalNum"`
+	Limit         int64  `json:"limit"`
+	Count         int64  `json:"count"`
+}
@@ -39,7 +39,13 @@ func (s *accountServiceTestSuite) TestGetAccount() {
 			],
 			"permissions": [
 				"SPOT"
-			]
+			],
+			"commissionRates": {
+				"maker": "0.00150000",
+				"taker": "0.00150000",
+				"buyer": "0.00000000",
+				"seller": "0.00000000"
+			}
   }`)
 	s.mockDo(data, nil)
 	defer s.assertDo()
@@ -73,6 +79,12 @@ func (s *accountServiceTestSuite) TestGetAccount() {
 			},
 		},
 		Permissions: []string{"SPOT"},
+		CommissionRates: CommissionRates{
+			Maker:  "0.00150000",
+			Taker:  "0.00150000",
+			Buyer:  "0.00000000",
+			Seller: "0.00000000",
+		},
 	}
 	s.assertAccountEqual(e, res)
 }
@@ -86,6 +98,7 @@ func (s *accountServiceTestSuite) assertAccountEqual(e, a *Account) {
 	r.Equal(e.CanTrade, a.CanTrade, "CanTrade")
 	r.Equal(e.CanWithdraw, a.CanWithdraw, "CanWithdraw")
 	r.Equal(e.CanDeposit, a.CanDeposit, "CanDeposit")
+	r.Equal(e.CommissionRates, a.CommissionRates, "CommissionRates")
 	r.Len(a.Balances, len(e.Balances))
 	for i := 0; i < len(a.Balances); i++ {
 		r.Equal(e.Balances[i].Asset, a.Balances[i].Asset, "Asset")
@@ -174,3 +187,123 @@ func (s *accountServiceTestSuite) assertSnapshotAccountEqual(e, a *Snapshot) {
 		}
 	}
 }
+
+func (s *accountServiceTestSuite) TestGetAccountCommission() {
+	data := []byte(`{
+		"symbol": "BTCUSDT",
+		"standardCommission": {
+			"maker": "0.00100000",
+			"taker": "0.00100000",
+			"buyer": "0.00000000",
+			"seller": "0.00000000"
+		},
+		"taxCommission": {
+			"maker": "0.00000000",
+			"taker": "0.00010000",
+			"buyer": "0.00000000",
+			"seller": "0.00000000"
+		},
+		"discount": {
+			"enabledForAccount": true,
+			"enabledForSymbol": true,
+			"discountAsset": "BNB",
+			"discount": "0.75000000"
+		}
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setParam("symbol", "BTCUSDT")
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewGetAccountCommissionService().Symbol("BTCUSDT").Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal(&AccountCommission{
+		Symbol: "BTCUSDT",
+		StandardCommission: CommissionRates{
+			Maker: "0.00100000", Taker: "0.00100000", Buyer: "0.00000000", Seller: "0.00000000",
+		},
+		TaxCommission: CommissionRates{
+			Maker: "0.00000000", Taker: "0.00010000", Buyer: "0.00000000", Seller: "0.00000000",
+		},
+		Discount: CommissionDiscount{
+			EnabledForAccount: true,
+			EnabledForSymbol:  true,
+			DiscountAsset:     "BNB",
+			Discount:          "0.75000000",
+		},
+	}, res)
+
+	rates, err := res.EffectiveRates(false)
+	r.NoError(err)
+	r.Equal(&CommissionRates{Maker: "0.00100000", Taker: "0.00110000", Buyer: "0.00000000", Seller: "0.00000000"}, rates)
+
+	rates, err = res.EffectiveRates(true)
+	r.NoError(err)
+	r.Equal(&CommissionRates{Maker: "0.00075000", Taker: "0.00085000", Buyer: "0.00000000", Seller: "0.00000000"}, rates)
+
+	res.Discount.EnabledForSymbol = false
+	rates, err = res.EffectiveRates(true)
+	r.NoError(err)
+	r.Equal("0.00100000", rates.Maker)
+
+	res.StandardCommission.Maker = "abc"
+	_, err = res.EffectiveRates(true)
+	r.Error(err)
+}
+
+func (s *accountServiceTestSuite) TestListTradeFee() {
+	data := []byte(`[
+		{
+			"symbol": "ADABNB",
+			"makerCommission": "0.001",
+			"takerCommission": "0.001"
+		}
+	]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setParam("symbol", "ADABNB")
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewListTradeFeeService().Symbol("ADABNB").Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal([]*TradeFee{{Symbol: "ADABNB", MakerCommission: "0.001", TakerCommission: "0.001"}}, res)
+}
+
+func (s *accountServiceTestSuite) TestGetOrderRateLimit() {
+	data := []byte(`[
+		{
+			"rateLimitType": "ORDERS",
+			"interval": "SECOND",
+			"intervalNum": 10,
+			"limit": 10000,
+			"count": 0
+		},
+		{
+			"rateLimitType": "ORDERS",
+			"interval": "DAY",
+			"intervalNum": 1,
+			"limit": 20000,
+			"count": 120
+		}
+	]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest()
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewGetOrderRateLimitService().Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal([]*OrderRateLimit{
+		{RateLimitType: "ORDERS", Interval: "SECOND", IntervalNum: 10, Limit: 10000, Count: 0},
+		{RateLimitType: "ORDERS", Interval: "DAY", IntervalNum: 1, Limit: 20000, Count: 120},
+	}, res)
+}
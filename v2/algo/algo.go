@@ -0,0 +1,728 @@
+// Package algo work large parent orders as a schedule of smaller child orders:
+// TWAP, VWAP and iceberg slicing over spot, margin or futures order services.
+package algo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/Zamzam-Technology/go-binance/v2/common"
+)
+
+// Side define the side of a parent order
+type Side string
+
+// Strategy define how a parent order is sliced
+type Strategy string
+
+// Status define the status of an execution
+type Status string
+
+// Global enums
+const (
+	SideBuy  Side = "BUY"
+	SideSell Side = "SELL"
+
+	// StrategyTWAP send slices of equal size evenly spread over the time window
+	StrategyTWAP Strategy = "TWAP"
+	// StrategyVWAP send slices sized by the volume profile of the time window
+	StrategyVWAP Strategy = "VWAP"
+	// StrategyIceberg keep one child order of the display quantity working until done
+	StrategyIceberg Strategy = "ICEBERG"
+
+	StatusPending   Status = "PENDING"
+	StatusRunning   Status = "RUNNING"
+	StatusPaused    Status = "PAUSED"
+	StatusCompleted Status = "COMPLETED"
+	StatusCanceled  Status = "CANCELED"
+	StatusExpired   Status = "EXPIRED"
+	StatusFailed    Status = "FAILED"
+)
+
+// Error codes of the exchange
+const (
+	errCodeUnknown         = -1006
+	errCodeTimeout         = -1007
+	errCodeTooManyRequests = -1003
+	errCodeTooManyOrders   = -1015
+	errCodeNoSuchOrder     = -2013
+)
+
+// ErrInvalidParentOrder is returned by New for an incomplete or inconsistent parent order
+var ErrInvalidParentOrder = errors.New("invalid parent order")
+
+// ParentOrder define the order to work
+type ParentOrder struct {
+	Symbol    string
+	Side      Side
+	Strategy  Strategy
+	Quantity  string
+	StartTime time.Time
+	EndTime   time.Time
+	// LimitPrice is the price of the child limit orders, market orders are sent when empty
+	LimitPrice string
+	// ParticipationRate cap the executed quantity to a fraction of the market volume
+	// reported by OnMarketTrade since the start, zero disables the cap
+	ParticipationRate float64
+	// Slices is the number of slices of TWAP and VWAP, 10 by default
+	Slices int
+	// VolumeProfile is the relative volume of each slice of a VWAP, e.g. from the klines of the previous days
+	VolumeProfile []float64
+	// DisplayQuantity is the quantity of each child order of an iceberg
+	DisplayQuantity string
+}
+
+// ChildOrder define an order sent for a parent order
+type ChildOrder struct {
+	ClientOrderID string
+	Symbol        string
+	Side          Side
+	Quantity      string
+	// Price is empty for market orders
+	Price            string
+	ExecutedQuantity string
+	SentAt           time.Time
+	// Done is set once the order is filled, canceled, expired or rejected
+	Done bool
+}
+
+// Fill define an update of a child order from the user data stream, see SpotFill and FuturesFill
+type Fill struct {
+	ClientOrderID   string
+	TradeID         int64
+	Quantity        string
+	Price           string
+	Commission      string
+	CommissionAsset string
+	// Final is set when the order is done
+	Final bool
+}
+
+// Report define the state of an execution
+type Report struct {
+	Parent           ParentOrder
+	Status           Status
+	ExecutedQuantity string
+	AveragePrice     string
+	Commissions      map[string]string
+	Children         []ChildOrder
+	Errors           []error
+	StartedAt        time.Time
+	FinishedAt       time.Time
+}
+
+// Config define the execution settings
+type Config struct {
+	// Tick is the period of the scheduler, 1 second by default
+	Tick time.Duration
+	// MinInterval is the minimum time between two requests to the exchange
+	MinInterval time.Duration
+	// Backoff is the pause after a rate limit error, 10 seconds by default
+	Backoff time.Duration
+	// MaxErrors stops the execution after as many consecutive request errors, 5 by default
+	MaxErrors int
+	// ClientOrderIDPrefix prefix the client order ids of the child orders, derived from the time by default
+	ClientOrderIDPrefix string
+}
+
+// Venue place, query and cancel the child orders, see NewSpotVenue, NewMarginVenue and NewFuturesVenue
+type Venue interface {
+	OrderFilters(ctx context.Context, symbol string) (*common.OrderFilters, error)
+	PlaceOrder(ctx context.Context, order *ChildOrder) error
+	// GetOrder return true when the order is done, the exchange error -2013 when it does not exist
+	GetOrder(ctx context.Context, symbol, clientOrderID string) (done bool, err error)
+	CancelOrder(ctx context.Context, symbol, clientOrderID string) error
+}
+
+type childState struct {
+	order    ChildOrder
+	executed *big.Rat
+	slice    int
+	cancel   bool
+	// unknown is set when the place request failed without telling if the order was placed
+	unknown bool
+	trades  map[int64]bool
+}
+
+// Execution work a parent order. Fills must be fed with OnFill from the user data stream,
+// a child order is considered working until its final update.
+type Execution struct {
+	venue  Venue
+	parent ParentOrder
+	config Config
+	now    func() time.Time
+
+	// stepMu serialize the steps, mu is released while their requests are sent
+	stepMu       sync.Mutex
+	mu           sync.Mutex
+	filters      *common.OrderFilters
+	status       Status
+	total        *big.Rat
+	limitPrice   *big.Rat
+	display      *big.Rat
+	weights      []*big.Rat
+	executed     *big.Rat
+	quote        *big.Rat
+	volume       *big.Rat
+	lastPrice    string
+	commissions  map[string]*big.Rat
+	children     []*childState
+	byID         map[string]*childState
+	slice        int
+	seq          int
+	errs         []error
+	errCount     int
+	lastRequest  time.Time
+	blockedUntil time.Time
+	startedAt    time.Time
+	finishedAt   time.Time
+	done         chan struct{}
+}
+
+// New init an execution of parent on venue, config may be nil
+func New(venue Venue, parent ParentOrder, config *Config) (*Execution, error) {
+	e := &Execution{
+		venue:       venue,
+		parent:      parent,
+		now:         time.Now,
+		status:      StatusPending,
+		executed:    new(big.Rat),
+		quote:       new(big.Rat),
+		volume:      new(big.Rat),
+		commissions: make(map[string]*big.Rat),
+		byID:        make(map[string]*childState),
+		slice:       -1,
+		done:        make(chan struct{}),
+	}
+	if config != nil {
+		e.config = *config
+	}
+	if e.config.Tick <= 0 {
+		e.config.Tick = time.Second
+	}
+	if e.config.Backoff <= 0 {
+		e.config.Backoff = 10 * time.Second
+	}
+	if e.config.MaxErrors <= 0 {
+		e.config.MaxErrors = 5
+	}
+	if e.config.ClientOrderIDPrefix == "" {
+		e.config.ClientOrderIDPrefix = fmt.Sprintf("algo%x", time.Now().UnixNano()/int64(time.Millisecond))
+	}
+	if err := e.init(); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func (e *Execution) init() error {
+	p := &e.parent
+	if p.Symbol == "" || (p.Side != SideBuy && p.Side != SideSell) || !p.EndTime.After(p.StartTime) {
+		return fmt.Errorf("%w: symbol, side and time window are required", ErrInvalidParentOrder)
+	}
+	if e.total = common.PositiveDecimal(p.Quantity); e.total == nil {
+		return fmt.Errorf("%w: quantity %q", ErrInvalidParentOrder, p.Quantity)
+	}
+	if p.LimitPrice != "" {
+		if e.limitPrice = common.PositiveDecimal(p.LimitPrice); e.limitPrice == nil {
+			return fmt.Errorf("%w: limit price %q", ErrInvalidParentOrder, p.LimitPrice)
+		}
+	}
+	if p.ParticipationRate < 0 || p.ParticipationRate > 1 {
+		return fmt.Errorf("%w: participation rate %v", ErrInvalidParentOrder, p.ParticipationRate)
+	}
+	switch p.Strategy {
+	case StrategyTWAP:
+		if p.Slices <= 0 {
+			p.Slices = 10
+		}
+		e.weights = cumulativeWeights(make([]float64, p.Slices))
+	case StrategyVWAP:
+		if len(p.VolumeProfile) == 0 {
+			return fmt.Errorf("%w: a VWAP needs a volume profile", ErrInvalidParentOrder)
+		}
+		p.Slices = len(p.VolumeProfile)
+		for _, v := range p.VolumeProfile {
+			if v < 0 {
+				return fmt.Errorf("%w: negative volume in profile", ErrInvalidParentOrder)
+			}
+		}
+		e.weights = cumulativeWeights(p.VolumeProfile)
+	case StrategyIceberg:
+		if e.display = common.PositiveDecimal(p.DisplayQuantity); e.display == nil {
+			return fmt.Errorf("%w: display quantity %q", ErrInvalidParentOrder, p.DisplayQuantity)
+		}
+	default:
+		return fmt.Errorf("%w: strategy %q", ErrInvalidParentOrder, p.Strategy)
+	}
+	return nil
+}
+
+// cumulativeWeights return the cumulative share of each slice, equal shares when the profile is all zeros
+func cumulativeWeights(profile []float64) []*big.Rat {
+	sum := new(big.Rat)
+	values := make([]*big.Rat, len(profile))
+	for i, v := range profile {
+		values[i] = new(big.Rat)
+		values[i].SetFloat64(v)
+		sum.Add(sum, values[i])
+	}
+	if sum.Sign() == 0 {
+		for i := range values {
+			values[i].SetInt64(1)
+		}
+		sum.SetInt64(int64(len(values)))
+	}
+	weights := make([]*big.Rat, len(values))
+	acc := new(big.Rat)
+	for i, v := range values {
+		acc.Add(acc, v)
+		weights[i] = new(big.Rat).Quo(acc, sum)
+	}
+	return weights
+}
+
+// Run load the symbol filters and work the parent order until it is completed, canceled or
+// expired. The open child orders are canceled when ctx is done.
+func (e *Execution) Run(ctx context.Context) (*Report, error) {
+	filters, err := e.venue.OrderFilters(ctx, e.parent.Symbol)
+	if err != nil {
+		return nil, err
+	}
+	e.start(filters)
+
+	ticker := time.NewTicker(e.config.Tick)
+	defer ticker.Stop()
+	for !e.step(ctx) {
+		select {
+		case <-ctx.Done():
+			e.Cancel()
+			// cancel the child orders with a fresh context, the run context is over
+			e.step(context.Background())
+			report := e.Report()
+			return report, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+	return e.Report(), nil
+}
+
+func (e *Execution) start(filters *common.OrderFilters) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.filters = filters
+	if e.status == StatusPending {
+		e.status = StatusRunning
+	}
+	e.startedAt = e.now()
+}
+
+// Pause stop sending child orders and cancel the working ones
+func (e *Execution) Pause() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.status == StatusRunning || e.status == StatusPending {
+		e.status = StatusPaused
+	}
+}
+
+// Resume resume a paused execution, the missed quantity is caught up by the next slices
+func (e *Execution) Resume() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.status == StatusPaused {
+		e.status = StatusRunning
+	}
+}
+
+// Cancel stop the execution and cancel the working child orders
+func (e *Execution) Cancel() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if !e.finished() {
+		e.status = StatusCanceled
+	}
+}
+
+// Done is closed when the execution is over
+func (e *Execution) Done() <-chan struct{} {
+	return e.done
+}
+
+// Status return the status of the execution
+func (e *Execution) Status() Status {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.status
+}
+
+// OnFill update the child orders with a user data stream update, updates of other orders are ignored
+func (e *Execution) OnFill(fill Fill) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	child, ok := e.byID[fill.ClientOrderID]
+	if !ok {
+		return
+	}
+	if quantity := common.PositiveDecimal(fill.Quantity); quantity != nil && !child.trades[fill.TradeID] {
+		child.trades[fill.TradeID] = true
+		child.executed.Add(child.executed, quantity)
+		child.order.ExecutedQuantity = common.FormatDecimal(child.executed)
+		e.executed.Add(e.executed, quantity)
+		if price := common.PositiveDecimal(fill.Price); price != nil {
+			e.quote.Add(e.quote, new(big.Rat).Mul(price, quantity))
+		}
+		if commission := common.PositiveDecimal(fill.Commission); commission != nil {
+			total, ok := e.commissions[fill.CommissionAsset]
+			if !ok {
+				total = new(big.Rat)
+				e.commissions[fill.CommissionAsset] = total
+			}
+			total.Add(total, commission)
+		}
+	}
+	if fill.Final {
+		child.order.Done = true
+	}
+}
+
+// OnMarketTrade report a trade of the symbol, used for the participation cap and as reference price
+func (e *Execution) OnMarketTrade(price, quantity string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if q := common.PositiveDecimal(quantity); q != nil && !e.startedAt.IsZero() {
+		e.volume.Add(e.volume, q)
+	}
+	if common.PositiveDecimal(price) != nil {
+		e.lastPrice = price
+	}
+}
+
+// Report return the current state of the execution
+func (e *Execution) Report() *Report {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	report := &Report{
+		Parent:           e.parent,
+		Status:           e.status,
+		ExecutedQuantity: common.FormatDecimal(e.executed),
+		Commissions:      make(map[string]string, len(e.commissions)),
+		Children:         make([]ChildOrder, len(e.children)),
+		Errors:           append([]error(nil), e.errs...),
+		StartedAt:        e.startedAt,
+		FinishedAt:       e.finishedAt,
+	}
+	if e.executed.Sign() > 0 && e.quote.Sign() > 0 {
+		report.AveragePrice = common.FormatDecimal(new(big.Rat).Quo(e.quote, e.executed))
+	}
+	for asset, commission := range e.commissions {
+		report.Commissions[asset] = common.FormatDecimal(commission)
+	}
+	for i, child := range e.children {
+		report.Children[i] = child.order
+	}
+	return report
+}
+
+func (e *Execution) finished() bool {
+	select {
+	case <-e.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// step run the scheduler once and return true when the execution is over.
+// The lock is released while the requests are sent.
+func (e *Execution) step(ctx context.Context) bool {
+	e.stepMu.Lock()
+	defer e.stepMu.Unlock()
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.finished() {
+		return true
+	}
+	now := e.now()
+	e.perform(ctx, now, e.reconcile(now))
+	if e.executed.Cmp(e.total) >= 0 {
+		e.status = StatusCompleted
+	} else if !now.Before(e.parent.EndTime) && (e.status == StatusRunning || e.status == StatusPaused) {
+		e.status = StatusExpired
+	}
+	switch e.status {
+	case StatusCompleted, StatusCanceled, StatusExpired, StatusFailed:
+		done, requests := e.cancelWorking(now, func(*childState) bool { return true })
+		if done {
+			e.finishedAt = now
+			close(e.done)
+			return true
+		}
+		e.perform(ctx, now, requests)
+		return false
+	case StatusPaused:
+		_, requests := e.cancelWorking(now, func(*childState) bool { return true })
+		e.perform(ctx, now, requests)
+		return false
+	}
+	if now.Before(e.parent.StartTime) || now.Before(e.blockedUntil) {
+		return false
+	}
+
+	if e.parent.Strategy == StrategyIceberg {
+		if e.working() {
+			return false
+		}
+		e.perform(ctx, now, e.send(now, common.MinRat(e.display, e.available())))
+		return false
+	}
+
+	slice := e.sliceAt(now)
+	if slice > e.slice {
+		e.slice = slice
+	}
+	// the working orders of the previous slices are canceled, their remaining quantity is caught up
+	_, requests := e.cancelWorking(now, func(child *childState) bool { return child.slice < e.slice })
+	e.perform(ctx, now, requests)
+	if e.working() {
+		return false
+	}
+	target := new(big.Rat).Mul(e.total, e.weights[e.slice])
+	e.perform(ctx, now, e.send(now, common.MinRat(target.Sub(target, e.executed), e.available())))
+	return false
+}
+
+func (e *Execution) sliceAt(now time.Time) int {
+	window := e.parent.EndTime.Sub(e.parent.StartTime)
+	slice := int(int64(now.Sub(e.parent.StartTime)) * int64(e.parent.Slices) / int64(window))
+	if slice >= e.parent.Slices {
+		slice = e.parent.Slices - 1
+	}
+	return slice
+}
+
+// available return the quantity which can be sent, within the total and the participation cap
+func (e *Execution) available() *big.Rat {
+	available := new(big.Rat).Sub(e.total, e.executed)
+	if e.parent.ParticipationRate > 0 {
+		rate := new(big.Rat)
+		rate.SetFloat64(e.parent.ParticipationRate)
+		allowed := rate.Mul(rate, e.volume)
+		available = common.MinRat(available, allowed.Sub(allowed, e.executed))
+	}
+	return available
+}
+
+func (e *Execution) working() bool {
+	for _, child := range e.children {
+		if !child.order.Done {
+			return true
+		}
+	}
+	return false
+}
+
+// request define a request of a step to the venue
+type request struct {
+	kind  requestKind
+	child *childState
+	// order is a copy of the child order taken under the lock
+	order ChildOrder
+	done  bool
+	err   error
+}
+
+type requestKind int
+
+const (
+	requestPlace requestKind = iota
+	requestQuery
+	requestCancel
+)
+
+// newRequest reserve a request to the venue, nil when the request rate does not allow it
+func (e *Execution) newRequest(now time.Time, kind requestKind, child *childState) *request {
+	if !e.allowRequest(now) {
+		return nil
+	}
+	e.lastRequest = now
+	return &request{kind: kind, child: child, order: child.order}
+}
+
+// perform release the lock to send the requests, then record their results
+func (e *Execution) perform(ctx context.Context, now time.Time, requests []*request) {
+	if len(requests) == 0 {
+		return
+	}
+	e.mu.Unlock()
+	for _, r := range requests {
+		switch r.kind {
+		case requestPlace:
+			r.err = e.venue.PlaceOrder(ctx, &r.order)
+		case requestQuery:
+			r.done, r.err = e.venue.GetOrder(ctx, r.order.Symbol, r.order.ClientOrderID)
+		case requestCancel:
+			r.err = e.venue.CancelOrder(ctx, r.order.Symbol, r.order.ClientOrderID)
+		}
+	}
+	e.mu.Lock()
+	for _, r := range requests {
+		e.record(now, r)
+	}
+}
+
+func (e *Execution) record(now time.Time, r *request) {
+	child := r.child
+	switch r.kind {
+	case requestPlace:
+		e.requestDone(now, r.err)
+		switch {
+		case r.err == nil:
+		case ambiguous(r.err):
+			child.unknown = true
+		default:
+			e.remove(child)
+		}
+	case requestQuery:
+		var apiErr *common.APIError
+		if errors.As(r.err, &apiErr) && apiErr.Code == errCodeNoSuchOrder {
+			// the place request did fail, the error is already counted
+			e.lastRequest = now
+			e.remove(child)
+			return
+		}
+		e.requestDone(now, r.err)
+		if r.err != nil {
+			return
+		}
+		child.unknown = false
+		if r.done {
+			child.order.Done = true
+		}
+	case requestCancel:
+		e.requestDone(now, r.err)
+		if r.err == nil {
+			child.cancel = true
+		}
+	}
+}
+
+// cancelWorking return the cancel requests of the matching working orders and true when none is left
+func (e *Execution) cancelWorking(now time.Time, match func(*childState) bool) (bool, []*request) {
+	done := true
+	var requests []*request
+	for _, child := range e.children {
+		if child.order.Done || !match(child) {
+			continue
+		}
+		done = false
+		if child.cancel || child.unknown {
+			continue
+		}
+		if r := e.newRequest(now, requestCancel, child); r != nil {
+			requests = append(requests, r)
+		}
+	}
+	return done, requests
+}
+
+// send return the place request of a child order of quantity rounded to the filters,
+// nothing is sent below the filters minimums
+func (e *Execution) send(now time.Time, quantity *big.Rat) []*request {
+	if quantity.Sign() <= 0 || !e.allowRequest(now) {
+		return nil
+	}
+	order := &ChildOrder{
+		ClientOrderID: fmt.Sprintf("%s-%d", e.config.ClientOrderIDPrefix, e.seq+1),
+		Symbol:        e.parent.Symbol,
+		Side:          e.parent.Side,
+		Quantity:      common.FormatDecimal(quantity),
+		Price:         e.parent.LimitPrice,
+	}
+	params := &common.OrderParams{
+		Buy:            e.parent.Side == SideBuy,
+		Market:         order.Price == "",
+		Quantity:       &order.Quantity,
+		ReferencePrice: e.lastPrice,
+	}
+	if order.Price != "" {
+		params.Price = &order.Price
+		params.ReferencePrice = order.Price
+	}
+	if e.filters.Normalize(params) != nil || e.filters.Validate(params) != nil || common.PositiveDecimal(order.Quantity) == nil {
+		// too small for the filters, the quantity is carried over
+		return nil
+	}
+	order.SentAt = now
+	e.seq++
+	// the child is registered first so that no fill is missed while the request is pending
+	child := &childState{order: *order, executed: new(big.Rat), slice: e.slice, trades: make(map[int64]bool)}
+	e.children = append(e.children, child)
+	e.byID[order.ClientOrderID] = child
+	return []*request{e.newRequest(now, requestPlace, child)}
+}
+
+// reconcile return the query requests of the child orders whose place request had an unknown result,
+// the orders which do not exist are removed
+func (e *Execution) reconcile(now time.Time) []*request {
+	var requests []*request
+	for _, child := range e.children {
+		if !child.unknown {
+			continue
+		}
+		if r := e.newRequest(now, requestQuery, child); r != nil {
+			requests = append(requests, r)
+		}
+	}
+	return requests
+}
+
+func (e *Execution) remove(child *childState) {
+	delete(e.byID, child.order.ClientOrderID)
+	for i, c := range e.children {
+		if c == child {
+			e.children = append(e.children[:i], e.children[i+1:]...)
+			return
+		}
+	}
+}
+
+// ambiguous return true when err does not tell if the request was executed:
+// network errors, timeouts and server errors
+func ambiguous(err error) bool {
+	var apiErr *common.APIError
+	if !errors.As(err, &apiErr) {
+		return true
+	}
+	return apiErr.Code == 0 || apiErr.Code == errCodeUnknown || apiErr.Code == errCodeTimeout
+}
+
+func (e *Execution) allowRequest(now time.Time) bool {
+	if now.Before(e.blockedUntil) {
+		return false
+	}
+	return e.config.MinInterval <= 0 || e.lastRequest.IsZero() || now.Sub(e.lastRequest) >= e.config.MinInterval
+}
+
+// requestDone record the result of a request, backing off on rate limit errors
+func (e *Execution) requestDone(now time.Time, err error) {
+	e.lastRequest = now
+	if err == nil {
+		e.errCount = 0
+		return
+	}
+	e.errs = append(e.errs, err)
+	var apiErr *common.APIError
+	if errors.As(err, &apiErr) && (apiErr.Code == errCodeTooManyRequests || apiErr.Code == errCodeTooManyOrders) {
+		e.blockedUntil = now.Add(e.config.Backoff)
+		return
+	}
+	e.errCount++
+	if e.errCount >= e.config.MaxErrors && e.status == StatusRunning {
+		e.status = StatusFailed
+	}
+}
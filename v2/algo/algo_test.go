@@ -0,0 +1,403 @@
+package algo
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	binance "github.com/Zamzam-Technology/go-binance/v2"
+	"github.com/Zamzam-Technology/go-binance/v2/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeVenue struct {
+	placed   []ChildOrder
+	canceled []string
+	placeErr error
+	// placeLost place the orders but return placeErr
+	placeLost bool
+	getErr    error
+	// onPlace is called while the order is placed
+	onPlace func(order *ChildOrder)
+}
+
+func (v *fakeVenue) OrderFilters(ctx context.Context, symbol string) (*common.OrderFilters, error) {
+	return testFilters(), nil
+}
+
+func (v *fakeVenue) PlaceOrder(ctx context.Context, order *ChildOrder) error {
+	if v.onPlace != nil {
+		v.onPlace(order)
+	}
+	if v.placeErr != nil && !v.placeLost {
+		return v.placeErr
+	}
+	v.placed = append(v.placed, *order)
+	return v.placeErr
+}
+
+func (v *fakeVenue) GetOrder(ctx context.Context, symbol, clientOrderID string) (bool, error) {
+	if v.getErr != nil {
+		return false, v.getErr
+	}
+	for _, order := range v.placed {
+		if order.ClientOrderID == clientOrderID {
+			return false, nil
+		}
+	}
+	return false, &common.APIError{Code: -2013, Message: "Order does not exist."}
+}
+
+func (v *fakeVenue) CancelOrder(ctx context.Context, symbol, clientOrderID string) error {
+	v.canceled = append(v.canceled, clientOrderID)
+	return nil
+}
+
+func testFilters() *common.OrderFilters {
+	return &common.OrderFilters{
+		Symbol:  "BTCUSDT",
+		Price:   &common.PriceRule{TickSize: "0.01"},
+		LotSize: &common.LotSizeRule{MinQuantity: "0.1", StepSize: "0.1"},
+	}
+}
+
+var testStart = time.Date(2021, 3, 18, 10, 0, 0, 0, time.UTC)
+
+// newTestExecution create a started execution whose clock is set by the returned function
+func newTestExecution(t *testing.T, venue Venue, parent ParentOrder, config *Config) (*Execution, func(d time.Duration)) {
+	parent.Symbol = "BTCUSDT"
+	parent.Side = SideBuy
+	parent.StartTime = testStart
+	parent.EndTime = testStart.Add(5 * time.Minute)
+	if config == nil {
+		config = &Config{}
+	}
+	config.ClientOrderIDPrefix = "test"
+	e, err := New(venue, parent, config)
+	require.NoError(t, err)
+	now := testStart
+	e.now = func() time.Time { return now }
+	e.start(testFilters())
+	return e, func(d time.Duration) { now = testStart.Add(d) }
+}
+
+func TestTWAP(t *testing.T) {
+	venue := &fakeVenue{}
+	e, at := newTestExecution(t, venue, ParentOrder{Strategy: StrategyTWAP, Quantity: "10", Slices: 5}, nil)
+	ctx := context.Background()
+
+	assert.False(t, e.step(ctx))
+	require.Len(t, venue.placed, 1)
+	assert.Equal(t, ChildOrder{ClientOrderID: "test-1", Symbol: "BTCUSDT", Side: SideBuy, Quantity: "2.0", SentAt: testStart}, venue.placed[0])
+
+	e.OnFill(Fill{ClientOrderID: "test-1", TradeID: 1, Quantity: "2", Price: "100", Commission: "0.002", CommissionAsset: "BTC", Final: true})
+	at(30 * time.Second)
+	e.step(ctx)
+	assert.Len(t, venue.placed, 1, "the first slice is done")
+
+	for i := 1; i < 5; i++ {
+		at(time.Duration(i) * time.Minute)
+		e.step(ctx)
+		require.Len(t, venue.placed, i+1)
+		assert.Equal(t, "2.0", venue.placed[i].Quantity)
+		e.OnFill(Fill{ClientOrderID: venue.placed[i].ClientOrderID, TradeID: int64(i + 1), Quantity: "2", Price: "110", Commission: "0.002", CommissionAsset: "BTC", Final: true})
+	}
+	assert.True(t, e.step(ctx))
+
+	report := e.Report()
+	assert.Equal(t, StatusCompleted, report.Status)
+	assert.Equal(t, "10", report.ExecutedQuantity)
+	assert.Equal(t, "108", report.AveragePrice)
+	assert.Equal(t, map[string]string{"BTC": "0.01"}, report.Commissions)
+	assert.Len(t, report.Children, 5)
+	assert.True(t, report.FinishedAt.Equal(testStart.Add(4*time.Minute)))
+}
+
+func TestVWAPCatchUp(t *testing.T) {
+	venue := &fakeVenue{}
+	parent := ParentOrder{Strategy: StrategyVWAP, Quantity: "10", VolumeProfile: []float64{1, 3, 1, 0, 0}}
+	e, at := newTestExecution(t, venue, parent, nil)
+	ctx := context.Background()
+
+	e.step(ctx)
+	require.Len(t, venue.placed, 1)
+	assert.Equal(t, "2.0", venue.placed[0].Quantity)
+	e.OnFill(Fill{ClientOrderID: "test-1", TradeID: 1, Quantity: "0.5", Price: "100"})
+	e.OnFill(Fill{ClientOrderID: "test-1", TradeID: 1, Quantity: "0.5", Price: "100"})
+
+	// the order of the previous slice is canceled before the next one is sent
+	at(time.Minute)
+	e.step(ctx)
+	assert.Equal(t, []string{"test-1"}, venue.canceled)
+	assert.Len(t, venue.placed, 1)
+	e.step(ctx)
+	assert.Equal(t, []string{"test-1"}, venue.canceled, "the cancel is requested once")
+
+	e.OnFill(Fill{ClientOrderID: "test-1", Final: true})
+	e.step(ctx)
+	require.Len(t, venue.placed, 2)
+	assert.Equal(t, "7.5", venue.placed[1].Quantity)
+	assert.Equal(t, "0.5", e.Report().Children[0].ExecutedQuantity)
+}
+
+func TestIceberg(t *testing.T) {
+	venue := &fakeVenue{}
+	parent := ParentOrder{Strategy: StrategyIceberg, Quantity: "7", DisplayQuantity: "3", LimitPrice: "100.005"}
+	e, at := newTestExecution(t, venue, parent, nil)
+	ctx := context.Background()
+
+	e.step(ctx)
+	require.Len(t, venue.placed, 1)
+	assert.Equal(t, "3.0", venue.placed[0].Quantity)
+	assert.Equal(t, "100.00", venue.placed[0].Price)
+
+	e.OnFill(Fill{ClientOrderID: "test-1", TradeID: 1, Quantity: "1", Price: "100"})
+	at(time.Second)
+	e.step(ctx)
+	assert.Len(t, venue.placed, 1, "one order is working at a time")
+
+	e.OnFill(Fill{ClientOrderID: "test-1", TradeID: 2, Quantity: "2", Price: "100", Final: true})
+	e.step(ctx)
+	require.Len(t, venue.placed, 2)
+	assert.Equal(t, "3.0", venue.placed[1].Quantity)
+	e.OnFill(Fill{ClientOrderID: "test-2", TradeID: 3, Quantity: "3", Price: "100", Final: true})
+	e.step(ctx)
+	require.Len(t, venue.placed, 3)
+	assert.Equal(t, "1.0", venue.placed[2].Quantity)
+}
+
+func TestParticipationCap(t *testing.T) {
+	venue := &fakeVenue{}
+	parent := ParentOrder{Strategy: StrategyTWAP, Quantity: "10", Slices: 1, ParticipationRate: 0.1}
+	e, _ := newTestExecution(t, venue, parent, nil)
+	ctx := context.Background()
+
+	e.step(ctx)
+	assert.Empty(t, venue.placed, "no market volume yet")
+	e.OnMarketTrade("100", "4")
+	e.OnMarketTrade("101", "11")
+	e.step(ctx)
+	require.Len(t, venue.placed, 1)
+	assert.Equal(t, "1.5", venue.placed[0].Quantity)
+}
+
+func TestMinQuantityCarryOver(t *testing.T) {
+	venue := &fakeVenue{}
+	e, at := newTestExecution(t, venue, ParentOrder{Strategy: StrategyTWAP, Quantity: "0.5", Slices: 5}, nil)
+	ctx := context.Background()
+
+	e.step(ctx)
+	assert.Len(t, venue.placed, 1)
+	assert.Equal(t, "0.1", venue.placed[0].Quantity)
+	e.OnFill(Fill{ClientOrderID: "test-1", TradeID: 1, Quantity: "0.05", Final: true})
+
+	// 0.05 is below the minimum quantity and is carried over to the next slice
+	at(30 * time.Second)
+	e.step(ctx)
+	assert.Len(t, venue.placed, 1)
+	at(time.Minute)
+	e.step(ctx)
+	require.Len(t, venue.placed, 2)
+	assert.Equal(t, "0.1", venue.placed[1].Quantity)
+}
+
+func TestPauseResumeCancel(t *testing.T) {
+	venue := &fakeVenue{}
+	e, at := newTestExecution(t, venue, ParentOrder{Strategy: StrategyTWAP, Quantity: "10", Slices: 5}, nil)
+	ctx := context.Background()
+
+	e.step(ctx)
+	e.Pause()
+	assert.Equal(t, StatusPaused, e.Status())
+	e.step(ctx)
+	assert.Equal(t, []string{"test-1"}, venue.canceled)
+	e.OnFill(Fill{ClientOrderID: "test-1", Final: true})
+	at(time.Minute)
+	e.step(ctx)
+	assert.Len(t, venue.placed, 1)
+
+	e.Resume()
+	e.step(ctx)
+	require.Len(t, venue.placed, 2)
+	assert.Equal(t, "4.0", venue.placed[1].Quantity)
+
+	e.Cancel()
+	assert.False(t, e.step(ctx), "the execution waits for the cancel of its orders")
+	assert.Equal(t, []string{"test-1", "test-2"}, venue.canceled)
+	e.OnFill(Fill{ClientOrderID: "test-2", Final: true})
+	assert.True(t, e.step(ctx))
+	e.Resume()
+	assert.Equal(t, StatusCanceled, e.Status())
+	select {
+	case <-e.Done():
+	default:
+		t.Fatal("done is not closed")
+	}
+}
+
+func TestExpired(t *testing.T) {
+	venue := &fakeVenue{}
+	e, at := newTestExecution(t, venue, ParentOrder{Strategy: StrategyTWAP, Quantity: "10", Slices: 5}, nil)
+	ctx := context.Background()
+
+	e.step(ctx)
+	e.OnFill(Fill{ClientOrderID: "test-1", TradeID: 1, Quantity: "2", Price: "100", Final: true})
+	at(5 * time.Minute)
+	assert.True(t, e.step(ctx))
+	report := e.Report()
+	assert.Equal(t, StatusExpired, report.Status)
+	assert.Equal(t, "2", report.ExecutedQuantity)
+}
+
+func TestRateLimitBackoff(t *testing.T) {
+	venue := &fakeVenue{placeErr: &common.APIError{Code: -1015, Message: "Too many new orders"}}
+	config := &Config{Backoff: 10 * time.Second, MinInterval: time.Second}
+	e, at := newTestExecution(t, venue, ParentOrder{Strategy: StrategyTWAP, Quantity: "10", Slices: 5}, config)
+	ctx := context.Background()
+
+	e.step(ctx)
+	venue.placeErr = nil
+	at(5 * time.Second)
+	e.step(ctx)
+	assert.Empty(t, venue.placed)
+	at(10 * time.Second)
+	e.step(ctx)
+	require.Len(t, venue.placed, 1)
+	assert.Equal(t, "test-2", venue.placed[0].ClientOrderID)
+	assert.Len(t, e.Report().Errors, 1)
+}
+
+func TestMaxErrors(t *testing.T) {
+	venue := &fakeVenue{placeErr: errors.New("connection reset")}
+	config := &Config{MaxErrors: 2}
+	e, _ := newTestExecution(t, venue, ParentOrder{Strategy: StrategyTWAP, Quantity: "10", Slices: 5}, config)
+	ctx := context.Background()
+
+	e.step(ctx)
+	assert.Equal(t, StatusRunning, e.Status())
+	e.step(ctx)
+	assert.True(t, e.step(ctx))
+	assert.Equal(t, StatusFailed, e.Status())
+}
+
+func TestPlaceTimeout(t *testing.T) {
+	venue := &fakeVenue{placeErr: context.DeadlineExceeded, placeLost: true}
+	e, at := newTestExecution(t, venue, ParentOrder{Strategy: StrategyTWAP, Quantity: "10", Slices: 5}, nil)
+	ctx := context.Background()
+
+	e.step(ctx)
+	require.Len(t, venue.placed, 1)
+	// the fill of the pending order is kept
+	e.OnFill(Fill{ClientOrderID: "test-1", TradeID: 1, Quantity: "1", Price: "100"})
+	assert.Equal(t, "1", e.Report().ExecutedQuantity)
+
+	venue.placeErr = nil
+	venue.getErr = errors.New("connection reset")
+	at(10 * time.Second)
+	e.step(ctx)
+	assert.Len(t, venue.placed, 1, "the order is still unknown")
+	assert.Empty(t, venue.canceled)
+
+	venue.getErr = nil
+	at(70 * time.Second)
+	e.step(ctx)
+	assert.Len(t, venue.placed, 1)
+	assert.Equal(t, []string{"test-1"}, venue.canceled, "the order is found and canceled with its slice")
+	require.Len(t, e.Report().Children, 1)
+}
+
+func TestPlaceTimeoutNotPlaced(t *testing.T) {
+	venue := &fakeVenue{placeErr: &common.APIError{Code: -1007, Message: "Timeout waiting for response from backend server."}}
+	e, _ := newTestExecution(t, venue, ParentOrder{Strategy: StrategyTWAP, Quantity: "10", Slices: 5}, nil)
+	ctx := context.Background()
+
+	e.step(ctx)
+	assert.Empty(t, venue.placed)
+	require.Len(t, e.Report().Children, 1)
+
+	venue.placeErr = nil
+	e.step(ctx)
+	require.Len(t, venue.placed, 1)
+	assert.Equal(t, "test-2", venue.placed[0].ClientOrderID)
+	children := e.Report().Children
+	require.Len(t, children, 1)
+	assert.Equal(t, "test-2", children[0].ClientOrderID)
+}
+
+func TestFillDuringPlace(t *testing.T) {
+	venue := &fakeVenue{}
+	e, _ := newTestExecution(t, venue, ParentOrder{Strategy: StrategyIceberg, Quantity: "2", DisplayQuantity: "1"}, nil)
+	venue.onPlace = func(order *ChildOrder) {
+		// the fill is reported before the response of the request
+		e.OnFill(Fill{ClientOrderID: order.ClientOrderID, TradeID: 1, Quantity: "1", Price: "100", Final: true})
+	}
+	ctx := context.Background()
+
+	e.step(ctx)
+	e.step(ctx)
+	require.Len(t, venue.placed, 2)
+	assert.Equal(t, "2", e.Report().ExecutedQuantity)
+	assert.True(t, e.step(ctx))
+	assert.Equal(t, StatusCompleted, e.Status())
+}
+
+func TestRun(t *testing.T) {
+	venue := &fakeVenue{}
+	parent := ParentOrder{
+		Symbol:    "BTCUSDT",
+		Side:      SideSell,
+		Strategy:  StrategyIceberg,
+		Quantity:  "1",
+		StartTime: time.Now(),
+		EndTime:   time.Now().Add(time.Hour),
+		// no fill is reported, the order stays working
+		DisplayQuantity: "1",
+	}
+	e, err := New(venue, parent, &Config{Tick: time.Millisecond})
+	require.NoError(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	report, err := e.Run(ctx)
+	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.Equal(t, StatusCanceled, report.Status)
+	assert.Len(t, venue.placed, 1)
+	assert.Equal(t, []string{venue.placed[0].ClientOrderID}, venue.canceled)
+}
+
+func TestNewInvalidParentOrder(t *testing.T) {
+	start := time.Now()
+	for _, parent := range []ParentOrder{
+		{Symbol: "BTCUSDT", Side: SideBuy, Strategy: StrategyTWAP, Quantity: "1", StartTime: start, EndTime: start},
+		{Symbol: "BTCUSDT", Side: SideBuy, Strategy: StrategyTWAP, Quantity: "0", StartTime: start, EndTime: start.Add(time.Hour)},
+		{Symbol: "BTCUSDT", Side: SideBuy, Strategy: StrategyVWAP, Quantity: "1", StartTime: start, EndTime: start.Add(time.Hour)},
+		{Symbol: "BTCUSDT", Side: SideBuy, Strategy: StrategyIceberg, Quantity: "1", StartTime: start, EndTime: start.Add(time.Hour)},
+		{Symbol: "BTCUSDT", Side: SideBuy, Strategy: "POV", Quantity: "1", StartTime: start, EndTime: start.Add(time.Hour)},
+	} {
+		_, err := New(&fakeVenue{}, parent, nil)
+		assert.True(t, errors.Is(err, ErrInvalidParentOrder), "%+v", parent)
+	}
+}
+
+func TestSpotFill(t *testing.T) {
+	fill := SpotFill(&binance.WsOrderUpdate{
+		ClientOrderID:      "test-1",
+		ExecutionType:      binance.ExecutionTypeTrade,
+		Status:             binance.OrderStatusTypePartiallyFilled,
+		TradeID:            12,
+		LastFilledQuantity: "0.5",
+		LastFilledPrice:    "100",
+		Commission:         "0.1",
+		CommissionAsset:    "BNB",
+	})
+	assert.Equal(t, Fill{ClientOrderID: "test-1", TradeID: 12, Quantity: "0.5", Price: "100", Commission: "0.1", CommissionAsset: "BNB"}, fill)
+
+	fill = SpotFill(&binance.WsOrderUpdate{
+		ClientOrderID:     "cancel-1",
+		OrigClientOrderID: "test-1",
+		ExecutionType:     binance.ExecutionTypeCanceled,
+		Status:            binance.OrderStatusTypeCanceled,
+	})
+	assert.Equal(t, Fill{ClientOrderID: "test-1", Final: true}, fill)
+}
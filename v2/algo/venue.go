@@ -0,0 +1,200 @@
+package algo
+
+import (
+	"context"
+	"time"
+
+	binance "github.com/Zamzam-Technology/go-binance/v2"
+	"github.com/Zamzam-Technology/go-binance/v2/common"
+	"github.com/Zamzam-Technology/go-binance/v2/futures"
+)
+
+// symbolsTTL is the lifetime of the cached exchange info of the venues
+const symbolsTTL = time.Hour
+
+// SpotVenue send the child orders with the spot order services
+type SpotVenue struct {
+	c       *binance.Client
+	symbols *binance.SymbolRegistry
+}
+
+// NewSpotVenue init a spot venue
+func NewSpotVenue(c *binance.Client) *SpotVenue {
+	return &SpotVenue{c: c, symbols: c.NewSymbolRegistry(symbolsTTL)}
+}
+
+// OrderFilters return the filters of symbol
+func (v *SpotVenue) OrderFilters(ctx context.Context, symbol string) (*common.OrderFilters, error) {
+	s, err := v.symbols.Symbol(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+	return s.OrderFilters(), nil
+}
+
+// PlaceOrder send a limit GTC order, or a market order when the order has no price
+func (v *SpotVenue) PlaceOrder(ctx context.Context, order *ChildOrder) error {
+	s := v.c.NewCreateOrderService().Symbol(order.Symbol).Side(binance.SideType(order.Side)).
+		Quantity(order.Quantity).NewClientOrderID(order.ClientOrderID)
+	if order.Price != "" {
+		s.Type(binance.OrderTypeLimit).TimeInForce(binance.TimeInForceTypeGTC).Price(order.Price)
+	} else {
+		s.Type(binance.OrderTypeMarket)
+	}
+	_, err := s.Do(ctx)
+	return err
+}
+
+// GetOrder return true when the order is done
+func (v *SpotVenue) GetOrder(ctx context.Context, symbol, clientOrderID string) (bool, error) {
+	order, err := v.c.NewGetOrderService().Symbol(symbol).OrigClientOrderID(clientOrderID).Do(ctx)
+	if err != nil {
+		return false, err
+	}
+	return spotDone(order.Status), nil
+}
+
+// CancelOrder cancel an order by client order id
+func (v *SpotVenue) CancelOrder(ctx context.Context, symbol, clientOrderID string) error {
+	_, err := v.c.NewCancelOrderService().Symbol(symbol).OrigClientOrderID(clientOrderID).Do(ctx)
+	return err
+}
+
+// MarginVenue send the child orders with the cross or isolated margin order services
+type MarginVenue struct {
+	SpotVenue
+	isolated bool
+}
+
+// NewMarginVenue init a margin venue, isolated selects the isolated margin account of the symbol
+func NewMarginVenue(c *binance.Client, isolated bool) *MarginVenue {
+	return &MarginVenue{SpotVenue: SpotVenue{c: c, symbols: c.NewSymbolRegistry(symbolsTTL)}, isolated: isolated}
+}
+
+// PlaceOrder send a limit GTC order, or a market order when the order has no price
+func (v *MarginVenue) PlaceOrder(ctx context.Context, order *ChildOrder) error {
+	s := v.c.NewCreateMarginOrderService().Symbol(order.Symbol).IsIsolated(v.isolated).
+		Side(binance.SideType(order.Side)).Quantity(order.Quantity).NewClientOrderID(order.ClientOrderID)
+	if order.Price != "" {
+		s.Type(binance.OrderTypeLimit).TimeInForce(binance.TimeInForceTypeGTC).Price(order.Price)
+	} else {
+		s.Type(binance.OrderTypeMarket)
+	}
+	_, err := s.Do(ctx)
+	return err
+}
+
+// GetOrder return true when the order is done
+func (v *MarginVenue) GetOrder(ctx context.Context, symbol, clientOrderID string) (bool, error) {
+	order, err := v.c.NewGetMarginOrderService().Symbol(symbol).IsIsolated(v.isolated).
+		OrigClientOrderID(clientOrderID).Do(ctx)
+	if err != nil {
+		return false, err
+	}
+	return spotDone(order.Status), nil
+}
+
+// CancelOrder cancel an order by client order id
+func (v *MarginVenue) CancelOrder(ctx context.Context, symbol, clientOrderID string) error {
+	_, err := v.c.NewCancelMarginOrderService().Symbol(symbol).IsIsolated(v.isolated).
+		OrigClientOrderID(clientOrderID).Do(ctx)
+	return err
+}
+
+// FuturesVenue send the child orders with the USDⓈ-M futures order services
+type FuturesVenue struct {
+	c       *futures.Client
+	symbols *futures.SymbolRegistry
+}
+
+// NewFuturesVenue init a futures venue
+func NewFuturesVenue(c *futures.Client) *FuturesVenue {
+	return &FuturesVenue{c: c, symbols: c.NewSymbolRegistry(symbolsTTL)}
+}
+
+// OrderFilters return the filters of symbol
+func (v *FuturesVenue) OrderFilters(ctx context.Context, symbol string) (*common.OrderFilters, error) {
+	s, err := v.symbols.Symbol(ctx, symbol)
+	if err != nil {
+		return nil, err
+	}
+	return s.OrderFilters(), nil
+}
+
+// PlaceOrder send a limit GTC order, or a market order when the order has no price
+func (v *FuturesVenue) PlaceOrder(ctx context.Context, order *ChildOrder) error {
+	s := v.c.NewCreateOrderService().Symbol(order.Symbol).Side(futures.SideType(order.Side)).
+		Quantity(order.Quantity).NewClientOrderID(order.ClientOrderID)
+	if order.Price != "" {
+		s.Type(futures.OrderTypeLimit).TimeInForce(futures.TimeInForceTypeGTC).Price(order.Price)
+	} else {
+		s.Type(futures.OrderTypeMarket)
+	}
+	_, err := s.Do(ctx)
+	return err
+}
+
+// GetOrder return true when the order is done
+func (v *FuturesVenue) GetOrder(ctx context.Context, symbol, clientOrderID string) (bool, error) {
+	order, err := v.c.NewGetOrderService().Symbol(symbol).OrigClientOrderID(clientOrderID).Do(ctx)
+	if err != nil {
+		return false, err
+	}
+	return futuresDone(order.Status), nil
+}
+
+// CancelOrder cancel an order by client order id
+func (v *FuturesVenue) CancelOrder(ctx context.Context, symbol, clientOrderID string) error {
+	_, err := v.c.NewCancelOrderService().Symbol(symbol).OrigClientOrderID(clientOrderID).Do(ctx)
+	return err
+}
+
+// SpotFill convert a spot or margin execution report to a Fill
+func SpotFill(update *binance.WsOrderUpdate) Fill {
+	fill := Fill{ClientOrderID: update.ClientOrderID}
+	if update.ExecutionType == binance.ExecutionTypeCanceled && update.OrigClientOrderID != "" {
+		// the cancel report carries the client order id of the cancel request
+		fill.ClientOrderID = update.OrigClientOrderID
+	}
+	if update.ExecutionType == binance.ExecutionTypeTrade {
+		fill.TradeID = update.TradeID
+		fill.Quantity = update.LastFilledQuantity
+		fill.Price = update.LastFilledPrice
+		fill.Commission = update.Commission
+		fill.CommissionAsset = update.CommissionAsset
+	}
+	fill.Final = spotDone(update.Status)
+	return fill
+}
+
+func spotDone(status binance.OrderStatusType) bool {
+	switch status {
+	case binance.OrderStatusTypeFilled, binance.OrderStatusTypeCanceled, binance.OrderStatusTypeRejected,
+		binance.OrderStatusTypeExpired, binance.OrderStatusTypeExpiredInMatch:
+		return true
+	}
+	return false
+}
+
+// FuturesFill convert a futures order update to a Fill
+func FuturesFill(update *futures.WsOrderTradeUpdate) Fill {
+	fill := Fill{ClientOrderID: update.ClientOrderID}
+	if update.ExecutionType == futures.OrderExecutionTypeTrade {
+		fill.TradeID = update.TradeID
+		fill.Quantity = update.LastFilledQty
+		fill.Price = update.LastFilledPrice
+		fill.Commission = update.Commission
+		fill.CommissionAsset = update.CommissionAsset
+	}
+	fill.Final = futuresDone(update.Status)
+	return fill
+}
+
+func futuresDone(status futures.OrderStatusType) bool {
+	switch status {
+	case futures.OrderStatusTypeFilled, futures.OrderStatusTypeCanceled, futures.OrderStatusTypeRejected,
+		futures.OrderStatusTypeExpired:
+		return true
+	}
+	return false
+}
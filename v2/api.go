@@ -0,0 +1,137 @@
+package binance
+
+import "time"
+
+// MarketDataClient is the market data services of Client
+type MarketDataClient interface {
+	NewPingService() *PingService
+	NewServerTimeService() *ServerTimeService
+	NewSetServerTimeService() *SetServerTimeService
+	NewDepthService() *DepthService
+	NewAggTradesService() *AggTradesService
+	NewRecentTradesService() *RecentTradesService
+	NewHistoricalTradesService() *HistoricalTradesService
+	NewKlinesService() *KlinesService
+	NewKlineDownloader(symbol string, interval KlineInterval) *KlineDownloader
+	NewListPriceChangeStatsService() *ListPriceChangeStatsService
+	NewListPricesService() *ListPricesService
+	NewListBookTickersService() *ListBookTickersService
+	NewAveragePriceService() *AveragePriceService
+	NewExchangeInfoService() *ExchangeInfoService
+	NewSymbolRegistry(ttl time.Duration) *SymbolRegistry
+	NewGetMarginAssetService() *GetMarginAssetService
+	NewGetMarginPairService() *GetMarginPairService
+	NewGetMarginAllPairsService() *GetMarginAllPairsService
+	NewGetMarginPriceIndexService() *GetMarginPriceIndexService
+	NewGetIsolatedMarginPairService() *GetIsolatedMarginPairService
+	NewGetIsolatedMarginAllPairsService() *GetIsolatedMarginAllPairsService
+	NewGetIsolatedMarginTierService() *GetIsolatedMarginTierService
+	NewListMarginInterestRatesService() *ListMarginInterestRatesService
+	NewGetCrossMarginDataService() *GetCrossMarginDataService
+}
+
+// TradingClient is the spot and margin order services of Client
+type TradingClient interface {
+	NewCreateOrderService() *CreateOrderService
+	NewCreateOCOService() *CreateOCOService
+	NewCreateOrderListOCOService() *CreateOrderListOCOService
+	NewGetOCOService() *GetOCOService
+	NewListOCOService() *ListOCOService
+	NewListOpenOCOService() *ListOpenOCOService
+	NewCancelOCOService() *CancelOCOService
+	NewGetOrderService() *GetOrderService
+	NewListPreventedMatchesService() *ListPreventedMatchesService
+	NewCancelReplaceOrderService() *CancelReplaceOrderService
+	NewCreateSOROrderService() *CreateSOROrderService
+	NewListAllocationsService() *ListAllocationsService
+	NewCancelOrderService() *CancelOrderService
+	NewCancelOpenOrdersService() *CancelOpenOrdersService
+	NewListOpenOrdersService() *ListOpenOrdersService
+	NewListOrdersService() *ListOrdersService
+	NewListTradesService() *ListTradesService
+	NewOrderTracker() *OrderTracker
+	NewMarginOrderTracker(isolated bool) *OrderTracker
+	NewCreateMarginOrderService() *CreateMarginOrderService
+	NewCancelMarginOrderService() *CancelMarginOrderService
+	NewGetMarginOrderService() *GetMarginOrderService
+	NewListMarginOpenOrdersService() *ListMarginOpenOrdersService
+	NewListMarginOrdersService() *ListMarginOrdersService
+	NewCreateMarginOCOService() *CreateMarginOCOService
+	NewCancelMarginOCOService() *CancelMarginOCOService
+	NewGetMarginOCOService() *GetMarginOCOService
+	NewListMarginOCOService() *ListMarginOCOService
+	NewListMarginOpenOCOService() *ListMarginOpenOCOService
+	NewCancelMarginOpenOrdersService() *CancelMarginOpenOrdersService
+	NewListMarginTradesService() *ListMarginTradesService
+}
+
+// AccountClient is the spot and margin account services of Client
+type AccountClient interface {
+	NewGetAccountService() *GetAccountService
+	NewGetAccountCommissionService() *GetAccountCommissionService
+	NewListTradeFeeService() *ListTradeFeeService
+	NewGetOrderRateLimitService() *GetOrderRateLimitService
+	NewGetAccountSnapshotService() *GetAccountSnapshotService
+	NewGetMarginAccountService() *GetMarginAccountService
+	NewGetIsolatedMarginAccountService() *GetIsolatedMarginAccountService
+	NewMarginLoanService() *MarginLoanService
+	NewMarginRepayService() *MarginRepayService
+	NewListMarginLoansService() *ListMarginLoansService
+	NewListMarginRepaysService() *ListMarginRepaysService
+	NewListMarginInterestsService() *ListMarginInterestsService
+	NewListMarginForceLiquidationsService() *ListMarginForceLiquidationsService
+	NewGetMaxBorrowableService() *GetMaxBorrowableService
+	NewGetMaxTransferableService() *GetMaxTransferableService
+	NewEnableIsolatedMarginAccountService() *EnableIsolatedMarginAccountService
+	NewDisableIsolatedMarginAccountService() *DisableIsolatedMarginAccountService
+	NewGetIsolatedMarginAccountLimitService() *GetIsolatedMarginAccountLimitService
+}
+
+// WalletClient is the wallet, transfer and swap services of Client
+type WalletClient interface {
+	NewListDepositsService() *ListDepositsService
+	NewGetDepositAddressService() *GetDepositsAddressService
+	NewCreateWithdrawService() *CreateWithdrawService
+	NewListWithdrawsService() *ListWithdrawsService
+	NewGetAssetDetailService() *GetAssetDetailService
+	NewMarginTransferService() *MarginTransferService
+	NewListMarginTransfersService() *ListMarginTransfersService
+	NewIsolatedMarginTransferService() *IsolatedMarginTransferService
+	NewListIsolatedMarginTransfersService() *ListIsolatedMarginTransfersService
+	NewFuturesTransferService() *FuturesTransferService
+	NewListFuturesTransferService() *ListFuturesTransferService
+	NewListDustLogService() *ListDustLogService
+	NewDustTransferService() *DustTransferService
+	NewListSwapPoolsService() *ListSwapPoolsService
+	NewRequestQuoteService() *RequestQuoteService
+	NewMakeSwapService() *MakeSwapService
+	NewSwapHistoryService() *SwapHistoryService
+	NewLiquidityInformationService() *LiquidityInformationService
+	NewAddLiquidityService() *AddLiquidityService
+	NewRemoveLiquidityService() *RemoveLiquidityService
+	NewLiquidityOperationService() *LiquidityOperationService
+}
+
+// UserStreamClient is the listen key services of the user data streams of Client
+type UserStreamClient interface {
+	NewStartUserStreamService() *StartUserStreamService
+	NewKeepaliveUserStreamService() *KeepaliveUserStreamService
+	NewCloseUserStreamService() *CloseUserStreamService
+	NewStartMarginUserStreamService() *StartMarginUserStreamService
+	NewKeepaliveMarginUserStreamService() *KeepaliveMarginUserStreamService
+	NewCloseMarginUserStreamService() *CloseMarginUserStreamService
+	NewStartIsolatedMarginUserStreamService() *StartIsolatedMarginUserStreamService
+	NewKeepaliveIsolatedMarginUserStreamService() *KeepaliveIsolatedMarginUserStreamService
+	NewCloseIsolatedMarginUserStreamService() *CloseIsolatedMarginUserStreamService
+}
+
+// API is the REST surface of Client, implemented by Client and FakeClient
+type API interface {
+	MarketDataClient
+	TradingClient
+	AccountClient
+	WalletClient
+	UserStreamClient
+}
+
+var _ API = (*Client)(nil)
@@ -0,0 +1,155 @@
+package binance
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Zamzam-Technology/go-binance/v2/common"
+)
+
+var errInvalidArchiveRecord = errors.New("invalid archive record")
+
+// KlineArchiveReader read the klines of an archive file
+type KlineArchiveReader struct {
+	r *common.ArchiveReader
+}
+
+// OpenKlineArchive open the klines archive of a symbol for the day or the month of date
+func OpenKlineArchive(ctx context.Context, fetcher common.ArchiveFetcher, period common.ArchivePeriod, symbol string, interval KlineInterval, date time.Time) (*KlineArchiveReader, error) {
+	r, err := common.OpenArchive(ctx, fetcher, &common.ArchiveFile{
+		Market:   common.ArchiveMarketSpot,
+		Period:   period,
+		DataType: common.ArchiveDataTypeKlines,
+		Symbol:   symbol,
+		Interval: interval,
+		Date:     date,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &KlineArchiveReader{r: r}, nil
+}
+
+// Read return the next kline, or io.EOF at the end of the file
+func (r *KlineArchiveReader) Read() (*Kline, error) {
+	record, err := r.r.Read()
+	if err != nil {
+		return nil, err
+	}
+	if record.Len() < 11 {
+		return nil, errInvalidArchiveRecord
+	}
+	kline := &Kline{
+		OpenTime:                 record.Time(0),
+		Open:                     record.String(1),
+		High:                     record.String(2),
+		Low:                      record.String(3),
+		Close:                    record.String(4),
+		Volume:                   record.String(5),
+		CloseTime:                record.Time(6),
+		QuoteAssetVolume:         record.String(7),
+		TradeNum:                 record.Int64(8),
+		TakerBuyBaseAssetVolume:  record.String(9),
+		TakerBuyQuoteAssetVolume: record.String(10),
+	}
+	return kline, record.Err()
+}
+
+// Close close the archive
+func (r *KlineArchiveReader) Close() error {
+	return r.r.Close()
+}
+
+// AggTradeArchiveReader read the aggregate trades of an archive file
+type AggTradeArchiveReader struct {
+	r *common.ArchiveReader
+}
+
+// OpenAggTradeArchive open the aggregate trades archive of a symbol for the day or the month of date
+func OpenAggTradeArchive(ctx context.Context, fetcher common.ArchiveFetcher, period common.ArchivePeriod, symbol string, date time.Time) (*AggTradeArchiveReader, error) {
+	r, err := common.OpenArchive(ctx, fetcher, &common.ArchiveFile{
+		Market:   common.ArchiveMarketSpot,
+		Period:   period,
+		DataType: common.ArchiveDataTypeAggTrades,
+		Symbol:   symbol,
+		Date:     date,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &AggTradeArchiveReader{r: r}, nil
+}
+
+// Read return the next aggregate trade, or io.EOF at the end of the file
+func (r *AggTradeArchiveReader) Read() (*AggTrade, error) {
+	record, err := r.r.Read()
+	if err != nil {
+		return nil, err
+	}
+	if record.Len() < 8 {
+		return nil, errInvalidArchiveRecord
+	}
+	trade := &AggTrade{
+		AggTradeID:       record.Int64(0),
+		Price:            record.String(1),
+		Quantity:         record.String(2),
+		FirstTradeID:     record.Int64(3),
+		LastTradeID:      record.Int64(4),
+		Timestamp:        record.Time(5),
+		IsBuyerMaker:     record.Bool(6),
+		IsBestPriceMatch: record.Bool(7),
+	}
+	return trade, record.Err()
+}
+
+// Close close the archive
+func (r *AggTradeArchiveReader) Close() error {
+	return r.r.Close()
+}
+
+// TradeArchiveReader read the trades of an archive file
+type TradeArchiveReader struct {
+	r *common.ArchiveReader
+}
+
+// OpenTradeArchive open the trades archive of a symbol for the day or the month of date
+func OpenTradeArchive(ctx context.Context, fetcher common.ArchiveFetcher, period common.ArchivePeriod, symbol string, date time.Time) (*TradeArchiveReader, error) {
+	r, err := common.OpenArchive(ctx, fetcher, &common.ArchiveFile{
+		Market:   common.ArchiveMarketSpot,
+		Period:   period,
+		DataType: common.ArchiveDataTypeTrades,
+		Symbol:   symbol,
+		Date:     date,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &TradeArchiveReader{r: r}, nil
+}
+
+// Read return the next trade, or io.EOF at the end of the file.
+// The quote quantity of the archive is not kept, Trade has no such field.
+func (r *TradeArchiveReader) Read() (*Trade, error) {
+	record, err := r.r.Read()
+	if err != nil {
+		return nil, err
+	}
+	if record.Len() < 7 {
+		return nil, errInvalidArchiveRecord
+	}
+	trade := &Trade{
+		ID:           record.Int64(0),
+		Price:        record.String(1),
+		Quantity:     record.String(2),
+		Time:         record.Time(4),
+		IsBuyerMaker: record.Bool(5),
+		IsBestMatch:  record.Bool(6),
+	}
+	return trade, record.Err()
+}
+
+// Close close the archive
+func (r *TradeArchiveReader) Close() error {
+	return r.r.Close()
+}
@@ -0,0 +1,114 @@
+package binance
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/Zamzam-Technology/go-binance/v2/common"
+	"github.com/stretchr/testify/suite"
+)
+
+type archiveTestSuite struct {
+	suite.Suite
+	files map[string][]byte
+}
+
+func TestArchive(t *testing.T) {
+	suite.Run(t, new(archiveTestSuite))
+}
+
+func (s *archiveTestSuite) SetupTest() {
+	s.files = make(map[string][]byte)
+}
+
+// addFile zip content at path and add its checksum
+func (s *archiveTestSuite) addFile(path string, content string) {
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	f, err := w.Create("data.csv")
+	s.Require().NoError(err)
+	_, err = f.Write([]byte(content))
+	s.Require().NoError(err)
+	s.Require().NoError(w.Close())
+	sum := sha256.Sum256(buf.Bytes())
+	s.files[path] = buf.Bytes()
+	s.files[path+".CHECKSUM"] = []byte(hex.EncodeToString(sum[:]) + "  data.zip")
+}
+
+func (s *archiveTestSuite) fetcher() common.ArchiveFetcher {
+	return common.ArchiveFetcherFunc(func(ctx context.Context, path string) (io.ReadCloser, error) {
+		data, ok := s.files[path]
+		if !ok {
+			return nil, common.ErrArchiveNotFound
+		}
+		return ioutil.NopCloser(bytes.NewReader(data)), nil
+	})
+}
+
+func (s *archiveTestSuite) TestKlineArchive() {
+	s.addFile("data/spot/daily/klines/BNBBTC/1h/BNBBTC-1h-2021-03-18.zip",
+		"1616025600000,0.01,0.02,0.005,0.015,100,1616029199999,1.5,20,50,0.75,0\n"+
+			"1616029200000000,0.015,0.02,0.01,0.01,10,1616032799999999,0.1,2,5,0.05,0\n")
+	r, err := OpenKlineArchive(context.Background(), s.fetcher(), common.ArchivePeriodDaily, "BNBBTC",
+		KlineInterval1h, time.Date(2021, 3, 18, 0, 0, 0, 0, time.UTC))
+	s.Require().NoError(err)
+	defer r.Close()
+
+	kline, err := r.Read()
+	s.Require().NoError(err)
+	s.Equal(&Kline{
+		OpenTime:                 1616025600000,
+		Open:                     "0.01",
+		High:                     "0.02",
+		Low:                      "0.005",
+		Close:                    "0.015",
+		Volume:                   "100",
+		CloseTime:                1616029199999,
+		QuoteAssetVolume:         "1.5",
+		TradeNum:                 20,
+		TakerBuyBaseAssetVolume:  "50",
+		TakerBuyQuoteAssetVolume: "0.75",
+	}, kline)
+
+	kline, err = r.Read()
+	s.Require().NoError(err)
+	s.Equal(int64(1616029200000), kline.OpenTime)
+	s.Equal(int64(1616032799999), kline.CloseTime)
+
+	_, err = r.Read()
+	s.Equal(io.EOF, err)
+}
+
+func (s *archiveTestSuite) TestAggTradeArchive() {
+	s.addFile("data/spot/monthly/aggTrades/BNBBTC/BNBBTC-aggTrades-2021-03.zip",
+		"26129,0.01633102,4.70443515,27781,27781,1498793709153,True,True\n")
+	r, err := OpenAggTradeArchive(context.Background(), s.fetcher(), common.ArchivePeriodMonthly, "BNBBTC",
+		time.Date(2021, 3, 18, 0, 0, 0, 0, time.UTC))
+	s.Require().NoError(err)
+	defer r.Close()
+
+	trade, err := r.Read()
+	s.Require().NoError(err)
+	s.Equal(&AggTrade{
+		AggTradeID:       26129,
+		Price:            "0.01633102",
+		Quantity:         "4.70443515",
+		FirstTradeID:     27781,
+		LastTradeID:      27781,
+		Timestamp:        1498793709153,
+		IsBuyerMaker:     true,
+		IsBestPriceMatch: true,
+	}, trade)
+}
+
+func (s *archiveTestSuite) TestTradeArchiveNotFound() {
+	_, err := OpenTradeArchive(context.Background(), s.fetcher(), common.ArchivePeriodDaily, "BNBBTC", time.Now())
+	s.Equal(common.ErrArchiveNotFound, err)
+}
@@ -0,0 +1,297 @@
+// Package backtest replay historical market data through the handlers of a strategy written
+// against this library, while its orders are served by a paper exchange.
+//
+// The klines, aggregate trades and depth updates added to a backtest are delivered in time order
+// to the same handler types as the websocket streams, e.g. binance.WsKlineHandler, and drive the
+// matching engine of the paper package. The client of the backtest sends the orders to the
+// exchange after the latency of the config, so that the market moves meanwhile. Run return the
+// equity curve, the trade log and, for futures, the funding payments.
+package backtest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/Zamzam-Technology/go-binance/v2/common"
+)
+
+// ErrAlreadyRun is returned by Run when the backtest was already run
+var ErrAlreadyRun = errors.New("backtest already run")
+
+// Config define the simulation settings
+type Config struct {
+	// Latency is the time between a request of the strategy and its arrival at the exchange
+	Latency time.Duration
+	// SampleInterval is the minimum time between two points of the equity curve, 1 minute by default
+	SampleInterval time.Duration
+	// Asset is the asset of the equity, USDT by default
+	Asset string
+}
+
+// EquityPoint define the equity at a time of the backtest
+type EquityPoint struct {
+	Time   int64
+	Equity string
+}
+
+// Trade define a fill of an order of the strategy
+type Trade struct {
+	Time            int64
+	Symbol          string
+	OrderID         int64
+	ClientOrderID   string
+	TradeID         int64
+	Side            string
+	Price           string
+	Quantity        string
+	Commission      string
+	CommissionAsset string
+	// RealizedPnL is the profit realized by a futures trade, "0" for spot
+	RealizedPnL string
+	Maker       bool
+}
+
+// Funding define a funding payment of the futures positions, negative when paid
+type Funding struct {
+	Time   int64
+	Symbol string
+	Rate   string
+	Amount string
+}
+
+// Result define the outcome of a backtest
+type Result struct {
+	// StartEquity is the equity before the first event, EndEquity after the last one
+	StartEquity string
+	EndEquity   string
+	EquityCurve []EquityPoint
+	Trades      []Trade
+	Fundings    []Funding
+}
+
+// item is an event of the replay: apply update the exchange, deliver call the handler of the strategy
+type item struct {
+	time    int64
+	apply   func() error
+	deliver func()
+}
+
+// runner replay the items in time order. The items up to the arrival time of a request are applied
+// to the exchange before the request, and delivered to the strategy after it.
+type runner struct {
+	latency        int64
+	sampleInterval int64
+	asset          string
+	exchange       http.RoundTripper
+	equity         func(asset string) string
+	items          []*item
+	// applied is the number of items applied to the exchange
+	applied    int
+	clock      int64
+	nextSample int64
+	result     *Result
+	err        error
+	ran        bool
+}
+
+func newRunner(cfg *Config) *runner {
+	if cfg == nil {
+		cfg = &Config{}
+	}
+	r := &runner{
+		latency:        int64(cfg.Latency / time.Millisecond),
+		sampleInterval: int64(cfg.SampleInterval / time.Millisecond),
+		asset:          cfg.Asset,
+		result:         &Result{},
+	}
+	if r.sampleInterval <= 0 {
+		r.sampleInterval = int64(time.Minute / time.Millisecond)
+	}
+	if r.asset == "" {
+		r.asset = "USDT"
+	}
+	return r
+}
+
+func (r *runner) add(t int64, apply func() error, deliver func()) {
+	r.items = append(r.items, &item{time: t, apply: apply, deliver: deliver})
+}
+
+// now return the time of the replay, used as the time of the exchange
+func (r *runner) now() time.Time {
+	return time.Unix(0, r.clock*int64(time.Millisecond))
+}
+
+func (r *runner) setClock(t int64) {
+	if t > r.clock {
+		r.clock = t
+	}
+}
+
+// advance apply to the exchange the items until t
+func (r *runner) advance(t int64) {
+	for r.err == nil && r.applied < len(r.items) && r.items[r.applied].time <= t {
+		it := r.items[r.applied]
+		r.applied++
+		r.setClock(it.time)
+		if it.apply != nil {
+			r.err = it.apply()
+		}
+	}
+}
+
+// RoundTrip send the request to the exchange after the latency
+func (r *runner) RoundTrip(req *http.Request) (*http.Response, error) {
+	arrival := r.clock + r.latency
+	r.advance(arrival)
+	r.setClock(arrival)
+	return r.exchange.RoundTrip(req)
+}
+
+func (r *runner) sample() {
+	if r.clock < r.nextSample {
+		return
+	}
+	r.result.EquityCurve = append(r.result.EquityCurve, EquityPoint{Time: r.clock, Equity: r.equity(r.asset)})
+	r.nextSample = (r.clock/r.sampleInterval + 1) * r.sampleInterval
+}
+
+func (r *runner) run(ctx context.Context) (*Result, error) {
+	if r.ran {
+		return nil, ErrAlreadyRun
+	}
+	r.ran = true
+	sort.SliceStable(r.items, func(i, j int) bool {
+		return r.items[i].time < r.items[j].time
+	})
+	if len(r.items) > 0 {
+		r.clock = r.items[0].time
+		r.nextSample = r.clock
+	}
+	r.result.StartEquity = r.equity(r.asset)
+	for i := 0; i < len(r.items); i++ {
+		if err := ctx.Err(); err != nil {
+			return r.result, err
+		}
+		it := r.items[i]
+		r.advance(it.time)
+		if r.err != nil {
+			return r.result, r.err
+		}
+		r.setClock(it.time)
+		if it.deliver != nil {
+			it.deliver()
+		}
+		if r.err != nil {
+			return r.result, r.err
+		}
+		r.sample()
+	}
+	r.result.EndEquity = r.equity(r.asset)
+	if n := len(r.result.EquityCurve); n == 0 || r.result.EquityCurve[n-1].Time != r.clock {
+		r.result.EquityCurve = append(r.result.EquityCurve, EquityPoint{Time: r.clock, Equity: r.result.EndEquity})
+	}
+	return r.result, nil
+}
+
+// errMarketData is returned for the market data requests of the strategy, which would read the live market
+var errMarketData = errors.New("market data requests are not available in a backtest")
+
+type noMarketData struct{}
+
+func (noMarketData) RoundTrip(req *http.Request) (*http.Response, error) {
+	return nil, fmt.Errorf("%w: %s", errMarketData, req.URL.Path)
+}
+
+// simulatedTrade is a trade made up from a kline
+type simulatedTrade struct {
+	time     int64
+	price    string
+	quantity string
+}
+
+// klineTrades split a kline in four trades of a quarter of its volume, at the open, the high and
+// the low, and the close. The low comes before the high in a rising kline, after it otherwise.
+func klineTrades(start, end int64, open, high, low, close, volume string) ([]simulatedTrade, error) {
+	v, ok := new(big.Rat).SetString(volume)
+	if !ok {
+		return nil, fmt.Errorf("invalid kline volume %q", volume)
+	}
+	o, ok := new(big.Rat).SetString(open)
+	if !ok {
+		return nil, fmt.Errorf("invalid kline open %q", open)
+	}
+	c, ok := new(big.Rat).SetString(close)
+	if !ok {
+		return nil, fmt.Errorf("invalid kline close %q", close)
+	}
+	quantity := common.FormatDecimal(v.Quo(v, big.NewRat(4, 1)))
+	prices := []string{open, low, high, close}
+	if c.Cmp(o) < 0 {
+		prices = []string{open, high, low, close}
+	}
+	step := (end - start) / 3
+	trades := make([]simulatedTrade, len(prices))
+	for i, price := range prices {
+		trades[i] = simulatedTrade{time: start + int64(i)*step, price: price, quantity: quantity}
+	}
+	trades[len(trades)-1].time = end
+	return trades, nil
+}
+
+// depthBook maintain an order book from diff depth updates
+type depthBook struct {
+	bids map[string]common.PriceLevel
+	asks map[string]common.PriceLevel
+}
+
+func newDepthBook() *depthBook {
+	return &depthBook{
+		bids: make(map[string]common.PriceLevel),
+		asks: make(map[string]common.PriceLevel),
+	}
+}
+
+func (b *depthBook) update(bids, asks []common.PriceLevel) error {
+	if err := updateLevels(b.bids, bids); err != nil {
+		return err
+	}
+	return updateLevels(b.asks, asks)
+}
+
+func updateLevels(side map[string]common.PriceLevel, levels []common.PriceLevel) error {
+	for _, level := range levels {
+		price, ok := new(big.Rat).SetString(level.Price)
+		if !ok {
+			return fmt.Errorf("invalid depth price %q", level.Price)
+		}
+		quantity, ok := new(big.Rat).SetString(level.Quantity)
+		if !ok {
+			return fmt.Errorf("invalid depth quantity %q", level.Quantity)
+		}
+		key := price.RatString()
+		if quantity.Sign() == 0 {
+			delete(side, key)
+		} else {
+			side[key] = level
+		}
+	}
+	return nil
+}
+
+func (b *depthBook) levels() (bids, asks []common.PriceLevel) {
+	return levelList(b.bids), levelList(b.asks)
+}
+
+func levelList(side map[string]common.PriceLevel) []common.PriceLevel {
+	levels := make([]common.PriceLevel, 0, len(side))
+	for _, level := range side {
+		levels = append(levels, level)
+	}
+	return levels
+}
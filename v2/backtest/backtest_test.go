@@ -0,0 +1,194 @@
+package backtest
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/Zamzam-Technology/go-binance/v2"
+	"github.com/Zamzam-Technology/go-binance/v2/common"
+	"github.com/Zamzam-Technology/go-binance/v2/futures"
+	"github.com/Zamzam-Technology/go-binance/v2/paper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const exchangeInfo = `{
+	"symbols": [{
+		"symbol": "BTCUSDT",
+		"status": "TRADING",
+		"baseAsset": "BTC",
+		"quoteAsset": "USDT",
+		"marginAsset": "USDT",
+		"filters": [
+			{"filterType": "PRICE_FILTER", "minPrice": "0.01", "maxPrice": "1000000", "tickSize": "0.01"},
+			{"filterType": "LOT_SIZE", "minQty": "0.001", "maxQty": "100", "stepSize": "0.001"}
+		]
+	}]
+}`
+
+func spotKline(i int64, open, high, low, close string) *binance.WsKlineEvent {
+	start := i * 60000
+	return &binance.WsKlineEvent{
+		Event:  "kline",
+		Time:   start + 59999,
+		Symbol: "BTCUSDT",
+		Kline: binance.WsKline{
+			StartTime: start,
+			EndTime:   start + 59999,
+			Symbol:    "BTCUSDT",
+			Interval:  "1m",
+			Open:      open,
+			High:      high,
+			Low:       low,
+			Close:     close,
+			Volume:    "10",
+			IsFinal:   true,
+		},
+	}
+}
+
+func runSpot(t *testing.T, latency time.Duration) (*Spot, *Result) {
+	info := new(binance.ExchangeInfo)
+	require.NoError(t, json.Unmarshal([]byte(exchangeInfo), info))
+	b, err := NewSpot(info, &paper.Config{Balances: map[string]string{"USDT": "1000"}}, &Config{Latency: latency})
+	require.NoError(t, err)
+	client := b.Client()
+	var closes []string
+	err = b.AddKlines([]*binance.WsKlineEvent{
+		spotKline(0, "100", "112", "98", "110"),
+		spotKline(1, "111", "116", "109", "115"),
+		spotKline(2, "115", "121", "114", "120"),
+	}, func(event *binance.WsKlineEvent) {
+		closes = append(closes, event.Kline.Close)
+		if len(closes) == 1 {
+			_, err := client.NewCreateOrderService().Symbol("BTCUSDT").Side(binance.SideTypeBuy).
+				Type(binance.OrderTypeMarket).Quantity("1").Do(context.Background())
+			assert.NoError(t, err)
+		}
+	})
+	require.NoError(t, err)
+	res, err := b.Run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"110", "115", "120"}, closes)
+	return b, res
+}
+
+func TestSpotKlines(t *testing.T) {
+	b, res := runSpot(t, 0)
+	require.Len(t, res.Trades, 1)
+	trade := res.Trades[0]
+	assert.Equal(t, "BUY", trade.Side)
+	assert.Equal(t, "110", trade.Price)
+	assert.Equal(t, "1", trade.Quantity)
+	assert.Equal(t, "0.001", trade.Commission)
+	assert.Equal(t, "BTC", trade.CommissionAsset)
+	assert.Equal(t, int64(59999), trade.Time)
+	assert.Equal(t, "1000", res.StartEquity)
+	// 890 USDT and 0.999 BTC at 120
+	assert.Equal(t, "1009.88", res.EndEquity)
+	assert.Equal(t, []EquityPoint{
+		{Time: 0, Equity: "1000"},
+		{Time: 60000, Equity: "1000.889"},
+		{Time: 120000, Equity: "1004.885"},
+		{Time: 179999, Equity: "1009.88"},
+	}, res.EquityCurve)
+	assert.Equal(t, time.Unix(0, 179999*int64(time.Millisecond)), b.Now())
+
+	_, err := b.Client().NewKlinesService().Symbol("BTCUSDT").Interval("1m").Do(context.Background())
+	assert.Error(t, err)
+	_, err = b.Run(context.Background())
+	assert.Equal(t, ErrAlreadyRun, err)
+}
+
+func TestSpotLatency(t *testing.T) {
+	// the order reaches the exchange after the open of the next kline
+	_, res := runSpot(t, 100*time.Millisecond)
+	require.Len(t, res.Trades, 1)
+	assert.Equal(t, "111", res.Trades[0].Price)
+	assert.Equal(t, int64(60099), res.Trades[0].Time)
+}
+
+func TestSpotDepth(t *testing.T) {
+	info := new(binance.ExchangeInfo)
+	require.NoError(t, json.Unmarshal([]byte(exchangeInfo), info))
+	b, err := NewSpot(info, &paper.Config{Balances: map[string]string{"USDT": "1000"}}, nil)
+	require.NoError(t, err)
+	client := b.Client()
+	var updates int
+	b.AddDepth([]*binance.WsDepthEvent{
+		{Time: 1000, Symbol: "BTCUSDT", Bids: []binance.Bid{{Price: "99", Quantity: "1"}}, Asks: []binance.Ask{{Price: "101", Quantity: "1"}}},
+		{Time: 2000, Symbol: "BTCUSDT", Asks: []binance.Ask{{Price: "101.00", Quantity: "0"}, {Price: "99.5", Quantity: "2"}}},
+	}, func(event *binance.WsDepthEvent) {
+		updates++
+		if updates == 1 {
+			_, err := client.NewCreateOrderService().Symbol("BTCUSDT").Side(binance.SideTypeBuy).
+				Type(binance.OrderTypeLimit).TimeInForce(binance.TimeInForceTypeGTC).
+				Quantity("1").Price("100").Do(context.Background())
+			assert.NoError(t, err)
+		}
+	})
+	res, err := b.Run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, updates)
+	require.Len(t, res.Trades, 1)
+	assert.Equal(t, "100", res.Trades[0].Price)
+	assert.True(t, res.Trades[0].Maker)
+}
+
+func TestFuturesFunding(t *testing.T) {
+	info := new(futures.ExchangeInfo)
+	require.NoError(t, json.Unmarshal([]byte(exchangeInfo), info))
+	b, err := NewFutures(info, &paper.Config{Balances: map[string]string{"USDT": "1000"}}, &Config{SampleInterval: time.Hour})
+	require.NoError(t, err)
+	client := b.Client()
+	var trades int
+	b.AddAggTrades([]*futures.WsAggTradeEvent{
+		{Symbol: "BTCUSDT", Price: "100", Quantity: "1", TradeTime: 1000},
+		{Symbol: "BTCUSDT", Price: "110", Quantity: "1", TradeTime: 3000},
+	}, func(event *futures.WsAggTradeEvent) {
+		trades++
+		if trades == 1 {
+			_, err := client.NewCreateOrderService().Symbol("BTCUSDT").Side(futures.SideTypeBuy).
+				Type(futures.OrderTypeMarket).Quantity("1").Do(context.Background())
+			assert.NoError(t, err)
+		}
+	})
+	b.AddFundingRates([]*futures.FundingRate{
+		{Symbol: "BTCUSDT", FundingRate: "0.0001", FundingTime: 2000},
+	})
+	var reasons []futures.UserDataEventReasonType
+	b.SubscribeUserData(func(event *futures.WsUserDataEvent) {
+		if event.Event == futures.UserDataEventTypeAccountUpdate {
+			reasons = append(reasons, event.AccountUpdate.Reason)
+		}
+	})
+	res, err := b.Run(context.Background())
+	require.NoError(t, err)
+	require.Len(t, res.Trades, 1)
+	assert.Equal(t, "100", res.Trades[0].Price)
+	assert.Equal(t, "0.04", res.Trades[0].Commission)
+	assert.Equal(t, []Funding{{Time: 2000, Symbol: "BTCUSDT", Rate: "0.0001", Amount: "-0.01"}}, res.Fundings)
+	assert.Equal(t, []futures.UserDataEventReasonType{futures.UserDataEventReasonTypeOrder, futures.UserDataEventReasonTypeFundingFee}, reasons)
+	// wallet 1000 - 0.04 - 0.01 and 10 of unrealized profit
+	assert.Equal(t, "1009.95", res.EndEquity)
+	assert.Equal(t, []EquityPoint{{Time: 1000, Equity: "999.96"}, {Time: 3000, Equity: "1009.95"}}, res.EquityCurve)
+}
+
+func TestKlineTrades(t *testing.T) {
+	trades, err := klineTrades(0, 59999, "100", "105", "90", "95", "2")
+	require.NoError(t, err)
+	assert.Equal(t, []simulatedTrade{
+		{time: 0, price: "100", quantity: "0.5"},
+		{time: 19999, price: "105", quantity: "0.5"},
+		{time: 39998, price: "90", quantity: "0.5"},
+		{time: 59999, price: "95", quantity: "0.5"},
+	}, trades)
+
+	book := newDepthBook()
+	require.NoError(t, book.update([]common.PriceLevel{{Price: "1.0", Quantity: "1"}}, nil))
+	require.NoError(t, book.update([]common.PriceLevel{{Price: "1", Quantity: "0"}}, nil))
+	bids, _ := book.levels()
+	assert.Empty(t, bids)
+}
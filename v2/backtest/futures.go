@@ -0,0 +1,172 @@
+package backtest
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/Zamzam-Technology/go-binance/v2/futures"
+	"github.com/Zamzam-Technology/go-binance/v2/paper"
+)
+
+// Futures replay futures market data and funding rates to a strategy trading on a paper futures exchange
+type Futures struct {
+	r        *runner
+	exchange *paper.FuturesExchange
+	books    map[string]*depthBook
+}
+
+// NewFutures create a futures backtest of the symbols of info, see NewSpot
+func NewFutures(info *futures.ExchangeInfo, exchangeCfg *paper.Config, cfg *Config) (*Futures, error) {
+	b := &Futures{r: newRunner(cfg), books: make(map[string]*depthBook)}
+	xcfg := paper.Config{}
+	if exchangeCfg != nil {
+		xcfg = *exchangeCfg
+	}
+	xcfg.Now = b.r.now
+	xcfg.Transport = noMarketData{}
+	x, err := paper.NewFuturesExchange(info, &xcfg)
+	if err != nil {
+		return nil, err
+	}
+	b.exchange = x
+	b.r.exchange = x
+	b.r.equity = x.Equity
+	x.SubscribeUserData(b.onUserData)
+	return b, nil
+}
+
+// Client return a client whose requests reach the paper exchange after the latency
+func (b *Futures) Client() *futures.Client {
+	c := futures.NewClient("backtest", "backtest")
+	c.HTTPClient = &http.Client{Transport: b.r}
+	return c
+}
+
+// Exchange return the paper exchange of the backtest
+func (b *Futures) Exchange() *paper.FuturesExchange {
+	return b.exchange
+}
+
+// Now return the time of the replay
+func (b *Futures) Now() time.Time {
+	return b.r.now()
+}
+
+// SubscribeUserData call handler with the events of the user data stream of the paper exchange
+func (b *Futures) SubscribeUserData(handler futures.WsUserDataHandler) (unsubscribe func()) {
+	return b.exchange.SubscribeUserData(handler)
+}
+
+func (b *Futures) onUserData(event *futures.WsUserDataEvent) {
+	u := &event.OrderTradeUpdate
+	if event.Event != futures.UserDataEventTypeOrderTradeUpdate || u.ExecutionType != futures.OrderExecutionTypeTrade {
+		return
+	}
+	b.r.result.Trades = append(b.r.result.Trades, Trade{
+		Time:            u.TradeTime,
+		Symbol:          u.Symbol,
+		OrderID:         u.ID,
+		ClientOrderID:   u.ClientOrderID,
+		TradeID:         u.TradeID,
+		Side:            string(u.Side),
+		Price:           u.LastFilledPrice,
+		Quantity:        u.LastFilledQty,
+		Commission:      u.Commission,
+		CommissionAsset: u.CommissionAsset,
+		RealizedPnL:     u.RealizedPnL,
+		Maker:           u.IsMaker,
+	})
+}
+
+// AddKlines add klines to replay, see Spot.AddKlines
+func (b *Futures) AddKlines(events []*futures.WsKlineEvent, handler futures.WsKlineHandler) error {
+	for _, event := range events {
+		event := event
+		k := &event.Kline
+		if k.IsFinal {
+			trades, err := klineTrades(k.StartTime, k.EndTime, k.Open, k.High, k.Low, k.Close, k.Volume)
+			if err != nil {
+				return err
+			}
+			for _, t := range trades {
+				t := t
+				b.r.add(t.time, func() error {
+					return b.exchange.OnTrade(event.Symbol, t.price, t.quantity)
+				}, nil)
+			}
+		}
+		b.r.add(k.EndTime, nil, func() {
+			if handler != nil {
+				handler(event)
+			}
+		})
+	}
+	return nil
+}
+
+// AddAggTrades add aggregate trades to replay, traded on the exchange then delivered to handler
+func (b *Futures) AddAggTrades(events []*futures.WsAggTradeEvent, handler futures.WsAggTradeHandler) {
+	for _, event := range events {
+		event := event
+		b.r.add(event.TradeTime, func() error {
+			return b.exchange.OnTrade(event.Symbol, event.Price, event.Quantity)
+		}, func() {
+			if handler != nil {
+				handler(event)
+			}
+		})
+	}
+}
+
+// AddDepth add recorded diff depth updates to replay, see Spot.AddDepth
+func (b *Futures) AddDepth(events []*futures.WsDepthEvent, handler futures.WsDepthHandler) {
+	for _, event := range events {
+		event := event
+		b.r.add(event.Time, func() error {
+			book, ok := b.books[event.Symbol]
+			if !ok {
+				book = newDepthBook()
+				b.books[event.Symbol] = book
+			}
+			if err := book.update(event.Bids, event.Asks); err != nil {
+				return err
+			}
+			bids, asks := book.levels()
+			b.exchange.OnBook(event.Symbol, bids, asks)
+			return nil
+		}, func() {
+			if handler != nil {
+				handler(event)
+			}
+		})
+	}
+}
+
+// AddFundingRates add the funding rates to settle on the positions, e.g. from FundingRateService.
+// The payments are made at the funding time at the mark price of the exchange.
+func (b *Futures) AddFundingRates(rates []*futures.FundingRate) {
+	for _, rate := range rates {
+		rate := rate
+		b.r.add(rate.FundingTime, func() error {
+			amount, err := b.exchange.OnFunding(rate.Symbol, rate.FundingRate)
+			if err != nil {
+				return err
+			}
+			if amount != "0" {
+				b.r.result.Fundings = append(b.r.result.Fundings, Funding{
+					Time:   rate.FundingTime,
+					Symbol: rate.Symbol,
+					Rate:   rate.FundingRate,
+					Amount: amount,
+				})
+			}
+			return nil
+		}, nil)
+	}
+}
+
+// Run replay the events in time order and return the result, see Spot.Run
+func (b *Futures) Run(ctx context.Context) (*Result, error) {
+	return b.r.run(ctx)
+}
@@ -0,0 +1,155 @@
+package backtest
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/Zamzam-Technology/go-binance/v2"
+	"github.com/Zamzam-Technology/go-binance/v2/paper"
+)
+
+// Spot replay spot market data to a strategy trading on a paper spot exchange
+type Spot struct {
+	r        *runner
+	exchange *paper.SpotExchange
+	books    map[string]*depthBook
+}
+
+// NewSpot create a spot backtest of the symbols of info. The balances and the commission rates
+// of the account are set by exchangeCfg, whose Now and Transport are replaced by the backtest.
+func NewSpot(info *binance.ExchangeInfo, exchangeCfg *paper.Config, cfg *Config) (*Spot, error) {
+	b := &Spot{r: newRunner(cfg), books: make(map[string]*depthBook)}
+	xcfg := paper.Config{}
+	if exchangeCfg != nil {
+		xcfg = *exchangeCfg
+	}
+	xcfg.Now = b.r.now
+	xcfg.Transport = noMarketData{}
+	x, err := paper.NewSpotExchange(info, &xcfg)
+	if err != nil {
+		return nil, err
+	}
+	b.exchange = x
+	b.r.exchange = x
+	b.r.equity = x.Equity
+	x.SubscribeUserData(b.onUserData)
+	return b, nil
+}
+
+// Client return a client whose requests reach the paper exchange after the latency
+func (b *Spot) Client() *binance.Client {
+	c := binance.NewClient("backtest", "backtest")
+	c.HTTPClient = &http.Client{Transport: b.r}
+	return c
+}
+
+// Exchange return the paper exchange of the backtest
+func (b *Spot) Exchange() *paper.SpotExchange {
+	return b.exchange
+}
+
+// Now return the time of the replay
+func (b *Spot) Now() time.Time {
+	return b.r.now()
+}
+
+// SubscribeUserData call handler with the events of the user data stream of the paper exchange
+func (b *Spot) SubscribeUserData(handler binance.WsUserDataHandler) (unsubscribe func()) {
+	return b.exchange.SubscribeUserData(handler)
+}
+
+func (b *Spot) onUserData(event *binance.WsUserDataEvent) {
+	u := event.OrderUpdate
+	if event.Event != binance.UserDataEventTypeExecutionReport || u == nil || u.ExecutionType != binance.ExecutionTypeTrade {
+		return
+	}
+	b.r.result.Trades = append(b.r.result.Trades, Trade{
+		Time:            u.TransactionTime,
+		Symbol:          u.Symbol,
+		OrderID:         u.ID,
+		ClientOrderID:   u.ClientOrderID,
+		TradeID:         u.TradeID,
+		Side:            string(u.Side),
+		Price:           u.LastFilledPrice,
+		Quantity:        u.LastFilledQuantity,
+		Commission:      u.Commission,
+		CommissionAsset: u.CommissionAsset,
+		RealizedPnL:     "0",
+		Maker:           u.IsMaker,
+	})
+}
+
+// AddKlines add klines to replay, delivered to handler at their close time. A final kline is
+// also traded on the exchange as four trades of a quarter of its volume at the open, the low
+// and the high, and the close, so that the exchange never sees a price before the strategy
+// could have. handler may be nil to only drive the exchange.
+func (b *Spot) AddKlines(events []*binance.WsKlineEvent, handler binance.WsKlineHandler) error {
+	for _, event := range events {
+		event := event
+		k := &event.Kline
+		if k.IsFinal {
+			trades, err := klineTrades(k.StartTime, k.EndTime, k.Open, k.High, k.Low, k.Close, k.Volume)
+			if err != nil {
+				return err
+			}
+			for _, t := range trades {
+				t := t
+				b.r.add(t.time, func() error {
+					return b.exchange.OnTrade(event.Symbol, t.price, t.quantity)
+				}, nil)
+			}
+		}
+		b.r.add(k.EndTime, nil, func() {
+			if handler != nil {
+				handler(event)
+			}
+		})
+	}
+	return nil
+}
+
+// AddAggTrades add aggregate trades to replay, traded on the exchange then delivered to handler
+func (b *Spot) AddAggTrades(events []*binance.WsAggTradeEvent, handler binance.WsAggTradeHandler) {
+	for _, event := range events {
+		event := event
+		b.r.add(event.TradeTime, func() error {
+			return b.exchange.OnTrade(event.Symbol, event.Price, event.Quantity)
+		}, func() {
+			if handler != nil {
+				handler(event)
+			}
+		})
+	}
+}
+
+// AddDepth add recorded diff depth updates to replay. The book of each symbol is built from
+// the updates, replaces the book of the exchange, then the update is delivered to handler.
+func (b *Spot) AddDepth(events []*binance.WsDepthEvent, handler binance.WsDepthHandler) {
+	for _, event := range events {
+		event := event
+		b.r.add(event.Time, func() error {
+			book, ok := b.books[event.Symbol]
+			if !ok {
+				book = newDepthBook()
+				b.books[event.Symbol] = book
+			}
+			if err := book.update(event.Bids, event.Asks); err != nil {
+				return err
+			}
+			bids, asks := book.levels()
+			b.exchange.OnBook(event.Symbol, bids, asks)
+			return nil
+		}, func() {
+			if handler != nil {
+				handler(event)
+			}
+		})
+	}
+}
+
+// Run replay the events in time order and return the result. The handlers are called in the
+// goroutine of Run and must call the client synchronously. A backtest can only be run once.
+func (b *Spot) Run(ctx context.Context) (*Result, error) {
+	return b.r.run(ctx)
+}
@@ -0,0 +1,500 @@
+// Package binancetest run a local fake Binance server for integration tests.
+//
+// Server is an httptest server emulating the REST endpoints of spot, usd(s)-m futures and
+// coin-m futures on a single host, and a websocket server for their streams. The requests of
+// the real clients are checked like the exchange does: the API key, the HMAC signature and the
+// timestamp of the signed requests. The orders and balances are kept by the paper exchanges of
+// the paper package, which also emit the events pushed to the user data streams, and the other
+// endpoints answer the responses stubbed with Stub. Tests may inject errors with Fail and close
+// the streams with Disconnect.
+package binancetest
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Zamzam-Technology/go-binance/v2"
+	"github.com/Zamzam-Technology/go-binance/v2/common"
+	"github.com/Zamzam-Technology/go-binance/v2/delivery"
+	"github.com/Zamzam-Technology/go-binance/v2/futures"
+	"github.com/Zamzam-Technology/go-binance/v2/paper"
+	"github.com/gorilla/websocket"
+)
+
+// Market is a market emulated by the server
+type Market string
+
+// Markets
+const (
+	MarketSpot     Market = "spot"
+	MarketFutures  Market = "futures"
+	MarketDelivery Market = "delivery"
+)
+
+// defaultRecvWindow is the receive window of the signed requests without recvWindow, in ms
+const defaultRecvWindow = 5000
+
+// Config define the accounts and the exchange info of a server
+type Config struct {
+	// APIKey and SecretKey are the credentials accepted by the server, "key" and "secret" by default
+	APIKey    string
+	SecretKey string
+	// Now return the time of the server, time.Now by default
+	Now func() time.Time
+	// SpotExchangeInfo, FuturesExchangeInfo and DeliveryExchangeInfo are served by the
+	// exchangeInfo endpoints, and define the symbols traded by the paper exchanges
+	SpotExchangeInfo     *binance.ExchangeInfo
+	FuturesExchangeInfo  *futures.ExchangeInfo
+	DeliveryExchangeInfo *delivery.ExchangeInfo
+	// Spot, Futures and Delivery are the accounts of the paper exchanges. Their Now and
+	// Transport are set by the server.
+	Spot     *paper.Config
+	Futures  *paper.Config
+	Delivery *paper.Config
+}
+
+// Failure is an error response injected with Fail
+type Failure struct {
+	// Status is the HTTP status of the response. A zero Status close the connection without
+	// any response.
+	Status  int
+	Code    int64
+	Message string
+}
+
+// Failures of the exchange
+var (
+	TooManyRequests = Failure{
+		Status:  http.StatusTooManyRequests,
+		Code:    -1003,
+		Message: "Too many requests; current limit is 1200 request weight per 1 MINUTE.",
+	}
+	InvalidTimestamp = Failure{
+		Status:  http.StatusBadRequest,
+		Code:    -1021,
+		Message: "Timestamp for this request is outside of the recvWindow.",
+	}
+	InvalidSignature = Failure{
+		Status:  http.StatusBadRequest,
+		Code:    -1022,
+		Message: "Signature for this request is not valid.",
+	}
+	InvalidAPIKey = Failure{
+		Status:  http.StatusUnauthorized,
+		Code:    -2015,
+		Message: "Invalid API-key, IP, or permissions for action.",
+	}
+	UnknownListenKey = Failure{
+		Status:  http.StatusBadRequest,
+		Code:    -1125,
+		Message: "This listenKey does not exist.",
+	}
+	ConnectionReset = Failure{}
+)
+
+type failure struct {
+	method string
+	path   string
+	Failure
+}
+
+// Server is a fake Binance server. It is safe for concurrent use.
+type Server struct {
+	// Spot, Futures and Delivery are the paper exchanges serving the orders and the accounts,
+	// drive their books with OnBook and OnTrade
+	Spot     *paper.SpotExchange
+	Futures  *paper.FuturesExchange
+	Delivery *paper.DeliveryExchange
+	// Stubs answer the requests not emulated by the server
+	*common.Stubs
+
+	cfg      Config
+	now      func() time.Time
+	http     *httptest.Server
+	upgrader websocket.Upgrader
+
+	mu          sync.Mutex
+	failures    []failure
+	listenKeys  map[string]Market
+	nextKey     int
+	conns       map[*wsConn]bool
+	unsubscribe []func()
+}
+
+// NewServer start a server, which must be closed with Close
+func NewServer(cfg *Config) (*Server, error) {
+	s := &Server{
+		Stubs:      common.NewStubs(),
+		now:        time.Now,
+		listenKeys: make(map[string]Market),
+		conns:      make(map[*wsConn]bool),
+		upgrader: websocket.Upgrader{
+			CheckOrigin: func(r *http.Request) bool { return true },
+		},
+	}
+	if cfg != nil {
+		s.cfg = *cfg
+	}
+	if s.cfg.APIKey == "" {
+		s.cfg.APIKey = "key"
+	}
+	if s.cfg.SecretKey == "" {
+		s.cfg.SecretKey = "secret"
+	}
+	if s.cfg.Now != nil {
+		s.now = s.cfg.Now
+	}
+	spotInfo := s.cfg.SpotExchangeInfo
+	if spotInfo == nil {
+		spotInfo = new(binance.ExchangeInfo)
+	}
+	futuresInfo := s.cfg.FuturesExchangeInfo
+	if futuresInfo == nil {
+		futuresInfo = new(futures.ExchangeInfo)
+	}
+	deliveryInfo := s.cfg.DeliveryExchangeInfo
+	if deliveryInfo == nil {
+		deliveryInfo = new(delivery.ExchangeInfo)
+	}
+	var err error
+	if s.Spot, err = paper.NewSpotExchange(spotInfo, s.paperConfig(s.cfg.Spot)); err != nil {
+		return nil, err
+	}
+	if s.Futures, err = paper.NewFuturesExchange(futuresInfo, s.paperConfig(s.cfg.Futures)); err != nil {
+		return nil, err
+	}
+	if s.Delivery, err = paper.NewDeliveryExchange(deliveryInfo, s.paperConfig(s.cfg.Delivery)); err != nil {
+		return nil, err
+	}
+	s.unsubscribe = append(s.unsubscribe,
+		s.Spot.SubscribeUserData(func(event *binance.WsUserDataEvent) {
+			s.PushUserData(MarketSpot, event)
+		}),
+		s.Futures.SubscribeUserData(func(event *futures.WsUserDataEvent) {
+			s.PushUserData(MarketFutures, event)
+		}),
+		s.Delivery.SubscribeUserData(func(event *delivery.WsUserDataEvent) {
+			s.PushUserData(MarketDelivery, event)
+		}),
+	)
+	s.http = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s, nil
+}
+
+func (s *Server) paperConfig(cfg *paper.Config) *paper.Config {
+	c := new(paper.Config)
+	if cfg != nil {
+		*c = *cfg
+	}
+	c.Now = s.now
+	c.Transport = s.Stubs
+	return c
+}
+
+// URL return the base URL of the REST endpoints, e.g. "http://127.0.0.1:8080"
+func (s *Server) URL() string {
+	return s.http.URL
+}
+
+// WebsocketURL return the base URL of the streams of market, for the WebsocketBaseURL variable of
+// its package
+func (s *Server) WebsocketURL(market Market) string {
+	return "ws" + strings.TrimPrefix(s.http.URL, "http") + "/" + string(market)
+}
+
+// UseWebsocket point the streams of the binance, futures and delivery packages to the server,
+// and return a function restoring the previous endpoints
+func (s *Server) UseWebsocket() (restore func()) {
+	spot, fut, dlv := binance.WebsocketBaseURL, futures.WebsocketBaseURL, delivery.WebsocketBaseURL
+	binance.WebsocketBaseURL = s.WebsocketURL(MarketSpot)
+	futures.WebsocketBaseURL = s.WebsocketURL(MarketFutures)
+	delivery.WebsocketBaseURL = s.WebsocketURL(MarketDelivery)
+	return func() {
+		binance.WebsocketBaseURL, futures.WebsocketBaseURL, delivery.WebsocketBaseURL = spot, fut, dlv
+	}
+}
+
+// Client return a spot client of the server
+func (s *Server) Client() *binance.Client {
+	c := binance.NewClient(s.cfg.APIKey, s.cfg.SecretKey)
+	c.BaseURL = s.URL()
+	return c
+}
+
+// FuturesClient return a usd(s)-m futures client of the server
+func (s *Server) FuturesClient() *futures.Client {
+	c := futures.NewClient(s.cfg.APIKey, s.cfg.SecretKey)
+	c.BaseURL = s.URL()
+	return c
+}
+
+// DeliveryClient return a coin-m futures client of the server
+func (s *Server) DeliveryClient() *delivery.Client {
+	c := delivery.NewClient(s.cfg.APIKey, s.cfg.SecretKey)
+	c.BaseURL = s.URL()
+	return c
+}
+
+// Close disconnect the streams and stop the server
+func (s *Server) Close() {
+	for _, unsubscribe := range s.unsubscribe {
+		unsubscribe()
+	}
+	s.Disconnect()
+	s.http.Close()
+}
+
+// Fail answer the next request of method to path with f, once. An empty method or path match
+// any request, e.g. Fail("", "", TooManyRequests) fail the next request.
+func (s *Server) Fail(method, path string, f Failure) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = append(s.failures, failure{method: method, path: path, Failure: f})
+}
+
+func (s *Server) takeFailure(method, path string) (Failure, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, f := range s.failures {
+		if (f.method == "" || f.method == method) && (f.path == "" || f.path == path) {
+			s.failures = append(s.failures[:i:i], s.failures[i+1:]...)
+			return f.Failure, true
+		}
+	}
+	return Failure{}, false
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if market, ok := streamMarket(r.URL.Path); ok {
+		s.serveStream(market, w, r)
+		return
+	}
+	if f, ok := s.takeFailure(r.Method, r.URL.Path); ok {
+		writeFailure(w, f)
+		return
+	}
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if f, ok := s.authenticate(r, string(data)); !ok {
+		writeFailure(w, f)
+		return
+	}
+	params := r.URL.Query()
+	form, err := url.ParseQuery(string(data))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	for key, values := range form {
+		params[key] = append(params[key], values...)
+	}
+
+	var handler http.RoundTripper = s.Stubs
+	if !s.Stubbed(r.Method, r.URL.Path) {
+		if res, ok := s.serveEndpoint(r.Method, r.URL.Path, params); ok {
+			writeJSON(w, res)
+			return
+		}
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/api/"):
+			handler = s.Spot
+		case strings.HasPrefix(r.URL.Path, "/fapi/"):
+			handler = s.Futures
+		case strings.HasPrefix(r.URL.Path, "/dapi/"):
+			handler = s.Delivery
+		}
+	}
+	req, err := http.NewRequest(r.Method, r.URL.String(), strings.NewReader(string(data)))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	req.Header = r.Header
+	res, err := handler.RoundTrip(req)
+	if err != nil {
+		// the transport errors of the stubs close the connection, like a network failure
+		writeFailure(w, ConnectionReset)
+		return
+	}
+	defer res.Body.Close()
+	for key, values := range res.Header {
+		w.Header()[key] = values
+	}
+	w.WriteHeader(res.StatusCode)
+	body, _ := ioutil.ReadAll(res.Body)
+	w.Write(body)
+}
+
+// authenticate check the API key of the requests sending one, and the signature and the
+// timestamp of the signed requests
+func (s *Server) authenticate(r *http.Request, body string) (Failure, bool) {
+	query := r.URL.RawQuery
+	values := r.URL.Query()
+	apiKey := r.Header.Get("X-MBX-APIKEY")
+	signed := values.Get("signature") != "" || values.Get("timestamp") != ""
+	if apiKey == "" && !signed {
+		return Failure{}, true
+	}
+	if apiKey != s.cfg.APIKey {
+		return InvalidAPIKey, false
+	}
+	if !signed {
+		return Failure{}, true
+	}
+	i := strings.LastIndex(query, "signature=")
+	if i < 0 {
+		return mandatory("signature"), false
+	}
+	payload := strings.TrimSuffix(query[:i], "&") + body
+	mac := hmac.New(sha256.New, []byte(s.cfg.SecretKey))
+	mac.Write([]byte(payload))
+	signature, err := hex.DecodeString(values.Get("signature"))
+	if err != nil || !hmac.Equal(signature, mac.Sum(nil)) {
+		return InvalidSignature, false
+	}
+	params, _ := url.ParseQuery(body)
+	timestampParam := values.Get("timestamp")
+	if timestampParam == "" {
+		timestampParam = params.Get("timestamp")
+	}
+	timestamp, err := strconv.ParseInt(timestampParam, 10, 64)
+	if err != nil {
+		return mandatory("timestamp"), false
+	}
+	recvWindow := int64(defaultRecvWindow)
+	if v := values.Get("recvWindow"); v != "" {
+		if recvWindow, err = strconv.ParseInt(v, 10, 64); err != nil {
+			return mandatory("recvWindow"), false
+		}
+	}
+	now := s.now().UnixNano() / int64(time.Millisecond)
+	// the exchange accept the timestamps at most 1s ahead of its clock
+	if timestamp > now+1000 || now-timestamp > recvWindow {
+		return InvalidTimestamp, false
+	}
+	return Failure{}, true
+}
+
+func mandatory(name string) Failure {
+	return Failure{
+		Status:  http.StatusBadRequest,
+		Code:    -1102,
+		Message: fmt.Sprintf("Mandatory parameter '%s' was not sent, was empty/null, or malformed.", name),
+	}
+}
+
+// serveEndpoint serve the general and the listen key endpoints of the markets
+func (s *Server) serveEndpoint(method, path string, params url.Values) (interface{}, bool) {
+	switch path {
+	case "/api/v3/ping", "/fapi/v1/ping", "/dapi/v1/ping":
+		return struct{}{}, true
+	case "/api/v3/time", "/fapi/v1/time", "/dapi/v1/time":
+		return map[string]int64{"serverTime": s.now().UnixNano() / int64(time.Millisecond)}, true
+	case "/api/v3/exchangeInfo":
+		return s.cfg.SpotExchangeInfo, s.cfg.SpotExchangeInfo != nil
+	case "/fapi/v1/exchangeInfo":
+		return s.cfg.FuturesExchangeInfo, s.cfg.FuturesExchangeInfo != nil
+	case "/dapi/v1/exchangeInfo":
+		return s.cfg.DeliveryExchangeInfo, s.cfg.DeliveryExchangeInfo != nil
+	case "/api/v3/userDataStream", "/sapi/v1/userDataStream", "/sapi/v1/userDataStream/isolated":
+		return s.serveListenKey(MarketSpot, method, params), true
+	case "/fapi/v1/listenKey":
+		return s.serveListenKey(MarketFutures, method, params), true
+	case "/dapi/v1/listenKey":
+		return s.serveListenKey(MarketDelivery, method, params), true
+	}
+	return nil, false
+}
+
+func (s *Server) serveListenKey(market Market, method string, params url.Values) interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if method == http.MethodPost {
+		s.nextKey++
+		key := fmt.Sprintf("%s%060d", market, s.nextKey)
+		s.listenKeys[key] = market
+		return map[string]string{"listenKey": key}
+	}
+	key := params.Get("listenKey")
+	// the futures endpoints do not require the listen key, the first one of the market is used
+	if key == "" {
+		for k, m := range s.listenKeys {
+			if m == market {
+				key = k
+				break
+			}
+		}
+	}
+	if _, ok := s.listenKeys[key]; !ok {
+		return UnknownListenKey
+	}
+	if method == http.MethodDelete {
+		delete(s.listenKeys, key)
+		for conn := range s.conns {
+			if conn.market == market && conn.streams[key] {
+				conn.close()
+			}
+		}
+	}
+	return struct{}{}
+}
+
+// ListenKeys return the number of open listen keys of market
+func (s *Server) ListenKeys(market Market) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := 0
+	for _, m := range s.listenKeys {
+		if m == market {
+			n++
+		}
+	}
+	return n
+}
+
+func writeJSON(w http.ResponseWriter, res interface{}) {
+	if f, ok := res.(Failure); ok {
+		writeFailure(w, f)
+		return
+	}
+	data, err := json.Marshal(res)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data)
+}
+
+func writeFailure(w http.ResponseWriter, f Failure) {
+	if f.Status == 0 {
+		if hijacker, ok := w.(http.Hijacker); ok {
+			if conn, _, err := hijacker.Hijack(); err == nil {
+				conn.Close()
+				return
+			}
+		}
+		f.Status = http.StatusInternalServerError
+	}
+	data, _ := json.Marshal(&common.APIError{Code: f.Code, Message: f.Message})
+	w.Header().Set("Content-Type", "application/json")
+	if f.Status == http.StatusTooManyRequests {
+		w.Header().Set("Retry-After", "1")
+	}
+	w.WriteHeader(f.Status)
+	w.Write(data)
+}
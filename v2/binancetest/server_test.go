@@ -0,0 +1,240 @@
+package binancetest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Zamzam-Technology/go-binance/v2"
+	"github.com/Zamzam-Technology/go-binance/v2/common"
+	"github.com/Zamzam-Technology/go-binance/v2/delivery"
+	"github.com/Zamzam-Technology/go-binance/v2/futures"
+	"github.com/Zamzam-Technology/go-binance/v2/paper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const exchangeInfo = `{
+	"symbols": [{
+		"symbol": "BTCUSDT",
+		"status": "TRADING",
+		"baseAsset": "BTC",
+		"quoteAsset": "USDT",
+		"marginAsset": "USDT",
+		"filters": [
+			{"filterType": "PRICE_FILTER", "minPrice": "0.01", "maxPrice": "1000000", "tickSize": "0.01"},
+			{"filterType": "LOT_SIZE", "minQty": "0.001", "maxQty": "100", "stepSize": "0.001"}
+		]
+	}]
+}`
+
+const deliveryExchangeInfo = `{
+	"symbols": [{
+		"symbol": "BTCUSD_PERP",
+		"pair": "BTCUSD",
+		"contractSize": 100,
+		"baseAsset": "BTC",
+		"quoteAsset": "USD",
+		"marginAsset": "BTC",
+		"filters": [
+			{"filterType": "PRICE_FILTER", "minPrice": "0.1", "maxPrice": "1000000", "tickSize": "0.1"},
+			{"filterType": "LOT_SIZE", "minQty": "1", "maxQty": "1000000", "stepSize": "1"}
+		]
+	}]
+}`
+
+func newTestServer(t *testing.T) *Server {
+	spotInfo := new(binance.ExchangeInfo)
+	require.NoError(t, json.Unmarshal([]byte(exchangeInfo), spotInfo))
+	futuresInfo := new(futures.ExchangeInfo)
+	require.NoError(t, json.Unmarshal([]byte(exchangeInfo), futuresInfo))
+	deliveryInfo := new(delivery.ExchangeInfo)
+	require.NoError(t, json.Unmarshal([]byte(deliveryExchangeInfo), deliveryInfo))
+	s, err := NewServer(&Config{
+		SpotExchangeInfo:     spotInfo,
+		FuturesExchangeInfo:  futuresInfo,
+		DeliveryExchangeInfo: deliveryInfo,
+		Spot:                 &paper.Config{Balances: map[string]string{"USDT": "10000"}},
+		Futures:              &paper.Config{Balances: map[string]string{"USDT": "10000"}},
+		Delivery:             &paper.Config{Balances: map[string]string{"BTC": "1"}},
+	})
+	require.NoError(t, err)
+	return s
+}
+
+func assertAPIError(t *testing.T, err error, code int64) {
+	apiErr, ok := err.(*common.APIError)
+	if assert.True(t, ok, "%v", err) {
+		assert.Equal(t, code, apiErr.Code)
+	}
+}
+
+func TestSpot(t *testing.T) {
+	s := newTestServer(t)
+	defer s.Close()
+	defer s.UseWebsocket()()
+	c := s.Client()
+	ctx := context.Background()
+
+	info, err := c.NewExchangeInfoService().Do(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "BTCUSDT", info.Symbols[0].Symbol)
+
+	listenKey, err := c.NewStartUserStreamService().Do(ctx)
+	require.NoError(t, err)
+	events := make(chan *binance.WsUserDataEvent, 10)
+	_, stopC, err := binance.WsUserDataEventServe(listenKey, func(event *binance.WsUserDataEvent) {
+		events <- event
+	}, func(err error) {})
+	require.NoError(t, err)
+	defer close(stopC)
+
+	s.Spot.OnBook("BTCUSDT", []common.PriceLevel{{Price: "99", Quantity: "10"}}, []common.PriceLevel{{Price: "100", Quantity: "10"}})
+	order, err := c.NewCreateOrderService().Symbol("BTCUSDT").Side(binance.SideTypeBuy).
+		Type(binance.OrderTypeMarket).Quantity("1").Do(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, binance.OrderStatusTypeFilled, order.Status)
+
+	// the execution reports of the new order and of its fill
+	for _, status := range []binance.OrderStatusType{binance.OrderStatusTypeNew, binance.OrderStatusTypeFilled} {
+		event := <-events
+		assert.Equal(t, binance.UserDataEventTypeExecutionReport, event.Event)
+		require.NotNil(t, event.OrderUpdate)
+		assert.Equal(t, order.OrderID, event.OrderUpdate.ID)
+		assert.Equal(t, status, event.OrderUpdate.Status)
+	}
+
+	account, err := c.NewGetAccountService().Do(ctx)
+	require.NoError(t, err)
+	balances := make(map[string]string)
+	for _, b := range account.Balances {
+		balances[b.Asset] = b.Free
+	}
+	assert.Equal(t, "0.999", balances["BTC"])
+	assert.Equal(t, "9900", balances["USDT"])
+
+	require.NoError(t, c.NewCloseUserStreamService().ListenKey(listenKey).Do(ctx))
+	assert.Equal(t, 0, s.ListenKeys(MarketSpot))
+	err = c.NewKeepaliveUserStreamService().ListenKey(listenKey).Do(ctx)
+	assertAPIError(t, err, -1125)
+}
+
+func TestAuthentication(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	s, err := NewServer(&Config{APIKey: "api", SecretKey: "s3cret", Now: func() time.Time { return now }})
+	require.NoError(t, err)
+	defer s.Close()
+	ctx := context.Background()
+
+	c := s.Client()
+	c.TimeOffset = time.Now().Sub(now).Nanoseconds() / int64(time.Millisecond)
+	_, err = c.NewGetAccountService().Do(ctx)
+	assert.NoError(t, err)
+
+	c.TimeOffset -= 2000
+	_, err = c.NewGetAccountService().Do(ctx)
+	assertAPIError(t, err, -1021)
+	c.TimeOffset += 2000 + 6000
+	_, err = c.NewGetAccountService().Do(ctx)
+	assertAPIError(t, err, -1021)
+	_, err = c.NewGetAccountService().Do(ctx, binance.WithRecvWindow(10000))
+	assert.NoError(t, err)
+
+	c.TimeOffset -= 6000
+	c.SecretKey = "secret"
+	_, err = c.NewGetAccountService().Do(ctx)
+	assertAPIError(t, err, -1022)
+	c.APIKey = "key"
+	_, err = c.NewGetAccountService().Do(ctx)
+	assertAPIError(t, err, -2015)
+	// the public endpoints need no key
+	assert.NoError(t, c.NewPingService().Do(ctx))
+}
+
+func TestFail(t *testing.T) {
+	s := newTestServer(t)
+	defer s.Close()
+	c := s.FuturesClient()
+	ctx := context.Background()
+
+	s.Fail(http.MethodPost, "/fapi/v1/order", TooManyRequests)
+	s.Fail("", "", ConnectionReset)
+	assert.Error(t, c.NewPingService().Do(ctx))
+	order := c.NewCreateOrderService().Symbol("BTCUSDT").Side(futures.SideTypeBuy).
+		Type(futures.OrderTypeLimit).TimeInForce(futures.TimeInForceTypeGTC).Quantity("1").Price("90")
+	_, err := order.Do(ctx)
+	assertAPIError(t, err, -1003)
+
+	res, err := order.Do(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, futures.OrderStatusTypeNew, res.Status)
+	orders, err := c.NewListOpenOrdersService().Symbol("BTCUSDT").Do(ctx)
+	require.NoError(t, err)
+	assert.Len(t, orders, 1)
+}
+
+func TestDelivery(t *testing.T) {
+	s := newTestServer(t)
+	defer s.Close()
+	defer s.UseWebsocket()()
+	c := s.DeliveryClient()
+	ctx := context.Background()
+
+	listenKey, err := c.NewStartUserStreamService().Do(ctx)
+	require.NoError(t, err)
+	userEvents := make(chan *delivery.WsUserDataEvent, 10)
+	_, stopC, err := delivery.WsUserDataEventServe(listenKey, func(event *delivery.WsUserDataEvent) {
+		userEvents <- event
+	}, func(err error) {})
+	require.NoError(t, err)
+	defer close(stopC)
+
+	s.Delivery.OnBook("BTCUSD_PERP", []common.PriceLevel{{Price: "49999", Quantity: "100"}}, []common.PriceLevel{{Price: "50000", Quantity: "100"}})
+	order, err := c.NewCreateOrderService().Symbol("BTCUSD_PERP").Side(delivery.SideTypeBuy).
+		Type(delivery.OrderTypeMarket).Quantity("10").Do(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, delivery.OrderStatusTypeFilled, order.Status)
+	assert.Equal(t, "0.02", order.CumBase)
+
+	// the order trade updates of the new order and of its fill, then the account update
+	for _, status := range []delivery.OrderStatusType{delivery.OrderStatusTypeNew, delivery.OrderStatusTypeFilled} {
+		event := <-userEvents
+		assert.Equal(t, delivery.UserDataEventTypeOrderTradeUpdate, event.Event)
+		require.NotNil(t, event.OrderTradeUpdate)
+		assert.Equal(t, order.OrderID, event.OrderTradeUpdate.ID)
+		assert.Equal(t, status, event.OrderTradeUpdate.Status)
+	}
+	event := <-userEvents
+	assert.Equal(t, delivery.UserDataEventTypeAccountUpdate, event.Event)
+	require.NotNil(t, event.AccountUpdate)
+	require.Len(t, event.AccountUpdate.Positions, 1)
+	assert.Equal(t, "10", event.AccountUpdate.Positions[0].Amount)
+
+	// the stubs answer before the paper exchange
+	require.NoError(t, s.Stub(http.MethodGet, "/dapi/v1/openOrders", `[{"symbol": "BTCUSD_PERP", "orderId": 100}]`))
+	orders, err := c.NewListOpenOrdersService().Do(ctx)
+	require.NoError(t, err)
+	require.Len(t, orders, 1)
+	assert.Equal(t, int64(100), orders[0].OrderID)
+
+	events := make(chan *delivery.WsMarkPriceEvent, 1)
+	errs := make(chan error, 1)
+	doneC, _, err := delivery.WsMarkPriceServe("BTCUSD_PERP", func(event *delivery.WsMarkPriceEvent) {
+		events <- event
+	}, func(err error) {
+		errs <- err
+	})
+	require.NoError(t, err)
+	n, err := s.Push(MarketDelivery, "btcusd_perp@markPrice", `{"e": "markPriceUpdate", "s": "BTCUSD_PERP", "p": "50000"}`)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	markPrice := <-events
+	assert.Equal(t, "BTCUSD_PERP", markPrice.Symbol)
+	assert.Equal(t, "50000", markPrice.MarkPrice)
+
+	s.Disconnect()
+	assert.Error(t, <-errs)
+	<-doneC
+}
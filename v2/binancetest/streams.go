@@ -0,0 +1,262 @@
+package binancetest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/Zamzam-Technology/go-binance/v2"
+	"github.com/gorilla/websocket"
+)
+
+// wsConn is a websocket connection of a client to raw streams under /ws, or to combined
+// streams under /stream
+type wsConn struct {
+	market   Market
+	streams  map[string]bool
+	combined bool
+
+	mu   sync.Mutex
+	conn *websocket.Conn
+	// pending are the messages written before the end of the upgrade
+	pending [][]byte
+	closed  bool
+}
+
+var errConnClosed = errors.New("binancetest: connection closed")
+
+func (c *wsConn) write(stream string, data []byte) error {
+	if c.combined {
+		var err error
+		data, err = json.Marshal(struct {
+			Stream string          `json:"stream"`
+			Data   json.RawMessage `json:"data"`
+		}{stream, data})
+		if err != nil {
+			return err
+		}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.conn == nil {
+		if c.closed {
+			return errConnClosed
+		}
+		c.pending = append(c.pending, data)
+		return nil
+	}
+	return c.conn.WriteMessage(websocket.TextMessage, data)
+}
+
+// connect set the upgraded connection and send the pending messages
+func (c *wsConn) connect(conn *websocket.Conn) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.conn = conn
+	if c.closed {
+		return errConnClosed
+	}
+	for _, data := range c.pending {
+		if err := conn.WriteMessage(websocket.TextMessage, data); err != nil {
+			return err
+		}
+	}
+	c.pending = nil
+	return nil
+}
+
+func (c *wsConn) close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closed = true
+	if c.conn != nil {
+		c.conn.Close()
+	}
+}
+
+// streamMarket return the market of the websocket endpoints, e.g. /spot/ws/btcusdt@depth
+func streamMarket(path string) (Market, bool) {
+	for _, market := range []Market{MarketSpot, MarketFutures, MarketDelivery} {
+		if strings.HasPrefix(path, "/"+string(market)+"/") {
+			return market, true
+		}
+	}
+	return "", false
+}
+
+func (s *Server) serveStream(market Market, w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/"+string(market))
+	conn := &wsConn{market: market, streams: make(map[string]bool)}
+	var streams []string
+	switch {
+	case strings.HasPrefix(path, "/ws/"):
+		streams = []string{strings.TrimPrefix(path, "/ws/")}
+	case path == "/stream":
+		conn.combined = true
+		streams = strings.Split(r.URL.Query().Get("streams"), "/")
+	}
+	for _, stream := range streams {
+		if stream != "" {
+			conn.streams[stream] = true
+		}
+	}
+	if len(conn.streams) == 0 {
+		http.NotFound(w, r)
+		return
+	}
+	// the connection is registered before the client sees the upgrade, the events pushed
+	// until the upgrade is done are queued
+	s.mu.Lock()
+	s.conns[conn] = true
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.conns, conn)
+		s.mu.Unlock()
+	}()
+	c, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer c.Close()
+	if err := conn.connect(c); err != nil {
+		return
+	}
+
+	// read the messages of the client until the connection is closed, to answer the pings
+	for {
+		if _, _, err := c.ReadMessage(); err != nil {
+			break
+		}
+	}
+}
+
+// Connections return the number of websocket connections to the streams of market
+func (s *Server) Connections(market Market) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := 0
+	for conn := range s.conns {
+		if conn.market == market {
+			n++
+		}
+	}
+	return n
+}
+
+// Disconnect close the websocket connections of the clients
+func (s *Server) Disconnect() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for conn := range s.conns {
+		conn.close()
+	}
+}
+
+func encodeEvent(event interface{}) ([]byte, error) {
+	switch e := event.(type) {
+	case []byte:
+		return e, nil
+	case string:
+		return []byte(e), nil
+	}
+	return json.Marshal(event)
+}
+
+// Push send event to the clients of stream of market, e.g. Push(MarketSpot, "btcusdt@kline_1m",
+// event), and return the number of connections it was sent to. event is sent as is when it is
+// a string or a []byte, and marshaled to JSON otherwise.
+func (s *Server) Push(market Market, stream string, event interface{}) (int, error) {
+	data, err := encodeEvent(event)
+	if err != nil {
+		return 0, err
+	}
+	s.mu.Lock()
+	var conns []*wsConn
+	for conn := range s.conns {
+		if conn.market == market && conn.streams[stream] {
+			conns = append(conns, conn)
+		}
+	}
+	s.mu.Unlock()
+	return s.send(conns, stream, data), nil
+}
+
+// PushUserData send event to the user data streams of market, and return the number of
+// connections it was sent to. The events of the paper exchanges are pushed by the server.
+// The *binance.WsUserDataEvent are sent in the format of the exchange, other events are
+// sent like with Push.
+func (s *Server) PushUserData(market Market, event interface{}) (int, error) {
+	var data []byte
+	var err error
+	if e, ok := event.(*binance.WsUserDataEvent); ok {
+		data, err = encodeSpotUserDataEvent(e)
+	} else {
+		data, err = encodeEvent(event)
+	}
+	if err != nil {
+		return 0, err
+	}
+	s.mu.Lock()
+	conns := make(map[*wsConn]string)
+	for conn := range s.conns {
+		if conn.market != market {
+			continue
+		}
+		for stream := range conn.streams {
+			if s.listenKeys[stream] == market {
+				conns[conn] = stream
+				break
+			}
+		}
+	}
+	s.mu.Unlock()
+	n := 0
+	for conn, stream := range conns {
+		n += s.send([]*wsConn{conn}, stream, data)
+	}
+	return n, nil
+}
+
+func (s *Server) send(conns []*wsConn, stream string, data []byte) int {
+	n := 0
+	for _, conn := range conns {
+		if err := conn.write(stream, data); err == nil {
+			n++
+		}
+	}
+	return n
+}
+
+// encodeSpotUserDataEvent encode event like the exchange: the fields of its update and the
+// event type and time at the top level
+func encodeSpotUserDataEvent(event *binance.WsUserDataEvent) ([]byte, error) {
+	var update interface{} = struct{}{}
+	switch {
+	case event.AccountUpdate != nil:
+		update = event.AccountUpdate
+	case event.BalanceUpdate != nil:
+		update = event.BalanceUpdate
+	case event.OrderUpdate != nil:
+		update = event.OrderUpdate
+	case event.OCOUpdate != nil:
+		update = event.OCOUpdate
+	}
+	data, err := json.Marshal(update)
+	if err != nil {
+		return nil, err
+	}
+	fields := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	if fields["e"], err = json.Marshal(event.Event); err != nil {
+		return nil, err
+	}
+	if fields["E"], err = json.Marshal(event.Time); err != nil {
+		return nil, err
+	}
+	return json.Marshal(fields)
+}
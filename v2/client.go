@@ -55,6 +55,18 @@ type MarginLoanStatusType string
 // MarginRepayStatusType define margin repay status type
 type MarginRepayStatusType string
 
+// MarginTransferStatusType define margin transfer status type
+type MarginTransferStatusType string
+
+// MarginTransferDirectionType define the direction of a margin transfer in the transfer history
+type MarginTransferDirectionType string
+
+// MarginInterestType define margin interest type
+type MarginInterestType string
+
+// IsolatedMarginAccountType define the accounts of an isolated margin transfer
+type IsolatedMarginAccountType string
+
 // FuturesTransferStatusType define futures transfer status type
 type FuturesTransferStatusType string
 
@@ -64,6 +76,30 @@ type SideEffectType string
 // FuturesTransferType define futures transfer type
 type FuturesTransferType int
 
+// SelfTradePreventionMode define the self-trade prevention mode of an order
+type SelfTradePreventionMode string
+
+// CancelReplaceModeType define the behaviour of a cancel-replace order when the cancel fails
+type CancelReplaceModeType string
+
+// CancelRestrictionsType define the order status a cancel-replace order is allowed to cancel
+type CancelRestrictionsType string
+
+// OrderRateLimitExceededModeType define the behaviour of a cancel-replace order when the order rate limit is exceeded
+type OrderRateLimitExceededModeType string
+
+// CancelReplaceResultType define the result of each step of a cancel-replace order
+type CancelReplaceResultType string
+
+// KlineInterval define kline interval, like 15m or 1d
+type KlineInterval = common.KlineInterval
+
+// UserDataEventType define user data event type
+type UserDataEventType string
+
+// ExecutionType define the execution type of an order update
+type ExecutionType string
+
 // Endpoints
 const (
 	baseAPIMainURL    = "https://api.binance.com"
@@ -101,6 +137,7 @@ const (
 	OrderStatusTypePendingCancel   OrderStatusType = "PENDING_CANCEL"
 	OrderStatusTypeRejected        OrderStatusType = "REJECTED"
 	OrderStatusTypeExpired         OrderStatusType = "EXPIRED"
+	OrderStatusTypeExpiredInMatch  OrderStatusType = "EXPIRED_IN_MATCH"
 
 	SymbolTypeSpot SymbolType = "SPOT"
 
@@ -134,6 +171,21 @@ const (
 	MarginRepayStatusTypeConfirmed MarginRepayStatusType = "CONFIRMED"
 	MarginRepayStatusTypeFailed    MarginRepayStatusType = "FAILED"
 
+	MarginTransferStatusTypePending   MarginTransferStatusType = "PENDING"
+	MarginTransferStatusTypeConfirmed MarginTransferStatusType = "CONFIRMED"
+	MarginTransferStatusTypeFailed    MarginTransferStatusType = "FAILED"
+
+	MarginTransferDirectionTypeRollIn  MarginTransferDirectionType = "ROLL_IN"
+	MarginTransferDirectionTypeRollOut MarginTransferDirectionType = "ROLL_OUT"
+
+	MarginInterestTypePeriodic          MarginInterestType = "PERIODIC"
+	MarginInterestTypeOnBorrow          MarginInterestType = "ON_BORROW"
+	MarginInterestTypePeriodicConverted MarginInterestType = "PERIODIC_CONVERTED"
+	MarginInterestTypeOnBorrowConverted MarginInterestType = "ON_BORROW_CONVERTED"
+
+	IsolatedMarginAccountTypeSpot           IsolatedMarginAccountType = "SPOT"
+	IsolatedMarginAccountTypeIsolatedMargin IsolatedMarginAccountType = "ISOLATED_MARGIN"
+
 	FuturesTransferStatusTypePending   FuturesTransferStatusType = "PENDING"
 	FuturesTransferStatusTypeConfirmed FuturesTransferStatusType = "CONFIRMED"
 	FuturesTransferStatusTypeFailed    FuturesTransferStatusType = "FAILED"
@@ -142,6 +194,55 @@ const (
 	SideEffectTypeMarginBuy    SideEffectType = "MARGIN_BUY"
 	SideEffectTypeAutoRepay    SideEffectType = "AUTO_REPAY"
 
+	SelfTradePreventionModeNone        SelfTradePreventionMode = "NONE"
+	SelfTradePreventionModeExpireTaker SelfTradePreventionMode = "EXPIRE_TAKER"
+	SelfTradePreventionModeExpireMaker SelfTradePreventionMode = "EXPIRE_MAKER"
+	SelfTradePreventionModeExpireBoth  SelfTradePreventionMode = "EXPIRE_BOTH"
+
+	CancelReplaceModeTypeStopOnFailure CancelReplaceModeType = "STOP_ON_FAILURE"
+	CancelReplaceModeTypeAllowFailure  CancelReplaceModeType = "ALLOW_FAILURE"
+
+	CancelRestrictionsTypeOnlyNew             CancelRestrictionsType = "ONLY_NEW"
+	CancelRestrictionsTypeOnlyPartiallyFilled CancelRestrictionsType = "ONLY_PARTIALLY_FILLED"
+
+	OrderRateLimitExceededModeTypeDoNothing  OrderRateLimitExceededModeType = "DO_NOTHING"
+	OrderRateLimitExceededModeTypeCancelOnly OrderRateLimitExceededModeType = "CANCEL_ONLY"
+
+	CancelReplaceResultTypeSuccess      CancelReplaceResultType = "SUCCESS"
+	CancelReplaceResultTypeFailure      CancelReplaceResultType = "FAILURE"
+	CancelReplaceResultTypeNotAttempted CancelReplaceResultType = "NOT_ATTEMPTED"
+
+	UserDataEventTypeOutboundAccountPosition UserDataEventType = "outboundAccountPosition"
+	UserDataEventTypeBalanceUpdate           UserDataEventType = "balanceUpdate"
+	UserDataEventTypeExecutionReport         UserDataEventType = "executionReport"
+	UserDataEventTypeListStatus              UserDataEventType = "listStatus"
+	UserDataEventTypeListenKeyExpired        UserDataEventType = "listenKeyExpired"
+
+	ExecutionTypeNew             ExecutionType = "NEW"
+	ExecutionTypeCanceled        ExecutionType = "CANCELED"
+	ExecutionTypeReplaced        ExecutionType = "REPLACED"
+	ExecutionTypeRejected        ExecutionType = "REJECTED"
+	ExecutionTypeTrade           ExecutionType = "TRADE"
+	ExecutionTypeExpired         ExecutionType = "EXPIRED"
+	ExecutionTypeTradePrevention ExecutionType = "TRADE_PREVENTION"
+
+	KlineInterval1s  = common.KlineInterval1s
+	KlineInterval1m  = common.KlineInterval1m
+	KlineInterval3m  = common.KlineInterval3m
+	KlineInterval5m  = common.KlineInterval5m
+	KlineInterval15m = common.KlineInterval15m
+	KlineInterval30m = common.KlineInterval30m
+	KlineInterval1h  = common.KlineInterval1h
+	KlineInterval2h  = common.KlineInterval2h
+	KlineInterval4h  = common.KlineInterval4h
+	KlineInterval6h  = common.KlineInterval6h
+	KlineInterval8h  = common.KlineInterval8h
+	KlineInterval12h = common.KlineInterval12h
+	KlineInterval1d  = common.KlineInterval1d
+	KlineInterval3d  = common.KlineInterval3d
+	KlineInterval1w  = common.KlineInterval1w
+	KlineInterval1M  = common.KlineInterval1M
+
 	timestampKey  = "timestamp"
 	signatureKey  = "signature"
 	recvWindowKey = "recvWindow"
@@ -361,6 +462,11 @@ func (c *Client) NewKlinesService() *KlinesService {
 	return &KlinesService{c: c}
 }
 
+// NewKlineDownloader init kline downloader
+func (c *Client) NewKlineDownloader(symbol string, interval KlineInterval) *KlineDownloader {
+	return &KlineDownloader{c: c, symbol: symbol, interval: interval, limit: 1000, concurrency: 4}
+}
+
 // NewListPriceChangeStatsService init list prices change stats service
 func (c *Client) NewListPriceChangeStatsService() *ListPriceChangeStatsService {
 	return &ListPriceChangeStatsService{c: c}
@@ -386,6 +492,26 @@ func (c *Client) NewCreateOCOService() *CreateOCOService {
 	return &CreateOCOService{c: c}
 }
 
+// NewCreateOrderListOCOService init creating OCO order list service
+func (c *Client) NewCreateOrderListOCOService() *CreateOrderListOCOService {
+	return &CreateOrderListOCOService{c: c}
+}
+
+// NewGetOCOService init get OCO service
+func (c *Client) NewGetOCOService() *GetOCOService {
+	return &GetOCOService{c: c}
+}
+
+// NewListOCOService init list OCO service
+func (c *Client) NewListOCOService() *ListOCOService {
+	return &ListOCOService{c: c}
+}
+
+// NewListOpenOCOService init list open OCO service
+func (c *Client) NewListOpenOCOService() *ListOpenOCOService {
+	return &ListOpenOCOService{c: c}
+}
+
 // NewCancelOCOService init cancel OCO service
 func (c *Client) NewCancelOCOService() *CancelOCOService {
 	return &CancelOCOService{c: c}
@@ -396,6 +522,26 @@ func (c *Client) NewGetOrderService() *GetOrderService {
 	return &GetOrderService{c: c}
 }
 
+// NewListPreventedMatchesService init list prevented matches service
+func (c *Client) NewListPreventedMatchesService() *ListPreventedMatchesService {
+	return &ListPreventedMatchesService{c: c}
+}
+
+// NewCancelReplaceOrderService init cancel replace order service
+func (c *Client) NewCancelReplaceOrderService() *CancelReplaceOrderService {
+	return &CancelReplaceOrderService{c: c}
+}
+
+// NewCreateSOROrderService init creating SOR order service
+func (c *Client) NewCreateSOROrderService() *CreateSOROrderService {
+	return &CreateSOROrderService{c: c}
+}
+
+// NewListAllocationsService init list allocations service
+func (c *Client) NewListAllocationsService() *ListAllocationsService {
+	return &ListAllocationsService{c: c}
+}
+
 // NewCancelOrderService init cancel order service
 func (c *Client) NewCancelOrderService() *CancelOrderService {
 	return &CancelOrderService{c: c}
@@ -421,6 +567,21 @@ func (c *Client) NewGetAccountService() *GetAccountService {
 	return &GetAccountService{c: c}
 }
 
+// NewGetAccountCommissionService init get account commission service
+func (c *Client) NewGetAccountCommissionService() *GetAccountCommissionService {
+	return &GetAccountCommissionService{c: c}
+}
+
+// NewListTradeFeeService init list trade fee service
+func (c *Client) NewListTradeFeeService() *ListTradeFeeService {
+	return &ListTradeFeeService{c: c}
+}
+
+// NewGetOrderRateLimitService init get order rate limit service
+func (c *Client) NewGetOrderRateLimitService() *GetOrderRateLimitService {
+	return &GetOrderRateLimitService{c: c}
+}
+
 // NewGetAccountSnapshotService init getting account snapshot service
 func (c *Client) NewGetAccountSnapshotService() *GetAccountSnapshotService {
 	return &GetAccountSnapshotService{c: c}
@@ -476,6 +637,22 @@ func (c *Client) NewExchangeInfoService() *ExchangeInfoService {
 	return &ExchangeInfoService{c: c}
 }
 
+// NewSymbolRegistry init symbol registry, the exchange info is refreshed when older than ttl,
+// a zero ttl loads it only once
+func (c *Client) NewSymbolRegistry(ttl time.Duration) *SymbolRegistry {
+	return &SymbolRegistry{c: c, ttl: ttl, now: time.Now}
+}
+
+// NewOrderTracker init order tracker of the spot orders
+func (c *Client) NewOrderTracker() *OrderTracker {
+	return &OrderTracker{OrderTracker: common.NewOrderTracker(), c: c}
+}
+
+// NewMarginOrderTracker init order tracker of the cross margin orders, or of the isolated margin orders
+func (c *Client) NewMarginOrderTracker(isolated bool) *OrderTracker {
+	return &OrderTracker{OrderTracker: common.NewOrderTracker(), c: c, margin: true, isolated: isolated}
+}
+
 // NewGetAssetDetailService init get asset detail service
 func (c *Client) NewGetAssetDetailService() *GetAssetDetailService {
 	return &GetAssetDetailService{c: c}
@@ -526,6 +703,31 @@ func (c *Client) NewListMarginRepaysService() *ListMarginRepaysService {
 	return &ListMarginRepaysService{c: c}
 }
 
+// NewListMarginInterestsService init list margin interest service
+func (c *Client) NewListMarginInterestsService() *ListMarginInterestsService {
+	return &ListMarginInterestsService{c: c}
+}
+
+// NewListMarginForceLiquidationsService init list margin force liquidation service
+func (c *Client) NewListMarginForceLiquidationsService() *ListMarginForceLiquidationsService {
+	return &ListMarginForceLiquidationsService{c: c}
+}
+
+// NewListMarginInterestRatesService init list margin interest rate service
+func (c *Client) NewListMarginInterestRatesService() *ListMarginInterestRatesService {
+	return &ListMarginInterestRatesService{c: c}
+}
+
+// NewGetCrossMarginDataService init get cross margin data service
+func (c *Client) NewGetCrossMarginDataService() *GetCrossMarginDataService {
+	return &GetCrossMarginDataService{c: c}
+}
+
+// NewListMarginTransfersService init list margin transfer service
+func (c *Client) NewListMarginTransfersService() *ListMarginTransfersService {
+	return &ListMarginTransfersService{c: c}
+}
+
 // NewGetMarginAccountService init get margin account service
 func (c *Client) NewGetMarginAccountService() *GetMarginAccountService {
 	return &GetMarginAccountService{c: c}
@@ -536,6 +738,46 @@ func (c *Client) NewGetIsolatedMarginAccountService() *GetIsolatedMarginAccountS
 	return &GetIsolatedMarginAccountService{c: c}
 }
 
+// NewIsolatedMarginTransferService init isolated margin account transfer service
+func (c *Client) NewIsolatedMarginTransferService() *IsolatedMarginTransferService {
+	return &IsolatedMarginTransferService{c: c}
+}
+
+// NewListIsolatedMarginTransfersService init list isolated margin transfers service
+func (c *Client) NewListIsolatedMarginTransfersService() *ListIsolatedMarginTransfersService {
+	return &ListIsolatedMarginTransfersService{c: c}
+}
+
+// NewEnableIsolatedMarginAccountService init enable isolated margin account service
+func (c *Client) NewEnableIsolatedMarginAccountService() *EnableIsolatedMarginAccountService {
+	return &EnableIsolatedMarginAccountService{c: c}
+}
+
+// NewDisableIsolatedMarginAccountService init disable isolated margin account service
+func (c *Client) NewDisableIsolatedMarginAccountService() *DisableIsolatedMarginAccountService {
+	return &DisableIsolatedMarginAccountService{c: c}
+}
+
+// NewGetIsolatedMarginAccountLimitService init get isolated margin account limit service
+func (c *Client) NewGetIsolatedMarginAccountLimitService() *GetIsolatedMarginAccountLimitService {
+	return &GetIsolatedMarginAccountLimitService{c: c}
+}
+
+// NewGetIsolatedMarginPairService init get isolated margin pair service
+func (c *Client) NewGetIsolatedMarginPairService() *GetIsolatedMarginPairService {
+	return &GetIsolatedMarginPairService{c: c}
+}
+
+// NewGetIsolatedMarginAllPairsService init get isolated margin all pairs service
+func (c *Client) NewGetIsolatedMarginAllPairsService() *GetIsolatedMarginAllPairsService {
+	return &GetIsolatedMarginAllPairsService{c: c}
+}
+
+// NewGetIsolatedMarginTierService init get isolated margin tier service
+func (c *Client) NewGetIsolatedMarginTierService() *GetIsolatedMarginTierService {
+	return &GetIsolatedMarginTierService{c: c}
+}
+
 // NewGetMarginAssetService init get margin asset service
 func (c *Client) NewGetMarginAssetService() *GetMarginAssetService {
 	return &GetMarginAssetService{c: c}
@@ -556,6 +798,36 @@ func (c *Client) NewGetMarginPriceIndexService() *GetMarginPriceIndexService {
 	return &GetMarginPriceIndexService{c: c}
 }
 
+// NewCreateMarginOCOService init creating margin OCO service
+func (c *Client) NewCreateMarginOCOService() *CreateMarginOCOService {
+	return &CreateMarginOCOService{c: c}
+}
+
+// NewCancelMarginOCOService init cancel margin OCO service
+func (c *Client) NewCancelMarginOCOService() *CancelMarginOCOService {
+	return &CancelMarginOCOService{c: c}
+}
+
+// NewGetMarginOCOService init get margin OCO service
+func (c *Client) NewGetMarginOCOService() *GetMarginOCOService {
+	return &GetMarginOCOService{c: c}
+}
+
+// NewListMarginOCOService init list margin OCO service
+func (c *Client) NewListMarginOCOService() *ListMarginOCOService {
+	return &ListMarginOCOService{c: c}
+}
+
+// NewListMarginOpenOCOService init list margin open OCO service
+func (c *Client) NewListMarginOpenOCOService() *ListMarginOpenOCOService {
+	return &ListMarginOpenOCOService{c: c}
+}
+
+// NewCancelMarginOpenOrdersService init cancel margin open orders service
+func (c *Client) NewCancelMarginOpenOrdersService() *CancelMarginOpenOrdersService {
+	return &CancelMarginOpenOrdersService{c: c}
+}
+
 // NewListMarginOpenOrdersService init list margin open orders service
 func (c *Client) NewListMarginOpenOrdersService() *ListMarginOpenOrdersService {
 	return &ListMarginOpenOrdersService{c: c}
@@ -0,0 +1,339 @@
+package common
+
+import (
+	"archive/zip"
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ArchiveBaseURL is the root of the public data archive
+const ArchiveBaseURL = "https://data.binance.vision"
+
+var (
+	// ErrArchiveNotFound is returned when an archive file does not exist, e.g. a day not published yet
+	ErrArchiveNotFound = errors.New("archive file not found")
+	// ErrArchiveChecksum is returned when an archive file does not match its checksum
+	ErrArchiveChecksum = errors.New("archive checksum mismatch")
+)
+
+// ArchiveMarket define the market of an archive
+type ArchiveMarket string
+
+// ArchivePeriod define the period covered by an archive file
+type ArchivePeriod string
+
+// ArchiveDataType define the data of an archive
+type ArchiveDataType string
+
+// Archive enums
+const (
+	ArchiveMarketSpot  ArchiveMarket = "spot"
+	ArchiveMarketUSDM  ArchiveMarket = "futures/um"
+	ArchiveMarketCOINM ArchiveMarket = "futures/cm"
+
+	ArchivePeriodDaily   ArchivePeriod = "daily"
+	ArchivePeriodMonthly ArchivePeriod = "monthly"
+
+	ArchiveDataTypeKlines             ArchiveDataType = "klines"
+	ArchiveDataTypeMarkPriceKlines    ArchiveDataType = "markPriceKlines"
+	ArchiveDataTypeIndexPriceKlines   ArchiveDataType = "indexPriceKlines"
+	ArchiveDataTypePremiumIndexKlines ArchiveDataType = "premiumIndexKlines"
+	ArchiveDataTypeAggTrades          ArchiveDataType = "aggTrades"
+	ArchiveDataTypeTrades             ArchiveDataType = "trades"
+	ArchiveDataTypeBookTicker         ArchiveDataType = "bookTicker"
+)
+
+// ArchiveFile define a file of the archive.
+// Interval is only set for klines, Date is the day or the month of the file.
+type ArchiveFile struct {
+	Market   ArchiveMarket
+	Period   ArchivePeriod
+	DataType ArchiveDataType
+	Symbol   string
+	Interval KlineInterval
+	Date     time.Time
+}
+
+// Name return the name of the zip file, like BTCUSDT-1m-2021-03-18.zip
+func (f *ArchiveFile) Name() string {
+	date := f.Date.UTC().Format("2006-01-02")
+	if f.Period == ArchivePeriodMonthly {
+		date = f.Date.UTC().Format("2006-01")
+	}
+	kind := string(f.DataType)
+	if f.Interval != "" {
+		kind = string(f.Interval)
+	}
+	return fmt.Sprintf("%s-%s-%s.zip", f.Symbol, kind, date)
+}
+
+// Path return the path of the zip file from the archive root
+func (f *ArchiveFile) Path() string {
+	parts := []string{"data", string(f.Market), string(f.Period), string(f.DataType), f.Symbol}
+	if f.Interval != "" {
+		parts = append(parts, string(f.Interval))
+	}
+	return strings.Join(append(parts, f.Name()), "/")
+}
+
+// ArchiveFetcher fetch a file of the archive, path is relative to the archive root
+type ArchiveFetcher interface {
+	Fetch(ctx context.Context, path string) (io.ReadCloser, error)
+}
+
+// ArchiveFetcherFunc is an adapter to use a function as ArchiveFetcher
+type ArchiveFetcherFunc func(ctx context.Context, path string) (io.ReadCloser, error)
+
+// Fetch call f
+func (f ArchiveFetcherFunc) Fetch(ctx context.Context, path string) (io.ReadCloser, error) {
+	return f(ctx, path)
+}
+
+// HTTPArchiveFetcher fetch the archive files over http
+type HTTPArchiveFetcher struct {
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// NewHTTPArchiveFetcher init a fetcher of the public data archive
+func NewHTTPArchiveFetcher() *HTTPArchiveFetcher {
+	return &HTTPArchiveFetcher{BaseURL: ArchiveBaseURL, HTTPClient: http.DefaultClient}
+}
+
+// Fetch download the file
+func (f *HTTPArchiveFetcher) Fetch(ctx context.Context, path string) (io.ReadCloser, error) {
+	req, err := http.NewRequest(http.MethodGet, strings.TrimSuffix(f.BaseURL, "/")+"/"+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := f.HTTPClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode == http.StatusNotFound {
+		res.Body.Close()
+		return nil, ErrArchiveNotFound
+	}
+	if res.StatusCode != http.StatusOK {
+		res.Body.Close()
+		return nil, fmt.Errorf("fetch archive %s: http status %d", path, res.StatusCode)
+	}
+	return res.Body, nil
+}
+
+// DirArchiveFetcher read the archive files from a local mirror of the archive
+type DirArchiveFetcher string
+
+// Fetch open the file
+func (d DirArchiveFetcher) Fetch(ctx context.Context, path string) (io.ReadCloser, error) {
+	file, err := os.Open(filepath.Join(string(d), filepath.FromSlash(path)))
+	if os.IsNotExist(err) {
+		return nil, ErrArchiveNotFound
+	}
+	return file, err
+}
+
+// ArchiveReader read the csv records of an archive file
+type ArchiveReader struct {
+	file  io.ReadCloser
+	csv   *csv.Reader
+	first bool
+	// zip is the zip file, removed on Close when it is a temporary copy of the fetched body
+	zip  *os.File
+	temp bool
+}
+
+// OpenArchive fetch an archive file and its CHECKSUM file, verify the checksum and open the csv file inside
+func OpenArchive(ctx context.Context, fetcher ArchiveFetcher, file *ArchiveFile) (*ArchiveReader, error) {
+	path := file.Path()
+	expected, err := fetchChecksum(ctx, fetcher, path+".CHECKSUM")
+	if err != nil {
+		return nil, err
+	}
+	body, err := fetcher.Fetch(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	r := &ArchiveReader{first: true}
+	sum, err := r.spool(body)
+	if err != nil {
+		r.Close()
+		return nil, err
+	}
+	if !strings.EqualFold(sum, expected) {
+		r.Close()
+		return nil, fmt.Errorf("%w: %s", ErrArchiveChecksum, path)
+	}
+	if err := r.open(path); err != nil {
+		r.Close()
+		return nil, err
+	}
+	return r, nil
+}
+
+// spool keep the zip file in a file, zip needs random access, and return its sha256 sum.
+// A body read from a local file is used in place, any other body is copied to a temporary file.
+func (r *ArchiveReader) spool(body io.ReadCloser) (string, error) {
+	hash := sha256.New()
+	if file, ok := body.(*os.File); ok {
+		r.zip = file
+		if _, err := io.Copy(hash, file); err != nil {
+			return "", err
+		}
+		return hex.EncodeToString(hash.Sum(nil)), nil
+	}
+	defer body.Close()
+	file, err := ioutil.TempFile("", "binance-archive-*.zip")
+	if err != nil {
+		return "", err
+	}
+	r.zip, r.temp = file, true
+	if _, err := io.Copy(io.MultiWriter(file, hash), body); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// open open the csv file of the zip file
+func (r *ArchiveReader) open(path string) error {
+	info, err := r.zip.Stat()
+	if err != nil {
+		return err
+	}
+	archive, err := zip.NewReader(r.zip, info.Size())
+	if err != nil {
+		return err
+	}
+	if len(archive.File) == 0 {
+		return fmt.Errorf("empty archive %s", path)
+	}
+	csvFile, err := archive.File[0].Open()
+	if err != nil {
+		return err
+	}
+	r.file = csvFile
+	r.csv = csv.NewReader(bufio.NewReader(csvFile))
+	r.csv.FieldsPerRecord = -1
+	r.csv.ReuseRecord = true
+	return nil
+}
+
+func fetchChecksum(ctx context.Context, fetcher ArchiveFetcher, path string) (string, error) {
+	body, err := fetcher.Fetch(ctx, path)
+	if err != nil {
+		return "", err
+	}
+	defer body.Close()
+	data, err := ioutil.ReadAll(body)
+	if err != nil {
+		return "", err
+	}
+	// the file holds the sha256 sum followed by the file name, like sha256sum output
+	fields := strings.Fields(string(data))
+	if len(fields) == 0 {
+		return "", fmt.Errorf("invalid checksum file %s", path)
+	}
+	return fields[0], nil
+}
+
+// Read return the next record, or io.EOF at the end of the file.
+// The record is only valid until the next call.
+func (r *ArchiveReader) Read() (*ArchiveRecord, error) {
+	for {
+		fields, err := r.csv.Read()
+		if err != nil {
+			return nil, err
+		}
+		first := r.first
+		r.first = false
+		if first && len(fields) > 0 {
+			// recent files start with a header row
+			if _, err := strconv.ParseInt(fields[0], 10, 64); err != nil {
+				continue
+			}
+		}
+		return &ArchiveRecord{fields: fields}, nil
+	}
+}
+
+// Close close the csv file and the zip file, a temporary zip file is removed
+func (r *ArchiveReader) Close() error {
+	var err error
+	if r.file != nil {
+		err = r.file.Close()
+	}
+	if r.zip != nil {
+		if closeErr := r.zip.Close(); err == nil {
+			err = closeErr
+		}
+		if r.temp {
+			if removeErr := os.Remove(r.zip.Name()); err == nil {
+				err = removeErr
+			}
+		}
+	}
+	return err
+}
+
+// ArchiveRecord define a csv record of an archive file.
+// Parsing errors are kept and returned by Err.
+type ArchiveRecord struct {
+	fields []string
+	err    error
+}
+
+// Len return the number of fields
+func (r *ArchiveRecord) Len() int {
+	return len(r.fields)
+}
+
+// String return the field i
+func (r *ArchiveRecord) String(i int) string {
+	return r.fields[i]
+}
+
+// Int64 return the field i as an integer
+func (r *ArchiveRecord) Int64(i int) int64 {
+	v, err := strconv.ParseInt(r.fields[i], 10, 64)
+	if err != nil && r.err == nil {
+		r.err = fmt.Errorf("archive field %d: %w", i, err)
+	}
+	return v
+}
+
+// Time return the field i as a timestamp in milliseconds.
+// Spot archives switched to microseconds in 2025, which are converted.
+func (r *ArchiveRecord) Time(i int) int64 {
+	v := r.Int64(i)
+	if v >= 1e14 {
+		v /= 1000
+	}
+	return v
+}
+
+// Bool return the field i as a boolean, written True or False
+func (r *ArchiveRecord) Bool(i int) bool {
+	v, err := strconv.ParseBool(r.fields[i])
+	if err != nil && r.err == nil {
+		r.err = fmt.Errorf("archive field %d: %w", i, err)
+	}
+	return v
+}
+
+// Err return the first parsing error of the record
+func (r *ArchiveRecord) Err() error {
+	return r.err
+}
@@ -0,0 +1,133 @@
+package common
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeTestArchive write a zipped csv file and its checksum in dir
+func writeTestArchive(t *testing.T, dir string, file *ArchiveFile, content string, checksum string) {
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	f, err := w.Create(file.Name()[:len(file.Name())-len(".zip")] + ".csv")
+	require.NoError(t, err)
+	_, err = f.Write([]byte(content))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	path := filepath.Join(dir, filepath.FromSlash(file.Path()))
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, ioutil.WriteFile(path, buf.Bytes(), 0644))
+	if checksum == "" {
+		sum := sha256.Sum256(buf.Bytes())
+		checksum = hex.EncodeToString(sum[:])
+	}
+	require.NoError(t, ioutil.WriteFile(path+".CHECKSUM", []byte(checksum+"  "+file.Name()+"\n"), 0644))
+}
+
+func TestArchiveFilePath(t *testing.T) {
+	assert := assert.New(t)
+	date := time.Date(2021, 3, 18, 0, 0, 0, 0, time.UTC)
+	file := &ArchiveFile{Market: ArchiveMarketSpot, Period: ArchivePeriodDaily, DataType: ArchiveDataTypeKlines,
+		Symbol: "BTCUSDT", Interval: KlineInterval1m, Date: date}
+	assert.Equal("data/spot/daily/klines/BTCUSDT/1m/BTCUSDT-1m-2021-03-18.zip", file.Path())
+
+	file = &ArchiveFile{Market: ArchiveMarketUSDM, Period: ArchivePeriodMonthly, DataType: ArchiveDataTypeAggTrades,
+		Symbol: "BTCUSDT", Date: date}
+	assert.Equal("data/futures/um/monthly/aggTrades/BTCUSDT/BTCUSDT-aggTrades-2021-03.zip", file.Path())
+}
+
+func TestOpenArchive(t *testing.T) {
+	dir, err := ioutil.TempDir("", "archive")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	assert := assert.New(t)
+
+	file := &ArchiveFile{Market: ArchiveMarketUSDM, Period: ArchivePeriodDaily, DataType: ArchiveDataTypeTrades,
+		Symbol: "BTCUSDT", Date: time.Date(2021, 3, 18, 0, 0, 0, 0, time.UTC)}
+	writeTestArchive(t, dir, file, "id,price,qty,quote_qty,time,is_buyer_maker\n"+
+		"1,100.5,2,201,1616025600000,true\n"+
+		"2,100.6,1,100.6,1616025600000123,False\n", "")
+
+	r, err := OpenArchive(context.Background(), DirArchiveFetcher(dir), file)
+	require.NoError(t, err)
+	defer r.Close()
+
+	record, err := r.Read()
+	require.NoError(t, err)
+	assert.Equal(6, record.Len())
+	assert.Equal(int64(1), record.Int64(0))
+	assert.Equal("100.5", record.String(1))
+	assert.True(record.Bool(5))
+	assert.NoError(record.Err())
+
+	record, err = r.Read()
+	require.NoError(t, err)
+	assert.Equal(int64(1616025600000), record.Time(4), "microseconds converted")
+	assert.False(record.Bool(5))
+	record.Int64(1)
+	assert.Error(record.Err())
+
+	_, err = r.Read()
+	assert.Equal(io.EOF, err)
+}
+
+func TestOpenArchiveErrors(t *testing.T) {
+	dir, err := ioutil.TempDir("", "archive")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	assert := assert.New(t)
+
+	file := &ArchiveFile{Market: ArchiveMarketSpot, Period: ArchivePeriodDaily, DataType: ArchiveDataTypeAggTrades,
+		Symbol: "BTCUSDT", Date: time.Date(2021, 3, 18, 0, 0, 0, 0, time.UTC)}
+	_, err = OpenArchive(context.Background(), DirArchiveFetcher(dir), file)
+	assert.Equal(ErrArchiveNotFound, err)
+
+	writeTestArchive(t, dir, file, "1,1,1,1,1,1,True,True\n", "0123abcd")
+	_, err = OpenArchive(context.Background(), DirArchiveFetcher(dir), file)
+	assert.True(errors.Is(err, ErrArchiveChecksum))
+}
+
+func TestOpenArchiveSpool(t *testing.T) {
+	dir, err := ioutil.TempDir("", "archive")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	assert := assert.New(t)
+
+	file := &ArchiveFile{Market: ArchiveMarketSpot, Period: ArchivePeriodDaily, DataType: ArchiveDataTypeAggTrades,
+		Symbol: "BTCUSDT", Date: time.Date(2021, 3, 18, 0, 0, 0, 0, time.UTC)}
+	writeTestArchive(t, dir, file, "1,100.5,2,1,1,1616025600000,True,True\n", "")
+	// a body which is not a local file, like an http response, is copied to a temporary file
+	fetcher := ArchiveFetcherFunc(func(ctx context.Context, path string) (io.ReadCloser, error) {
+		data, err := ioutil.ReadFile(filepath.Join(dir, filepath.FromSlash(path)))
+		if err != nil {
+			return nil, err
+		}
+		return ioutil.NopCloser(bytes.NewReader(data)), nil
+	})
+
+	r, err := OpenArchive(context.Background(), fetcher, file)
+	require.NoError(t, err)
+	record, err := r.Read()
+	require.NoError(t, err)
+	assert.Equal("100.5", record.String(1))
+	temp := r.zip.Name()
+	_, err = os.Stat(temp)
+	assert.NoError(err)
+	assert.NoError(r.Close())
+	_, err = os.Stat(temp)
+	assert.True(os.IsNotExist(err))
+}
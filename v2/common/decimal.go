@@ -0,0 +1,62 @@
+package common
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// ParseDecimal parse a decimal value of the exchange, an empty value is zero
+func ParseDecimal(value string) (*big.Rat, error) {
+	if value == "" {
+		return new(big.Rat), nil
+	}
+	r, ok := new(big.Rat).SetString(value)
+	if !ok {
+		return nil, fmt.Errorf("invalid decimal %q", value)
+	}
+	return r, nil
+}
+
+// DecimalOrZero parse a decimal value of the exchange, zero when it is empty or invalid
+func DecimalOrZero(value string) *big.Rat {
+	r, err := ParseDecimal(value)
+	if err != nil {
+		return new(big.Rat)
+	}
+	return r
+}
+
+// PositiveDecimal parse a decimal value of the exchange, nil unless it is a positive number
+func PositiveDecimal(value string) *big.Rat {
+	r, err := ParseDecimal(value)
+	if err != nil || r.Sign() <= 0 {
+		return nil
+	}
+	return r
+}
+
+// FormatDecimal format r with at most the 8 decimals of the exchange and without trailing zeros,
+// a nil value is 0
+func FormatDecimal(r *big.Rat) string {
+	if r == nil {
+		return "0"
+	}
+	s := r.FloatString(8)
+	if strings.Contains(s, ".") {
+		s = strings.TrimRight(s, "0")
+		s = strings.TrimSuffix(s, ".")
+	}
+	if s == "-0" {
+		return "0"
+	}
+	return s
+}
+
+// MinRat return a copy of the lowest of a and b
+func MinRat(a, b *big.Rat) *big.Rat {
+	if a.Cmp(b) <= 0 {
+		return new(big.Rat).Set(a)
+	}
+	return new(big.Rat).Set(b)
+}
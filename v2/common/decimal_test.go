@@ -0,0 +1,35 @@
+package common
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDecimal(t *testing.T) {
+	assert := assert.New(t)
+	r, err := ParseDecimal("1.50")
+	assert.NoError(err)
+	assert.Equal(big.NewRat(3, 2), r)
+	r, err = ParseDecimal("")
+	assert.NoError(err)
+	assert.Equal(0, r.Sign())
+	_, err = ParseDecimal("abc")
+	assert.Error(err)
+
+	assert.Equal(0, DecimalOrZero("abc").Sign())
+	assert.Nil(PositiveDecimal("0"))
+	assert.Nil(PositiveDecimal("-1"))
+	assert.Equal(big.NewRat(1, 10), PositiveDecimal("0.1"))
+}
+
+func TestFormatDecimal(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal("0", FormatDecimal(nil))
+	assert.Equal("0", FormatDecimal(big.NewRat(-1, 1000000000)))
+	assert.Equal("1.5", FormatDecimal(big.NewRat(3, 2)))
+	assert.Equal("0.33333333", FormatDecimal(big.NewRat(1, 3)))
+	assert.Equal("100", FormatDecimal(big.NewRat(100, 1)))
+	assert.Equal(big.NewRat(1, 1), MinRat(big.NewRat(2, 1), big.NewRat(1, 1)))
+}
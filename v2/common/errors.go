@@ -1,6 +1,7 @@
 package common
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 )
@@ -9,6 +10,9 @@ import (
 type APIError struct {
 	Code    int64  `json:"code"`
 	Message string `json:"msg"`
+	// Data is the detail of the error returned by some endpoints, like the
+	// results of a partially failed cancel-replace order
+	Data json.RawMessage `json:"data,omitempty"`
 }
 
 // Error return error code and message
@@ -25,3 +29,33 @@ func IsAPIError(e error) bool {
 func CreateErrorMandatoryField(field string) error {
 	return errors.New(fmt.Sprintf("%s: field is MANDATORY", field))
 }
+
+// WsDecodeError define error when a websocket message can not be decoded
+type WsDecodeError struct {
+	Payload []byte
+	Err     error
+}
+
+// Error return the decode error and the raw message
+func (e WsDecodeError) Error() string {
+	return fmt.Sprintf("<WsDecodeError> err=%s, payload=%s", e.Err, e.Payload)
+}
+
+// Unwrap return the underlying decode error
+func (e WsDecodeError) Unwrap() error {
+	return e.Err
+}
+
+// IsWsDecodeError check if e is a websocket decode error
+func IsWsDecodeError(e error) bool {
+	_, ok := e.(*WsDecodeError)
+	return ok
+}
+
+// NewWsDecodeError create a websocket decode error for the raw message payload
+func NewWsDecodeError(payload []byte, err error) *WsDecodeError {
+	return &WsDecodeError{Payload: payload, Err: err}
+}
+
+// ErrSymbolNotFound is returned by symbol registries when a symbol is not listed
+var ErrSymbolNotFound = errors.New("symbol not found")
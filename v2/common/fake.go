@@ -0,0 +1,327 @@
+package common
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sync"
+)
+
+// StubCall define a request answered by Stubs
+type StubCall struct {
+	Method string
+	Path   string
+	// Params are the parameters of the query and of the form body
+	Params url.Values
+}
+
+type stub struct {
+	body []byte
+	err  error
+}
+
+// Stubs answer HTTP requests with the responses stubbed by method and path, for the fake clients
+// of the binance, futures and delivery packages. It is safe for concurrent use.
+type Stubs struct {
+	mu    sync.Mutex
+	stubs map[string]stub
+	calls []*StubCall
+}
+
+// NewStubs init stubs without any response
+func NewStubs() *Stubs {
+	return &Stubs{stubs: make(map[string]stub)}
+}
+
+func stubKey(method, path string) string {
+	return method + " " + path
+}
+
+// Stub answer the requests of method to path with response: a JSON string, a []byte, or a value
+// marshaled to JSON. The value must marshal to the JSON of the exchange, e.g. a map or a slice.
+func (s *Stubs) Stub(method, path string, response interface{}) error {
+	var body []byte
+	switch r := response.(type) {
+	case string:
+		body = []byte(r)
+	case []byte:
+		body = r
+	default:
+		data, err := json.Marshal(response)
+		if err != nil {
+			return err
+		}
+		body = data
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stubs[stubKey(method, path)] = stub{body: body}
+	return nil
+}
+
+// StubError answer the requests of method to path with err. An *APIError is sent as the error
+// response of the exchange, other errors are returned as transport errors.
+func (s *Stubs) StubError(method, path string, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stubs[stubKey(method, path)] = stub{err: err}
+}
+
+// Stubbed return whether the requests of method to path have a stub
+func (s *Stubs) Stubbed(method, path string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.stubs[stubKey(method, path)]
+	return ok
+}
+
+// Calls return the requests of method to path, in the order they were answered
+func (s *Stubs) Calls(method, path string) []*StubCall {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var calls []*StubCall
+	for _, call := range s.calls {
+		if call.Method == method && call.Path == path {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+// RoundTrip answer req with its stub, so that Stubs can be the transport of an http.Client. The
+// requests without stub fail with an *APIError.
+func (s *Stubs) RoundTrip(req *http.Request) (*http.Response, error) {
+	call := &StubCall{Method: req.Method, Path: req.URL.Path, Params: req.URL.Query()}
+	if req.Body != nil {
+		data, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		form, err := url.ParseQuery(string(data))
+		if err != nil {
+			return nil, err
+		}
+		for key, values := range form {
+			call.Params[key] = append(call.Params[key], values...)
+		}
+	}
+	s.mu.Lock()
+	s.calls = append(s.calls, call)
+	st, ok := s.stubs[stubKey(req.Method, req.URL.Path)]
+	s.mu.Unlock()
+
+	status := http.StatusOK
+	body := st.body
+	switch {
+	case !ok:
+		st.err = &APIError{Code: -1000, Message: fmt.Sprintf("no stub for %s %s", req.Method, req.URL.Path)}
+		fallthrough
+	case st.err != nil:
+		apiErr, ok := st.err.(*APIError)
+		if !ok {
+			return nil, st.err
+		}
+		data, err := json.Marshal(apiErr)
+		if err != nil {
+			return nil, err
+		}
+		status = http.StatusBadRequest
+		body = data
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          ioutil.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// errProbe stop the request of a service probed for its endpoint
+var errProbe = errors.New("service probe")
+
+// ServiceStubs are Stubs which also stub the responses by service, named by the method of the
+// client which creates it, e.g. "NewCreateOrderService", for the fake clients of the binance,
+// futures and delivery packages. It is safe for concurrent use.
+type ServiceStubs struct {
+	*Stubs
+	mu        sync.Mutex
+	client    reflect.Value
+	probed    *http.Request
+	endpoints map[string]StubCall
+}
+
+// NewServiceStubs init stubs without any response for the services of the client returned by
+// newClient, which must send its requests through transport. The endpoints of the services are
+// found by sending a request without parameters, endpoints give them for the services which
+// fail before sending it.
+func NewServiceStubs(newClient func(transport http.RoundTripper) interface{}, endpoints map[string]StubCall) *ServiceStubs {
+	s := &ServiceStubs{Stubs: NewStubs(), endpoints: make(map[string]StubCall)}
+	for service, e := range endpoints {
+		s.endpoints[service] = e
+	}
+	s.client = reflect.ValueOf(newClient(serviceProbe{s}))
+	return s
+}
+
+// serviceProbe record the request of a probed service instead of sending it
+type serviceProbe struct {
+	s *ServiceStubs
+}
+
+func (p serviceProbe) RoundTrip(req *http.Request) (*http.Response, error) {
+	if p.s.probed == nil {
+		p.s.probed = req
+	}
+	return nil, errProbe
+}
+
+// endpoint return the method and the path of the requests of a service
+func (s *ServiceStubs) endpoint(service string) (method, path string, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if e, ok := s.endpoints[service]; ok {
+		return e.Method, e.Path, nil
+	}
+	newService := s.client.MethodByName(service)
+	if !newService.IsValid() || newService.Type().NumIn() != 0 || newService.Type().NumOut() != 1 {
+		return "", "", fmt.Errorf("unknown service %s", service)
+	}
+	do := newService.Call(nil)[0].MethodByName("Do")
+	if !do.IsValid() || do.Type().NumIn() == 0 || do.Type().In(0) != reflect.TypeOf((*context.Context)(nil)).Elem() {
+		return "", "", fmt.Errorf("unknown service %s", service)
+	}
+	s.probed = nil
+	func() {
+		// a service may fail before its request without parameters
+		defer func() {
+			recover()
+		}()
+		do.Call([]reflect.Value{reflect.ValueOf(context.Background())})
+	}()
+	if s.probed == nil {
+		return "", "", fmt.Errorf("service %s sent no request", service)
+	}
+	s.endpoints[service] = StubCall{Method: s.probed.Method, Path: s.probed.URL.Path}
+	return s.probed.Method, s.probed.URL.Path, nil
+}
+
+// StubService answer the requests of service with response, see Stub. The services sending their
+// requests to the same method and path share their response.
+func (s *ServiceStubs) StubService(service string, response interface{}) error {
+	method, path, err := s.endpoint(service)
+	if err != nil {
+		return err
+	}
+	return s.Stub(method, path, response)
+}
+
+// StubServiceError answer the requests of service with err, see StubError
+func (s *ServiceStubs) StubServiceError(service string, err error) error {
+	method, path, probeErr := s.endpoint(service)
+	if probeErr != nil {
+		return probeErr
+	}
+	s.StubError(method, path, err)
+	return nil
+}
+
+// ServiceCalls return the requests of service, in the order they were answered
+func (s *ServiceStubs) ServiceCalls(service string) ([]*StubCall, error) {
+	method, path, err := s.endpoint(service)
+	if err != nil {
+		return nil, err
+	}
+	return s.Calls(method, path), nil
+}
+
+type streamSubscription struct {
+	handler    reflect.Value
+	errHandler func(err error)
+	stopC      chan struct{}
+}
+
+// StreamStubs keep the handlers of the streams served by the fake streams of the binance, futures
+// and delivery packages, and deliver them the events of the tests. It is safe for concurrent use.
+type StreamStubs struct {
+	mu            sync.Mutex
+	subscriptions map[string][]*streamSubscription
+}
+
+// NewStreamStubs init stream stubs without any stream
+func NewStreamStubs() *StreamStubs {
+	return &StreamStubs{subscriptions: make(map[string][]*streamSubscription)}
+}
+
+// Serve keep the handlers of a stream served by method, e.g. "WsKlineServe". The stream is done
+// once stopC is closed.
+func (s *StreamStubs) Serve(method string, handler interface{}, errHandler func(err error)) (doneC, stopC chan struct{}, err error) {
+	sub := &streamSubscription{handler: reflect.ValueOf(handler), errHandler: errHandler, stopC: make(chan struct{})}
+	doneC = make(chan struct{})
+	s.mu.Lock()
+	s.subscriptions[method] = append(s.subscriptions[method], sub)
+	s.mu.Unlock()
+	go func() {
+		<-sub.stopC
+		s.mu.Lock()
+		subs := s.subscriptions[method]
+		for i, other := range subs {
+			if other == sub {
+				s.subscriptions[method] = append(subs[:i:i], subs[i+1:]...)
+				break
+			}
+		}
+		s.mu.Unlock()
+		close(doneC)
+	}()
+	return doneC, sub.stopC, nil
+}
+
+func (s *StreamStubs) active(method string) []*streamSubscription {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*streamSubscription(nil), s.subscriptions[method]...)
+}
+
+// Streams return the number of running streams served by method
+func (s *StreamStubs) Streams(method string) int {
+	return len(s.active(method))
+}
+
+// Send deliver event to the handlers of the streams served by method, and return the number of
+// handlers called. event must be of the type of the handlers, e.g. *WsKlineEvent for WsKlineServe.
+func (s *StreamStubs) Send(method string, event interface{}) int {
+	n := 0
+	for _, sub := range s.active(method) {
+		t := sub.handler.Type()
+		if t.NumIn() != 1 {
+			continue
+		}
+		arg := reflect.ValueOf(event)
+		if event == nil {
+			arg = reflect.Zero(t.In(0))
+		}
+		if !arg.Type().AssignableTo(t.In(0)) {
+			continue
+		}
+		sub.handler.Call([]reflect.Value{arg})
+		n++
+	}
+	return n
+}
+
+// SendError deliver err to the error handlers of the streams served by method
+func (s *StreamStubs) SendError(method string, err error) int {
+	subs := s.active(method)
+	for _, sub := range subs {
+		sub.errHandler(err)
+	}
+	return len(subs)
+}
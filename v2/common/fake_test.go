@@ -0,0 +1,74 @@
+package common
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStubs(t *testing.T) {
+	s := NewStubs()
+	require.NoError(t, s.Stub(http.MethodGet, "/api/v3/ticker/price", []map[string]string{{"symbol": "BTCUSDT", "price": "100"}}))
+	s.StubError(http.MethodPost, "/api/v3/order", &APIError{Code: -2010, Message: "insufficient balance"})
+	transportErr := errors.New("connection reset")
+	s.StubError(http.MethodDelete, "/api/v3/order", transportErr)
+	c := &http.Client{Transport: s}
+
+	res, err := c.Get("https://api.binance.com/api/v3/ticker/price?symbol=BTCUSDT")
+	require.NoError(t, err)
+	body, _ := ioutil.ReadAll(res.Body)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.JSONEq(t, `[{"symbol": "BTCUSDT", "price": "100"}]`, string(body))
+
+	res, err = c.Post("https://api.binance.com/api/v3/order?timestamp=1", "application/x-www-form-urlencoded", strings.NewReader("symbol=BTCUSDT"))
+	require.NoError(t, err)
+	body, _ = ioutil.ReadAll(res.Body)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	assert.JSONEq(t, `{"code": -2010, "msg": "insufficient balance"}`, string(body))
+
+	req, _ := http.NewRequest(http.MethodDelete, "https://api.binance.com/api/v3/order", nil)
+	_, err = c.Do(req)
+	assert.True(t, errors.Is(err, transportErr))
+
+	res, err = c.Get("https://api.binance.com/api/v3/depth")
+	require.NoError(t, err)
+	body, _ = ioutil.ReadAll(res.Body)
+	assert.JSONEq(t, `{"code": -1000, "msg": "no stub for GET /api/v3/depth"}`, string(body))
+
+	calls := s.Calls(http.MethodPost, "/api/v3/order")
+	require.Len(t, calls, 1)
+	assert.Equal(t, "1", calls[0].Params.Get("timestamp"))
+	assert.Equal(t, "BTCUSDT", calls[0].Params.Get("symbol"))
+}
+
+func TestStreamStubs(t *testing.T) {
+	s := NewStreamStubs()
+	var events []string
+	var errs []error
+	doneC, stopC, err := s.Serve("WsTradeServe", func(event *string) {
+		events = append(events, *event)
+	}, func(err error) {
+		errs = append(errs, err)
+	})
+	require.NoError(t, err)
+	_, _, err = s.Serve("WsTradeServe", func(event *string) {}, func(err error) {})
+	require.NoError(t, err)
+	assert.Equal(t, 2, s.Streams("WsTradeServe"))
+
+	event := "trade"
+	assert.Equal(t, 2, s.Send("WsTradeServe", &event))
+	assert.Equal(t, 0, s.Send("WsTradeServe", event), "the type of the event must match the handler")
+	assert.Equal(t, 0, s.Send("WsKlineServe", &event))
+	assert.Equal(t, 2, s.SendError("WsTradeServe", errors.New("read failed")))
+	assert.Equal(t, []string{"trade"}, events)
+	assert.Len(t, errs, 1)
+
+	close(stopC)
+	<-doneC
+	assert.Equal(t, 1, s.Streams("WsTradeServe"), "the other stream is still running")
+}
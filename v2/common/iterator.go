@@ -0,0 +1,265 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrIteratorBudgetExhausted is returned by iterators which sent the maximum number of requests of their budget
+var ErrIteratorBudgetExhausted = errors.New("iterator request budget exhausted")
+
+// errPageLimit is returned by pagers whose Limit is not positive, which could not tell the last page
+var errPageLimit = errors.New("pager limit must be positive")
+
+// IteratorBudget limit the requests sent by an iterator
+type IteratorBudget struct {
+	// Interval is the minimum time between two requests
+	Interval time.Duration
+	// MaxRequests stops the iteration with ErrIteratorBudgetExhausted once reached, zero for unlimited
+	MaxRequests int
+}
+
+// PageFetcher fetch the next page of an iteration, more is false when no page follows.
+// An empty page with more set is skipped, e.g. an empty time window.
+type PageFetcher func(ctx context.Context) (items []interface{}, more bool, err error)
+
+// PageIterator iterate over the items of the pages returned by a PageFetcher
+type PageIterator struct {
+	fetch    PageFetcher
+	budget   IteratorBudget
+	items    []interface{}
+	index    int
+	more     bool
+	err      error
+	requests int
+	last     time.Time
+}
+
+// NewPageIterator create an iterator over the pages of fetch, budget may be nil
+func NewPageIterator(fetch PageFetcher, budget *IteratorBudget) *PageIterator {
+	it := &PageIterator{fetch: fetch, index: -1, more: true}
+	if budget != nil {
+		it.budget = *budget
+	}
+	return it
+}
+
+// Next advance to the next item, fetching the next page when needed.
+// It returns false at the end of the iteration or on error, see Err.
+func (it *PageIterator) Next(ctx context.Context) bool {
+	if it.err != nil {
+		return false
+	}
+	for it.index+1 >= len(it.items) {
+		if !it.more {
+			return false
+		}
+		if err := it.wait(ctx); err != nil {
+			it.err = err
+			return false
+		}
+		items, more, err := it.fetch(ctx)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.items, it.index, it.more = items, -1, more
+	}
+	it.index++
+	return true
+}
+
+// wait enforce the budget before a request
+func (it *PageIterator) wait(ctx context.Context) error {
+	if it.budget.MaxRequests > 0 && it.requests >= it.budget.MaxRequests {
+		return ErrIteratorBudgetExhausted
+	}
+	if it.budget.Interval > 0 && !it.last.IsZero() {
+		if d := it.budget.Interval - time.Since(it.last); d > 0 {
+			timer := time.NewTimer(d)
+			defer timer.Stop()
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-timer.C:
+			}
+		}
+	}
+	it.requests++
+	it.last = time.Now()
+	return nil
+}
+
+// Item return the current item
+func (it *PageIterator) Item() interface{} {
+	if it.index < 0 || it.index >= len(it.items) {
+		return nil
+	}
+	return it.items[it.index]
+}
+
+// Err return the error which stopped the iteration, if any
+func (it *PageIterator) Err() error {
+	return it.err
+}
+
+// Requests return the number of requests sent so far
+func (it *PageIterator) Requests() int {
+	return it.requests
+}
+
+// IDRangePager walk the items of an endpoint paginated by id, like trades or orders.
+// When StartTime is set and FromID is not, the first item is searched with time windows
+// of at most Window, then the iteration goes on by id from the last item + 1, so that no
+// item is returned twice. Items after EndTime stop the iteration.
+type IDRangePager struct {
+	FromID    *int64
+	StartTime *int64
+	EndTime   *int64
+	// Window is the maximum time range of a request, zero when unlimited
+	Window time.Duration
+	// Limit is the page size, a page with fewer items is the last one
+	Limit int
+	// Fetch request a page starting at fromID, or within [startTime, endTime] when fromID is nil.
+	// All arguments may be nil for the default page of the endpoint.
+	Fetch func(ctx context.Context, fromID, startTime, endTime *int64, limit int) ([]interface{}, error)
+	// Key return the id and the time in milliseconds of an item
+	Key func(item interface{}) (id int64, time int64)
+
+	started bool
+	nextID  *int64
+	cursor  int64
+	end     *int64
+}
+
+// Pager return the PageFetcher of the iteration
+func (p *IDRangePager) Pager() PageFetcher {
+	return p.fetch
+}
+
+func (p *IDRangePager) fetch(ctx context.Context) ([]interface{}, bool, error) {
+	if !p.started {
+		if p.Limit <= 0 {
+			return nil, false, errPageLimit
+		}
+		p.started = true
+		p.nextID = p.FromID
+		p.end = p.EndTime
+		if p.StartTime != nil {
+			p.cursor = *p.StartTime
+			if p.end == nil && p.nextID == nil {
+				now := time.Now().UnixNano() / int64(time.Millisecond)
+				p.end = &now
+			}
+		}
+	}
+	if p.nextID == nil && p.StartTime != nil {
+		return p.fetchWindow(ctx)
+	}
+	items, err := p.Fetch(ctx, p.nextID, nil, nil, p.Limit)
+	if err != nil {
+		return nil, false, err
+	}
+	return p.accept(items, len(items) >= p.Limit)
+}
+
+// fetchWindow search the first items with a time window request
+func (p *IDRangePager) fetchWindow(ctx context.Context) ([]interface{}, bool, error) {
+	end := *p.end
+	if p.Window > 0 {
+		if windowEnd := p.cursor + int64(p.Window/time.Millisecond) - 1; windowEnd < end {
+			end = windowEnd
+		}
+	}
+	start := p.cursor
+	items, err := p.Fetch(ctx, nil, &start, &end, p.Limit)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(items) == 0 {
+		p.cursor = end + 1
+		return nil, p.cursor <= *p.end, nil
+	}
+	// go on by id from the last item, the next window may hold more items than a page
+	return p.accept(items, true)
+}
+
+// accept keep the items up to the end time and move the cursor after the last one
+func (p *IDRangePager) accept(items []interface{}, more bool) ([]interface{}, bool, error) {
+	for i, item := range items {
+		if _, t := p.Key(item); p.end != nil && t > *p.end {
+			return items[:i], false, nil
+		}
+	}
+	if len(items) == 0 {
+		return items, false, nil
+	}
+	id, _ := p.Key(items[len(items)-1])
+	next := id + 1
+	p.nextID = &next
+	return items, more, nil
+}
+
+// TimeRangePager walk the items of an endpoint paginated by time, like klines
+type TimeRangePager struct {
+	StartTime *int64
+	EndTime   *int64
+	// Window is the maximum time range of a request, zero when unlimited
+	Window time.Duration
+	// Limit is the page size, a page with fewer items is the last one
+	Limit int
+	// Fetch request a page within [startTime, endTime], endTime may be nil
+	Fetch func(ctx context.Context, startTime int64, endTime *int64, limit int) ([]interface{}, error)
+	// Key return the time in milliseconds of an item, the open time for klines
+	Key func(item interface{}) int64
+
+	started bool
+	cursor  int64
+	end     *int64
+}
+
+// Pager return the PageFetcher of the iteration
+func (p *TimeRangePager) Pager() PageFetcher {
+	return p.fetch
+}
+
+func (p *TimeRangePager) fetch(ctx context.Context) ([]interface{}, bool, error) {
+	if !p.started {
+		if p.Limit <= 0 {
+			return nil, false, errPageLimit
+		}
+		p.started = true
+		if p.StartTime != nil {
+			p.cursor = *p.StartTime
+		}
+		p.end = p.EndTime
+		if p.end == nil && p.Window > 0 {
+			// windows need a bound, the iteration stops at the time it started
+			now := time.Now().UnixNano() / int64(time.Millisecond)
+			p.end = &now
+		}
+	}
+	end := p.end
+	windowed := false
+	if p.Window > 0 {
+		if windowEnd := p.cursor + int64(p.Window/time.Millisecond) - 1; windowEnd < *end {
+			end = &windowEnd
+			windowed = true
+		}
+	}
+	items, err := p.Fetch(ctx, p.cursor, end, p.Limit)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(items) < p.Limit {
+		if !windowed {
+			return items, false, nil
+		}
+		// the window is exhausted, go on with the next one
+		p.cursor = *end + 1
+		return items, true, nil
+	}
+	p.cursor = p.Key(items[len(items)-1]) + 1
+	return items, p.end == nil || p.cursor <= *p.end, nil
+}
@@ -0,0 +1,200 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testItem struct {
+	id   int64
+	time int64
+}
+
+// testItems return the items with ids from..to, at time id * 10
+func testItems(from, to int64) []testItem {
+	var items []testItem
+	for id := from; id <= to; id++ {
+		items = append(items, testItem{id: id, time: id * 10})
+	}
+	return items
+}
+
+type pagerCall struct {
+	fromID, startTime, endTime *int64
+}
+
+// testIDFetch serve items by id or by time range like the trade endpoints
+func testIDFetch(all []testItem, calls *[]pagerCall) func(ctx context.Context, fromID, startTime, endTime *int64, limit int) ([]interface{}, error) {
+	return func(ctx context.Context, fromID, startTime, endTime *int64, limit int) ([]interface{}, error) {
+		*calls = append(*calls, pagerCall{fromID, startTime, endTime})
+		var page []interface{}
+		for _, item := range all {
+			if len(page) == limit {
+				break
+			}
+			if fromID != nil && item.id < *fromID {
+				continue
+			}
+			if startTime != nil && (item.time < *startTime || item.time > *endTime) {
+				continue
+			}
+			page = append(page, item)
+		}
+		return page, nil
+	}
+}
+
+func testKey(item interface{}) (int64, int64) {
+	return item.(testItem).id, item.(testItem).time
+}
+
+func collectIDs(it *PageIterator) []int64 {
+	var ids []int64
+	for it.Next(context.Background()) {
+		ids = append(ids, it.Item().(testItem).id)
+	}
+	return ids
+}
+
+func int64Ptr(v int64) *int64 {
+	return &v
+}
+
+func TestIDRangePagerFromID(t *testing.T) {
+	assert := assert.New(t)
+	var calls []pagerCall
+	pager := &IDRangePager{
+		FromID: int64Ptr(3),
+		Limit:  3,
+		Fetch:  testIDFetch(testItems(1, 10), &calls),
+		Key:    testKey,
+	}
+	it := NewPageIterator(pager.Pager(), nil)
+	assert.Equal([]int64{3, 4, 5, 6, 7, 8, 9, 10}, collectIDs(it))
+	assert.NoError(it.Err())
+	assert.Len(calls, 3)
+	assert.Equal(int64(6), *calls[1].fromID)
+	assert.Equal(int64(9), *calls[2].fromID)
+}
+
+func TestIDRangePagerTimeWindows(t *testing.T) {
+	assert := assert.New(t)
+	// a gap between the items 3 and 40 spans several empty windows
+	all := append(testItems(1, 3), testItems(40, 45)...)
+	var calls []pagerCall
+	pager := &IDRangePager{
+		StartTime: int64Ptr(35),
+		EndTime:   int64Ptr(430),
+		Window:    100 * time.Millisecond,
+		Limit:     4,
+		Fetch:     testIDFetch(all, &calls),
+		Key:       testKey,
+	}
+	it := NewPageIterator(pager.Pager(), nil)
+	assert.Equal([]int64{40, 41, 42, 43}, collectIDs(it))
+	assert.NoError(it.Err())
+
+	// [35, 134], [135, 234], [235, 334], [335, 434] then by id from 44
+	assert.Len(calls, 5)
+	assert.Equal(int64(134), *calls[0].endTime)
+	assert.Equal(int64(135), *calls[1].startTime)
+	assert.Equal(int64(430), *calls[3].endTime)
+	assert.Nil(calls[4].startTime)
+	assert.Equal(int64(44), *calls[4].fromID)
+}
+
+func TestTimeRangePager(t *testing.T) {
+	assert := assert.New(t)
+	var starts []int64
+	pager := &TimeRangePager{
+		StartTime: int64Ptr(10),
+		EndTime:   int64Ptr(75),
+		Limit:     3,
+		Fetch: func(ctx context.Context, startTime int64, endTime *int64, limit int) ([]interface{}, error) {
+			starts = append(starts, startTime)
+			var page []interface{}
+			for _, item := range testItems(1, 10) {
+				if item.time >= startTime && item.time <= *endTime && len(page) < limit {
+					page = append(page, item)
+				}
+			}
+			return page, nil
+		},
+		Key: func(item interface{}) int64 {
+			return item.(testItem).time
+		},
+	}
+	it := NewPageIterator(pager.Pager(), nil)
+	assert.Equal([]int64{1, 2, 3, 4, 5, 6, 7}, collectIDs(it))
+	assert.NoError(it.Err())
+	assert.Equal([]int64{10, 31, 61}, starts)
+}
+
+func TestPagerLimit(t *testing.T) {
+	assert := assert.New(t)
+	fetched := false
+	ids := &IDRangePager{
+		Fetch: func(ctx context.Context, fromID, startTime, endTime *int64, limit int) ([]interface{}, error) {
+			fetched = true
+			return nil, nil
+		},
+		Key: testKey,
+	}
+	it := NewPageIterator(ids.Pager(), nil)
+	assert.False(it.Next(context.Background()))
+	assert.Equal(errPageLimit, it.Err())
+
+	times := &TimeRangePager{
+		Fetch: func(ctx context.Context, startTime int64, endTime *int64, limit int) ([]interface{}, error) {
+			fetched = true
+			return nil, nil
+		},
+		Key: func(item interface{}) int64 {
+			return item.(testItem).time
+		},
+	}
+	it = NewPageIterator(times.Pager(), nil)
+	assert.False(it.Next(context.Background()))
+	assert.Equal(errPageLimit, it.Err())
+	assert.False(fetched)
+}
+
+func TestPageIteratorBudget(t *testing.T) {
+	assert := assert.New(t)
+	calls := 0
+	fetch := func(ctx context.Context) ([]interface{}, bool, error) {
+		calls++
+		return []interface{}{testItem{id: int64(calls)}}, true, nil
+	}
+	it := NewPageIterator(fetch, &IteratorBudget{MaxRequests: 2})
+	assert.Equal([]int64{1, 2}, collectIDs(it))
+	assert.Equal(ErrIteratorBudgetExhausted, it.Err())
+	assert.Equal(2, it.Requests())
+
+	it = NewPageIterator(fetch, &IteratorBudget{Interval: time.Hour})
+	ctx, cancel := context.WithCancel(context.Background())
+	assert.True(it.Next(ctx))
+	cancel()
+	assert.False(it.Next(ctx))
+	assert.Equal(context.Canceled, it.Err())
+}
+
+func TestPageIteratorError(t *testing.T) {
+	assert := assert.New(t)
+	fetchErr := errors.New("dummy error")
+	pages := 0
+	it := NewPageIterator(func(ctx context.Context) ([]interface{}, bool, error) {
+		pages++
+		if pages == 2 {
+			return nil, false, fetchErr
+		}
+		return []interface{}{testItem{id: 1}}, true, nil
+	}, nil)
+	assert.Equal([]int64{1}, collectIDs(it))
+	assert.Equal(fetchErr, it.Err())
+	assert.False(it.Next(context.Background()))
+}
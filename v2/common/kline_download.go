@@ -0,0 +1,118 @@
+package common
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// KlineGap define a range of missing klines, from the open time Start included to End excluded
+type KlineGap struct {
+	Start int64
+	End   int64
+	Count int
+}
+
+// FindKlineGaps return the ranges of [start, end) with no kline, openTimes must be sorted
+func FindKlineGaps(interval KlineInterval, start, end time.Time, openTimes []int64) []KlineGap {
+	var gaps []KlineGap
+	expected := interval.Align(start)
+	var gap *KlineGap
+	j := 0
+	for ; expected.Before(end); expected = interval.Add(expected, 1) {
+		ms := toMilliseconds(expected)
+		for j < len(openTimes) && openTimes[j] < ms {
+			j++
+		}
+		if j < len(openTimes) && openTimes[j] == ms {
+			if gap != nil {
+				gap.End = ms
+				gaps = append(gaps, *gap)
+				gap = nil
+			}
+			continue
+		}
+		if gap == nil {
+			gap = &KlineGap{Start: ms}
+		}
+		gap.Count++
+	}
+	if gap != nil {
+		gap.End = toMilliseconds(expected)
+		gaps = append(gaps, *gap)
+	}
+	return gaps
+}
+
+// KlineChunk define the open time range [Start, End) of a kline request, in milliseconds
+type KlineChunk struct {
+	Start int64
+	End   int64
+}
+
+// SplitKlineRange split [start, end) in chunks of at most limit klines, it return no chunk if limit is not positive
+func SplitKlineRange(interval KlineInterval, start, end time.Time, limit int) []KlineChunk {
+	var chunks []KlineChunk
+	if limit <= 0 {
+		return chunks
+	}
+	for open := interval.Align(start); open.Before(end); {
+		next := interval.Add(open, limit)
+		if next.After(end) {
+			next = end
+		}
+		chunks = append(chunks, KlineChunk{Start: toMilliseconds(open), End: toMilliseconds(next)})
+		open = next
+	}
+	return chunks
+}
+
+// DownloadKlineChunks call fetch for each chunk with at most concurrency calls at once.
+// It stops at the first error, canceling the context of the pending calls.
+func DownloadKlineChunks(ctx context.Context, chunks []KlineChunk, concurrency int, fetch func(ctx context.Context, i int, chunk KlineChunk) error) error {
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+	sem := make(chan struct{}, concurrency)
+	for i, chunk := range chunks {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		go func(i int, chunk KlineChunk) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			if err := fetch(ctx, i, chunk); err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = err
+					cancel()
+				}
+				mu.Unlock()
+			}
+		}(i, chunk)
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return firstErr
+	}
+	return ctx.Err()
+}
+
+func toMilliseconds(t time.Time) int64 {
+	return t.UnixNano() / int64(time.Millisecond)
+}
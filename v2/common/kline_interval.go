@@ -0,0 +1,154 @@
+package common
+
+import (
+	"fmt"
+	"time"
+)
+
+// KlineInterval define kline interval
+type KlineInterval string
+
+// Kline intervals
+const (
+	KlineInterval1s  KlineInterval = "1s"
+	KlineInterval1m  KlineInterval = "1m"
+	KlineInterval3m  KlineInterval = "3m"
+	KlineInterval5m  KlineInterval = "5m"
+	KlineInterval15m KlineInterval = "15m"
+	KlineInterval30m KlineInterval = "30m"
+	KlineInterval1h  KlineInterval = "1h"
+	KlineInterval2h  KlineInterval = "2h"
+	KlineInterval4h  KlineInterval = "4h"
+	KlineInterval6h  KlineInterval = "6h"
+	KlineInterval8h  KlineInterval = "8h"
+	KlineInterval12h KlineInterval = "12h"
+	KlineInterval1d  KlineInterval = "1d"
+	KlineInterval3d  KlineInterval = "3d"
+	KlineInterval1w  KlineInterval = "1w"
+	KlineInterval1M  KlineInterval = "1M"
+)
+
+const day = 24 * time.Hour
+
+var klineIntervalDurations = map[KlineInterval]time.Duration{
+	KlineInterval1s:  time.Second,
+	KlineInterval1m:  time.Minute,
+	KlineInterval3m:  3 * time.Minute,
+	KlineInterval5m:  5 * time.Minute,
+	KlineInterval15m: 15 * time.Minute,
+	KlineInterval30m: 30 * time.Minute,
+	KlineInterval1h:  time.Hour,
+	KlineInterval2h:  2 * time.Hour,
+	KlineInterval4h:  4 * time.Hour,
+	KlineInterval6h:  6 * time.Hour,
+	KlineInterval8h:  8 * time.Hour,
+	KlineInterval12h: 12 * time.Hour,
+	KlineInterval1d:  day,
+	KlineInterval3d:  3 * day,
+	KlineInterval1w:  7 * day,
+}
+
+// weekOffset is the offset of the first monday from the unix epoch
+const weekOffset = 4 * day
+
+// ParseKlineInterval return the kline interval named s, like 15m or 1M
+func ParseKlineInterval(s string) (KlineInterval, error) {
+	i := KlineInterval(s)
+	if !i.IsValid() {
+		return "", fmt.Errorf("invalid kline interval %q", s)
+	}
+	return i, nil
+}
+
+// IsValid return true if i is an interval supported by the exchange
+func (i KlineInterval) IsValid() bool {
+	_, ok := klineIntervalDurations[i]
+	return ok || i == KlineInterval1M
+}
+
+// Duration return the duration of a kline, zero for the monthly interval whose duration varies
+func (i KlineInterval) Duration() time.Duration {
+	return klineIntervalDurations[i]
+}
+
+// Truncate return the open time of the kline containing t, in UTC
+func (i KlineInterval) Truncate(t time.Time) time.Time {
+	t = t.UTC()
+	if i == KlineInterval1M {
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+	d := i.Duration()
+	if d == 0 {
+		return t
+	}
+	// open times are aligned on the unix epoch, except weeks which open on mondays
+	offset := time.Duration(0)
+	if i == KlineInterval1w {
+		offset = weekOffset
+	}
+	rem := time.Duration(t.UnixNano()) - offset
+	rem %= d
+	if rem < 0 {
+		rem += d
+	}
+	return t.Add(-rem)
+}
+
+// Add return the open time n klines after the open time t
+func (i KlineInterval) Add(t time.Time, n int) time.Time {
+	if i == KlineInterval1M {
+		return t.AddDate(0, n, 0)
+	}
+	return t.Add(time.Duration(n) * i.Duration())
+}
+
+// Next return the open time of the kline following the one containing t
+func (i KlineInterval) Next(t time.Time) time.Time {
+	return i.Add(i.Truncate(t), 1)
+}
+
+// Align return t if it is an open time, or the next open time
+func (i KlineInterval) Align(t time.Time) time.Time {
+	if open := i.Truncate(t); !open.Before(t.UTC()) {
+		return open
+	}
+	return i.Next(t)
+}
+
+// Count return the number of klines opening in [start, end)
+func (i KlineInterval) Count(start, end time.Time) int {
+	count := 0
+	if i == KlineInterval1M {
+		for open := i.Align(start); open.Before(end); open = i.Add(open, 1) {
+			count++
+		}
+		return count
+	}
+	first := i.Align(start)
+	if !first.Before(end) {
+		return 0
+	}
+	return int((end.Sub(first)-1)/i.Duration()) + 1
+}
+
+// Ratio return the number of i klines in a kline of the larger interval to,
+// or an error if a kline of to is not made of whole klines of i.
+// The monthly interval is only made of daily or smaller intervals, with a variable ratio reported as zero.
+func (i KlineInterval) Ratio(to KlineInterval) (int, error) {
+	if !i.IsValid() || !to.IsValid() {
+		return 0, fmt.Errorf("invalid kline intervals %q and %q", i, to)
+	}
+	if to == KlineInterval1M {
+		if i == KlineInterval1M {
+			return 1, nil
+		}
+		if day%i.Duration() == 0 {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("kline interval %s does not divide %s", i, to)
+	}
+	if i == KlineInterval1M || to.Duration()%i.Duration() != 0 {
+		return 0, fmt.Errorf("kline interval %s does not divide %s", i, to)
+	}
+	return int(to.Duration() / i.Duration()), nil
+}
@@ -0,0 +1,133 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseKlineInterval(t *testing.T) {
+	assert := assert.New(t)
+	i, err := ParseKlineInterval("15m")
+	assert.NoError(err)
+	assert.Equal(KlineInterval15m, i)
+	assert.Equal(15*time.Minute, i.Duration())
+	_, err = ParseKlineInterval("1H")
+	assert.Error(err)
+	assert.True(KlineInterval1M.IsValid())
+	assert.Equal(time.Duration(0), KlineInterval1M.Duration())
+}
+
+func TestKlineIntervalTruncate(t *testing.T) {
+	assert := assert.New(t)
+	// 2021-03-18 is a thursday
+	ts := time.Date(2021, 3, 18, 13, 47, 12, 0, time.UTC)
+	assert.Equal(time.Date(2021, 3, 18, 13, 45, 0, 0, time.UTC), KlineInterval15m.Truncate(ts))
+	assert.Equal(time.Date(2021, 3, 18, 12, 0, 0, 0, time.UTC), KlineInterval4h.Truncate(ts))
+	assert.Equal(time.Date(2021, 3, 15, 0, 0, 0, 0, time.UTC), KlineInterval1w.Truncate(ts))
+	assert.Equal(time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC), KlineInterval1M.Truncate(ts))
+	assert.Equal(time.Unix(3*24*60*60, 0).UTC(), KlineInterval3d.Truncate(time.Unix(5*24*60*60, 0)))
+	assert.Equal(time.Date(2021, 3, 22, 0, 0, 0, 0, time.UTC), KlineInterval1w.Next(ts))
+	assert.Equal(time.Date(2021, 4, 1, 0, 0, 0, 0, time.UTC), KlineInterval1M.Next(ts))
+	assert.Equal(time.Date(2021, 3, 18, 14, 0, 0, 0, time.UTC), KlineInterval1h.Align(ts))
+	assert.Equal(time.Date(2021, 3, 18, 0, 0, 0, 0, time.UTC), KlineInterval1d.Align(time.Date(2021, 3, 18, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestKlineIntervalCount(t *testing.T) {
+	assert := assert.New(t)
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	assert.Equal(24, KlineInterval1h.Count(start, start.Add(24*time.Hour)))
+	assert.Equal(25, KlineInterval1h.Count(start, start.Add(24*time.Hour+time.Second)))
+	assert.Equal(23, KlineInterval1h.Count(start.Add(time.Second), start.Add(24*time.Hour)))
+	assert.Equal(12, KlineInterval1M.Count(start, start.AddDate(1, 0, 0)))
+	assert.Equal(0, KlineInterval1d.Count(start, start))
+}
+
+func TestKlineIntervalRatio(t *testing.T) {
+	assert := assert.New(t)
+	tests := []struct {
+		from, to KlineInterval
+		want     int
+		err      bool
+	}{
+		{KlineInterval1m, KlineInterval1h, 60, false},
+		{KlineInterval15m, KlineInterval4h, 16, false},
+		{KlineInterval1d, KlineInterval1w, 7, false},
+		{KlineInterval3d, KlineInterval1w, 0, true},
+		{KlineInterval1h, KlineInterval1M, 0, false},
+		{KlineInterval1w, KlineInterval1M, 0, true},
+		{KlineInterval1h, KlineInterval15m, 0, true},
+	}
+	for _, tt := range tests {
+		ratio, err := tt.from.Ratio(tt.to)
+		if tt.err {
+			assert.Error(err, "%s to %s", tt.from, tt.to)
+			continue
+		}
+		assert.NoError(err)
+		assert.Equal(tt.want, ratio, "%s to %s", tt.from, tt.to)
+	}
+}
+
+func TestFindKlineGaps(t *testing.T) {
+	assert := assert.New(t)
+	start := time.Unix(0, 0)
+	end := start.Add(10 * time.Minute)
+	minute := int64(60000)
+	openTimes := []int64{0, minute, 4 * minute, 5 * minute, 6 * minute, 8 * minute}
+	gaps := FindKlineGaps(KlineInterval1m, start, end, openTimes)
+	assert.Equal([]KlineGap{
+		{Start: 2 * minute, End: 4 * minute, Count: 2},
+		{Start: 7 * minute, End: 8 * minute, Count: 1},
+		{Start: 9 * minute, End: 10 * minute, Count: 1},
+	}, gaps)
+	assert.Empty(FindKlineGaps(KlineInterval5m, start, end, []int64{0, 5 * minute}))
+}
+
+func TestSplitKlineRange(t *testing.T) {
+	assert := assert.New(t)
+	start := time.Unix(0, 0)
+	chunks := SplitKlineRange(KlineInterval1m, start.Add(time.Second), start.Add(25*time.Minute), 10)
+	assert.Equal([]KlineChunk{
+		{Start: 60000, End: 660000},
+		{Start: 660000, End: 1260000},
+		{Start: 1260000, End: 1500000},
+	}, chunks)
+	assert.Empty(SplitKlineRange(KlineInterval1m, start, start.Add(25*time.Minute), 0))
+	assert.Empty(SplitKlineRange(KlineInterval1m, start, start.Add(25*time.Minute), -1))
+}
+
+func TestDownloadKlineChunks(t *testing.T) {
+	assert := assert.New(t)
+	chunks := make([]KlineChunk, 10)
+	var running, maxRunning, calls int32
+	err := DownloadKlineChunks(context.Background(), chunks, 3, func(ctx context.Context, i int, chunk KlineChunk) error {
+		n := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for {
+			max := atomic.LoadInt32(&maxRunning)
+			if n <= max || atomic.CompareAndSwapInt32(&maxRunning, max, n) {
+				break
+			}
+		}
+		atomic.AddInt32(&calls, 1)
+		time.Sleep(time.Millisecond)
+		return nil
+	})
+	assert.NoError(err)
+	assert.Equal(int32(10), calls)
+	assert.True(maxRunning <= 3)
+
+	fetchErr := errors.New("dummy error")
+	err = DownloadKlineChunks(context.Background(), chunks, 1, func(ctx context.Context, i int, chunk KlineChunk) error {
+		if i == 2 {
+			return fetchErr
+		}
+		return nil
+	})
+	assert.Equal(fetchErr, err)
+}
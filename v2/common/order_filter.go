@@ -0,0 +1,374 @@
+package common
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Names of the symbol filters checked by OrderFilters
+const (
+	OrderFilterPrice         = "PRICE_FILTER"
+	OrderFilterLotSize       = "LOT_SIZE"
+	OrderFilterMarketLotSize = "MARKET_LOT_SIZE"
+	OrderFilterMinNotional   = "MIN_NOTIONAL"
+	OrderFilterPercentPrice  = "PERCENT_PRICE"
+	OrderFilterIcebergParts  = "ICEBERG_PARTS"
+)
+
+// OrderFilterViolation define an order parameter rejected by a symbol filter,
+// Filter is empty when the parameter is not a valid decimal number
+type OrderFilterViolation struct {
+	Filter string
+	Field  string
+	Value  string
+	Limit  string
+	Reason string
+}
+
+// String return a readable description of the violation
+func (v OrderFilterViolation) String() string {
+	s := fmt.Sprintf("%s=%s %s", v.Field, v.Value, v.Reason)
+	if v.Limit != "" {
+		s += " " + v.Limit
+	}
+	if v.Filter != "" {
+		s = v.Filter + ": " + s
+	}
+	return s
+}
+
+// OrderFilterError define error when an order does not pass the filters of its symbol
+type OrderFilterError struct {
+	Symbol     string
+	Violations []OrderFilterViolation
+}
+
+// Error return the symbol and the violations
+func (e *OrderFilterError) Error() string {
+	violations := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		violations = append(violations, v.String())
+	}
+	return fmt.Sprintf("<OrderFilterError> symbol=%s, violations=[%s]", e.Symbol, strings.Join(violations, "; "))
+}
+
+// IsOrderFilterError check if e is an order filter error
+func IsOrderFilterError(e error) bool {
+	_, ok := e.(*OrderFilterError)
+	return ok
+}
+
+// PriceRule define the PRICE_FILTER of a symbol, zero or empty values disable a bound
+type PriceRule struct {
+	MinPrice string
+	MaxPrice string
+	TickSize string
+}
+
+// LotSizeRule define the LOT_SIZE or MARKET_LOT_SIZE filter of a symbol,
+// zero or empty values disable a bound
+type LotSizeRule struct {
+	MinQuantity string
+	MaxQuantity string
+	StepSize    string
+}
+
+// MinNotionalRule define the MIN_NOTIONAL filter of a symbol
+type MinNotionalRule struct {
+	MinNotional   string
+	ApplyToMarket bool
+}
+
+// PercentPriceRule define the PERCENT_PRICE filter of a symbol
+type PercentPriceRule struct {
+	MultiplierUp   string
+	MultiplierDown string
+}
+
+// OrderFilters define the filters of a symbol an order is checked against,
+// nil rules are not checked
+type OrderFilters struct {
+	Symbol        string
+	Price         *PriceRule
+	LotSize       *LotSizeRule
+	MarketLotSize *LotSizeRule
+	MinNotional   *MinNotionalRule
+	PercentPrice  *PercentPriceRule
+	// IcebergParts is the maximum number of parts of an iceberg order, zero disables the check
+	IcebergParts int64
+}
+
+// OrderParams define the parameters of an order, the pointers reference the
+// fields of the order service so that Normalize updates the order in place
+type OrderParams struct {
+	Buy             bool
+	Market          bool
+	Price           *string
+	StopPrice       *string
+	Quantity        *string
+	QuoteQuantity   *string
+	IcebergQuantity *string
+	// ReferencePrice is the average or mark price of the symbol, used for the
+	// PERCENT_PRICE filter and the notional of market orders. Those checks are
+	// skipped when it is empty.
+	ReferencePrice string
+}
+
+// Normalize round the prices of the order to the tick size and the quantities to the step size,
+// counted from the minimum price and quantity like Validate does.
+// Quantities are rounded down, the price of a buy order is rounded down and the price of a sell
+// order is rounded up so that the order never gets a worse price than requested.
+// The stop price is rounded to the nearest tick.
+func (f *OrderFilters) Normalize(o *OrderParams) error {
+	if f.Price != nil {
+		priceMode := roundUp
+		if o.Buy {
+			priceMode = roundDown
+		}
+		if err := roundParam(o.Price, f.Price.MinPrice, f.Price.TickSize, priceMode); err != nil {
+			return err
+		}
+		if err := roundParam(o.StopPrice, f.Price.MinPrice, f.Price.TickSize, roundNearest); err != nil {
+			return err
+		}
+	}
+	if lot := f.lotSize(o); lot != nil {
+		if err := roundParam(o.Quantity, lot.MinQuantity, lot.StepSize, roundDown); err != nil {
+			return err
+		}
+	}
+	if f.LotSize != nil {
+		if err := roundParam(o.IcebergQuantity, f.LotSize.MinQuantity, f.LotSize.StepSize, roundDown); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Validate check the order against the filters and return an *OrderFilterError
+// listing all the violations, or nil when the order passes every filter
+func (f *OrderFilters) Validate(o *OrderParams) error {
+	c := &filterCheck{}
+	price := c.decimal("price", o.Price)
+	stopPrice := c.decimal("stopPrice", o.StopPrice)
+	quantity := c.decimal("quantity", o.Quantity)
+	quoteQuantity := c.decimal("quoteOrderQty", o.QuoteQuantity)
+	icebergQuantity := c.decimal("icebergQty", o.IcebergQuantity)
+	reference := c.decimal("referencePrice", &o.ReferencePrice)
+
+	if f.Price != nil {
+		c.priceRule(f.Price, "price", o.Price, price)
+		c.priceRule(f.Price, "stopPrice", o.StopPrice, stopPrice)
+	}
+	if f.LotSize != nil {
+		c.lotSizeRule(OrderFilterLotSize, f.LotSize, "quantity", o.Quantity, quantity)
+		c.lotSizeRule(OrderFilterLotSize, f.LotSize, "icebergQty", o.IcebergQuantity, icebergQuantity)
+	}
+	if o.Market && f.MarketLotSize != nil {
+		c.lotSizeRule(OrderFilterMarketLotSize, f.MarketLotSize, "quantity", o.Quantity, quantity)
+	}
+	if f.PercentPrice != nil && !o.Market && price != nil && reference != nil {
+		c.percentPriceRule(f.PercentPrice, o.Price, price, reference)
+	}
+	if f.MinNotional != nil {
+		c.minNotionalRule(f.MinNotional, o, price, quantity, quoteQuantity, reference)
+	}
+	if f.IcebergParts > 0 && quantity != nil && icebergQuantity != nil && icebergQuantity.Sign() > 0 {
+		parts := new(big.Rat).Quo(quantity, icebergQuantity)
+		n := new(big.Int).Quo(parts.Num(), parts.Denom())
+		if !parts.IsInt() {
+			n.Add(n, big.NewInt(1))
+		}
+		if n.Cmp(big.NewInt(f.IcebergParts)) > 0 {
+			c.add(OrderFilterIcebergParts, "icebergQty", *o.IcebergQuantity,
+				fmt.Sprintf("%d", f.IcebergParts), fmt.Sprintf("splits the order in %s parts, more than", n.String()))
+		}
+	}
+	if len(c.violations) == 0 {
+		return nil
+	}
+	return &OrderFilterError{Symbol: f.Symbol, Violations: c.violations}
+}
+
+func (f *OrderFilters) lotSize(o *OrderParams) *LotSizeRule {
+	if o.Market && f.MarketLotSize != nil && PositiveDecimal(f.MarketLotSize.StepSize) != nil {
+		return f.MarketLotSize
+	}
+	return f.LotSize
+}
+
+type filterCheck struct {
+	violations []OrderFilterViolation
+}
+
+func (c *filterCheck) add(filter, field, value, limit, reason string) {
+	c.violations = append(c.violations, OrderFilterViolation{
+		Filter: filter,
+		Field:  field,
+		Value:  value,
+		Limit:  limit,
+		Reason: reason,
+	})
+}
+
+// decimal parse an optional order parameter, recording a violation when it is not a number
+func (c *filterCheck) decimal(field string, value *string) *big.Rat {
+	if value == nil || *value == "" {
+		return nil
+	}
+	r, ok := new(big.Rat).SetString(*value)
+	if !ok {
+		c.add("", field, *value, "", "is not a decimal number")
+		return nil
+	}
+	return r
+}
+
+func (c *filterCheck) priceRule(rule *PriceRule, field string, value *string, price *big.Rat) {
+	if price == nil {
+		return
+	}
+	c.bounds(OrderFilterPrice, field, *value, price, rule.MinPrice, rule.MaxPrice)
+	c.step(OrderFilterPrice, field, *value, price, rule.MinPrice, rule.TickSize, "is not a multiple of the tick size")
+}
+
+func (c *filterCheck) lotSizeRule(filter string, rule *LotSizeRule, field string, value *string, quantity *big.Rat) {
+	if quantity == nil {
+		return
+	}
+	c.bounds(filter, field, *value, quantity, rule.MinQuantity, rule.MaxQuantity)
+	c.step(filter, field, *value, quantity, rule.MinQuantity, rule.StepSize, "is not a multiple of the step size")
+}
+
+func (c *filterCheck) bounds(filter, field, value string, v *big.Rat, min, max string) {
+	if m := PositiveDecimal(min); m != nil && v.Cmp(m) < 0 {
+		c.add(filter, field, value, min, "is lower than")
+	}
+	if m := PositiveDecimal(max); m != nil && v.Cmp(m) > 0 {
+		c.add(filter, field, value, max, "is greater than")
+	}
+}
+
+// step check that (v - min) is a multiple of size, as the exchange does
+func (c *filterCheck) step(filter, field, value string, v *big.Rat, min, size, reason string) {
+	s := PositiveDecimal(size)
+	if s == nil {
+		return
+	}
+	d := new(big.Rat).Set(v)
+	if m := PositiveDecimal(min); m != nil {
+		d.Sub(d, m)
+	}
+	if !d.Quo(d, s).IsInt() {
+		c.add(filter, field, value, size, reason)
+	}
+}
+
+func (c *filterCheck) percentPriceRule(rule *PercentPriceRule, value *string, price, reference *big.Rat) {
+	if up := PositiveDecimal(rule.MultiplierUp); up != nil {
+		max := new(big.Rat).Mul(reference, up)
+		if price.Cmp(max) > 0 {
+			c.add(OrderFilterPercentPrice, "price", *value, FormatDecimal(max), "is greater than")
+		}
+	}
+	if down := PositiveDecimal(rule.MultiplierDown); down != nil {
+		min := new(big.Rat).Mul(reference, down)
+		if price.Cmp(min) < 0 {
+			c.add(OrderFilterPercentPrice, "price", *value, FormatDecimal(min), "is lower than")
+		}
+	}
+}
+
+func (c *filterCheck) minNotionalRule(rule *MinNotionalRule, o *OrderParams, price, quantity, quoteQuantity, reference *big.Rat) {
+	min := PositiveDecimal(rule.MinNotional)
+	if min == nil {
+		return
+	}
+	var notional *big.Rat
+	switch {
+	case o.Market && !rule.ApplyToMarket:
+		return
+	case o.Market && quoteQuantity != nil:
+		notional = quoteQuantity
+	case o.Market && quantity != nil && reference != nil:
+		notional = new(big.Rat).Mul(quantity, reference)
+	case !o.Market && quantity != nil && price != nil:
+		notional = new(big.Rat).Mul(quantity, price)
+	case !o.Market && quoteQuantity != nil:
+		notional = quoteQuantity
+	default:
+		return
+	}
+	if notional.Cmp(min) < 0 {
+		c.add(OrderFilterMinNotional, "notional", FormatDecimal(notional), rule.MinNotional, "is lower than")
+	}
+}
+
+type roundMode int
+
+const (
+	roundDown roundMode = iota
+	roundUp
+	roundNearest
+)
+
+// roundParam round value to a multiple of step from min, the filters count their steps from
+// their minimum when it is set
+func roundParam(value *string, min, step string, mode roundMode) error {
+	if value == nil || *value == "" || PositiveDecimal(step) == nil {
+		return nil
+	}
+	rounded, err := roundToStep(*value, min, step, mode == roundUp, mode == roundNearest)
+	if err != nil {
+		return err
+	}
+	*value = rounded
+	return nil
+}
+
+// RoundToStep round value to a multiple of step, down unless up is set, or to the
+// nearest multiple when nearest is set. The result has the number of decimals of step.
+func RoundToStep(value, step string, up, nearest bool) (string, error) {
+	return roundToStep(value, "", step, up, nearest)
+}
+
+func roundToStep(value, min, step string, up, nearest bool) (string, error) {
+	v, ok := new(big.Rat).SetString(value)
+	if !ok {
+		return "", fmt.Errorf("invalid decimal value %q", value)
+	}
+	s := PositiveDecimal(step)
+	if s == nil {
+		return "", fmt.Errorf("invalid step %q", step)
+	}
+	offset := PositiveDecimal(min)
+	if offset == nil {
+		offset = new(big.Rat)
+	}
+	q := new(big.Rat).Sub(v, offset)
+	q.Quo(q, s)
+	if nearest {
+		q.Add(q, big.NewRat(1, 2))
+	}
+	n, m := new(big.Int).DivMod(q.Num(), q.Denom(), new(big.Int))
+	if up && !nearest && m.Sign() != 0 {
+		n.Add(n, big.NewInt(1))
+	}
+	res := new(big.Rat).Mul(new(big.Rat).SetInt(n), s)
+	res.Add(res, offset)
+	places := decimals(step)
+	if d := decimals(min); offset.Sign() > 0 && d > places {
+		places = d
+	}
+	return res.FloatString(places), nil
+}
+
+// decimals return the number of significant decimals of a decimal string, e.g. 3 for 0.00100000
+func decimals(value string) int {
+	i := strings.IndexByte(value, '.')
+	if i < 0 {
+		return 0
+	}
+	return len(strings.TrimRight(value[i+1:], "0"))
+}
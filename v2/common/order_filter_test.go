@@ -0,0 +1,131 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func TestRoundToStep(t *testing.T) {
+	assert := assert.New(t)
+	tests := []struct {
+		value   string
+		step    string
+		up      bool
+		nearest bool
+		want    string
+	}{
+		{"0.123456", "0.00100000", false, false, "0.123"},
+		{"0.123456", "0.00100000", true, false, "0.124"},
+		{"0.123456", "0.00100000", false, true, "0.123"},
+		{"0.1235", "0.00100000", false, true, "0.124"},
+		{"0.123", "0.00100000", true, false, "0.123"},
+		{"1234.5", "1.00000000", false, false, "1234"},
+		{"17", "5", false, false, "15"},
+	}
+	for _, tt := range tests {
+		res, err := RoundToStep(tt.value, tt.step, tt.up, tt.nearest)
+		assert.NoError(err)
+		assert.Equal(tt.want, res, "%s to %s", tt.value, tt.step)
+	}
+	_, err := RoundToStep("abc", "0.1", false, false)
+	assert.Error(err)
+	_, err = RoundToStep("1", "0", false, false)
+	assert.Error(err)
+}
+
+func testOrderFilters() *OrderFilters {
+	return &OrderFilters{
+		Symbol:        "BNBUSDT",
+		Price:         &PriceRule{MinPrice: "0.01000000", MaxPrice: "10000.00000000", TickSize: "0.01000000"},
+		LotSize:       &LotSizeRule{MinQuantity: "0.01000000", MaxQuantity: "9000.00000000", StepSize: "0.01000000"},
+		MarketLotSize: &LotSizeRule{MinQuantity: "0.00000000", MaxQuantity: "1000.00000000", StepSize: "0.00000000"},
+		MinNotional:   &MinNotionalRule{MinNotional: "10.00000000", ApplyToMarket: true},
+		PercentPrice:  &PercentPriceRule{MultiplierUp: "5", MultiplierDown: "0.2"},
+		IcebergParts:  10,
+	}
+}
+
+func TestOrderFiltersNormalize(t *testing.T) {
+	assert := assert.New(t)
+	f := testOrderFilters()
+
+	buy := &OrderParams{Buy: true, Price: stringPtr("300.126"), StopPrice: stringPtr("299.995"), Quantity: stringPtr("1.239"), IcebergQuantity: stringPtr("0.125")}
+	assert.NoError(f.Normalize(buy))
+	assert.Equal("300.12", *buy.Price)
+	assert.Equal("300.00", *buy.StopPrice)
+	assert.Equal("1.23", *buy.Quantity)
+	assert.Equal("0.12", *buy.IcebergQuantity)
+
+	sell := &OrderParams{Price: stringPtr("300.121"), Quantity: stringPtr("1.239")}
+	assert.NoError(f.Normalize(sell))
+	assert.Equal("300.13", *sell.Price)
+	assert.Equal("1.23", *sell.Quantity)
+
+	market := &OrderParams{Market: true, Quantity: stringPtr("1.239")}
+	assert.NoError(f.Normalize(market))
+	assert.Equal("1.23", *market.Quantity)
+
+	invalid := &OrderParams{Price: stringPtr("abc")}
+	assert.Error(f.Normalize(invalid))
+
+	// the ticks count from the minimum price
+	f.Price = &PriceRule{MinPrice: "0.05", MaxPrice: "100", TickSize: "0.1"}
+	offset := &OrderParams{Buy: true, Price: stringPtr("1.23"), Quantity: stringPtr("10")}
+	assert.NoError(f.Normalize(offset))
+	assert.Equal("1.15", *offset.Price)
+	assert.NoError(f.Validate(offset))
+}
+
+func TestOrderFiltersValidate(t *testing.T) {
+	assert := assert.New(t)
+	f := testOrderFilters()
+
+	valid := &OrderParams{Buy: true, Price: stringPtr("300.12"), Quantity: stringPtr("1.23"), ReferencePrice: "301"}
+	assert.NoError(f.Validate(valid))
+
+	order := &OrderParams{
+		Buy:             true,
+		Price:           stringPtr("0.015"),
+		Quantity:        stringPtr("9000.005"),
+		IcebergQuantity: stringPtr("100"),
+		ReferencePrice:  "300",
+	}
+	err := f.Validate(order)
+	assert.True(IsOrderFilterError(err))
+	violations := err.(*OrderFilterError).Violations
+	assert.Equal([]OrderFilterViolation{
+		{Filter: OrderFilterPrice, Field: "price", Value: "0.015", Limit: "0.01000000", Reason: "is not a multiple of the tick size"},
+		{Filter: OrderFilterLotSize, Field: "quantity", Value: "9000.005", Limit: "9000.00000000", Reason: "is greater than"},
+		{Filter: OrderFilterLotSize, Field: "quantity", Value: "9000.005", Limit: "0.01000000", Reason: "is not a multiple of the step size"},
+		{Filter: OrderFilterPercentPrice, Field: "price", Value: "0.015", Limit: "60", Reason: "is lower than"},
+		{Filter: OrderFilterIcebergParts, Field: "icebergQty", Value: "100", Limit: "10", Reason: "splits the order in 91 parts, more than"},
+	}, violations)
+	assert.Contains(err.Error(), "<OrderFilterError> symbol=BNBUSDT")
+
+	notional := &OrderParams{Price: stringPtr("5.00"), Quantity: stringPtr("1.00")}
+	err = f.Validate(notional)
+	assert.Equal([]OrderFilterViolation{
+		{Filter: OrderFilterMinNotional, Field: "notional", Value: "5", Limit: "10.00000000", Reason: "is lower than"},
+	}, err.(*OrderFilterError).Violations)
+
+	market := &OrderParams{Market: true, Quantity: stringPtr("1001"), ReferencePrice: "0.005"}
+	err = f.Validate(market)
+	assert.Equal([]OrderFilterViolation{
+		{Filter: OrderFilterMarketLotSize, Field: "quantity", Value: "1001", Limit: "1000.00000000", Reason: "is greater than"},
+		{Filter: OrderFilterMinNotional, Field: "notional", Value: "5.005", Limit: "10.00000000", Reason: "is lower than"},
+	}, err.(*OrderFilterError).Violations)
+
+	quote := &OrderParams{Market: true, QuoteQuantity: stringPtr("20")}
+	assert.NoError(f.Validate(quote))
+
+	invalid := &OrderParams{Quantity: stringPtr("1,5")}
+	err = f.Validate(invalid)
+	assert.Equal([]OrderFilterViolation{
+		{Field: "quantity", Value: "1,5", Reason: "is not a decimal number"},
+	}, err.(*OrderFilterError).Violations)
+}
@@ -0,0 +1,379 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+)
+
+// OrderStatus define the status of an order tracked by OrderTracker, the values
+// are the order statuses of the spot and futures APIs
+type OrderStatus string
+
+// Order statuses
+const (
+	OrderStatusNew             OrderStatus = "NEW"
+	OrderStatusNewInsurance    OrderStatus = "NEW_INSURANCE"
+	OrderStatusNewADL          OrderStatus = "NEW_ADL"
+	OrderStatusPartiallyFilled OrderStatus = "PARTIALLY_FILLED"
+	OrderStatusPendingCancel   OrderStatus = "PENDING_CANCEL"
+	OrderStatusFilled          OrderStatus = "FILLED"
+	OrderStatusCanceled        OrderStatus = "CANCELED"
+	OrderStatusRejected        OrderStatus = "REJECTED"
+	OrderStatusExpired         OrderStatus = "EXPIRED"
+	OrderStatusExpiredInMatch  OrderStatus = "EXPIRED_IN_MATCH"
+)
+
+// orderStatusRanks order the statuses, an order never goes back to a lower rank
+var orderStatusRanks = map[OrderStatus]int{
+	OrderStatusNew:             0,
+	OrderStatusNewInsurance:    0,
+	OrderStatusNewADL:          0,
+	OrderStatusPartiallyFilled: 1,
+	OrderStatusPendingCancel:   2,
+	OrderStatusFilled:          3,
+	OrderStatusCanceled:        3,
+	OrderStatusRejected:        3,
+	OrderStatusExpired:         3,
+	OrderStatusExpiredInMatch:  3,
+}
+
+// IsTerminal return true if no update follows the status
+func (s OrderStatus) IsTerminal() bool {
+	return orderStatusRanks[s] == 3
+}
+
+// OrderTransitionError define error when an update moves an order to a status it can not reach,
+// e.g. from a terminal status or back to NEW. The update is not applied.
+type OrderTransitionError struct {
+	ClientOrderID string
+	From          OrderStatus
+	To            OrderStatus
+}
+
+// Error return the order and the statuses
+func (e *OrderTransitionError) Error() string {
+	return fmt.Sprintf("<OrderTransitionError> clientOrderId=%s, from=%s, to=%s", e.ClientOrderID, e.From, e.To)
+}
+
+// IsOrderTransitionError check if e is an order transition error
+func IsOrderTransitionError(e error) bool {
+	_, ok := e.(*OrderTransitionError)
+	return ok
+}
+
+// OrderUpdate define an update of an order, from the user data stream or from a REST order.
+// TradeID and the Last fields are only set by updates reporting a trade.
+type OrderUpdate struct {
+	Symbol        string
+	OrderID       int64
+	ClientOrderID string
+	Side          string
+	Type          string
+	Status        OrderStatus
+	Price         string
+	Quantity      string
+	// ExecutedQuantity and CumulativeQuote are the totals of the order, empty when unknown
+	ExecutedQuantity string
+	CumulativeQuote  string
+	TradeID          int64
+	LastQuantity     string
+	LastPrice        string
+	Commission       string
+	CommissionAsset  string
+	// Time is the transaction or update time in milliseconds
+	Time int64
+}
+
+// OrderState define the state of a tracked order
+type OrderState struct {
+	Symbol           string
+	OrderID          int64
+	ClientOrderID    string
+	Side             string
+	Type             string
+	Status           OrderStatus
+	Price            string
+	Quantity         string
+	ExecutedQuantity string
+	CumulativeQuote  string
+	// Commissions is the commission paid by asset, summed from the trades of the user data stream
+	Commissions map[string]string
+	Trades      int
+	CreateTime  int64
+	UpdateTime  int64
+}
+
+// AveragePrice return the average price of the executed quantity, empty when nothing is executed
+func (s *OrderState) AveragePrice() string {
+	executed := PositiveDecimal(s.ExecutedQuantity)
+	quote := PositiveDecimal(s.CumulativeQuote)
+	if executed == nil || quote == nil {
+		return ""
+	}
+	return FormatDecimal(quote.Quo(quote, executed))
+}
+
+// OrderStateHandler handle the changes of tracked orders
+type OrderStateHandler func(state *OrderState)
+
+type trackedOrder struct {
+	state       OrderState
+	executed    *big.Rat
+	quote       *big.Rat
+	commissions map[string]*big.Rat
+	trades      map[int64]bool
+}
+
+type orderSubscription struct {
+	id            int
+	clientOrderID string
+	handler       OrderStateHandler
+}
+
+// OrderTracker keep the state of live orders from user data stream updates and REST orders.
+// It enforces the status transitions, accumulates fills and commissions, and ignores replayed
+// updates. Orders are keyed by client order id. It is safe for concurrent use.
+type OrderTracker struct {
+	mu     sync.Mutex
+	orders map[string]*trackedOrder
+	ids    map[string]string
+	// subscriptions are the live subscriptions, in the order they were made
+	subscriptions []orderSubscription
+	nextID        int
+}
+
+// NewOrderTracker create an empty order tracker
+func NewOrderTracker() *OrderTracker {
+	return &OrderTracker{
+		orders: make(map[string]*trackedOrder),
+		ids:    make(map[string]string),
+	}
+}
+
+func orderIDKey(symbol string, orderID int64) string {
+	return fmt.Sprintf("%s/%d", symbol, orderID)
+}
+
+// Apply update the order of update, creating it when it is not tracked yet.
+// It returns false when the update changes nothing, e.g. a replayed event, and an
+// *OrderTransitionError when the update is inconsistent with the tracked status.
+func (t *OrderTracker) Apply(update *OrderUpdate) (bool, error) {
+	t.mu.Lock()
+	changed, state, err := t.apply(update)
+	var handlers []OrderStateHandler
+	if changed {
+		handlers = t.handlers(state.ClientOrderID)
+	}
+	t.mu.Unlock()
+	for _, handler := range handlers {
+		handler(state)
+	}
+	return changed, err
+}
+
+func (t *OrderTracker) apply(update *OrderUpdate) (bool, *OrderState, error) {
+	clientOrderID := update.ClientOrderID
+	if id, ok := t.ids[orderIDKey(update.Symbol, update.OrderID)]; ok && update.OrderID != 0 {
+		// the client order id of a canceled spot order is the one of the cancel request
+		clientOrderID = id
+	}
+	if clientOrderID == "" {
+		return false, nil, fmt.Errorf("order update of %s %d without client order id", update.Symbol, update.OrderID)
+	}
+	order, ok := t.orders[clientOrderID]
+	if !ok {
+		order = &trackedOrder{
+			state:       OrderState{ClientOrderID: clientOrderID, Status: update.Status, CreateTime: update.Time},
+			executed:    new(big.Rat),
+			quote:       new(big.Rat),
+			commissions: make(map[string]*big.Rat),
+			trades:      make(map[int64]bool),
+		}
+	}
+	state := &order.state
+	if ok && state.Status != update.Status {
+		from, to := orderStatusRanks[state.Status], orderStatusRanks[update.Status]
+		if state.Status.IsTerminal() || to < from {
+			return false, nil, &OrderTransitionError{ClientOrderID: clientOrderID, From: state.Status, To: update.Status}
+		}
+	}
+
+	changed := !ok || state.Status != update.Status
+	if update.TradeID != 0 && !order.trades[update.TradeID] {
+		if quantity := PositiveDecimal(update.LastQuantity); quantity != nil {
+			order.trades[update.TradeID] = true
+			state.Trades++
+			changed = true
+			// the cumulative quantity of the update already counts the trade, see below
+			if update.ExecutedQuantity == "" {
+				order.executed.Add(order.executed, quantity)
+			}
+			if price := PositiveDecimal(update.LastPrice); price != nil && update.CumulativeQuote == "" {
+				order.quote.Add(order.quote, price.Mul(price, quantity))
+			}
+			if commission := PositiveDecimal(update.Commission); commission != nil {
+				sum, ok := order.commissions[update.CommissionAsset]
+				if !ok {
+					sum = new(big.Rat)
+					order.commissions[update.CommissionAsset] = sum
+				}
+				sum.Add(sum, commission)
+			}
+		}
+	}
+	// the totals of the update are authoritative when they are ahead of the trades seen
+	if executed := PositiveDecimal(update.ExecutedQuantity); executed != nil && executed.Cmp(order.executed) > 0 {
+		order.executed = executed
+		changed = true
+	}
+	if quote := PositiveDecimal(update.CumulativeQuote); quote != nil && quote.Cmp(order.quote) > 0 {
+		order.quote = quote
+		changed = true
+	}
+	if !changed {
+		return false, nil, nil
+	}
+
+	state.Status = update.Status
+	if update.Symbol != "" {
+		state.Symbol = update.Symbol
+	}
+	if update.OrderID != 0 {
+		state.OrderID = update.OrderID
+		t.ids[orderIDKey(update.Symbol, update.OrderID)] = clientOrderID
+	}
+	if update.Side != "" {
+		state.Side = update.Side
+	}
+	if update.Type != "" {
+		state.Type = update.Type
+	}
+	if update.Price != "" {
+		state.Price = update.Price
+	}
+	if update.Quantity != "" {
+		state.Quantity = update.Quantity
+	}
+	if update.Time > state.UpdateTime {
+		state.UpdateTime = update.Time
+	}
+	state.ExecutedQuantity = FormatDecimal(order.executed)
+	state.CumulativeQuote = FormatDecimal(order.quote)
+	state.Commissions = make(map[string]string, len(order.commissions))
+	for asset, commission := range order.commissions {
+		state.Commissions[asset] = FormatDecimal(commission)
+	}
+	t.orders[clientOrderID] = order
+	return true, order.copy(), nil
+}
+
+func (o *trackedOrder) copy() *OrderState {
+	state := o.state
+	state.Commissions = make(map[string]string, len(o.state.Commissions))
+	for asset, commission := range o.state.Commissions {
+		state.Commissions[asset] = commission
+	}
+	return &state
+}
+
+func (t *OrderTracker) handlers(clientOrderID string) []OrderStateHandler {
+	var handlers []OrderStateHandler
+	for _, s := range t.subscriptions {
+		if s.clientOrderID == "" || s.clientOrderID == clientOrderID {
+			handlers = append(handlers, s.handler)
+		}
+	}
+	return handlers
+}
+
+// Order return the state of the order with the client order id
+func (t *OrderTracker) Order(clientOrderID string) (*OrderState, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	order, ok := t.orders[clientOrderID]
+	if !ok {
+		return nil, false
+	}
+	return order.copy(), true
+}
+
+// Orders return the state of all the tracked orders
+func (t *OrderTracker) Orders() []*OrderState {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	states := make([]*OrderState, 0, len(t.orders))
+	for _, order := range t.orders {
+		states = append(states, order.copy())
+	}
+	return states
+}
+
+// OpenOrders return the state of the tracked orders which are not in a terminal status
+func (t *OrderTracker) OpenOrders() []*OrderState {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var states []*OrderState
+	for _, order := range t.orders {
+		if !order.state.Status.IsTerminal() {
+			states = append(states, order.copy())
+		}
+	}
+	return states
+}
+
+// Forget stop tracking an order, e.g. once its terminal state is handled
+func (t *OrderTracker) Forget(clientOrderID string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if order, ok := t.orders[clientOrderID]; ok {
+		delete(t.ids, orderIDKey(order.state.Symbol, order.state.OrderID))
+		delete(t.orders, clientOrderID)
+	}
+}
+
+// Subscribe call handler with each change of the order with the client order id, or of all
+// orders when clientOrderID is empty. Handlers are called in order, outside of the tracker lock.
+// The returned function removes the subscription.
+func (t *OrderTracker) Subscribe(clientOrderID string, handler OrderStateHandler) (unsubscribe func()) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	id := t.nextID
+	t.nextID++
+	t.subscriptions = append(t.subscriptions, orderSubscription{id: id, clientOrderID: clientOrderID, handler: handler})
+	return func() {
+		t.mu.Lock()
+		defer t.mu.Unlock()
+		for i, s := range t.subscriptions {
+			if s.id == id {
+				t.subscriptions = append(t.subscriptions[:i:i], t.subscriptions[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// Await wait until the order with the client order id reaches a terminal status and return its state.
+// The order does not need to be tracked yet.
+func (t *OrderTracker) Await(ctx context.Context, clientOrderID string) (*OrderState, error) {
+	done := make(chan *OrderState, 1)
+	unsubscribe := t.Subscribe(clientOrderID, func(state *OrderState) {
+		if state.Status.IsTerminal() {
+			select {
+			case done <- state:
+			default:
+			}
+		}
+	})
+	defer unsubscribe()
+	if state, ok := t.Order(clientOrderID); ok && state.Status.IsTerminal() {
+		return state, nil
+	}
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case state := <-done:
+		return state, nil
+	}
+}
@@ -0,0 +1,158 @@
+package common
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderTrackerFills(t *testing.T) {
+	tracker := NewOrderTracker()
+	var states []OrderStatus
+	tracker.Subscribe("", func(state *OrderState) {
+		states = append(states, state.Status)
+	})
+
+	updates := []*OrderUpdate{
+		{Symbol: "BTCUSDT", OrderID: 1, ClientOrderID: "a", Status: OrderStatusNew, Quantity: "2", Price: "100", Time: 1},
+		{Symbol: "BTCUSDT", OrderID: 1, ClientOrderID: "a", Status: OrderStatusPartiallyFilled, TradeID: 10,
+			LastQuantity: "0.5", LastPrice: "100", Commission: "0.001", CommissionAsset: "BNB", Time: 2},
+		{Symbol: "BTCUSDT", OrderID: 1, ClientOrderID: "a", Status: OrderStatusPartiallyFilled, TradeID: 11,
+			LastQuantity: "1", LastPrice: "99", Commission: "0.002", CommissionAsset: "BNB", Time: 3},
+	}
+	for _, update := range updates {
+		changed, err := tracker.Apply(update)
+		require.NoError(t, err)
+		assert.True(t, changed)
+	}
+	// replayed trade
+	changed, err := tracker.Apply(updates[1])
+	assert.NoError(t, err)
+	assert.False(t, changed)
+
+	state, ok := tracker.Order("a")
+	require.True(t, ok)
+	assert.Equal(t, OrderStatusPartiallyFilled, state.Status)
+	assert.Equal(t, "1.5", state.ExecutedQuantity)
+	assert.Equal(t, "149", state.CumulativeQuote)
+	assert.Equal(t, "99.33333333", state.AveragePrice())
+	assert.Equal(t, map[string]string{"BNB": "0.003"}, state.Commissions)
+	assert.Equal(t, 2, state.Trades)
+	assert.Equal(t, int64(1), state.CreateTime)
+	assert.Equal(t, int64(3), state.UpdateTime)
+	assert.Len(t, tracker.OpenOrders(), 1)
+
+	// a REST order ahead of the stream
+	changed, err = tracker.Apply(&OrderUpdate{Symbol: "BTCUSDT", OrderID: 1, ClientOrderID: "a",
+		Status: OrderStatusFilled, ExecutedQuantity: "2", CumulativeQuote: "198.5", Time: 4})
+	require.NoError(t, err)
+	assert.True(t, changed)
+	state, _ = tracker.Order("a")
+	assert.Equal(t, "2", state.ExecutedQuantity)
+	assert.Equal(t, "99.25", state.AveragePrice())
+	assert.Empty(t, tracker.OpenOrders())
+	assert.Equal(t, []OrderStatus{OrderStatusNew, OrderStatusPartiallyFilled, OrderStatusPartiallyFilled, OrderStatusFilled}, states)
+}
+
+func TestOrderTrackerReconcileThenLateTrade(t *testing.T) {
+	tracker := NewOrderTracker()
+	// the REST order is seen before the trade event
+	_, err := tracker.Apply(&OrderUpdate{Symbol: "BTCUSDT", OrderID: 1, ClientOrderID: "a", Status: OrderStatusPartiallyFilled,
+		Quantity: "2", ExecutedQuantity: "1", CumulativeQuote: "100", Time: 2})
+	require.NoError(t, err)
+	changed, err := tracker.Apply(&OrderUpdate{Symbol: "BTCUSDT", OrderID: 1, ClientOrderID: "a", Status: OrderStatusPartiallyFilled,
+		TradeID: 10, LastQuantity: "1", LastPrice: "100", ExecutedQuantity: "1", CumulativeQuote: "100",
+		Commission: "0.001", CommissionAsset: "BNB", Time: 1})
+	require.NoError(t, err)
+	assert.True(t, changed)
+
+	state, _ := tracker.Order("a")
+	assert.Equal(t, "1", state.ExecutedQuantity)
+	assert.Equal(t, "100", state.CumulativeQuote)
+	assert.Equal(t, map[string]string{"BNB": "0.001"}, state.Commissions)
+	assert.Equal(t, 1, state.Trades)
+
+	_, err = tracker.Apply(&OrderUpdate{Symbol: "BTCUSDT", OrderID: 1, ClientOrderID: "a", Status: OrderStatusFilled,
+		TradeID: 11, LastQuantity: "1", LastPrice: "102", ExecutedQuantity: "2", CumulativeQuote: "202", Time: 3})
+	require.NoError(t, err)
+	state, _ = tracker.Order("a")
+	assert.Equal(t, "2", state.ExecutedQuantity)
+	assert.Equal(t, "202", state.CumulativeQuote)
+}
+
+func TestOrderTrackerTransitions(t *testing.T) {
+	tracker := NewOrderTracker()
+	_, err := tracker.Apply(&OrderUpdate{Symbol: "BTCUSDT", OrderID: 1, ClientOrderID: "a", Status: OrderStatusPartiallyFilled,
+		TradeID: 1, LastQuantity: "1", LastPrice: "10"})
+	require.NoError(t, err)
+
+	_, err = tracker.Apply(&OrderUpdate{Symbol: "BTCUSDT", OrderID: 1, ClientOrderID: "a", Status: OrderStatusNew})
+	assert.Equal(t, &OrderTransitionError{ClientOrderID: "a", From: OrderStatusPartiallyFilled, To: OrderStatusNew}, err)
+	assert.True(t, IsOrderTransitionError(err))
+
+	// the cancel of a spot order is reported with the client order id of the cancel request
+	changed, err := tracker.Apply(&OrderUpdate{Symbol: "BTCUSDT", OrderID: 1, ClientOrderID: "cancel", Status: OrderStatusCanceled})
+	require.NoError(t, err)
+	assert.True(t, changed)
+	_, ok := tracker.Order("cancel")
+	assert.False(t, ok)
+	state, _ := tracker.Order("a")
+	assert.Equal(t, OrderStatusCanceled, state.Status)
+
+	changed, err = tracker.Apply(&OrderUpdate{Symbol: "BTCUSDT", OrderID: 1, ClientOrderID: "a", Status: OrderStatusCanceled})
+	assert.NoError(t, err)
+	assert.False(t, changed)
+	_, err = tracker.Apply(&OrderUpdate{Symbol: "BTCUSDT", OrderID: 1, ClientOrderID: "a", Status: OrderStatusFilled})
+	assert.True(t, IsOrderTransitionError(err))
+
+	_, err = tracker.Apply(&OrderUpdate{Symbol: "BTCUSDT", OrderID: 2, Status: OrderStatusNew})
+	assert.Error(t, err)
+
+	tracker.Forget("a")
+	assert.Empty(t, tracker.Orders())
+}
+
+func TestOrderTrackerAwait(t *testing.T) {
+	tracker := NewOrderTracker()
+	var updates []OrderStatus
+	unsubscribe := tracker.Subscribe("a", func(state *OrderState) {
+		updates = append(updates, state.Status)
+	})
+	done := make(chan *OrderState)
+	go func() {
+		state, err := tracker.Await(context.Background(), "a")
+		assert.NoError(t, err)
+		done <- state
+	}()
+	_, err := tracker.Apply(&OrderUpdate{Symbol: "BTCUSDT", OrderID: 2, ClientOrderID: "b", Status: OrderStatusNew})
+	require.NoError(t, err)
+	_, err = tracker.Apply(&OrderUpdate{Symbol: "BTCUSDT", OrderID: 1, ClientOrderID: "a", Status: OrderStatusNew})
+	require.NoError(t, err)
+	unsubscribe()
+	_, err = tracker.Apply(&OrderUpdate{Symbol: "BTCUSDT", OrderID: 1, ClientOrderID: "a", Status: OrderStatusFilled,
+		ExecutedQuantity: "1"})
+	require.NoError(t, err)
+
+	select {
+	case state := <-done:
+		assert.Equal(t, OrderStatusFilled, state.Status)
+	case <-time.After(time.Second):
+		t.Fatal("await did not return")
+	}
+	assert.Equal(t, []OrderStatus{OrderStatusNew}, updates)
+
+	// the order is already done
+	state, err := tracker.Await(context.Background(), "a")
+	require.NoError(t, err)
+	assert.Equal(t, "1", state.ExecutedQuantity)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = tracker.Await(ctx, "b")
+	assert.Equal(t, context.Canceled, err)
+	// the subscriptions of the awaits are removed
+	assert.Empty(t, tracker.subscriptions)
+}
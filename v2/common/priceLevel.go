@@ -1,6 +1,10 @@
 package common
 
-import "strconv"
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
 
 // PriceLevel is a common structure for bids and asks in the
 // order book.
@@ -23,3 +27,31 @@ func (p *PriceLevel) Parse() (float64, float64, error) {
 	}
 	return price, quantity, nil
 }
+
+// UnmarshalJSON decodes a price level pushed by Binance as ["price", "quantity"].
+// The object form produced by encoding a PriceLevel is accepted as well.
+func (p *PriceLevel) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '{' {
+		type priceLevel PriceLevel
+		return json.Unmarshal(data, (*priceLevel)(p))
+	}
+	var item []interface{}
+	err := json.Unmarshal(data, &item)
+	if err != nil {
+		return err
+	}
+	if len(item) < 2 {
+		return fmt.Errorf("invalid price level: %s", data)
+	}
+	price, ok := item[0].(string)
+	if !ok {
+		return fmt.Errorf("invalid price level price: %s", data)
+	}
+	quantity, ok := item[1].(string)
+	if !ok {
+		return fmt.Errorf("invalid price level quantity: %s", data)
+	}
+	p.Price = price
+	p.Quantity = quantity
+	return nil
+}
@@ -0,0 +1,353 @@
+package common
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultMissedIntervals is the number of expected intervals a stream may stay silent before it is stale
+const defaultMissedIntervals = 3
+
+// WsWatchdog define the stale-stream watchdog configuration of websocket streams
+type WsWatchdog struct {
+	// ExpectedInterval return the expected time between two messages of a stream,
+	// zero disables the check for the stream. DefaultWsExpectedInterval is used when nil.
+	ExpectedInterval func(stream string) time.Duration
+	// MissedIntervals is the number of expected intervals without any message after which
+	// a stream is reported as stale, 3 when zero.
+	MissedIntervals int
+	// StaleHandler is called with the metrics of a stream once it becomes stale.
+	StaleHandler func(stats WsStreamStats)
+	// Reconnect forces a reconnection of the websocket when one of its streams becomes stale.
+	Reconnect bool
+}
+
+func (w *WsWatchdog) expectedInterval(stream string) time.Duration {
+	if w.ExpectedInterval != nil {
+		return w.ExpectedInterval(stream)
+	}
+	return DefaultWsExpectedInterval(stream)
+}
+
+func (w *WsWatchdog) staleAfter(interval time.Duration) time.Duration {
+	n := w.MissedIntervals
+	if n <= 0 {
+		n = defaultMissedIntervals
+	}
+	return interval * time.Duration(n)
+}
+
+// WsStreamStats define the health metrics of a websocket stream
+type WsStreamStats struct {
+	Endpoint         string
+	Stream           string
+	ExpectedInterval time.Duration
+	MessageCount     int64
+	ReconnectCount   int64
+	ConnectedAt      time.Time
+	LastMessageAt    time.Time
+	// LastEventTime is the event time E of the last message, in milliseconds
+	LastEventTime int64
+	// Lag is the time between the event time E of the last message and its reception
+	Lag   time.Duration
+	Stale bool
+}
+
+// DefaultWsExpectedInterval return the documented update speed of a stream,
+// or the kline interval for kline streams. Event driven streams, like trades
+// or user data, return zero.
+func DefaultWsExpectedInterval(stream string) time.Duration {
+	switch {
+	case strings.HasSuffix(stream, "@100ms"):
+		return 100 * time.Millisecond
+	case strings.HasSuffix(stream, "@250ms"):
+		return 250 * time.Millisecond
+	case strings.HasSuffix(stream, "@500ms"):
+		return 500 * time.Millisecond
+	case strings.HasSuffix(stream, "@1s"):
+		return time.Second
+	}
+	if i := strings.Index(stream, "line_"); i >= 0 && strings.Contains(stream, "@") {
+		return klineIntervalDuration(stream[i+len("line_"):])
+	}
+	switch {
+	case strings.Contains(stream, "bookTicker"):
+		return 0
+	case strings.Contains(stream, "@depth"):
+		return time.Second
+	case strings.Contains(stream, "markPrice"), strings.Contains(stream, "@indexPrice"):
+		return 3 * time.Second
+	case strings.Contains(stream, "icker"), strings.Contains(stream, "@compositeIndex"), strings.Contains(stream, "@assetIndex"):
+		return time.Second
+	}
+	return 0
+}
+
+func klineIntervalDuration(interval string) time.Duration {
+	if len(interval) < 2 {
+		return 0
+	}
+	unit := map[byte]time.Duration{
+		's': time.Second,
+		'm': time.Minute,
+		'h': time.Hour,
+		'd': 24 * time.Hour,
+		'w': 7 * 24 * time.Hour,
+		'M': 31 * 24 * time.Hour,
+	}[interval[len(interval)-1]]
+	n := 0
+	for _, c := range interval[:len(interval)-1] {
+		if c < '0' || c > '9' {
+			return 0
+		}
+		n = n*10 + int(c-'0')
+	}
+	return time.Duration(n) * unit
+}
+
+// WsEndpointStreams return the stream names served by a websocket endpoint,
+// either a single raw stream or a combined stream.
+func WsEndpointStreams(endpoint string) []string {
+	if i := strings.Index(endpoint, "streams="); i >= 0 {
+		return strings.Split(endpoint[i+len("streams="):], "/")
+	}
+	if i := strings.LastIndex(endpoint, "/ws/"); i >= 0 {
+		return []string{endpoint[i+len("/ws/"):]}
+	}
+	return []string{endpoint}
+}
+
+// WsStreamMonitor track the metrics of the streams of a websocket connection
+// and detect the streams that stopped sending messages.
+type WsStreamMonitor struct {
+	mu       sync.Mutex
+	watchdog *WsWatchdog
+	combined bool
+	streams  map[string]*WsStreamStats
+	now      func() time.Time
+}
+
+// NewWsStreamMonitor create a monitor for the streams of endpoint
+func NewWsStreamMonitor(endpoint string, watchdog *WsWatchdog) *WsStreamMonitor {
+	m := &WsStreamMonitor{
+		watchdog: watchdog,
+		combined: strings.Contains(endpoint, "streams="),
+		streams:  make(map[string]*WsStreamStats),
+		now:      time.Now,
+	}
+	now := m.now()
+	for _, stream := range WsEndpointStreams(endpoint) {
+		m.streams[stream] = &WsStreamStats{
+			Endpoint:         endpoint,
+			Stream:           stream,
+			ExpectedInterval: watchdog.expectedInterval(stream),
+			ConnectedAt:      now,
+		}
+	}
+	return m
+}
+
+type wsMonitoredMessage struct {
+	Stream string          `json:"stream"`
+	Event  string          `json:"e"` // declared so that "e" does not match "E" case-insensitively
+	Time   int64           `json:"E"`
+	Data   json.RawMessage `json:"data"`
+}
+
+// Observe record the reception of a message
+func (m *WsStreamMonitor) Observe(message []byte) {
+	now := m.now()
+	stream, eventTime := m.parse(message)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	stats, ok := m.streams[stream]
+	if !ok {
+		return
+	}
+	stats.MessageCount++
+	stats.LastMessageAt = now
+	stats.Stale = false
+	if eventTime > 0 {
+		stats.LastEventTime = eventTime
+		stats.Lag = now.Sub(time.Unix(0, eventTime*int64(time.Millisecond)))
+	}
+}
+
+func (m *WsStreamMonitor) parse(message []byte) (stream string, eventTime int64) {
+	if !m.combined {
+		for s := range m.streams {
+			stream = s
+		}
+		return stream, messageEventTime(message)
+	}
+	msg := new(wsMonitoredMessage)
+	if json.Unmarshal(message, msg) != nil {
+		return "", 0
+	}
+	return msg.Stream, messageEventTime(msg.Data)
+}
+
+// messageEventTime return the latest event time E of a message, which can be an array of events
+func messageEventTime(message []byte) int64 {
+	message = bytes.TrimSpace(message)
+	if len(message) > 0 && message[0] == '[' {
+		var events []wsMonitoredMessage
+		if json.Unmarshal(message, &events) != nil {
+			return 0
+		}
+		var latest int64
+		for _, e := range events {
+			if e.Time > latest {
+				latest = e.Time
+			}
+		}
+		return latest
+	}
+	msg := new(wsMonitoredMessage)
+	if json.Unmarshal(message, msg) != nil {
+		return 0
+	}
+	return msg.Time
+}
+
+// Reconnected reset the stale state of the streams after a reconnection
+func (m *WsStreamMonitor) Reconnected() {
+	now := m.now()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, stats := range m.streams {
+		stats.ReconnectCount++
+		stats.ConnectedAt = now
+		stats.Stale = false
+	}
+}
+
+// Stats return the metrics of the streams, sorted by stream name
+func (m *WsStreamMonitor) Stats() []WsStreamStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	res := make([]WsStreamStats, 0, len(m.streams))
+	for _, stats := range m.streams {
+		res = append(res, *stats)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Stream < res[j].Stream
+	})
+	return res
+}
+
+// Check return the streams which became stale since the last check
+func (m *WsStreamMonitor) Check() []WsStreamStats {
+	now := m.now()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var stale []WsStreamStats
+	for _, stats := range m.streams {
+		if stats.ExpectedInterval <= 0 || stats.Stale {
+			continue
+		}
+		last := stats.LastMessageAt
+		if last.Before(stats.ConnectedAt) {
+			last = stats.ConnectedAt
+		}
+		if now.Sub(last) > m.watchdog.staleAfter(stats.ExpectedInterval) {
+			stats.Stale = true
+			stale = append(stale, *stats)
+		}
+	}
+	return stale
+}
+
+// checkInterval return how often the streams should be checked
+func (m *WsStreamMonitor) checkInterval() time.Duration {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var interval time.Duration
+	for _, stats := range m.streams {
+		if stats.ExpectedInterval > 0 && (interval == 0 || stats.ExpectedInterval < interval) {
+			interval = stats.ExpectedInterval
+		}
+	}
+	return interval
+}
+
+// Watch check the streams until done is closed, calling the StaleHandler of the watchdog
+// and onStale for every check which found stale streams.
+func (m *WsStreamMonitor) Watch(done <-chan struct{}, onStale func(stale []WsStreamStats)) {
+	interval := m.checkInterval()
+	if interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+		}
+		stale := m.Check()
+		if len(stale) == 0 {
+			continue
+		}
+		if m.watchdog.StaleHandler != nil {
+			for _, stats := range stale {
+				m.watchdog.StaleHandler(stats)
+			}
+		}
+		if onStale != nil {
+			onStale(stale)
+		}
+	}
+}
+
+// WsMonitorRegistry keep the monitors of the running websocket connections
+type WsMonitorRegistry struct {
+	mu       sync.Mutex
+	monitors map[*WsStreamMonitor]struct{}
+}
+
+// NewWsMonitorRegistry create an empty registry
+func NewWsMonitorRegistry() *WsMonitorRegistry {
+	return &WsMonitorRegistry{monitors: make(map[*WsStreamMonitor]struct{})}
+}
+
+// Add register a monitor
+func (r *WsMonitorRegistry) Add(m *WsStreamMonitor) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.monitors[m] = struct{}{}
+}
+
+// Remove unregister a monitor
+func (r *WsMonitorRegistry) Remove(m *WsStreamMonitor) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.monitors, m)
+}
+
+// Stats return the metrics of the streams of all registered monitors
+func (r *WsMonitorRegistry) Stats() []WsStreamStats {
+	r.mu.Lock()
+	monitors := make([]*WsStreamMonitor, 0, len(r.monitors))
+	for m := range r.monitors {
+		monitors = append(monitors, m)
+	}
+	r.mu.Unlock()
+	var res []WsStreamStats
+	for _, m := range monitors {
+		res = append(res, m.Stats()...)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Endpoint != res[j].Endpoint {
+			return res[i].Endpoint < res[j].Endpoint
+		}
+		return res[i].Stream < res[j].Stream
+	})
+	return res
+}
@@ -0,0 +1,106 @@
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaultWsExpectedInterval(t *testing.T) {
+	assert := assert.New(t)
+	tests := map[string]time.Duration{
+		"btcusdt@depth":                        time.Second,
+		"btcusdt@depth5@100ms":                 100 * time.Millisecond,
+		"btcusdt@depth@500ms":                  500 * time.Millisecond,
+		"btcusdt@kline_1m":                     time.Minute,
+		"btcusdt_perpetual@continuousKline_4h": 4 * time.Hour,
+		"btcusdt@markPrice":                    3 * time.Second,
+		"!markPrice@arr@1s":                    time.Second,
+		"!ticker@arr":                          time.Second,
+		"btcusdt@aggTrade":                     0,
+		"btcusdt@bookTicker":                   0,
+		"pqia91ma19a5s61cv6a81va65sdf19v8a65a1a5s61cv6a81va65sdf19v8a65a1": 0,
+	}
+	for stream, expected := range tests {
+		assert.Equal(expected, DefaultWsExpectedInterval(stream), stream)
+	}
+}
+
+func TestWsEndpointStreams(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal([]string{"btcusdt@depth"}, WsEndpointStreams("wss://fstream.binance.com/ws/btcusdt@depth"))
+	assert.Equal([]string{"btcusdt@depth", "ethusdt@kline_1m"}, WsEndpointStreams("wss://fstream.binance.com/stream?streams=btcusdt@depth/ethusdt@kline_1m"))
+}
+
+func TestWsStreamMonitor(t *testing.T) {
+	assert := assert.New(t)
+	now := time.Unix(1600000000, 0)
+	var staleStats []WsStreamStats
+	m := NewWsStreamMonitor("wss://stream.binance.com:9443/stream?streams=btcusdt@depth/btcusdt@aggTrade", &WsWatchdog{
+		StaleHandler: func(stats WsStreamStats) {
+			staleStats = append(staleStats, stats)
+		},
+	})
+	m.now = func() time.Time { return now }
+	m.streams["btcusdt@depth"].ConnectedAt = now
+
+	now = now.Add(500 * time.Millisecond)
+	m.Observe([]byte(`{"stream":"btcusdt@depth","data":{"e":"depthUpdate","E":1600000000400}}`))
+	m.Observe([]byte(`{"stream":"btcusdt@aggTrade","data":{"e":"aggTrade","E":1600000000300}}`))
+	m.Observe([]byte(`{"stream":"btcusdt@aggTrade","data":{"e":"aggTrade","E":1600000000450}}`))
+
+	stats := m.Stats()
+	assert.Len(stats, 2)
+	assert.Equal("btcusdt@aggTrade", stats[0].Stream)
+	assert.Equal(int64(2), stats[0].MessageCount)
+	assert.Equal(int64(1600000000450), stats[0].LastEventTime)
+	assert.Equal(50*time.Millisecond, stats[0].Lag)
+	assert.Equal("btcusdt@depth", stats[1].Stream)
+	assert.Equal(int64(1), stats[1].MessageCount)
+	assert.Equal(100*time.Millisecond, stats[1].Lag)
+	assert.Equal(time.Second, stats[1].ExpectedInterval)
+
+	now = now.Add(2 * time.Second)
+	assert.Empty(m.Check())
+
+	now = now.Add(2 * time.Second)
+	stale := m.Check()
+	assert.Len(stale, 1)
+	assert.Equal("btcusdt@depth", stale[0].Stream)
+	assert.True(stale[0].Stale)
+	// a stale stream is only reported once
+	assert.Empty(m.Check())
+
+	m.Observe([]byte(`{"stream":"btcusdt@depth","data":{"e":"depthUpdate","E":1600000004500}}`))
+	assert.False(m.Stats()[1].Stale)
+
+	m.Reconnected()
+	assert.Equal(int64(1), m.Stats()[0].ReconnectCount)
+	assert.Empty(staleStats)
+}
+
+func TestWsStreamMonitorArrayEvent(t *testing.T) {
+	assert := assert.New(t)
+	m := NewWsStreamMonitor("wss://fstream.binance.com/ws/!markPrice@arr", &WsWatchdog{})
+	m.Observe([]byte(`[{"e":"markPriceUpdate","E":1562305380000},{"e":"markPriceUpdate","E":1562305380001}]`))
+	stats := m.Stats()
+	assert.Len(stats, 1)
+	assert.Equal(int64(1), stats[0].MessageCount)
+	assert.Equal(int64(1562305380001), stats[0].LastEventTime)
+	assert.Equal(3*time.Second, stats[0].ExpectedInterval)
+}
+
+func TestWsMonitorRegistry(t *testing.T) {
+	assert := assert.New(t)
+	r := NewWsMonitorRegistry()
+	m1 := NewWsStreamMonitor("wss://fstream.binance.com/ws/btcusdt@depth", &WsWatchdog{})
+	m2 := NewWsStreamMonitor("wss://dstream.binance.com/ws/btcusd_perp@depth", &WsWatchdog{})
+	r.Add(m1)
+	r.Add(m2)
+	assert.Len(r.Stats(), 2)
+	r.Remove(m1)
+	stats := r.Stats()
+	assert.Len(stats, 1)
+	assert.Equal("btcusd_perp@depth", stats[0].Stream)
+}
@@ -0,0 +1,87 @@
+package common
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// wsCombinedMessage define the envelope of a message pushed by a combined stream
+type wsCombinedMessage struct {
+	Stream string          `json:"stream"`
+	Data   json.RawMessage `json:"data"`
+	Error  *APIError       `json:"error"`
+}
+
+// wsErrorMessage define an error frame pushed in place of an event, e.g. {"code": 2, "msg": "Invalid request"}
+type wsErrorMessage struct {
+	Code    *int64  `json:"code"`
+	Message *string `json:"msg"`
+}
+
+// UnmarshalWsMessage decode a websocket message into v.
+// An error frame of the exchange is returned as an *APIError, and any other failure,
+// including a panic raised while decoding, as a *WsDecodeError.
+func UnmarshalWsMessage(message []byte, v interface{}) error {
+	if err := wsErrorFrame(message); err != nil {
+		return err
+	}
+	return unmarshalWsPayload(message, message, v)
+}
+
+// UnmarshalCombinedWsMessage decode the data of a combined stream message into v
+// and return the name of the stream that pushed it.
+// An error frame is returned as an *APIError, and any other failure as a *WsDecodeError
+// holding the whole message.
+func UnmarshalCombinedWsMessage(message []byte, v interface{}) (stream string, err error) {
+	envelope := new(wsCombinedMessage)
+	err = unmarshalWsPayload(message, message, envelope)
+	if err != nil {
+		return "", err
+	}
+	if envelope.Error != nil {
+		return "", envelope.Error
+	}
+	if err := wsErrorFrame(envelope.Data); err != nil {
+		return "", err
+	}
+	if len(envelope.Data) == 0 {
+		return "", NewWsDecodeError(message, errors.New("missing data in combined stream message"))
+	}
+	err = unmarshalWsPayload(message, envelope.Data, v)
+	if err != nil {
+		return "", err
+	}
+	return envelope.Stream, nil
+}
+
+// StreamSymbol return the upper case symbol of a stream name like btcusdt@kline_1m
+func StreamSymbol(stream string) string {
+	return strings.ToUpper(strings.Split(stream, "@")[0])
+}
+
+func unmarshalWsPayload(message []byte, data []byte, v interface{}) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = NewWsDecodeError(message, fmt.Errorf("panic while decoding: %v", r))
+		}
+	}()
+	if e := json.Unmarshal(data, v); e != nil {
+		return NewWsDecodeError(message, e)
+	}
+	return nil
+}
+
+// wsErrorFrame return the error of an error frame, or nil for any other message
+func wsErrorFrame(data []byte) error {
+	if !bytes.Contains(data, []byte(`"msg"`)) {
+		return nil
+	}
+	frame := new(wsErrorMessage)
+	if json.Unmarshal(data, frame) != nil || frame.Code == nil || frame.Message == nil {
+		return nil
+	}
+	return &APIError{Code: *frame.Code, Message: *frame.Message}
+}
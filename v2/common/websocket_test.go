@@ -0,0 +1,99 @@
+package common
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnmarshalWsMessage(t *testing.T) {
+	assert := assert.New(t)
+
+	var v struct {
+		Symbol string       `json:"s"`
+		Bids   []PriceLevel `json:"b"`
+	}
+	err := UnmarshalWsMessage([]byte(`{"s":"BTCUSDT","b":[["1.0","2.0",[]]]}`), &v)
+	assert.NoError(err)
+	assert.Equal("BTCUSDT", v.Symbol)
+	assert.Equal([]PriceLevel{{Price: "1.0", Quantity: "2.0"}}, v.Bids)
+
+	message := []byte(`{"s":"BTCUSDT","b":[["1.0"]]}`)
+	err = UnmarshalWsMessage(message, &v)
+	assert.True(IsWsDecodeError(err))
+	assert.Equal(message, err.(*WsDecodeError).Payload)
+}
+
+func TestUnmarshalWsErrorFrame(t *testing.T) {
+	assert := assert.New(t)
+
+	var v struct {
+		Code   int64  `json:"code"`
+		Symbol string `json:"s"`
+	}
+	err := UnmarshalWsMessage([]byte(`{"code":2,"msg":"Invalid request"}`), &v)
+	assert.Equal(&APIError{Code: 2, Message: "Invalid request"}, err)
+	assert.Empty(v.Symbol)
+	// an event with a code field is not an error frame
+	assert.NoError(UnmarshalWsMessage([]byte(`{"code":2,"s":"BTCUSDT"}`), &v))
+	assert.Equal("BTCUSDT", v.Symbol)
+
+	_, err = UnmarshalCombinedWsMessage([]byte(`{"error":{"code":3,"msg":"Invalid JSON"}}`), &v)
+	assert.Equal(&APIError{Code: 3, Message: "Invalid JSON"}, err)
+	_, err = UnmarshalCombinedWsMessage([]byte(`{"stream":"btcusdt@depth","data":{"code":4,"msg":"Too many"}}`), &v)
+	assert.Equal(&APIError{Code: 4, Message: "Too many"}, err)
+}
+
+type panickingEvent struct{}
+
+func (e *panickingEvent) UnmarshalJSON(data []byte) error {
+	panic("boom")
+}
+
+func TestUnmarshalWsMessagePanic(t *testing.T) {
+	assert := assert.New(t)
+
+	message := []byte(`{}`)
+	err := UnmarshalWsMessage(message, new(panickingEvent))
+	assert.True(IsWsDecodeError(err))
+	assert.Equal(message, err.(*WsDecodeError).Payload)
+	assert.Contains(err.Error(), "boom")
+}
+
+func TestUnmarshalCombinedWsMessage(t *testing.T) {
+	assert := assert.New(t)
+
+	var v struct {
+		LastUpdateID int64        `json:"lastUpdateId"`
+		Asks         []PriceLevel `json:"asks"`
+	}
+	stream, err := UnmarshalCombinedWsMessage([]byte(`{"stream":"ethbtc@depth5","data":{"lastUpdateId":160,"asks":[["0.0026","100"]]}}`), &v)
+	assert.NoError(err)
+	assert.Equal("ethbtc@depth5", stream)
+	assert.Equal("ETHBTC", StreamSymbol(stream))
+	assert.Equal(int64(160), v.LastUpdateID)
+	assert.Equal([]PriceLevel{{Price: "0.0026", Quantity: "100"}}, v.Asks)
+
+	for _, message := range [][]byte{
+		[]byte(`{"code":1,"msg":"Invalid request"}`),
+		[]byte(`{"stream":"ethbtc@depth5","data":{"asks":"oops"}}`),
+		[]byte(`not json`),
+	} {
+		_, err = UnmarshalCombinedWsMessage(message, &v)
+		assert.True(IsWsDecodeError(err), string(message))
+		decodeErr := new(WsDecodeError)
+		assert.True(errors.As(err, &decodeErr))
+		assert.Equal(message, decodeErr.Payload)
+	}
+}
+
+func TestPriceLevelUnmarshalJSON(t *testing.T) {
+	assert := assert.New(t)
+
+	p := new(PriceLevel)
+	assert.NoError(p.UnmarshalJSON([]byte(`{"Price":"1.5","Quantity":"3"}`)))
+	assert.Equal(PriceLevel{Price: "1.5", Quantity: "3"}, *p)
+	assert.Error(p.UnmarshalJSON([]byte(`[1.5,3]`)))
+	assert.Error(p.UnmarshalJSON([]byte(`"1.5"`)))
+}
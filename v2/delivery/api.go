@@ -0,0 +1,58 @@
+package delivery
+
+import "time"
+
+// MarketDataClient is the market data services of Client
+type MarketDataClient interface {
+	NewPingService() *PingService
+	NewServerTimeService() *ServerTimeService
+	NewSetServerTimeService() *SetServerTimeService
+	NewKlinesService() *KlinesService
+	NewKlineDownloader(symbol string, interval KlineInterval) *KlineDownloader
+	NewListPriceChangeStatsService() *ListPriceChangeStatsService
+	NewListPricesService() *ListPricesService
+	NewListBookTickersService() *ListBookTickersService
+	NewExchangeInfoService() *ExchangeInfoService
+	NewSymbolRegistry(ttl time.Duration) *SymbolRegistry
+	NewListLiquidationOrdersService() *ListLiquidationOrdersService
+}
+
+// TradingClient is the order services of Client
+type TradingClient interface {
+	NewCreateOrderService() *CreateOrderService
+	NewGetOrderService() *GetOrderService
+	NewCancelOrderService() *CancelOrderService
+	NewCancelAllOpenOrdersService() *CancelAllOpenOrdersService
+	NewListOpenOrdersService() *ListOpenOrdersService
+	NewListOrdersService() *ListOrdersService
+	NewOrderTracker() *OrderTracker
+}
+
+// AccountClient is the account and position services of Client
+type AccountClient interface {
+	NewGetAccountService() *GetAccountService
+	NewGetBalanceService() *GetBalanceService
+	NewGetPositionRiskService() *GetPositionRiskService
+	NewChangeLeverageService() *ChangeLeverageService
+	NewChangeMarginTypeService() *ChangeMarginTypeService
+	NewUpdatePositionMarginService() *UpdatePositionMarginService
+	NewChangePositionModeService() *ChangePositionModeService
+	NewGetPositionModeService() *GetPositionModeService
+}
+
+// UserStreamClient is the listen key services of the user data stream of Client
+type UserStreamClient interface {
+	NewStartUserStreamService() *StartUserStreamService
+	NewKeepaliveUserStreamService() *KeepaliveUserStreamService
+	NewCloseUserStreamService() *CloseUserStreamService
+}
+
+// API is the REST surface of Client, implemented by Client and FakeClient
+type API interface {
+	MarketDataClient
+	TradingClient
+	AccountClient
+	UserStreamClient
+}
+
+var _ API = (*Client)(nil)
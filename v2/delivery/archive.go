@@ -0,0 +1,208 @@
+package delivery
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Zamzam-Technology/go-binance/v2/common"
+)
+
+var errInvalidArchiveRecord = errors.New("invalid archive record")
+
+// AggTrade define aggregate trade info
+type AggTrade struct {
+	AggTradeID   int64  `json:"a"`
+	Price        string `json:"p"`
+	Quantity     string `json:"q"`
+	FirstTradeID int64  `json:"f"`
+	LastTradeID  int64  `json:"l"`
+	Timestamp    int64  `json:"T"`
+	IsBuyerMaker bool   `json:"m"`
+}
+
+// Trade define trade info, quantities are in contracts and BaseQuantity in the base asset
+type Trade struct {
+	ID           int64  `json:"id"`
+	Price        string `json:"price"`
+	Quantity     string `json:"qty"`
+	BaseQuantity string `json:"baseQty"`
+	Time         int64  `json:"time"`
+	IsBuyerMaker bool   `json:"isBuyerMaker"`
+}
+
+func openArchive(ctx context.Context, fetcher common.ArchiveFetcher, dataType common.ArchiveDataType, period common.ArchivePeriod, symbol string, interval KlineInterval, date time.Time) (*common.ArchiveReader, error) {
+	return common.OpenArchive(ctx, fetcher, &common.ArchiveFile{
+		Market:   common.ArchiveMarketCOINM,
+		Period:   period,
+		DataType: dataType,
+		Symbol:   symbol,
+		Interval: interval,
+		Date:     date,
+	})
+}
+
+// KlineArchiveReader read the klines of an archive file
+type KlineArchiveReader struct {
+	r *common.ArchiveReader
+}
+
+// OpenKlineArchive open the klines archive of a symbol for the day or the month of date
+func OpenKlineArchive(ctx context.Context, fetcher common.ArchiveFetcher, period common.ArchivePeriod, symbol string, interval KlineInterval, date time.Time) (*KlineArchiveReader, error) {
+	r, err := openArchive(ctx, fetcher, common.ArchiveDataTypeKlines, period, symbol, interval, date)
+	if err != nil {
+		return nil, err
+	}
+	return &KlineArchiveReader{r: r}, nil
+}
+
+// Read return the next kline, or io.EOF at the end of the file
+func (r *KlineArchiveReader) Read() (*Kline, error) {
+	record, err := r.r.Read()
+	if err != nil {
+		return nil, err
+	}
+	if record.Len() < 11 {
+		return nil, errInvalidArchiveRecord
+	}
+	kline := &Kline{
+		OpenTime:                 record.Time(0),
+		Open:                     record.String(1),
+		High:                     record.String(2),
+		Low:                      record.String(3),
+		Close:                    record.String(4),
+		Volume:                   record.String(5),
+		CloseTime:                record.Time(6),
+		QuoteAssetVolume:         record.String(7),
+		TradeNum:                 record.Int64(8),
+		TakerBuyBaseAssetVolume:  record.String(9),
+		TakerBuyQuoteAssetVolume: record.String(10),
+	}
+	return kline, record.Err()
+}
+
+// Close close the archive
+func (r *KlineArchiveReader) Close() error {
+	return r.r.Close()
+}
+
+// AggTradeArchiveReader read the aggregate trades of an archive file
+type AggTradeArchiveReader struct {
+	r *common.ArchiveReader
+}
+
+// OpenAggTradeArchive open the aggregate trades archive of a symbol for the day or the month of date
+func OpenAggTradeArchive(ctx context.Context, fetcher common.ArchiveFetcher, period common.ArchivePeriod, symbol string, date time.Time) (*AggTradeArchiveReader, error) {
+	r, err := openArchive(ctx, fetcher, common.ArchiveDataTypeAggTrades, period, symbol, "", date)
+	if err != nil {
+		return nil, err
+	}
+	return &AggTradeArchiveReader{r: r}, nil
+}
+
+// Read return the next aggregate trade, or io.EOF at the end of the file
+func (r *AggTradeArchiveReader) Read() (*AggTrade, error) {
+	record, err := r.r.Read()
+	if err != nil {
+		return nil, err
+	}
+	if record.Len() < 7 {
+		return nil, errInvalidArchiveRecord
+	}
+	trade := &AggTrade{
+		AggTradeID:   record.Int64(0),
+		Price:        record.String(1),
+		Quantity:     record.String(2),
+		FirstTradeID: record.Int64(3),
+		LastTradeID:  record.Int64(4),
+		Timestamp:    record.Time(5),
+		IsBuyerMaker: record.Bool(6),
+	}
+	return trade, record.Err()
+}
+
+// Close close the archive
+func (r *AggTradeArchiveReader) Close() error {
+	return r.r.Close()
+}
+
+// TradeArchiveReader read the trades of an archive file
+type TradeArchiveReader struct {
+	r *common.ArchiveReader
+}
+
+// OpenTradeArchive open the trades archive of a symbol for the day or the month of date
+func OpenTradeArchive(ctx context.Context, fetcher common.ArchiveFetcher, period common.ArchivePeriod, symbol string, date time.Time) (*TradeArchiveReader, error) {
+	r, err := openArchive(ctx, fetcher, common.ArchiveDataTypeTrades, period, symbol, "", date)
+	if err != nil {
+		return nil, err
+	}
+	return &TradeArchiveReader{r: r}, nil
+}
+
+// Read return the next trade, or io.EOF at the end of the file
+func (r *TradeArchiveReader) Read() (*Trade, error) {
+	record, err := r.r.Read()
+	if err != nil {
+		return nil, err
+	}
+	if record.Len() < 6 {
+		return nil, errInvalidArchiveRecord
+	}
+	trade := &Trade{
+		ID:           record.Int64(0),
+		Price:        record.String(1),
+		Quantity:     record.String(2),
+		BaseQuantity: record.String(3),
+		Time:         record.Time(4),
+		IsBuyerMaker: record.Bool(5),
+	}
+	return trade, record.Err()
+}
+
+// Close close the archive
+func (r *TradeArchiveReader) Close() error {
+	return r.r.Close()
+}
+
+// BookTickerArchiveReader read the best price updates of an archive file
+type BookTickerArchiveReader struct {
+	r      *common.ArchiveReader
+	symbol string
+}
+
+// OpenBookTickerArchive open the book ticker archive of a symbol for the day or the month of date
+func OpenBookTickerArchive(ctx context.Context, fetcher common.ArchiveFetcher, period common.ArchivePeriod, symbol string, date time.Time) (*BookTickerArchiveReader, error) {
+	r, err := openArchive(ctx, fetcher, common.ArchiveDataTypeBookTicker, period, symbol, "", date)
+	if err != nil {
+		return nil, err
+	}
+	return &BookTickerArchiveReader{r: r, symbol: symbol}, nil
+}
+
+// Read return the next book ticker, or io.EOF at the end of the file.
+// Time is the transaction time of the update.
+func (r *BookTickerArchiveReader) Read() (*BookTicker, error) {
+	record, err := r.r.Read()
+	if err != nil {
+		return nil, err
+	}
+	if record.Len() < 6 {
+		return nil, errInvalidArchiveRecord
+	}
+	ticker := &BookTicker{
+		Symbol:      r.symbol,
+		UpdateID:    record.Int64(0),
+		BidPrice:    record.String(1),
+		BidQuantity: record.String(2),
+		AskPrice:    record.String(3),
+		AskQuantity: record.String(4),
+		Time:        record.Time(5),
+	}
+	return ticker, record.Err()
+}
+
+// Close close the archive
+func (r *BookTickerArchiveReader) Close() error {
+	return r.r.Close()
+}
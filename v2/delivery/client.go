@@ -54,6 +54,18 @@ type WorkingType string
 // MarginType define margin type
 type MarginType string
 
+// UserDataEventType define user data event type
+type UserDataEventType string
+
+// UserDataEventReasonType define reason type for user data event
+type UserDataEventReasonType string
+
+// OrderExecutionType define order execution type
+type OrderExecutionType string
+
+// KlineInterval define kline interval, like 15m or 1d
+type KlineInterval = common.KlineInterval
+
 // Endpoints
 const (
 	baseApiMainUrl    = "https://dapi.binance.com"
@@ -119,6 +131,46 @@ const (
 	MarginTypeIsolated MarginType = "ISOLATED"
 	MarginTypeCrossed  MarginType = "CROSSED"
 
+	UserDataEventTypeListenKeyExpired UserDataEventType = "listenKeyExpired"
+	UserDataEventTypeMarginCall       UserDataEventType = "MARGIN_CALL"
+	UserDataEventTypeAccountUpdate    UserDataEventType = "ACCOUNT_UPDATE"
+	UserDataEventTypeOrderTradeUpdate UserDataEventType = "ORDER_TRADE_UPDATE"
+
+	UserDataEventReasonTypeDeposit          UserDataEventReasonType = "DEPOSIT"
+	UserDataEventReasonTypeWithdraw         UserDataEventReasonType = "WITHDRAW"
+	UserDataEventReasonTypeOrder            UserDataEventReasonType = "ORDER"
+	UserDataEventReasonTypeFundingFee       UserDataEventReasonType = "FUNDING_FEE"
+	UserDataEventReasonTypeWithdrawReject   UserDataEventReasonType = "WITHDRAW_REJECT"
+	UserDataEventReasonTypeAdjustment       UserDataEventReasonType = "ADJUSTMENT"
+	UserDataEventReasonTypeInsuranceClear   UserDataEventReasonType = "INSURANCE_CLEAR"
+	UserDataEventReasonTypeAdminDeposit     UserDataEventReasonType = "ADMIN_DEPOSIT"
+	UserDataEventReasonTypeAdminWithdraw    UserDataEventReasonType = "ADMIN_WITHDRAW"
+	UserDataEventReasonTypeMarginTransfer   UserDataEventReasonType = "MARGIN_TRANSFER"
+	UserDataEventReasonTypeMarginTypeChange UserDataEventReasonType = "MARGIN_TYPE_CHANGE"
+	UserDataEventReasonTypeAssetTransfer    UserDataEventReasonType = "ASSET_TRANSFER"
+
+	OrderExecutionTypeNew        OrderExecutionType = "NEW"
+	OrderExecutionTypeCanceled   OrderExecutionType = "CANCELED"
+	OrderExecutionTypeCalculated OrderExecutionType = "CALCULATED"
+	OrderExecutionTypeExpired    OrderExecutionType = "EXPIRED"
+	OrderExecutionTypeTrade      OrderExecutionType = "TRADE"
+
+	KlineInterval1m  = common.KlineInterval1m
+	KlineInterval3m  = common.KlineInterval3m
+	KlineInterval5m  = common.KlineInterval5m
+	KlineInterval15m = common.KlineInterval15m
+	KlineInterval30m = common.KlineInterval30m
+	KlineInterval1h  = common.KlineInterval1h
+	KlineInterval2h  = common.KlineInterval2h
+	KlineInterval4h  = common.KlineInterval4h
+	KlineInterval6h  = common.KlineInterval6h
+	KlineInterval8h  = common.KlineInterval8h
+	KlineInterval12h = common.KlineInterval12h
+	KlineInterval1d  = common.KlineInterval1d
+	KlineInterval3d  = common.KlineInterval3d
+	KlineInterval1w  = common.KlineInterval1w
+	KlineInterval1M  = common.KlineInterval1M
+
 	timestampKey  = "timestamp"
 	signatureKey  = "signature"
 	recvWindowKey = "recvWindow"
@@ -304,6 +356,11 @@ func (c *Client) NewKlinesService() *KlinesService {
 	return &KlinesService{c: c}
 }
 
+// NewKlineDownloader init kline downloader
+func (c *Client) NewKlineDownloader(symbol string, interval KlineInterval) *KlineDownloader {
+	return &KlineDownloader{c: c, symbol: symbol, interval: interval, limit: 1000, concurrency: 4}
+}
+
 // NewListPriceChangeStatsService init list prices change stats service
 func (c *Client) NewListPriceChangeStatsService() *ListPriceChangeStatsService {
 	return &ListPriceChangeStatsService{c: c}
@@ -339,6 +396,17 @@ func (c *Client) NewExchangeInfoService() *ExchangeInfoService {
 	return &ExchangeInfoService{c: c}
 }
 
+// NewSymbolRegistry init symbol registry, the exchange info is refreshed when older than ttl,
+// a zero ttl loads it only once
+func (c *Client) NewSymbolRegistry(ttl time.Duration) *SymbolRegistry {
+	return &SymbolRegistry{c: c, ttl: ttl, now: time.Now}
+}
+
+// NewOrderTracker init order tracker
+func (c *Client) NewOrderTracker() *OrderTracker {
+	return &OrderTracker{OrderTracker: common.NewOrderTracker(), c: c}
+}
+
 // NewCreateOrderService init creating order service
 func (c *Client) NewCreateOrderService() *CreateOrderService {
 	return &CreateOrderService{c: c}
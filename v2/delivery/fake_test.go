@@ -0,0 +1,50 @@
+package delivery
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFakeClient(t *testing.T) {
+	c := NewFakeClient()
+	require.NoError(t, c.Stub(http.MethodDelete, "/dapi/v1/allOpenOrders", `{"code": 200, "msg": "done"}`))
+	require.NoError(t, c.NewCancelAllOpenOrdersService().Symbol("BTCUSD_PERP").Do(context.Background()))
+	calls := c.Calls(http.MethodDelete, "/dapi/v1/allOpenOrders")
+	require.Len(t, calls, 1)
+	assert.Equal(t, "BTCUSD_PERP", calls[0].Params.Get("symbol"))
+
+	reset := errors.New("connection reset")
+	c.StubError(http.MethodGet, "/dapi/v1/openOrders", reset)
+	_, err := c.NewListOpenOrdersService().Do(context.Background())
+	assert.True(t, errors.Is(err, reset))
+}
+
+func TestFakeClientAllServices(t *testing.T) {
+	c := NewFakeClient()
+	api := reflect.TypeOf((*API)(nil)).Elem()
+	for i := 0; i < api.NumMethod(); i++ {
+		m := api.Method(i)
+		if _, ok := m.Type.Out(0).MethodByName("Do"); !ok {
+			continue
+		}
+		assert.NoError(t, c.StubService(m.Name, "{}"), m.Name)
+	}
+}
+
+func TestFakeStreams(t *testing.T) {
+	f := NewFakeStreams()
+	var streams Streams = f
+	var errs []error
+	_, _, err := streams.WsMarkPriceServe("BTCUSD_PERP", func(event *WsMarkPriceEvent) {}, func(err error) {
+		errs = append(errs, err)
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, f.SendError("WsMarkPriceServe", errors.New("read failed")))
+	assert.Len(t, errs, 1)
+}
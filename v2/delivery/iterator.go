@@ -0,0 +1,102 @@
+package delivery
+
+import (
+	"context"
+	"time"
+
+	"github.com/Zamzam-Technology/go-binance/v2/common"
+)
+
+// Time windows accepted by the endpoints between startTime and endTime
+const (
+	ordersWindow = 7 * 24 * time.Hour
+	klinesWindow = 200 * 24 * time.Hour
+)
+
+// pageLimit return the page size of an iteration, the service limit or max when unset
+func pageLimit(limit *int, max int) int {
+	if limit != nil && *limit > 0 {
+		return *limit
+	}
+	return max
+}
+
+// OrderIterator iterate over account orders
+type OrderIterator struct {
+	*common.PageIterator
+}
+
+// Order return the current order
+func (it *OrderIterator) Order() *Order {
+	order, _ := it.Item().(*Order)
+	return order
+}
+
+// Iterator return an iterator over all the orders from the service orderID or startTime up to
+// its endTime, walking the time range by 7 days windows. Pages have the service limit, 100 by default.
+func (s *ListOrdersService) Iterator(budget *common.IteratorBudget, opts ...RequestOption) *OrderIterator {
+	pager := &common.IDRangePager{
+		FromID:    s.orderID,
+		StartTime: s.startTime,
+		EndTime:   s.endTime,
+		Window:    ordersWindow,
+		Limit:     pageLimit(s.limit, 100),
+		Fetch: func(ctx context.Context, orderID, startTime, endTime *int64, limit int) ([]interface{}, error) {
+			page := *s
+			page.orderID, page.startTime, page.endTime, page.limit = orderID, startTime, endTime, &limit
+			orders, err := page.Do(ctx, opts...)
+			if err != nil {
+				return nil, err
+			}
+			items := make([]interface{}, len(orders))
+			for i, order := range orders {
+				items[i] = order
+			}
+			return items, nil
+		},
+		Key: func(item interface{}) (int64, int64) {
+			order := item.(*Order)
+			return order.OrderID, order.Time
+		},
+	}
+	return &OrderIterator{common.NewPageIterator(pager.Pager(), budget)}
+}
+
+// KlineIterator iterate over klines
+type KlineIterator struct {
+	*common.PageIterator
+}
+
+// Kline return the current kline
+func (it *KlineIterator) Kline() *Kline {
+	kline, _ := it.Item().(*Kline)
+	return kline
+}
+
+// Iterator return an iterator over all the klines from the service startTime, or the first kline, up to its endTime,
+// or up to the latest kline, walking the time range by 200 days windows. Pages have the service limit, 1500 by default.
+func (s *KlinesService) Iterator(budget *common.IteratorBudget, opts ...RequestOption) *KlineIterator {
+	pager := &common.TimeRangePager{
+		StartTime: s.startTime,
+		EndTime:   s.endTime,
+		Window:    klinesWindow,
+		Limit:     pageLimit(s.limit, 1500),
+		Fetch: func(ctx context.Context, startTime int64, endTime *int64, limit int) ([]interface{}, error) {
+			page := *s
+			page.startTime, page.endTime, page.limit = &startTime, endTime, &limit
+			klines, err := page.Do(ctx, opts...)
+			if err != nil {
+				return nil, err
+			}
+			items := make([]interface{}, len(klines))
+			for i, kline := range klines {
+				items[i] = kline
+			}
+			return items, nil
+		},
+		Key: func(item interface{}) int64 {
+			return item.(*Kline).OpenTime
+		},
+	}
+	return &KlineIterator{common.NewPageIterator(pager.Pager(), budget)}
+}
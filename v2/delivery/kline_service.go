@@ -3,13 +3,14 @@ package delivery
 import (
 	"context"
 	"fmt"
+	"time"
 )
 
 // KlinesService list klines
 type KlinesService struct {
 	c         *Client
 	symbol    string
-	interval  string
+	interval  KlineInterval
 	limit     *int
 	startTime *int64
 	endTime   *int64
@@ -22,7 +23,7 @@ func (s *KlinesService) Symbol(symbol string) *KlinesService {
 }
 
 // Interval set interval
-func (s *KlinesService) Interval(interval string) *KlinesService {
+func (s *KlinesService) Interval(interval KlineInterval) *KlinesService {
 	s.interval = interval
 	return s
 }
@@ -109,3 +110,13 @@ type Kline struct {
 	TakerBuyBaseAssetVolume  string `json:"takerBuyBaseAssetVolume"`
 	TakerBuyQuoteAssetVolume string `json:"takerBuyQuoteAssetVolume"`
 }
+
+// OpenedAt return the open time of the kline
+func (k *Kline) OpenedAt() time.Time {
+	return time.Unix(0, k.OpenTime*int64(time.Millisecond))
+}
+
+// ClosedAt return the close time of the kline
+func (k *Kline) ClosedAt() time.Time {
+	return time.Unix(0, k.CloseTime*int64(time.Millisecond))
+}
@@ -0,0 +1,68 @@
+package delivery
+
+import (
+	"github.com/Zamzam-Technology/go-binance/v2/common"
+)
+
+// OrderFilters return the filters of the symbol used to check orders before they are sent
+func (s *Symbol) OrderFilters() *common.OrderFilters {
+	f := &common.OrderFilters{Symbol: s.Symbol}
+	if p := s.PriceFilter(); p != nil {
+		f.Price = &common.PriceRule{MinPrice: p.MinPrice, MaxPrice: p.MaxPrice, TickSize: p.TickSize}
+	}
+	if l := s.LotSizeFilter(); l != nil {
+		f.LotSize = &common.LotSizeRule{MinQuantity: l.MinQuantity, MaxQuantity: l.MaxQuantity, StepSize: l.StepSize}
+	}
+	if l := s.MarketLotSizeFilter(); l != nil {
+		f.MarketLotSize = &common.LotSizeRule{MinQuantity: l.MinQuantity, MaxQuantity: l.MaxQuantity, StepSize: l.StepSize}
+	}
+	if p := s.PercentPriceFilter(); p != nil {
+		f.PercentPrice = &common.PercentPriceRule{MultiplierUp: p.MultiplierUp, MultiplierDown: p.MultiplierDown}
+	}
+	return f
+}
+
+func (s *CreateOrderService) isMarket() bool {
+	switch s.orderType {
+	case OrderTypeMarket, OrderTypeStopMarket, OrderTypeTakeProfitMarket, OrderTypeTrailingStopMarket:
+		return true
+	}
+	return false
+}
+
+// orderFilters return the filters of symbol which apply to the order,
+// LOT_SIZE applies to limit orders and MARKET_LOT_SIZE to market orders
+func (s *CreateOrderService) orderFilters(symbol *Symbol) *common.OrderFilters {
+	f := symbol.OrderFilters()
+	if s.isMarket() && f.MarketLotSize != nil {
+		f.LotSize = nil
+	} else {
+		f.MarketLotSize = nil
+	}
+	return f
+}
+
+func (s *CreateOrderService) orderParams() *common.OrderParams {
+	return &common.OrderParams{
+		Buy:       s.side == SideTypeBuy,
+		Market:    s.isMarket(),
+		Price:     s.price,
+		StopPrice: s.stopPrice,
+		Quantity:  &s.quantity,
+	}
+}
+
+// Normalize round the price and stop price of the order to the tick size of symbol
+// and its quantity to the step size
+func (s *CreateOrderService) Normalize(symbol *Symbol) error {
+	return s.orderFilters(symbol).Normalize(s.orderParams())
+}
+
+// Validate check the order against the filters of symbol and return a *common.OrderFilterError
+// with all the violations. markPrice is used for the PERCENT_PRICE filter, which is skipped
+// when it is empty.
+func (s *CreateOrderService) Validate(symbol *Symbol, markPrice string) error {
+	p := s.orderParams()
+	p.ReferencePrice = markPrice
+	return s.orderFilters(symbol).Validate(p)
+}
@@ -0,0 +1,338 @@
+package delivery
+
+import (
+	"time"
+
+	"github.com/Zamzam-Technology/go-binance/v2/common"
+)
+
+// Streams is the websocket surface of the package, implemented by DefaultStreams and FakeStreams
+type Streams interface {
+	WsUserDataServe(listenKey string, handler WsHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+	WsUserDataEventServe(listenKey string, handler WsUserDataHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+	WsAggTradeServe(symbol string, handler WsAggTradeHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+	WsCombinedAggTradeServe(symbols []string, handler WsAggTradeHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+	WsIndexPriceServe(symbol string, handler WsIndexPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+	WsMarkPriceServe(symbol string, handler WsMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+	WsCombinedMarkPriceServe(symbols []string, handler WsMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+	WsPairMarkPriceServe(handler WsPairMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+	WsKlineServe(symbol string, interval KlineInterval, handler WsKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+	WsCombinedKlineServe(symbolIntervalPair map[string]KlineInterval, handler WsKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+	WsContinuousKlineServe(pair string, contractType string, interval KlineInterval, handler WsContinuousKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+	WsIndexPriceKlineServe(pair string, interval KlineInterval, handler WsIndexPriceKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+	WsMarkPriceKlineServe(symbol string, interval KlineInterval, handler WsMarkPriceKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+	WsMiniMarketTickerServe(symbol string, handler WsMiniMarketTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+	WsAllMiniMarketTickerServe(handler WsAllMiniMarketTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+	WsMarketTickerServe(symbol string, handler WsMarketTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+	WsAllMarketTickerServe(handler WsAllMarketTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+	WsBookTickerServe(symbol string, handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+	WsCombinedBookTickerServe(symbols []string, handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+	WsAllBookTickerServe(handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+	WsLiquidationOrderServe(symbol string, handler WsLiquidationOrderHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+	WsAllLiquidationOrderServe(handler WsLiquidationOrderHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+	WsPartialDepthServe(symbol string, levels int, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+	WsPartialDepthServeWithRate(symbol string, levels int, rate time.Duration, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+	WsDiffDepthServe(symbol string, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+	WsDiffDepthServeWithRate(symbol string, rate time.Duration, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+	WsCombinedDepthServe(symbolLevels map[string]string, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+	WsCombinedDepthServeWithRate(symbolLevels map[string]string, rate time.Duration, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+}
+
+// DefaultStreams serve the streams with the functions of the package
+type DefaultStreams struct{}
+
+var _ Streams = DefaultStreams{}
+
+// WsUserDataServe call the WsUserDataServe function of the package
+func (DefaultStreams) WsUserDataServe(listenKey string, handler WsHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return WsUserDataServe(listenKey, handler, errHandler)
+}
+
+// WsUserDataEventServe call the WsUserDataEventServe function of the package
+func (DefaultStreams) WsUserDataEventServe(listenKey string, handler WsUserDataHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return WsUserDataEventServe(listenKey, handler, errHandler)
+}
+
+// WsAggTradeServe call the WsAggTradeServe function of the package
+func (DefaultStreams) WsAggTradeServe(symbol string, handler WsAggTradeHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return WsAggTradeServe(symbol, handler, errHandler)
+}
+
+// WsCombinedAggTradeServe call the WsCombinedAggTradeServe function of the package
+func (DefaultStreams) WsCombinedAggTradeServe(symbols []string, handler WsAggTradeHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return WsCombinedAggTradeServe(symbols, handler, errHandler)
+}
+
+// WsIndexPriceServe call the WsIndexPriceServe function of the package
+func (DefaultStreams) WsIndexPriceServe(symbol string, handler WsIndexPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return WsIndexPriceServe(symbol, handler, errHandler)
+}
+
+// WsMarkPriceServe call the WsMarkPriceServe function of the package
+func (DefaultStreams) WsMarkPriceServe(symbol string, handler WsMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return WsMarkPriceServe(symbol, handler, errHandler)
+}
+
+// WsCombinedMarkPriceServe call the WsCombinedMarkPriceServe function of the package
+func (DefaultStreams) WsCombinedMarkPriceServe(symbols []string, handler WsMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return WsCombinedMarkPriceServe(symbols, handler, errHandler)
+}
+
+// WsPairMarkPriceServe call the WsPairMarkPriceServe function of the package
+func (DefaultStreams) WsPairMarkPriceServe(handler WsPairMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return WsPairMarkPriceServe(handler, errHandler)
+}
+
+// WsKlineServe call the WsKlineServe function of the package
+func (DefaultStreams) WsKlineServe(symbol string, interval KlineInterval, handler WsKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return WsKlineServe(symbol, interval, handler, errHandler)
+}
+
+// WsCombinedKlineServe call the WsCombinedKlineServe function of the package
+func (DefaultStreams) WsCombinedKlineServe(symbolIntervalPair map[string]KlineInterval, handler WsKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return WsCombinedKlineServe(symbolIntervalPair, handler, errHandler)
+}
+
+// WsContinuousKlineServe call the WsContinuousKlineServe function of the package
+func (DefaultStreams) WsContinuousKlineServe(pair string, contractType string, interval KlineInterval, handler WsContinuousKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return WsContinuousKlineServe(pair, contractType, interval, handler, errHandler)
+}
+
+// WsIndexPriceKlineServe call the WsIndexPriceKlineServe function of the package
+func (DefaultStreams) WsIndexPriceKlineServe(pair string, interval KlineInterval, handler WsIndexPriceKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return WsIndexPriceKlineServe(pair, interval, handler, errHandler)
+}
+
+// WsMarkPriceKlineServe call the WsMarkPriceKlineServe function of the package
+func (DefaultStreams) WsMarkPriceKlineServe(symbol string, interval KlineInterval, handler WsMarkPriceKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return WsMarkPriceKlineServe(symbol, interval, handler, errHandler)
+}
+
+// WsMiniMarketTickerServe call the WsMiniMarketTickerServe function of the package
+func (DefaultStreams) WsMiniMarketTickerServe(symbol string, handler WsMiniMarketTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return WsMiniMarketTickerServe(symbol, handler, errHandler)
+}
+
+// WsAllMiniMarketTickerServe call the WsAllMiniMarketTickerServe function of the package
+func (DefaultStreams) WsAllMiniMarketTickerServe(handler WsAllMiniMarketTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return WsAllMiniMarketTickerServe(handler, errHandler)
+}
+
+// WsMarketTickerServe call the WsMarketTickerServe function of the package
+func (DefaultStreams) WsMarketTickerServe(symbol string, handler WsMarketTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return WsMarketTickerServe(symbol, handler, errHandler)
+}
+
+// WsAllMarketTickerServe call the WsAllMarketTickerServe function of the package
+func (DefaultStreams) WsAllMarketTickerServe(handler WsAllMarketTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return WsAllMarketTickerServe(handler, errHandler)
+}
+
+// WsBookTickerServe call the WsBookTickerServe function of the package
+func (DefaultStreams) WsBookTickerServe(symbol string, handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return WsBookTickerServe(symbol, handler, errHandler)
+}
+
+// WsCombinedBookTickerServe call the WsCombinedBookTickerServe function of the package
+func (DefaultStreams) WsCombinedBookTickerServe(symbols []string, handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return WsCombinedBookTickerServe(symbols, handler, errHandler)
+}
+
+// WsAllBookTickerServe call the WsAllBookTickerServe function of the package
+func (DefaultStreams) WsAllBookTickerServe(handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return WsAllBookTickerServe(handler, errHandler)
+}
+
+// WsLiquidationOrderServe call the WsLiquidationOrderServe function of the package
+func (DefaultStreams) WsLiquidationOrderServe(symbol string, handler WsLiquidationOrderHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return WsLiquidationOrderServe(symbol, handler, errHandler)
+}
+
+// WsAllLiquidationOrderServe call the WsAllLiquidationOrderServe function of the package
+func (DefaultStreams) WsAllLiquidationOrderServe(handler WsLiquidationOrderHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return WsAllLiquidationOrderServe(handler, errHandler)
+}
+
+// WsPartialDepthServe call the WsPartialDepthServe function of the package
+func (DefaultStreams) WsPartialDepthServe(symbol string, levels int, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return WsPartialDepthServe(symbol, levels, handler, errHandler)
+}
+
+// WsPartialDepthServeWithRate call the WsPartialDepthServeWithRate function of the package
+func (DefaultStreams) WsPartialDepthServeWithRate(symbol string, levels int, rate time.Duration, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return WsPartialDepthServeWithRate(symbol, levels, rate, handler, errHandler)
+}
+
+// WsDiffDepthServe call the WsDiffDepthServe function of the package
+func (DefaultStreams) WsDiffDepthServe(symbol string, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return WsDiffDepthServe(symbol, handler, errHandler)
+}
+
+// WsDiffDepthServeWithRate call the WsDiffDepthServeWithRate function of the package
+func (DefaultStreams) WsDiffDepthServeWithRate(symbol string, rate time.Duration, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return WsDiffDepthServeWithRate(symbol, rate, handler, errHandler)
+}
+
+// WsCombinedDepthServe call the WsCombinedDepthServe function of the package
+func (DefaultStreams) WsCombinedDepthServe(symbolLevels map[string]string, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return WsCombinedDepthServe(symbolLevels, handler, errHandler)
+}
+
+// WsCombinedDepthServeWithRate call the WsCombinedDepthServeWithRate function of the package
+func (DefaultStreams) WsCombinedDepthServeWithRate(symbolLevels map[string]string, rate time.Duration, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return WsCombinedDepthServeWithRate(symbolLevels, rate, handler, errHandler)
+}
+
+// FakeStreams implement Streams for tests: the handlers of the served streams are kept, and
+// the events given to Send are delivered to the handlers of a method, e.g.
+// Send("WsKlineServe", event).
+type FakeStreams struct {
+	*common.StreamStubs
+}
+
+var _ Streams = (*FakeStreams)(nil)
+
+// NewFakeStreams init fake streams
+func NewFakeStreams() *FakeStreams {
+	return &FakeStreams{StreamStubs: common.NewStreamStubs()}
+}
+
+// WsUserDataServe keep the handlers of the stream
+func (f *FakeStreams) WsUserDataServe(listenKey string, handler WsHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return f.Serve("WsUserDataServe", handler, errHandler)
+}
+
+// WsUserDataEventServe keep the handlers of the stream
+func (f *FakeStreams) WsUserDataEventServe(listenKey string, handler WsUserDataHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return f.Serve("WsUserDataEventServe", handler, errHandler)
+}
+
+// WsAggTradeServe keep the handlers of the stream
+func (f *FakeStreams) WsAggTradeServe(symbol string, handler WsAggTradeHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return f.Serve("WsAggTradeServe", handler, errHandler)
+}
+
+// WsCombinedAggTradeServe keep the handlers of the stream
+func (f *FakeStreams) WsCombinedAggTradeServe(symbols []string, handler WsAggTradeHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return f.Serve("WsCombinedAggTradeServe", handler, errHandler)
+}
+
+// WsIndexPriceServe keep the handlers of the stream
+func (f *FakeStreams) WsIndexPriceServe(symbol string, handler WsIndexPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return f.Serve("WsIndexPriceServe", handler, errHandler)
+}
+
+// WsMarkPriceServe keep the handlers of the stream
+func (f *FakeStreams) WsMarkPriceServe(symbol string, handler WsMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return f.Serve("WsMarkPriceServe", handler, errHandler)
+}
+
+// WsCombinedMarkPriceServe keep the handlers of the stream
+func (f *FakeStreams) WsCombinedMarkPriceServe(symbols []string, handler WsMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return f.Serve("WsCombinedMarkPriceServe", handler, errHandler)
+}
+
+// WsPairMarkPriceServe keep the handlers of the stream
+func (f *FakeStreams) WsPairMarkPriceServe(handler WsPairMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return f.Serve("WsPairMarkPriceServe", handler, errHandler)
+}
+
+// WsKlineServe keep the handlers of the stream
+func (f *FakeStreams) WsKlineServe(symbol string, interval KlineInterval, handler WsKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return f.Serve("WsKlineServe", handler, errHandler)
+}
+
+// WsCombinedKlineServe keep the handlers of the stream
+func (f *FakeStreams) WsCombinedKlineServe(symbolIntervalPair map[string]KlineInterval, handler WsKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return f.Serve("WsCombinedKlineServe", handler, errHandler)
+}
+
+// WsContinuousKlineServe keep the handlers of the stream
+func (f *FakeStreams) WsContinuousKlineServe(pair string, contractType string, interval KlineInterval, handler WsContinuousKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return f.Serve("WsContinuousKlineServe", handler, errHandler)
+}
+
+// WsIndexPriceKlineServe keep the handlers of the stream
+func (f *FakeStreams) WsIndexPriceKlineServe(pair string, interval KlineInterval, handler WsIndexPriceKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return f.Serve("WsIndexPriceKlineServe", handler, errHandler)
+}
+
+// WsMarkPriceKlineServe keep the handlers of the stream
+func (f *FakeStreams) WsMarkPriceKlineServe(symbol string, interval KlineInterval, handler WsMarkPriceKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return f.Serve("WsMarkPriceKlineServe", handler, errHandler)
+}
+
+// WsMiniMarketTickerServe keep the handlers of the stream
+func (f *FakeStreams) WsMiniMarketTickerServe(symbol string, handler WsMiniMarketTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return f.Serve("WsMiniMarketTickerServe", handler, errHandler)
+}
+
+// WsAllMiniMarketTickerServe keep the handlers of the stream
+func (f *FakeStreams) WsAllMiniMarketTickerServe(handler WsAllMiniMarketTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return f.Serve("WsAllMiniMarketTickerServe", handler, errHandler)
+}
+
+// WsMarketTickerServe keep the handlers of the stream
+func (f *FakeStreams) WsMarketTickerServe(symbol string, handler WsMarketTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return f.Serve("WsMarketTickerServe", handler, errHandler)
+}
+
+// WsAllMarketTickerServe keep the handlers of the stream
+func (f *FakeStreams) WsAllMarketTickerServe(handler WsAllMarketTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return f.Serve("WsAllMarketTickerServe", handler, errHandler)
+}
+
+// WsBookTickerServe keep the handlers of the stream
+func (f *FakeStreams) WsBookTickerServe(symbol string, handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return f.Serve("WsBookTickerServe", handler, errHandler)
+}
+
+// WsCombinedBookTickerServe keep the handlers of the stream
+func (f *FakeStreams) WsCombinedBookTickerServe(symbols []string, handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return f.Serve("WsCombinedBookTickerServe", handler, errHandler)
+}
+
+// WsAllBookTickerServe keep the handlers of the stream
+func (f *FakeStreams) WsAllBookTickerServe(handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return f.Serve("WsAllBookTickerServe", handler, errHandler)
+}
+
+// WsLiquidationOrderServe keep the handlers of the stream
+func (f *FakeStreams) WsLiquidationOrderServe(symbol string, handler WsLiquidationOrderHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return f.Serve("WsLiquidationOrderServe", handler, errHandler)
+}
+
+// WsAllLiquidationOrderServe keep the handlers of the stream
+func (f *FakeStreams) WsAllLiquidationOrderServe(handler WsLiquidationOrderHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return f.Serve("WsAllLiquidationOrderServe", handler, errHandler)
+}
+
+// WsPartialDepthServe keep the handlers of the stream
+func (f *FakeStreams) WsPartialDepthServe(symbol string, levels int, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return f.Serve("WsPartialDepthServe", handler, errHandler)
+}
+
+// WsPartialDepthServeWithRate keep the handlers of the stream
+func (f *FakeStreams) WsPartialDepthServeWithRate(symbol string, levels int, rate time.Duration, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return f.Serve("WsPartialDepthServeWithRate", handler, errHandler)
+}
+
+// WsDiffDepthServe keep the handlers of the stream
+func (f *FakeStreams) WsDiffDepthServe(symbol string, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return f.Serve("WsDiffDepthServe", handler, errHandler)
+}
+
+// WsDiffDepthServeWithRate keep the handlers of the stream
+func (f *FakeStreams) WsDiffDepthServeWithRate(symbol string, rate time.Duration, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return f.Serve("WsDiffDepthServeWithRate", handler, errHandler)
+}
+
+// WsCombinedDepthServe keep the handlers of the stream
+func (f *FakeStreams) WsCombinedDepthServe(symbolLevels map[string]string, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return f.Serve("WsCombinedDepthServe", handler, errHandler)
+}
+
+// WsCombinedDepthServeWithRate keep the handlers of the stream
+func (f *FakeStreams) WsCombinedDepthServeWithRate(symbolLevels map[string]string, rate time.Duration, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return f.Serve("WsCombinedDepthServeWithRate", handler, errHandler)
+}
@@ -57,6 +57,8 @@ type BookTicker struct {
 	BidQuantity string `json:"bidQty"`
 	AskPrice    string `json:"askPrice"`
 	AskQuantity string `json:"askQty"`
+	UpdateID    int64  `json:"lastUpdateId"`
+	Time        int64  `json:"time"`
 }
 
 // ListPricesService list latest price for a symbol or symbols.
@@ -1,16 +1,20 @@
 package delivery
 
 import (
-	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
+
+	"github.com/Zamzam-Technology/go-binance/v2/common"
 )
 
 // Endpoints
 const (
-	baseWsMainUrl    = "wss://dstream.binance.com/ws"
-	baseWsTestnetUrl = "wss://dstream.binancefuture.com/ws"
+	baseWsMainUrl          = "wss://dstream.binance.com/ws"
+	baseWsTestnetUrl       = "wss://dstream.binancefuture.com/ws"
+	baseCombinedMainURL    = "wss://dstream.binance.com/stream?streams="
+	baseCombinedTestnetURL = "wss://dstream.binancefuture.com/stream?streams="
 )
 
 var (
@@ -18,18 +22,37 @@ var (
 	WebsocketTimeout = time.Second * 60
 	// WebsocketKeepalive enables sending ping/pong messages to check the connection stability
 	WebsocketKeepalive = false
+	// WebsocketWatchdog enables the stale-stream watchdog and the per-stream metrics returned by WsStreamsStats
+	WebsocketWatchdog *common.WsWatchdog
+	// WebsocketBaseURL replace the base endpoint of the WS streams when set, e.g. "ws://127.0.0.1:8080"
+	// for a local server, which then serves the streams under /ws and /stream
+	WebsocketBaseURL string
 	// UseTestnet switch all the WS streams from production to the testnet
 	UseTestnet = false
 )
 
 // getWsEndpoint return the base endpoint of the WS according the UseTestnet flag
 func getWsEndpoint() string {
+	if WebsocketBaseURL != "" {
+		return WebsocketBaseURL + "/ws"
+	}
 	if UseTestnet {
 		return baseWsTestnetUrl
 	}
 	return baseWsMainUrl
 }
 
+// getCombinedEndpoint return the base endpoint of the combined stream according the UseTestnet flag
+func getCombinedEndpoint() string {
+	if WebsocketBaseURL != "" {
+		return WebsocketBaseURL + "/stream?streams="
+	}
+	if UseTestnet {
+		return baseCombinedTestnetURL
+	}
+	return baseCombinedMainURL
+}
+
 // WsUserDataServe serve user data handler with listen key
 func WsUserDataServe(listenKey string, handler WsHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
 	endpoint := fmt.Sprintf("%s/%s", getWsEndpoint(), listenKey)
@@ -37,6 +60,95 @@ func WsUserDataServe(listenKey string, handler WsHandler, errHandler ErrHandler)
 	return wsServe(cfg, handler, errHandler)
 }
 
+// WsUserDataEvent define user data event, AccountUpdate is set for ACCOUNT_UPDATE events and
+// OrderTradeUpdate for ORDER_TRADE_UPDATE events
+type WsUserDataEvent struct {
+	Event            UserDataEventType   `json:"e"`
+	Time             int64               `json:"E"`
+	TransactionTime  int64               `json:"T"`
+	AccountAlias     string              `json:"i"`
+	AccountUpdate    *WsAccountUpdate    `json:"a"`
+	OrderTradeUpdate *WsOrderTradeUpdate `json:"o"`
+}
+
+// WsAccountUpdate define account update
+type WsAccountUpdate struct {
+	Reason    UserDataEventReasonType `json:"m"`
+	Balances  []WsBalance             `json:"B"`
+	Positions []WsPosition            `json:"P"`
+}
+
+// WsBalance define balance
+type WsBalance struct {
+	Asset              string `json:"a"`
+	Balance            string `json:"wb"`
+	CrossWalletBalance string `json:"cw"`
+}
+
+// WsPosition define position
+type WsPosition struct {
+	Symbol              string           `json:"s"`
+	Side                PositionSideType `json:"ps"`
+	Amount              string           `json:"pa"`
+	MarginType          MarginType       `json:"mt"`
+	IsolatedWallet      string           `json:"iw"`
+	EntryPrice          string           `json:"ep"`
+	UnrealizedPnL       string           `json:"up"`
+	AccumulatedRealized string           `json:"cr"`
+}
+
+// WsOrderTradeUpdate define order trade update
+type WsOrderTradeUpdate struct {
+	Symbol               string             `json:"s"`
+	ClientOrderID        string             `json:"c"`
+	Side                 SideType           `json:"S"`
+	Type                 OrderType          `json:"o"`
+	TimeInForce          TimeInForceType    `json:"f"`
+	OriginalQty          string             `json:"q"`
+	OriginalPrice        string             `json:"p"`
+	AveragePrice         string             `json:"ap"`
+	StopPrice            string             `json:"sp"`
+	ExecutionType        OrderExecutionType `json:"x"`
+	Status               OrderStatusType    `json:"X"`
+	ID                   int64              `json:"i"`
+	LastFilledQty        string             `json:"l"`
+	AccumulatedFilledQty string             `json:"z"`
+	LastFilledPrice      string             `json:"L"`
+	MarginAsset          string             `json:"ma"`
+	CommissionAsset      string             `json:"N"`
+	Commission           string             `json:"n"`
+	TradeTime            int64              `json:"T"`
+	TradeID              int64              `json:"t"`
+	BidsNotional         string             `json:"b"`
+	AsksNotional         string             `json:"a"`
+	IsMaker              bool               `json:"m"`
+	IsReduceOnly         bool               `json:"R"`
+	WorkingType          WorkingType        `json:"wt"`
+	OriginalType         OrderType          `json:"ot"`
+	PositionSide         PositionSideType   `json:"ps"`
+	IsClosingPosition    bool               `json:"cp"`
+	ActivationPrice      string             `json:"AP"`
+	CallbackRate         string             `json:"cr"`
+	RealizedPnL          string             `json:"rp"`
+}
+
+// WsUserDataHandler handle typed user data events
+type WsUserDataHandler func(event *WsUserDataEvent)
+
+// WsUserDataEventServe is similar to WsUserDataServe, but it decodes the messages to WsUserDataEvent
+func WsUserDataEventServe(listenKey string, handler WsUserDataHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	wsHandler := func(message []byte) {
+		event := new(WsUserDataEvent)
+		err := common.UnmarshalWsMessage(message, event)
+		if err != nil {
+			errHandler(err)
+			return
+		}
+		handler(event)
+	}
+	return WsUserDataServe(listenKey, wsHandler, errHandler)
+}
+
 // WsAggTradeEvent define websocket aggTrde event.
 type WsAggTradeEvent struct {
 	Event            string `json:"e"`
@@ -60,7 +172,27 @@ func WsAggTradeServe(symbol string, handler WsAggTradeHandler, errHandler ErrHan
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsAggTradeEvent)
-		err := json.Unmarshal(message, &event)
+		err := common.UnmarshalWsMessage(message, &event)
+		if err != nil {
+			errHandler(err)
+			return
+		}
+		handler(event)
+	}
+	return wsServe(cfg, wsHandler, errHandler)
+}
+
+// WsCombinedAggTradeServe is similar to WsAggTradeServe, but it handles multiple symbols
+func WsCombinedAggTradeServe(symbols []string, handler WsAggTradeHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := getCombinedEndpoint()
+	for _, s := range symbols {
+		endpoint += fmt.Sprintf("%s@aggTrade", strings.ToLower(s)) + "/"
+	}
+	endpoint = endpoint[:len(endpoint)-1]
+	cfg := newWsConfig(endpoint)
+	wsHandler := func(message []byte) {
+		event := new(WsAggTradeEvent)
+		_, err := common.UnmarshalCombinedWsMessage(message, event)
 		if err != nil {
 			errHandler(err)
 			return
@@ -87,7 +219,7 @@ func WsIndexPriceServe(symbol string, handler WsIndexPriceHandler, errHandler Er
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsIndexPriceEvent)
-		err := json.Unmarshal(message, &event)
+		err := common.UnmarshalWsMessage(message, &event)
 		if err != nil {
 			errHandler(err)
 			return
@@ -117,7 +249,27 @@ func WsMarkPriceServe(symbol string, handler WsMarkPriceHandler, errHandler ErrH
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsMarkPriceEvent)
-		err := json.Unmarshal(message, &event)
+		err := common.UnmarshalWsMessage(message, &event)
+		if err != nil {
+			errHandler(err)
+			return
+		}
+		handler(event)
+	}
+	return wsServe(cfg, wsHandler, errHandler)
+}
+
+// WsCombinedMarkPriceServe is similar to WsMarkPriceServe, but it handles multiple symbols
+func WsCombinedMarkPriceServe(symbols []string, handler WsMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := getCombinedEndpoint()
+	for _, s := range symbols {
+		endpoint += fmt.Sprintf("%s@markPrice", strings.ToLower(s)) + "/"
+	}
+	endpoint = endpoint[:len(endpoint)-1]
+	cfg := newWsConfig(endpoint)
+	wsHandler := func(message []byte) {
+		event := new(WsMarkPriceEvent)
+		_, err := common.UnmarshalCombinedWsMessage(message, event)
 		if err != nil {
 			errHandler(err)
 			return
@@ -139,7 +291,7 @@ func WsPairMarkPriceServe(handler WsPairMarkPriceHandler, errHandler ErrHandler)
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		var event WsPairMarkPriceEvent
-		err := json.Unmarshal(message, &event)
+		err := common.UnmarshalWsMessage(message, &event)
 		if err != nil {
 			errHandler(err)
 			return
@@ -181,12 +333,32 @@ type WsKline struct {
 type WsKlineHandler func(event *WsKlineEvent)
 
 // WsKlineServe serve websocket kline handler with a symbol and interval like 15m, 30s
-func WsKlineServe(symbol string, interval string, handler WsKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+func WsKlineServe(symbol string, interval KlineInterval, handler WsKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
 	endpoint := fmt.Sprintf("%s/%s@kline_%s", getWsEndpoint(), strings.ToLower(symbol), interval)
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsKlineEvent)
-		err := json.Unmarshal(message, event)
+		err := common.UnmarshalWsMessage(message, event)
+		if err != nil {
+			errHandler(err)
+			return
+		}
+		handler(event)
+	}
+	return wsServe(cfg, wsHandler, errHandler)
+}
+
+// WsCombinedKlineServe is similar to WsKlineServe, but it handles multiple symbols with it interval
+func WsCombinedKlineServe(symbolIntervalPair map[string]KlineInterval, handler WsKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := getCombinedEndpoint()
+	for symbol, interval := range symbolIntervalPair {
+		endpoint += fmt.Sprintf("%s@kline_%s", strings.ToLower(symbol), interval) + "/"
+	}
+	endpoint = endpoint[:len(endpoint)-1]
+	cfg := newWsConfig(endpoint)
+	wsHandler := func(message []byte) {
+		event := new(WsKlineEvent)
+		_, err := common.UnmarshalCombinedWsMessage(message, event)
 		if err != nil {
 			errHandler(err)
 			return
@@ -228,12 +400,12 @@ type WsContinuousKline struct {
 type WsContinuousKlineHandler func(event *WsContinuousKlineEvent)
 
 // WsContinuousKlineServe serve websocket kline handler with a pair, a contract type and interval like 15m, 30s
-func WsContinuousKlineServe(pair string, contractType string, interval string, handler WsContinuousKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+func WsContinuousKlineServe(pair string, contractType string, interval KlineInterval, handler WsContinuousKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
 	endpoint := fmt.Sprintf("%s/%s_%s@continuousKline_%s", getWsEndpoint(), strings.ToLower(pair), strings.ToLower(contractType), interval)
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsContinuousKlineEvent)
-		err := json.Unmarshal(message, event)
+		err := common.UnmarshalWsMessage(message, event)
 		if err != nil {
 			errHandler(err)
 			return
@@ -269,12 +441,12 @@ type WsIndexPriceKline struct {
 type WsIndexPriceKlineHandler func(event *WsIndexPriceKlineEvent)
 
 // WsIndexPriceKlineServe serve websocket kline handler with a pair and interval like 15m, 30s
-func WsIndexPriceKlineServe(pair string, interval string, handler WsIndexPriceKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+func WsIndexPriceKlineServe(pair string, interval KlineInterval, handler WsIndexPriceKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
 	endpoint := fmt.Sprintf("%s/%s@indexPriceKline_%s", getWsEndpoint(), strings.ToLower(pair), interval)
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsIndexPriceKlineEvent)
-		err := json.Unmarshal(message, event)
+		err := common.UnmarshalWsMessage(message, event)
 		if err != nil {
 			errHandler(err)
 			return
@@ -311,12 +483,12 @@ type WsMarkPriceKline struct {
 type WsMarkPriceKlineHandler func(event *WsMarkPriceKlineEvent)
 
 // WsMarkPriceKlineServe serve websocket kline handler with a symbol and interval like 15m, 30s
-func WsMarkPriceKlineServe(symbol string, interval string, handler WsMarkPriceKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+func WsMarkPriceKlineServe(symbol string, interval KlineInterval, handler WsMarkPriceKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
 	endpoint := fmt.Sprintf("%s/%s@markPriceKline_%s", getWsEndpoint(), strings.ToLower(symbol), interval)
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsMarkPriceKlineEvent)
-		err := json.Unmarshal(message, event)
+		err := common.UnmarshalWsMessage(message, event)
 		if err != nil {
 			errHandler(err)
 			return
@@ -349,7 +521,7 @@ func WsMiniMarketTickerServe(symbol string, handler WsMiniMarketTickerHandler, e
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsMiniMarketTickerEvent)
-		err := json.Unmarshal(message, &event)
+		err := common.UnmarshalWsMessage(message, &event)
 		if err != nil {
 			errHandler(err)
 			return
@@ -371,7 +543,7 @@ func WsAllMiniMarketTickerServe(handler WsAllMiniMarketTickerHandler, errHandler
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		var event WsAllMiniMarketTickerEvent
-		err := json.Unmarshal(message, &event)
+		err := common.UnmarshalWsMessage(message, &event)
 		if err != nil {
 			errHandler(err)
 			return
@@ -413,7 +585,7 @@ func WsMarketTickerServe(symbol string, handler WsMarketTickerHandler, errHandle
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsMarketTickerEvent)
-		err := json.Unmarshal(message, &event)
+		err := common.UnmarshalWsMessage(message, &event)
 		if err != nil {
 			errHandler(err)
 			return
@@ -435,7 +607,7 @@ func WsAllMarketTickerServe(handler WsAllMarketTickerHandler, errHandler ErrHand
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		var event WsAllMarketTickerEvent
-		err := json.Unmarshal(message, &event)
+		err := common.UnmarshalWsMessage(message, &event)
 		if err != nil {
 			errHandler(err)
 			return
@@ -468,7 +640,27 @@ func WsBookTickerServe(symbol string, handler WsBookTickerHandler, errHandler Er
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsBookTickerEvent)
-		err := json.Unmarshal(message, &event)
+		err := common.UnmarshalWsMessage(message, &event)
+		if err != nil {
+			errHandler(err)
+			return
+		}
+		handler(event)
+	}
+	return wsServe(cfg, wsHandler, errHandler)
+}
+
+// WsCombinedBookTickerServe is similar to WsBookTickerServe, but it handles multiple symbols
+func WsCombinedBookTickerServe(symbols []string, handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := getCombinedEndpoint()
+	for _, s := range symbols {
+		endpoint += fmt.Sprintf("%s@bookTicker", strings.ToLower(s)) + "/"
+	}
+	endpoint = endpoint[:len(endpoint)-1]
+	cfg := newWsConfig(endpoint)
+	wsHandler := func(message []byte) {
+		event := new(WsBookTickerEvent)
+		_, err := common.UnmarshalCombinedWsMessage(message, event)
 		if err != nil {
 			errHandler(err)
 			return
@@ -484,7 +676,7 @@ func WsAllBookTickerServe(handler WsBookTickerHandler, errHandler ErrHandler) (d
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsBookTickerEvent)
-		err := json.Unmarshal(message, &event)
+		err := common.UnmarshalWsMessage(message, &event)
 		if err != nil {
 			errHandler(err)
 			return
@@ -526,7 +718,7 @@ func WsLiquidationOrderServe(symbol string, handler WsLiquidationOrderHandler, e
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsLiquidationOrderEvent)
-		err := json.Unmarshal(message, &event)
+		err := common.UnmarshalWsMessage(message, &event)
 		if err != nil {
 			errHandler(err)
 			return
@@ -542,7 +734,7 @@ func WsAllLiquidationOrderServe(handler WsLiquidationOrderHandler, errHandler Er
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsLiquidationOrderEvent)
-		err := json.Unmarshal(message, &event)
+		err := common.UnmarshalWsMessage(message, &event)
 		if err != nil {
 			errHandler(err)
 			return
@@ -571,51 +763,91 @@ type WsDepthHandler func(event *WsDepthEvent)
 
 // WsPartialDepthServe serve websocket partial depth handler.
 func WsPartialDepthServe(symbol string, levels int, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/%s@depth%d", getWsEndpoint(), strings.ToLower(symbol), levels)
-	cfg := newWsConfig(endpoint)
-	return wsDepthServe(cfg, handler, errHandler)
+	return wsDepthServe(symbol, fmt.Sprintf("%d", levels), nil, handler, errHandler)
+}
+
+// WsPartialDepthServeWithRate serve websocket partial depth handler with rate.
+// The levels must be 5, 10 or 20.
+func WsPartialDepthServeWithRate(symbol string, levels int, rate time.Duration, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	if levels != 5 && levels != 10 && levels != 20 {
+		return nil, nil, errors.New("Invalid levels")
+	}
+	return wsDepthServe(symbol, fmt.Sprintf("%d", levels), &rate, handler, errHandler)
 }
 
 // WsDiffDepthServe serve websocket diff. depth handler.
 func WsDiffDepthServe(symbol string, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
-	endpoint := fmt.Sprintf("%s/%s@depth", getWsEndpoint(), strings.ToLower(symbol))
-	cfg := newWsConfig(endpoint)
-	return wsDepthServe(cfg, handler, errHandler)
+	return wsDepthServe(symbol, "", nil, handler, errHandler)
+}
+
+// WsDiffDepthServeWithRate serve websocket diff. depth handler with rate.
+func WsDiffDepthServeWithRate(symbol string, rate time.Duration, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return wsDepthServe(symbol, "", &rate, handler, errHandler)
 }
 
-func wsDepthServe(cfg *WsConfig, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+func depthRateSuffix(rate *time.Duration) (string, error) {
+	if rate == nil {
+		return "", nil
+	}
+	switch *rate {
+	case 250 * time.Millisecond:
+		return "", nil
+	case 500 * time.Millisecond:
+		return "@500ms", nil
+	case 100 * time.Millisecond:
+		return "@100ms", nil
+	default:
+		return "", errors.New("Invalid rate")
+	}
+}
+
+func wsDepthServe(symbol string, levels string, rate *time.Duration, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	rateStr, err := depthRateSuffix(rate)
+	if err != nil {
+		return nil, nil, err
+	}
+	endpoint := fmt.Sprintf("%s/%s@depth%s%s", getWsEndpoint(), strings.ToLower(symbol), levels, rateStr)
+	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
-		j, err := newJSON(message)
+		event := new(WsDepthEvent)
+		err := common.UnmarshalWsMessage(message, event)
 		if err != nil {
 			errHandler(err)
 			return
 		}
+		handler(event)
+	}
+	return wsServe(cfg, wsHandler, errHandler)
+}
+
+// WsCombinedDepthServe is similar to WsPartialDepthServe, but it for multiple symbols.
+// The value of symbolLevels is the levels of the stream, or an empty string for diff. depth.
+func WsCombinedDepthServe(symbolLevels map[string]string, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return wsCombinedDepthServe(symbolLevels, nil, handler, errHandler)
+}
+
+// WsCombinedDepthServeWithRate is similar to WsCombinedDepthServe, but with the update speed of every stream set to rate
+func WsCombinedDepthServeWithRate(symbolLevels map[string]string, rate time.Duration, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return wsCombinedDepthServe(symbolLevels, &rate, handler, errHandler)
+}
+
+func wsCombinedDepthServe(symbolLevels map[string]string, rate *time.Duration, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	rateStr, err := depthRateSuffix(rate)
+	if err != nil {
+		return nil, nil, err
+	}
+	endpoint := getCombinedEndpoint()
+	for s, l := range symbolLevels {
+		endpoint += fmt.Sprintf("%s@depth%s%s", strings.ToLower(s), l, rateStr) + "/"
+	}
+	endpoint = endpoint[:len(endpoint)-1]
+	cfg := newWsConfig(endpoint)
+	wsHandler := func(message []byte) {
 		event := new(WsDepthEvent)
-		event.Event = j.Get("e").MustString()
-		event.Time = j.Get("E").MustInt64()
-		event.TransactionTime = j.Get("T").MustInt64()
-		event.Symbol = j.Get("s").MustString()
-		event.Pair = j.Get("ps").MustString()
-		event.FirstUpdateID = j.Get("U").MustInt64()
-		event.LastUpdateID = j.Get("u").MustInt64()
-		event.PrevLastUpdateID = j.Get("pu").MustInt64()
-		bidsLen := len(j.Get("b").MustArray())
-		event.Bids = make([]Bid, bidsLen)
-		for i := 0; i < bidsLen; i++ {
-			item := j.Get("b").GetIndex(i)
-			event.Bids[i] = Bid{
-				Price:    item.GetIndex(0).MustString(),
-				Quantity: item.GetIndex(1).MustString(),
-			}
-		}
-		asksLen := len(j.Get("a").MustArray())
-		event.Asks = make([]Ask, asksLen)
-		for i := 0; i < asksLen; i++ {
-			item := j.Get("a").GetIndex(i)
-			event.Asks[i] = Ask{
-				Price:    item.GetIndex(0).MustString(),
-				Quantity: item.GetIndex(1).MustString(),
-			}
+		_, err := common.UnmarshalCombinedWsMessage(message, event)
+		if err != nil {
+			errHandler(err)
+			return
 		}
 		handler(event)
 	}
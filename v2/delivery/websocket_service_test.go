@@ -2,7 +2,9 @@ package delivery
 
 import (
 	"errors"
+	"math/rand"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 )
@@ -11,6 +13,7 @@ type websocketServiceTestSuite struct {
 	baseTestSuite
 	origWsServe func(*WsConfig, WsHandler, ErrHandler) (chan struct{}, chan struct{}, error)
 	serveCount  int
+	endpoint    string
 }
 
 func TestWebsocketService(t *testing.T) {
@@ -29,6 +32,7 @@ func (s *websocketServiceTestSuite) TearDownTest() {
 func (s *websocketServiceTestSuite) mockWsServe(data []byte, err error) {
 	wsServe = func(cfg *WsConfig, handler WsHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, innerErr error) {
 		s.serveCount++
+		s.endpoint = cfg.Endpoint
 		doneC = make(chan struct{})
 		stopC = make(chan struct{})
 		go func() {
@@ -86,6 +90,146 @@ func (s *websocketServiceTestSuite) TestWsUserDataServe() {
     }`))
 }
 
+// https://binance-docs.github.io/apidocs/delivery/en/#event-order-update
+func (s *websocketServiceTestSuite) TestWsUserDataEventServe() {
+	data := []byte(`{
+	  "e":"ORDER_TRADE_UPDATE",
+	  "E":1591274595442,
+	  "T":1591274595453,
+	  "i":"SfsR",
+	  "o":{
+	    "s":"BTCUSD_200925",
+	    "c":"TEST",
+	    "S":"SELL",
+	    "o":"TRAILING_STOP_MARKET",
+	    "f":"GTC",
+	    "q":"2",
+	    "p":"0",
+	    "ap":"0",
+	    "sp":"9103.1",
+	    "x":"TRADE",
+	    "X":"PARTIALLY_FILLED",
+	    "i":8888888,
+	    "l":"1",
+	    "z":"1",
+	    "L":"9103.1",
+	    "ma":"BTC",
+	    "N":"BTC",
+	    "n":"0.00000055",
+	    "T":1591274595442,
+	    "t":121,
+	    "rp":"0",
+	    "b":"0",
+	    "a":"0",
+	    "m":false,
+	    "R":false,
+	    "wt":"CONTRACT_PRICE",
+	    "ot":"TRAILING_STOP_MARKET",
+	    "ps":"LONG",
+	    "cp":false,
+	    "AP":"9476.8",
+	    "cr":"5.0",
+	    "pP":false
+	  }
+	}`)
+	fakeErrMsg := "fake error"
+	s.mockWsServe(data, errors.New(fakeErrMsg))
+	defer s.assertWsServe()
+
+	doneC, stopC, err := WsUserDataEventServe("listenKey", func(event *WsUserDataEvent) {
+		r := s.r()
+		r.Equal(UserDataEventTypeOrderTradeUpdate, event.Event)
+		r.Equal(int64(1591274595453), event.TransactionTime)
+		r.Equal("SfsR", event.AccountAlias)
+		r.Equal(&WsOrderTradeUpdate{
+			Symbol:               "BTCUSD_200925",
+			ClientOrderID:        "TEST",
+			Side:                 SideTypeSell,
+			Type:                 "TRAILING_STOP_MARKET",
+			TimeInForce:          TimeInForceTypeGTC,
+			OriginalQty:          "2",
+			OriginalPrice:        "0",
+			AveragePrice:         "0",
+			StopPrice:            "9103.1",
+			ExecutionType:        OrderExecutionTypeTrade,
+			Status:               OrderStatusTypePartiallyFilled,
+			ID:                   8888888,
+			LastFilledQty:        "1",
+			AccumulatedFilledQty: "1",
+			LastFilledPrice:      "9103.1",
+			MarginAsset:          "BTC",
+			CommissionAsset:      "BTC",
+			Commission:           "0.00000055",
+			TradeTime:            1591274595442,
+			TradeID:              121,
+			BidsNotional:         "0",
+			AsksNotional:         "0",
+			WorkingType:          WorkingTypeContractPrice,
+			OriginalType:         "TRAILING_STOP_MARKET",
+			PositionSide:         PositionSideTypeLong,
+			ActivationPrice:      "9476.8",
+			CallbackRate:         "5.0",
+			RealizedPnL:          "0",
+		}, event.OrderTradeUpdate)
+	}, func(err error) {
+		s.r().EqualError(err, fakeErrMsg)
+	})
+	s.r().NoError(err)
+	stopC <- struct{}{}
+	<-doneC
+}
+
+func (s *websocketServiceTestSuite) TestWsUserDataEventServeAccountUpdate() {
+	data := []byte(`{
+	  "e":"ACCOUNT_UPDATE",
+	  "E":1564745798939,
+	  "T":1564745798938,
+	  "i":"SfsR",
+	  "a":{
+	    "m":"ORDER",
+	    "B":[{"a":"BTC","wb":"122624.12345678","cw":"100.12345678"}],
+	    "P":[{
+	      "s":"BTCUSD_200925",
+	      "pa":"0",
+	      "ep":"0.0",
+	      "cr":"200",
+	      "up":"0",
+	      "mt":"isolated",
+	      "iw":"0.00000000",
+	      "ps":"BOTH"
+	    }]
+	  }
+	}`)
+	fakeErrMsg := "fake error"
+	s.mockWsServe(data, errors.New(fakeErrMsg))
+	defer s.assertWsServe()
+
+	doneC, stopC, err := WsUserDataEventServe("listenKey", func(event *WsUserDataEvent) {
+		r := s.r()
+		r.Equal(UserDataEventTypeAccountUpdate, event.Event)
+		r.Nil(event.OrderTradeUpdate)
+		r.Equal(&WsAccountUpdate{
+			Reason:   UserDataEventReasonTypeOrder,
+			Balances: []WsBalance{{Asset: "BTC", Balance: "122624.12345678", CrossWalletBalance: "100.12345678"}},
+			Positions: []WsPosition{{
+				Symbol:              "BTCUSD_200925",
+				Side:                PositionSideTypeBoth,
+				Amount:              "0",
+				MarginType:          "isolated",
+				IsolatedWallet:      "0.00000000",
+				EntryPrice:          "0.0",
+				UnrealizedPnL:       "0",
+				AccumulatedRealized: "200",
+			}},
+		}, event.AccountUpdate)
+	}, func(err error) {
+		s.r().EqualError(err, fakeErrMsg)
+	})
+	s.r().NoError(err)
+	stopC <- struct{}{}
+	<-doneC
+}
+
 // https://binance-docs.github.io/apidocs/delivery/en/#aggregate-trade-streams
 func (s *websocketServiceTestSuite) TestAggTradeServe() {
 	data := []byte(`{
@@ -181,6 +325,51 @@ func (s *websocketServiceTestSuite) assertWsIndexPriceEvent(e, a *WsIndexPriceEv
 }
 
 // https://binance-docs.github.io/apidocs/delivery/en/#mark-price-stream
+func (s *websocketServiceTestSuite) TestCombinedAggTradeServe() {
+	data := []byte(`{
+		"stream":"btcusd_200626@aggTrade",
+		"data":{
+		  "e":"aggTrade",
+		  "E":1591261134288,
+		  "a":424951,
+		  "s":"BTCUSD_200626",
+		  "p":"9643.5",
+		  "q":"2",
+		  "f":606073,
+		  "l":606073,
+		  "T":1591261134199,
+		  "m":false
+		}
+	  }`)
+	fakeErrMsg := "fake error"
+	s.mockWsServe(data, errors.New(fakeErrMsg))
+	defer s.assertWsServe()
+
+	doneC, stopC, err := WsCombinedAggTradeServe([]string{"BTCUSD_200626", "ETHUSD_200626"}, func(event *WsAggTradeEvent) {
+		e := &WsAggTradeEvent{
+			Event:            "aggTrade",
+			Time:             1591261134288,
+			AggregateTradeID: 424951,
+			Symbol:           "BTCUSD_200626",
+			Price:            "9643.5",
+			Quantity:         "2",
+			FirstTradeID:     606073,
+			LastTradeID:      606073,
+			TradeTime:        1591261134199,
+			Maker:            false,
+		}
+		s.assertWsAggTradeEvent(e, event)
+	},
+		func(err error) {
+			s.r().EqualError(err, fakeErrMsg)
+		})
+
+	s.r().NoError(err)
+	s.r().Equal("wss://dstream.binance.com/stream?streams=btcusd_200626@aggTrade/ethusd_200626@aggTrade", s.endpoint)
+	stopC <- struct{}{}
+	<-doneC
+}
+
 func (s *websocketServiceTestSuite) TestMarkPriceServe() {
 	data := []byte(`{
     	"e":"markPriceUpdate",
@@ -216,6 +405,45 @@ func (s *websocketServiceTestSuite) TestMarkPriceServe() {
 	<-doneC
 }
 
+func (s *websocketServiceTestSuite) TestCombinedMarkPriceServe() {
+	data := []byte(`{
+		"stream":"btcusd_201225@markPrice",
+		"data":{
+    	  "e":"markPriceUpdate",
+    	  "E":1596095725000,
+    	  "s":"BTCUSD_201225",
+    	  "p":"10934.62615417",
+    	  "P":"10962.17178236",
+    	  "r":"",
+    	  "T":0
+		}
+	  }`)
+	fakeErrMsg := "fake error"
+	s.mockWsServe(data, errors.New(fakeErrMsg))
+	defer s.assertWsServe()
+
+	doneC, stopC, err := WsCombinedMarkPriceServe([]string{"BTCUSD_201225"}, func(event *WsMarkPriceEvent) {
+		e := &WsMarkPriceEvent{
+			Event:                "markPriceUpdate",
+			Time:                 1596095725000,
+			Symbol:               "BTCUSD_201225",
+			MarkPrice:            "10934.62615417",
+			EstimatedSettlePrice: "10962.17178236",
+			FundingRate:          "",
+			NextFundingTime:      0,
+		}
+		s.assertWsMarkPriceEvent(e, event)
+	},
+		func(err error) {
+			s.r().EqualError(err, fakeErrMsg)
+		})
+
+	s.r().NoError(err)
+	s.r().Equal("wss://dstream.binance.com/stream?streams=btcusd_201225@markPrice", s.endpoint)
+	stopC <- struct{}{}
+	<-doneC
+}
+
 // https://binance-docs.github.io/apidocs/delivery/en/#mark-price-of-all-symbols-of-a-pair
 func (s *websocketServiceTestSuite) TestPairMarkPriceServe() {
 	data := []byte(`[
@@ -345,6 +573,75 @@ func (s *websocketServiceTestSuite) TestKlineServe() {
 	<-doneC
 }
 
+func (s *websocketServiceTestSuite) TestCombinedKlineServe() {
+	data := []byte(`{
+	  "stream":"btcusd_200626@kline_1m",
+	  "data":{
+	    "e":"kline",
+	    "E":1591261542539,
+	    "s":"BTCUSD_200626",
+	    "k":{
+		  "t":1591261500000,
+		  "T":1591261559999,
+		  "s":"BTCUSD_200626",
+		  "i":"1m",
+		  "f":606400,
+		  "L":606430,
+		  "o":"9638.9",
+		  "c":"9639.8",
+		  "h":"9639.8",
+		  "l":"9638.6",
+		  "v":"156",
+		  "n":31,
+		  "x":false,
+		  "q":"1.61836886",
+		  "V":"73",
+		  "Q":"0.75731156",
+		  "B":"0"
+	    }
+	  }
+	}`)
+	fakeErrMsg := "fake error"
+	s.mockWsServe(data, errors.New(fakeErrMsg))
+	defer s.assertWsServe()
+
+	input := map[string]KlineInterval{
+		"BTCUSD_200626": "1m",
+	}
+	doneC, stopC, err := WsCombinedKlineServe(input, func(event *WsKlineEvent) {
+		e := &WsKlineEvent{
+			Event:  "kline",
+			Time:   1591261542539,
+			Symbol: "BTCUSD_200626",
+			Kline: WsKline{
+				StartTime:            1591261500000,
+				EndTime:              1591261559999,
+				Symbol:               "BTCUSD_200626",
+				Interval:             "1m",
+				FirstTradeID:         606400,
+				LastTradeID:          606430,
+				Open:                 "9638.9",
+				Close:                "9639.8",
+				High:                 "9639.8",
+				Low:                  "9638.6",
+				Volume:               "156",
+				TradeNum:             31,
+				IsFinal:              false,
+				QuoteVolume:          "1.61836886",
+				ActiveBuyVolume:      "73",
+				ActiveBuyQuoteVolume: "0.75731156",
+			},
+		}
+		s.assertWsKlineEventEqual(e, event)
+	}, func(err error) {
+		s.r().EqualError(err, fakeErrMsg)
+	})
+	s.r().NoError(err)
+	s.r().Equal("wss://dstream.binance.com/stream?streams=btcusd_200626@kline_1m", s.endpoint)
+	stopC <- struct{}{}
+	<-doneC
+}
+
 func (s *websocketServiceTestSuite) assertWsKlineEventEqual(e, a *WsKlineEvent) {
 	r := s.r()
 	r.Equal(e.Event, a.Event, "Event")
@@ -929,6 +1226,51 @@ func (s *websocketServiceTestSuite) TestAllBookTickerServe() {
 	<-doneC
 }
 
+func (s *websocketServiceTestSuite) TestCombinedBookTickerServe() {
+	data := []byte(`{
+		"stream":"btcusd_200626@bookTicker",
+		"data":{
+  		  "e":"bookTicker",
+  		  "u":17242169,
+  		  "s":"BTCUSD_200626",
+  		  "ps":"BTCUSD",
+  		  "b":"9548.1",
+  		  "B":"52",
+  		  "a":"9548.5",
+  		  "A":"11",
+  		  "T":1591268628155,
+  		  "E":1591268628166
+		}
+	  }`)
+	fakeErrMsg := "fake error"
+	s.mockWsServe(data, errors.New(fakeErrMsg))
+	defer s.assertWsServe()
+
+	doneC, stopC, err := WsCombinedBookTickerServe([]string{"BTCUSD_200626"}, func(event *WsBookTickerEvent) {
+		e := &WsBookTickerEvent{
+			Event:           "bookTicker",
+			UpdateID:        17242169,
+			Symbol:          "BTCUSD_200626",
+			Pair:            "BTCUSD",
+			BestBidPrice:    "9548.1",
+			BestBidQty:      "52",
+			BestAskPrice:    "9548.5",
+			BestAskQty:      "11",
+			TransactionTime: 1591268628155,
+			Time:            1591268628166,
+		}
+		s.assertWsBookTickerEvent(e, event)
+	},
+		func(err error) {
+			s.r().EqualError(err, fakeErrMsg)
+		})
+
+	s.r().NoError(err)
+	s.r().Equal("wss://dstream.binance.com/stream?streams=btcusd_200626@bookTicker", s.endpoint)
+	stopC <- struct{}{}
+	<-doneC
+}
+
 func (s *websocketServiceTestSuite) assertWsBookTickerEvent(e, a *WsBookTickerEvent) {
 	r := s.r()
 	r.Equal(e.Event, a.Event, "Event")
@@ -1201,3 +1543,170 @@ func (s *websocketServiceTestSuite) assertDepthEvent(e, a *WsDepthEvent) {
 		r.Equal(b.Quantity, a.Asks[i].Quantity, "Quantity")
 	}
 }
+
+func (s *websocketServiceTestSuite) testDepthServeWithRate(rate time.Duration, expectedErr error, expectedServeCnt int, expectedEndpoint string, serve func(WsDepthHandler, ErrHandler) (chan struct{}, chan struct{}, error)) {
+	data := []byte(`{
+	  "e": "depthUpdate",
+	  "E": 1591270260907,
+	  "T": 1591270260891,
+	  "s": "BTCUSD_200626",
+	  "ps": "BTCUSD",
+	  "U": 17285681,
+	  "u": 17285702,
+	  "pu": 17285675,
+	  "b": [
+		["9517.6","10"]
+	  ],
+	  "a": [
+		["9518.5","45"]
+	  ]
+	}`)
+	s.mockWsServe(data, nil)
+	defer s.assertWsServe(expectedServeCnt)
+
+	doneC, stopC, err := serve(func(event *WsDepthEvent) {
+		e := &WsDepthEvent{
+			Event:            "depthUpdate",
+			Time:             1591270260907,
+			TransactionTime:  1591270260891,
+			Symbol:           "BTCUSD_200626",
+			Pair:             "BTCUSD",
+			FirstUpdateID:    17285681,
+			LastUpdateID:     17285702,
+			PrevLastUpdateID: 17285675,
+			Bids:             []Bid{{Price: "9517.6", Quantity: "10"}},
+			Asks:             []Ask{{Price: "9518.5", Quantity: "45"}},
+		}
+		s.assertDepthEvent(e, event)
+	}, func(err error) {
+	})
+
+	if expectedErr != nil {
+		s.r().EqualError(err, expectedErr.Error())
+		return
+	}
+	s.r().NoError(err)
+	s.r().Equal(expectedEndpoint, s.endpoint)
+	stopC <- struct{}{}
+	<-doneC
+}
+
+func (s *websocketServiceTestSuite) TestDepthServeWithValidRate() {
+	rates := map[time.Duration]string{
+		100 * time.Millisecond: "@100ms",
+		250 * time.Millisecond: "",
+		500 * time.Millisecond: "@500ms",
+	}
+	cnt := 0
+	for rate, suffix := range rates {
+		rate := rate
+		cnt++
+		s.testDepthServeWithRate(rate, nil, cnt, "wss://dstream.binance.com/ws/btcusd_200626@depth"+suffix, func(h WsDepthHandler, e ErrHandler) (chan struct{}, chan struct{}, error) {
+			return WsDiffDepthServeWithRate("BTCUSD_200626", rate, h, e)
+		})
+		cnt++
+		s.testDepthServeWithRate(rate, nil, cnt, "wss://dstream.binance.com/ws/btcusd_200626@depth10"+suffix, func(h WsDepthHandler, e ErrHandler) (chan struct{}, chan struct{}, error) {
+			return WsPartialDepthServeWithRate("BTCUSD_200626", 10, rate, h, e)
+		})
+	}
+}
+
+func (s *websocketServiceTestSuite) TestDepthServeWithInvalidRate() {
+	randSrc := rand.NewSource(time.Now().UnixNano())
+	rand := rand.New(randSrc)
+	for {
+		rate := time.Duration(rand.Intn(100)*10) * time.Millisecond
+		switch rate {
+		case 250 * time.Millisecond:
+		case 500 * time.Millisecond:
+		case 100 * time.Millisecond:
+		default:
+			s.testDepthServeWithRate(rate, errors.New("Invalid rate"), 0, "", func(h WsDepthHandler, e ErrHandler) (chan struct{}, chan struct{}, error) {
+				return WsDiffDepthServeWithRate("BTCUSD_200626", rate, h, e)
+			})
+			s.testDepthServeWithRate(rate, errors.New("Invalid rate"), 0, "", func(h WsDepthHandler, e ErrHandler) (chan struct{}, chan struct{}, error) {
+				return WsPartialDepthServeWithRate("BTCUSD_200626", 5, rate, h, e)
+			})
+			return
+		}
+	}
+}
+
+func (s *websocketServiceTestSuite) TestPartialDepthServeWithInvalidLevels() {
+	s.testDepthServeWithRate(100*time.Millisecond, errors.New("Invalid levels"), 0, "", func(h WsDepthHandler, e ErrHandler) (chan struct{}, chan struct{}, error) {
+		return WsPartialDepthServeWithRate("BTCUSD_200626", 7, 100*time.Millisecond, h, e)
+	})
+}
+
+func (s *websocketServiceTestSuite) TestPartialDepthServeWithoutLevelsCheck() {
+	s.testDepthServeWithRate(0, nil, 1, "wss://dstream.binance.com/ws/btcusd_200626@depth7", func(h WsDepthHandler, e ErrHandler) (chan struct{}, chan struct{}, error) {
+		return WsPartialDepthServe("BTCUSD_200626", 7, h, e)
+	})
+}
+
+func (s *websocketServiceTestSuite) TestCombinedDepthServe() {
+	s.testCombinedDepthServe(nil, "wss://dstream.binance.com/stream?streams=btcusd_200626@depth5")
+}
+
+func (s *websocketServiceTestSuite) TestCombinedDepthServeWithRate() {
+	rate := 100 * time.Millisecond
+	s.testCombinedDepthServe(&rate, "wss://dstream.binance.com/stream?streams=btcusd_200626@depth5@100ms")
+}
+
+func (s *websocketServiceTestSuite) testCombinedDepthServe(rate *time.Duration, expectedEndpoint string) {
+	data := []byte(`{
+	  "stream": "btcusd_200626@depth5",
+	  "data": {
+	    "e": "depthUpdate",
+	    "E": 1591270260907,
+	    "T": 1591270260891,
+	    "s": "BTCUSD_200626",
+	    "ps": "BTCUSD",
+	    "U": 17285681,
+	    "u": 17285702,
+	    "pu": 17285675,
+	    "b": [
+		  ["9517.6","10"]
+	    ],
+	    "a": [
+		  ["9518.5","45"]
+	    ]
+	  }
+	}`)
+	fakeErrMsg := "fake error"
+	s.mockWsServe(data, errors.New(fakeErrMsg))
+	defer s.assertWsServe()
+
+	handler := func(event *WsDepthEvent) {
+		e := &WsDepthEvent{
+			Event:            "depthUpdate",
+			Time:             1591270260907,
+			TransactionTime:  1591270260891,
+			Symbol:           "BTCUSD_200626",
+			Pair:             "BTCUSD",
+			FirstUpdateID:    17285681,
+			LastUpdateID:     17285702,
+			PrevLastUpdateID: 17285675,
+			Bids:             []Bid{{Price: "9517.6", Quantity: "10"}},
+			Asks:             []Ask{{Price: "9518.5", Quantity: "45"}},
+		}
+		s.assertDepthEvent(e, event)
+	}
+	errHandler := func(err error) {
+		s.r().EqualError(err, fakeErrMsg)
+	}
+
+	symbolLevels := map[string]string{"BTCUSD_200626": "5"}
+	var doneC, stopC chan struct{}
+	var err error
+	if rate == nil {
+		doneC, stopC, err = WsCombinedDepthServe(symbolLevels, handler, errHandler)
+	} else {
+		doneC, stopC, err = WsCombinedDepthServeWithRate(symbolLevels, *rate, handler, errHandler)
+	}
+
+	s.r().NoError(err)
+	s.r().Equal(expectedEndpoint, s.endpoint)
+	stopC <- struct{}{}
+	<-doneC
+}
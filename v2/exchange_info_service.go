@@ -61,6 +61,13 @@ type ExchangeInfo struct {
 	RateLimits      []RateLimit   `json:"rateLimits"`
 	ExchangeFilters []interface{} `json:"exchangeFilters"`
 	Symbols         []Symbol      `json:"symbols"`
+	Sors            []SOR         `json:"sors"`
+}
+
+// SOR define the symbols a smart order routing order on a base asset can be routed to
+type SOR struct {
+	BaseAsset string   `json:"baseAsset"`
+	Symbols   []string `json:"symbols"`
 }
 
 // RateLimit struct
@@ -0,0 +1,38 @@
+package binance
+
+import (
+	"net/http"
+
+	"github.com/Zamzam-Technology/go-binance/v2/common"
+)
+
+// FakeClient is a Client answering the requests with stubbed responses, for tests.
+// The responses are stubbed by service, named by the method of Client which creates it,
+// or by method and path, e.g.
+//
+//	c := NewFakeClient()
+//	c.StubService("NewServerTimeService", `{"serverTime": 1499827319559}`)
+//	c.Stub(http.MethodGet, "/api/v3/time", `{"serverTime": 1499827319559}`)
+type FakeClient struct {
+	*Client
+	*common.ServiceStubs
+}
+
+var _ API = (*FakeClient)(nil)
+
+// unprobedEndpoints are the endpoints of the services which fail without parameters
+var unprobedEndpoints = map[string]common.StubCall{
+	"NewCreateOCOService": {Method: http.MethodPost, Path: "/api/v3/order/oco"},
+}
+
+// NewFakeClient init a fake client without any stubbed response
+func NewFakeClient() *FakeClient {
+	stubs := common.NewServiceStubs(func(transport http.RoundTripper) interface{} {
+		c := NewClient("key", "secret")
+		c.HTTPClient = &http.Client{Transport: transport}
+		return c
+	}, unprobedEndpoints)
+	c := NewClient("key", "secret")
+	c.HTTPClient = &http.Client{Transport: stubs}
+	return &FakeClient{Client: c, ServiceStubs: stubs}
+}
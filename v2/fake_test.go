@@ -0,0 +1,97 @@
+package binance
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/Zamzam-Technology/go-binance/v2/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// lastPrice use only the API interface, like the code under test would
+func lastPrice(c API, symbol string) (string, error) {
+	prices, err := c.NewListPricesService().Symbol(symbol).Do(context.Background())
+	if err != nil {
+		return "", err
+	}
+	return prices[0].Price, nil
+}
+
+func TestFakeClient(t *testing.T) {
+	c := NewFakeClient()
+	require.NoError(t, c.Stub(http.MethodGet, "/api/v3/ticker/price", `{"symbol": "BTCUSDT", "price": "100.5"}`))
+	c.StubError(http.MethodPost, "/api/v3/order", &common.APIError{Code: -2010, Message: "insufficient balance"})
+
+	price, err := lastPrice(c, "BTCUSDT")
+	require.NoError(t, err)
+	assert.Equal(t, "100.5", price)
+	calls := c.Calls(http.MethodGet, "/api/v3/ticker/price")
+	require.Len(t, calls, 1)
+	assert.Equal(t, "BTCUSDT", calls[0].Params.Get("symbol"))
+
+	_, err = c.NewCreateOrderService().Symbol("BTCUSDT").Side(SideTypeBuy).Type(OrderTypeMarket).
+		Quantity("1").Do(context.Background())
+	require.True(t, common.IsAPIError(err))
+	assert.Equal(t, int64(-2010), err.(*common.APIError).Code)
+	calls = c.Calls(http.MethodPost, "/api/v3/order")
+	require.Len(t, calls, 1)
+	assert.Equal(t, "MARKET", calls[0].Params.Get("type"))
+	assert.NotEmpty(t, calls[0].Params.Get("signature"))
+}
+
+func TestFakeClientServices(t *testing.T) {
+	c := NewFakeClient()
+	require.NoError(t, c.StubService("NewListPricesService", `{"symbol": "BTCUSDT", "price": "100.5"}`))
+	require.NoError(t, c.StubServiceError("NewCreateOCOService", &common.APIError{Code: -2010, Message: "insufficient balance"}))
+
+	price, err := lastPrice(c, "BTCUSDT")
+	require.NoError(t, err)
+	assert.Equal(t, "100.5", price)
+	calls, err := c.ServiceCalls("NewListPricesService")
+	require.NoError(t, err)
+	require.Len(t, calls, 1)
+	assert.Equal(t, "BTCUSDT", calls[0].Params.Get("symbol"))
+
+	_, err = c.NewCreateOCOService().Symbol("BTCUSDT").Side(SideTypeSell).Quantity("1").Price("110").
+		StopPrice("90").Do(context.Background())
+	require.True(t, common.IsAPIError(err))
+	calls, err = c.ServiceCalls("NewCreateOCOService")
+	require.NoError(t, err)
+	require.Len(t, calls, 1)
+	assert.Equal(t, "90", calls[0].Params.Get("stopPrice"))
+
+	assert.Error(t, c.StubService("NewUnknownService", "{}"))
+}
+
+func TestFakeClientAllServices(t *testing.T) {
+	c := NewFakeClient()
+	api := reflect.TypeOf((*API)(nil)).Elem()
+	for i := 0; i < api.NumMethod(); i++ {
+		m := api.Method(i)
+		if _, ok := m.Type.Out(0).MethodByName("Do"); !ok {
+			continue
+		}
+		assert.NoError(t, c.StubService(m.Name, "{}"), m.Name)
+	}
+}
+
+func TestFakeStreams(t *testing.T) {
+	var streams Streams = NewFakeStreams()
+	var events []*WsKlineEvent
+	doneC, stopC, err := streams.WsKlineServe("BTCUSDT", "1m", func(event *WsKlineEvent) {
+		events = append(events, event)
+	}, func(err error) {})
+	require.NoError(t, err)
+
+	f := streams.(*FakeStreams)
+	assert.Equal(t, 1, f.Send("WsKlineServe", &WsKlineEvent{Symbol: "BTCUSDT"}))
+	require.Len(t, events, 1)
+	assert.Equal(t, "BTCUSDT", events[0].Symbol)
+
+	close(stopC)
+	<-doneC
+	assert.Equal(t, 0, f.Streams("WsKlineServe"))
+}
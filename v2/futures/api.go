@@ -0,0 +1,74 @@
+package futures
+
+import "time"
+
+// MarketDataClient is the market data services of Client
+type MarketDataClient interface {
+	NewPingService() *PingService
+	NewServerTimeService() *ServerTimeService
+	NewSetServerTimeService() *SetServerTimeService
+	NewDepthService() *DepthService
+	NewAggTradesService() *AggTradesService
+	NewRecentTradesService() *RecentTradesService
+	NewHistoricalTradesService() *HistoricalTradesService
+	NewKlinesService() *KlinesService
+	NewKlineDownloader(symbol string, interval KlineInterval) *KlineDownloader
+	NewMarkPriceKlinesService() *MarkPriceKlinesService
+	NewIndexPriceKlinesService() *IndexPriceKlinesService
+	NewPremiumIndexKlinesService() *PremiumIndexKlinesService
+	NewListPriceChangeStatsService() *ListPriceChangeStatsService
+	NewListPricesService() *ListPricesService
+	NewListBookTickersService() *ListBookTickersService
+	NewExchangeInfoService() *ExchangeInfoService
+	NewSymbolRegistry(ttl time.Duration) *SymbolRegistry
+	NewPremiumIndexService() *PremiumIndexService
+	NewFundingRateService() *FundingRateService
+	NewListLiquidationOrdersService() *ListLiquidationOrdersService
+}
+
+// TradingClient is the order services of Client
+type TradingClient interface {
+	NewCreateOrderService() *CreateOrderService
+	NewGetOrderService() *GetOrderService
+	NewCancelOrderService() *CancelOrderService
+	NewCancelAllOpenOrdersService() *CancelAllOpenOrdersService
+	NewCancelMultipleOrdersService() *CancelMultiplesOrdersService
+	NewListOpenOrdersService() *ListOpenOrdersService
+	NewListOrdersService() *ListOrdersService
+	NewListAccountTradeService() *ListAccountTradeService
+	NewOrderTracker() *OrderTracker
+	NewListUserLiquidationOrdersService() *ListUserLiquidationOrdersService
+}
+
+// AccountClient is the account and position services of Client
+type AccountClient interface {
+	NewGetAccountService() *GetAccountService
+	NewGetBalanceService() *GetBalanceService
+	NewGetPositionRiskService() *GetPositionRiskService
+	NewGetPositionMarginHistoryService() *GetPositionMarginHistoryService
+	NewGetIncomeHistoryService() *GetIncomeHistoryService
+	NewChangeLeverageService() *ChangeLeverageService
+	NewGetLeverageBracketService() *GetLeverageBracketService
+	NewChangeMarginTypeService() *ChangeMarginTypeService
+	NewUpdatePositionMarginService() *UpdatePositionMarginService
+	NewChangePositionModeService() *ChangePositionModeService
+	NewGetPositionModeService() *GetPositionModeService
+	NewGetRebateNewUserService() *GetRebateNewUserService
+}
+
+// UserStreamClient is the listen key services of the user data stream of Client
+type UserStreamClient interface {
+	NewStartUserStreamService() *StartUserStreamService
+	NewKeepaliveUserStreamService() *KeepaliveUserStreamService
+	NewCloseUserStreamService() *CloseUserStreamService
+}
+
+// API is the REST surface of Client, implemented by Client and FakeClient
+type API interface {
+	MarketDataClient
+	TradingClient
+	AccountClient
+	UserStreamClient
+}
+
+var _ API = (*Client)(nil)
@@ -0,0 +1,103 @@
+package futures
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/Zamzam-Technology/go-binance/v2/common"
+	"github.com/stretchr/testify/suite"
+)
+
+type archiveTestSuite struct {
+	suite.Suite
+	files map[string][]byte
+}
+
+func TestArchive(t *testing.T) {
+	suite.Run(t, new(archiveTestSuite))
+}
+
+func (s *archiveTestSuite) SetupTest() {
+	s.files = make(map[string][]byte)
+}
+
+// addFile zip content at path and add its checksum
+func (s *archiveTestSuite) addFile(path string, content string) {
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	f, err := w.Create("data.csv")
+	s.Require().NoError(err)
+	_, err = f.Write([]byte(content))
+	s.Require().NoError(err)
+	s.Require().NoError(w.Close())
+	sum := sha256.Sum256(buf.Bytes())
+	s.files[path] = buf.Bytes()
+	s.files[path+".CHECKSUM"] = []byte(hex.EncodeToString(sum[:]) + "  data.zip")
+}
+
+func (s *archiveTestSuite) fetcher() common.ArchiveFetcher {
+	return common.ArchiveFetcherFunc(func(ctx context.Context, path string) (io.ReadCloser, error) {
+		data, ok := s.files[path]
+		if !ok {
+			return nil, common.ErrArchiveNotFound
+		}
+		return ioutil.NopCloser(bytes.NewReader(data)), nil
+	})
+}
+
+func (s *archiveTestSuite) TestBookTickerArchive() {
+	s.addFile("data/futures/um/daily/bookTicker/BTCUSDT/BTCUSDT-bookTicker-2021-03-18.zip",
+		"update_id,best_bid_price,best_bid_qty,best_ask_price,best_ask_qty,transaction_time,event_time\n"+
+			"1,58000.10,1.5,58000.20,0.3,1616025600001,1616025600002\n")
+	r, err := OpenBookTickerArchive(context.Background(), s.fetcher(), common.ArchivePeriodDaily, "BTCUSDT",
+		time.Date(2021, 3, 18, 0, 0, 0, 0, time.UTC))
+	s.Require().NoError(err)
+	defer r.Close()
+
+	ticker, err := r.Read()
+	s.Require().NoError(err)
+	s.Equal(&BookTicker{
+		Symbol:      "BTCUSDT",
+		BidPrice:    "58000.10",
+		BidQuantity: "1.5",
+		AskPrice:    "58000.20",
+		AskQuantity: "0.3",
+		UpdateID:    1,
+		Time:        1616025600001,
+	}, ticker)
+	_, err = r.Read()
+	s.Equal(io.EOF, err)
+}
+
+func (s *archiveTestSuite) TestMarkPriceKlineArchive() {
+	s.addFile("data/futures/um/daily/markPriceKlines/BTCUSDT/1m/BTCUSDT-1m-2021-03-18.zip",
+		"open_time,open,high,low,close,volume,close_time,quote_volume,count,taker_buy_volume,taker_buy_quote_volume,ignore\n"+
+			"1616025600000,58000,58010,57990,58005,0,1616025659999,0,60,0,0,0\n")
+	r, err := OpenPriceKlineArchive(context.Background(), s.fetcher(), KlineTypeMarkPrice, common.ArchivePeriodDaily,
+		"BTCUSDT", KlineInterval1m, time.Date(2021, 3, 18, 0, 0, 0, 0, time.UTC))
+	s.Require().NoError(err)
+	defer r.Close()
+
+	kline, err := r.Read()
+	s.Require().NoError(err)
+	s.Equal(int64(1616025600000), kline.OpenTime)
+	s.Equal("58005", kline.Close)
+	s.Equal(int64(60), kline.TradeNum)
+}
+
+func (s *archiveTestSuite) TestTradeArchiveInvalidRecord() {
+	s.addFile("data/futures/um/daily/trades/BTCUSDT/BTCUSDT-trades-2021-03-18.zip", "1,58000,0.1\n")
+	r, err := OpenTradeArchive(context.Background(), s.fetcher(), common.ArchivePeriodDaily, "BTCUSDT",
+		time.Date(2021, 3, 18, 0, 0, 0, 0, time.UTC))
+	s.Require().NoError(err)
+	defer r.Close()
+	_, err = r.Read()
+	s.Error(err)
+}
@@ -69,6 +69,12 @@ type UserDataEventReasonType string
 // ForceOrderCloseType define reason type for force order
 type ForceOrderCloseType string
 
+// KlineInterval define kline interval, like 15m or 1d
+type KlineInterval = common.KlineInterval
+
+// KlineType define the price series of klines
+type KlineType string
+
 // Endpoints
 const (
 	baseApiMainUrl    = "https://fapi.binance.com"
@@ -145,7 +151,9 @@ const (
 	MarginTypeIsolated MarginType = "ISOLATED"
 	MarginTypeCrossed  MarginType = "CROSSED"
 
-	ContractTypePerpetual ContractType = "PERPETUAL"
+	ContractTypePerpetual      ContractType = "PERPETUAL"
+	ContractTypeCurrentQuarter ContractType = "CURRENT_QUARTER"
+	ContractTypeNextQuarter    ContractType = "NEXT_QUARTER"
 
 	UserDataEventTypeListenKeyExpired    UserDataEventType = "listenKeyExpired"
 	UserDataEventTypeMarginCall          UserDataEventType = "MARGIN_CALL"
@@ -171,6 +179,27 @@ const (
 	ForceOrderCloseTypeLiquidation ForceOrderCloseType = "LIQUIDATION"
 	ForceOrderCloseTypeADL         ForceOrderCloseType = "ADL"
 
+	KlineInterval1m  = common.KlineInterval1m
+	KlineInterval3m  = common.KlineInterval3m
+	KlineInterval5m  = common.KlineInterval5m
+	KlineInterval15m = common.KlineInterval15m
+	KlineInterval30m = common.KlineInterval30m
+	KlineInterval1h  = common.KlineInterval1h
+	KlineInterval2h  = common.KlineInterval2h
+	KlineInterval4h  = common.KlineInterval4h
+	KlineInterval6h  = common.KlineInterval6h
+	KlineInterval8h  = common.KlineInterval8h
+	KlineInterval12h = common.KlineInterval12h
+	KlineInterval1d  = common.KlineInterval1d
+	KlineInterval3d  = common.KlineInterval3d
+	KlineInterval1w  = common.KlineInterval1w
+	KlineInterval1M  = common.KlineInterval1M
+
+	KlineTypeTrade        KlineType = "TRADE"
+	KlineTypeMarkPrice    KlineType = "MARK_PRICE"
+	KlineTypeIndexPrice   KlineType = "INDEX_PRICE"
+	KlineTypePremiumIndex KlineType = "PREMIUM_INDEX"
+
 	timestampKey  = "timestamp"
 	signatureKey  = "signature"
 	recvWindowKey = "recvWindow"
@@ -371,6 +400,26 @@ func (c *Client) NewKlinesService() *KlinesService {
 	return &KlinesService{c: c}
 }
 
+// NewKlineDownloader init kline downloader
+func (c *Client) NewKlineDownloader(symbol string, interval KlineInterval) *KlineDownloader {
+	return &KlineDownloader{c: c, symbol: symbol, interval: interval, klineType: KlineTypeTrade, limit: 1000, concurrency: 4}
+}
+
+// NewMarkPriceKlinesService init mark price klines service
+func (c *Client) NewMarkPriceKlinesService() *MarkPriceKlinesService {
+	return &MarkPriceKlinesService{c: c}
+}
+
+// NewIndexPriceKlinesService init index price klines service
+func (c *Client) NewIndexPriceKlinesService() *IndexPriceKlinesService {
+	return &IndexPriceKlinesService{c: c}
+}
+
+// NewPremiumIndexKlinesService init premium index klines service
+func (c *Client) NewPremiumIndexKlinesService() *PremiumIndexKlinesService {
+	return &PremiumIndexKlinesService{c: c}
+}
+
 // NewListPriceChangeStatsService init list prices change stats service
 func (c *Client) NewListPriceChangeStatsService() *ListPriceChangeStatsService {
 	return &ListPriceChangeStatsService{c: c}
@@ -476,6 +525,17 @@ func (c *Client) NewExchangeInfoService() *ExchangeInfoService {
 	return &ExchangeInfoService{c: c}
 }
 
+// NewSymbolRegistry init symbol registry, the exchange info is refreshed when older than ttl,
+// a zero ttl loads it only once
+func (c *Client) NewSymbolRegistry(ttl time.Duration) *SymbolRegistry {
+	return &SymbolRegistry{c: c, ttl: ttl, now: time.Now}
+}
+
+// NewOrderTracker init order tracker
+func (c *Client) NewOrderTracker() *OrderTracker {
+	return &OrderTracker{OrderTracker: common.NewOrderTracker(), c: c}
+}
+
 // NewPremiumIndexService init premium index service
 func (c *Client) NewPremiumIndexService() *PremiumIndexService {
 	return &PremiumIndexService{c: c}
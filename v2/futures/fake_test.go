@@ -0,0 +1,52 @@
+package futures
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFakeClient(t *testing.T) {
+	var c API = NewFakeClient()
+	f := c.(*FakeClient)
+	require.NoError(t, f.Stub(http.MethodPost, "/fapi/v1/order", map[string]interface{}{
+		"symbol": "BTCUSDT", "orderId": 1, "status": "NEW",
+	}))
+	res, err := c.NewCreateOrderService().Symbol("BTCUSDT").Side(SideTypeBuy).Type(OrderTypeLimit).
+		TimeInForce(TimeInForceTypeGTC).Quantity("1").Price("100").Do(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), res.OrderID)
+	assert.Equal(t, OrderStatusTypeNew, res.Status)
+	calls := f.Calls(http.MethodPost, "/fapi/v1/order")
+	require.Len(t, calls, 1)
+	assert.Equal(t, "100", calls[0].Params.Get("price"))
+}
+
+func TestFakeClientAllServices(t *testing.T) {
+	c := NewFakeClient()
+	api := reflect.TypeOf((*API)(nil)).Elem()
+	for i := 0; i < api.NumMethod(); i++ {
+		m := api.Method(i)
+		if _, ok := m.Type.Out(0).MethodByName("Do"); !ok {
+			continue
+		}
+		assert.NoError(t, c.StubService(m.Name, "{}"), m.Name)
+	}
+}
+
+func TestFakeStreams(t *testing.T) {
+	f := NewFakeStreams()
+	var streams Streams = f
+	var events []*WsUserDataEvent
+	_, _, err := streams.WsUserDataServe("listen-key", func(event *WsUserDataEvent) {
+		events = append(events, event)
+	}, func(err error) {})
+	require.NoError(t, err)
+	assert.Equal(t, 1, f.Send("WsUserDataServe", &WsUserDataEvent{Event: UserDataEventTypeAccountUpdate}))
+	require.Len(t, events, 1)
+	assert.Equal(t, UserDataEventTypeAccountUpdate, events[0].Event)
+}
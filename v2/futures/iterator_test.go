@@ -0,0 +1,66 @@
+package futures
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type iteratorTestSuite struct {
+	baseTestSuite
+	responses [][]byte
+	queries   []url.Values
+}
+
+func TestIterator(t *testing.T) {
+	suite.Run(t, new(iteratorTestSuite))
+}
+
+func (s *iteratorTestSuite) SetupTest() {
+	s.baseTestSuite.SetupTest()
+	s.queries = nil
+	s.client.Client.do = func(req *http.Request) (*http.Response, error) {
+		data := s.responses[len(s.queries)]
+		s.queries = append(s.queries, req.URL.Query())
+		return newHTTPResponse(data, http.StatusOK), nil
+	}
+}
+
+func (s *iteratorTestSuite) TestAccountTradesIterator() {
+	week := int64(7 * 24 * 60 * 60 * 1000)
+	s.responses = [][]byte{
+		[]byte(`[{"id": 5, "time": 100}, {"id": 6, "time": 200}]`),
+		[]byte(`[{"id": 7, "time": 300}]`),
+	}
+	it := s.client.NewListAccountTradeService().Symbol("BTCUSDT").
+		StartTime(0).EndTime(2 * week).Limit(2).Iterator(nil)
+	var ids []int64
+	for it.Next(newContext()) {
+		ids = append(ids, it.Trade().ID)
+	}
+	r := s.r()
+	r.NoError(it.Err())
+	r.Equal([]int64{5, 6, 7}, ids)
+	r.Len(s.queries, 2)
+	r.Equal("604799999", s.queries[0].Get("endTime"))
+	r.Equal("7", s.queries[1].Get("fromId"))
+}
+
+func (s *iteratorTestSuite) TestAggTradesIteratorEndTime() {
+	s.responses = [][]byte{
+		[]byte(`[{"a": 1, "T": 10}, {"a": 2, "T": 20}]`),
+		[]byte(`[{"a": 3, "T": 30}, {"a": 4, "T": 40}]`),
+	}
+	it := s.client.NewAggTradesService().Symbol("BTCUSDT").
+		StartTime(0).EndTime(35).Limit(2).Iterator(nil)
+	var ids []int64
+	for it.Next(newContext()) {
+		ids = append(ids, it.AggTrade().AggTradeID)
+	}
+	r := s.r()
+	r.NoError(it.Err())
+	r.Equal([]int64{1, 2, 3}, ids)
+	r.Len(s.queries, 2)
+}
@@ -0,0 +1,50 @@
+package futures
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type klineDownloaderTestSuite struct {
+	baseTestSuite
+}
+
+func TestKlineDownloader(t *testing.T) {
+	suite.Run(t, new(klineDownloaderTestSuite))
+}
+
+func (s *klineDownloaderTestSuite) TestDownloadMarkPrice() {
+	var paths []string
+	s.client.Client.do = func(req *http.Request) (*http.Response, error) {
+		paths = append(paths, req.URL.Path+"?"+req.URL.Query().Get("symbol")+req.URL.Query().Get("pair"))
+		data := []byte(`[
+			[0, "1", "1", "1", "1", "0", 3599999, "0", 60, "0", "0", "0"],
+			[7200000, "1", "1", "1", "1", "0", 10799999, "0", 60, "0", "0", "0"]
+		]`)
+		return newHTTPResponse(data, http.StatusOK), nil
+	}
+	start := time.Unix(0, 0)
+	download, err := s.client.NewKlineDownloader("BTCUSDT", KlineInterval1h).Type(KlineTypeMarkPrice).
+		Download(newContext(), start, start.Add(3*time.Hour))
+	r := s.r()
+	r.NoError(err)
+	r.Equal([]string{"/fapi/v1/markPriceKlines?BTCUSDT"}, paths)
+	r.Len(download.Klines, 2)
+	r.Len(download.Gaps, 1)
+	r.Equal(int64(3600000), download.Gaps[0].Start)
+
+	paths = nil
+	_, err = s.client.NewKlineDownloader("BTCUSDT", KlineInterval1h).Type(KlineTypeIndexPrice).
+		Download(newContext(), start, start.Add(3*time.Hour))
+	r.NoError(err)
+	r.Equal([]string{"/fapi/v1/indexPriceKlines?BTCUSDT"}, paths)
+}
+
+func (s *klineDownloaderTestSuite) TestDownloadInvalidType() {
+	_, err := s.client.NewKlineDownloader("BTCUSDT", KlineInterval1h).Type("LAST").
+		Download(newContext(), time.Unix(0, 0), time.Unix(3600, 0))
+	s.r().Error(err)
+}
@@ -3,13 +3,14 @@ package futures
 import (
 	"context"
 	"fmt"
+	"time"
 )
 
 // KlinesService list klines
 type KlinesService struct {
 	c         *Client
 	symbol    string
-	interval  string
+	interval  KlineInterval
 	limit     *int
 	startTime *int64
 	endTime   *int64
@@ -22,7 +23,7 @@ func (s *KlinesService) Symbol(symbol string) *KlinesService {
 }
 
 // Interval set interval
-func (s *KlinesService) Interval(interval string) *KlinesService {
+func (s *KlinesService) Interval(interval KlineInterval) *KlinesService {
 	s.interval = interval
 	return s
 }
@@ -66,6 +67,11 @@ func (s *KlinesService) Do(ctx context.Context, opts ...RequestOption) (res []*K
 	if err != nil {
 		return []*Kline{}, err
 	}
+	return parseKlines(data)
+}
+
+// parseKlines decode a kline list, the common format of all the kline endpoints
+func parseKlines(data []byte) (res []*Kline, err error) {
 	j, err := newJSON(data)
 	if err != nil {
 		return []*Kline{}, err
@@ -109,3 +115,205 @@ type Kline struct {
 	TakerBuyBaseAssetVolume  string `json:"takerBuyBaseAssetVolume"`
 	TakerBuyQuoteAssetVolume string `json:"takerBuyQuoteAssetVolume"`
 }
+
+// OpenedAt return the open time of the kline
+func (k *Kline) OpenedAt() time.Time {
+	return time.Unix(0, k.OpenTime*int64(time.Millisecond))
+}
+
+// ClosedAt return the close time of the kline
+func (k *Kline) ClosedAt() time.Time {
+	return time.Unix(0, k.CloseTime*int64(time.Millisecond))
+}
+
+// MarkPriceKlinesService list mark price klines, the volume fields of the klines are empty
+type MarkPriceKlinesService struct {
+	c         *Client
+	symbol    string
+	interval  KlineInterval
+	limit     *int
+	startTime *int64
+	endTime   *int64
+}
+
+// Symbol set symbol
+func (s *MarkPriceKlinesService) Symbol(symbol string) *MarkPriceKlinesService {
+	s.symbol = symbol
+	return s
+}
+
+// Interval set interval
+func (s *MarkPriceKlinesService) Interval(interval KlineInterval) *MarkPriceKlinesService {
+	s.interval = interval
+	return s
+}
+
+// Limit set limit
+func (s *MarkPriceKlinesService) Limit(limit int) *MarkPriceKlinesService {
+	s.limit = &limit
+	return s
+}
+
+// StartTime set startTime
+func (s *MarkPriceKlinesService) StartTime(startTime int64) *MarkPriceKlinesService {
+	s.startTime = &startTime
+	return s
+}
+
+// EndTime set endTime
+func (s *MarkPriceKlinesService) EndTime(endTime int64) *MarkPriceKlinesService {
+	s.endTime = &endTime
+	return s
+}
+
+// Do send request
+func (s *MarkPriceKlinesService) Do(ctx context.Context, opts ...RequestOption) (res []*Kline, err error) {
+	r := &request{
+		method:   "GET",
+		endpoint: "/fapi/v1/markPriceKlines",
+	}
+	r.setParam("symbol", s.symbol)
+	r.setParam("interval", s.interval)
+	if s.limit != nil {
+		r.setParam("limit", *s.limit)
+	}
+	if s.startTime != nil {
+		r.setParam("startTime", *s.startTime)
+	}
+	if s.endTime != nil {
+		r.setParam("endTime", *s.endTime)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*Kline{}, err
+	}
+	return parseKlines(data)
+}
+
+// IndexPriceKlinesService list index price klines of a pair, the volume fields of the klines are empty
+type IndexPriceKlinesService struct {
+	c         *Client
+	pair      string
+	interval  KlineInterval
+	limit     *int
+	startTime *int64
+	endTime   *int64
+}
+
+// Pair set pair
+func (s *IndexPriceKlinesService) Pair(pair string) *IndexPriceKlinesService {
+	s.pair = pair
+	return s
+}
+
+// Interval set interval
+func (s *IndexPriceKlinesService) Interval(interval KlineInterval) *IndexPriceKlinesService {
+	s.interval = interval
+	return s
+}
+
+// Limit set limit
+func (s *IndexPriceKlinesService) Limit(limit int) *IndexPriceKlinesService {
+	s.limit = &limit
+	return s
+}
+
+// StartTime set startTime
+func (s *IndexPriceKlinesService) StartTime(startTime int64) *IndexPriceKlinesService {
+	s.startTime = &startTime
+	return s
+}
+
+// EndTime set endTime
+func (s *IndexPriceKlinesService) EndTime(endTime int64) *IndexPriceKlinesService {
+	s.endTime = &endTime
+	return s
+}
+
+// Do send request
+func (s *IndexPriceKlinesService) Do(ctx context.Context, opts ...RequestOption) (res []*Kline, err error) {
+	r := &request{
+		method:   "GET",
+		endpoint: "/fapi/v1/indexPriceKlines",
+	}
+	r.setParam("pair", s.pair)
+	r.setParam("interval", s.interval)
+	if s.limit != nil {
+		r.setParam("limit", *s.limit)
+	}
+	if s.startTime != nil {
+		r.setParam("startTime", *s.startTime)
+	}
+	if s.endTime != nil {
+		r.setParam("endTime", *s.endTime)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*Kline{}, err
+	}
+	return parseKlines(data)
+}
+
+// PremiumIndexKlinesService list premium index klines, the volume fields of the klines are empty
+type PremiumIndexKlinesService struct {
+	c         *Client
+	symbol    string
+	interval  KlineInterval
+	limit     *int
+	startTime *int64
+	endTime   *int64
+}
+
+// Symbol set symbol
+func (s *PremiumIndexKlinesService) Symbol(symbol string) *PremiumIndexKlinesService {
+	s.symbol = symbol
+	return s
+}
+
+// Interval set interval
+func (s *PremiumIndexKlinesService) Interval(interval KlineInterval) *PremiumIndexKlinesService {
+	s.interval = interval
+	return s
+}
+
+// Limit set limit
+func (s *PremiumIndexKlinesService) Limit(limit int) *PremiumIndexKlinesService {
+	s.limit = &limit
+	return s
+}
+
+// StartTime set startTime
+func (s *PremiumIndexKlinesService) StartTime(startTime int64) *PremiumIndexKlinesService {
+	s.startTime = &startTime
+	return s
+}
+
+// EndTime set endTime
+func (s *PremiumIndexKlinesService) EndTime(endTime int64) *PremiumIndexKlinesService {
+	s.endTime = &endTime
+	return s
+}
+
+// Do send request
+func (s *PremiumIndexKlinesService) Do(ctx context.Context, opts ...RequestOption) (res []*Kline, err error) {
+	r := &request{
+		method:   "GET",
+		endpoint: "/fapi/v1/premiumIndexKlines",
+	}
+	r.setParam("symbol", s.symbol)
+	r.setParam("interval", s.interval)
+	if s.limit != nil {
+		r.setParam("limit", *s.limit)
+	}
+	if s.startTime != nil {
+		r.setParam("startTime", *s.startTime)
+	}
+	if s.endTime != nil {
+		r.setParam("endTime", *s.endTime)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*Kline{}, err
+	}
+	return parseKlines(data)
+}
@@ -0,0 +1,55 @@
+package futures
+
+import (
+	"testing"
+
+	"github.com/Zamzam-Technology/go-binance/v2/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func testFilterSymbol() *Symbol {
+	return &Symbol{
+		Symbol: "BTCUSDT",
+		Filters: []map[string]interface{}{
+			{"filterType": "PRICE_FILTER", "minPrice": "556.80", "maxPrice": "4529764", "tickSize": "0.10"},
+			{"filterType": "LOT_SIZE", "minQty": "0.001", "maxQty": "1000", "stepSize": "0.001"},
+			{"filterType": "MARKET_LOT_SIZE", "minQty": "0.001", "maxQty": "120", "stepSize": "0.001"},
+			{"filterType": "MIN_NOTIONAL", "notional": "5"},
+			{"filterType": "PERCENT_PRICE", "multiplierUp": "1.0500", "multiplierDown": "0.9500", "multiplierDecimal": float64(4)},
+		},
+	}
+}
+
+func TestCreateOrderServiceNormalize(t *testing.T) {
+	assert := assert.New(t)
+	s := NewClient("", "").NewCreateOrderService().Symbol("BTCUSDT").Side(SideTypeBuy).
+		Type(OrderTypeLimit).Price("30000.17").StopPrice("29000.05").Quantity("0.0129")
+	assert.NoError(s.Normalize(testFilterSymbol()))
+	assert.Equal("30000.1", *s.price)
+	assert.Equal("29000.1", *s.stopPrice)
+	assert.Equal("0.012", s.quantity)
+	assert.NoError(s.Validate(testFilterSymbol(), "30000"))
+}
+
+func TestCreateOrderServiceValidate(t *testing.T) {
+	assert := assert.New(t)
+	limit := NewClient("", "").NewCreateOrderService().Symbol("BTCUSDT").Side(SideTypeSell).
+		Type(OrderTypeLimit).Price("40000").Quantity("500")
+	assert.Equal([]common.OrderFilterViolation{
+		{Filter: "PERCENT_PRICE", Field: "price", Value: "40000", Limit: "31500", Reason: "is greater than"},
+	}, limit.Validate(testFilterSymbol(), "30000").(*common.OrderFilterError).Violations)
+
+	// LOT_SIZE does not apply to market orders
+	market := NewClient("", "").NewCreateOrderService().Symbol("BTCUSDT").Side(SideTypeSell).
+		Type(OrderTypeMarket).Quantity("500")
+	assert.Equal([]common.OrderFilterViolation{
+		{Filter: "MARKET_LOT_SIZE", Field: "quantity", Value: "500", Limit: "120", Reason: "is greater than"},
+	}, market.Validate(testFilterSymbol(), "30000").(*common.OrderFilterError).Violations)
+
+	small := market.Quantity("0.0001")
+	assert.Equal([]common.OrderFilterViolation{
+		{Filter: "MARKET_LOT_SIZE", Field: "quantity", Value: "0.0001", Limit: "0.001", Reason: "is lower than"},
+		{Filter: "MARKET_LOT_SIZE", Field: "quantity", Value: "0.0001", Limit: "0.001", Reason: "is not a multiple of the step size"},
+		{Filter: "MIN_NOTIONAL", Field: "notional", Value: "3", Limit: "5", Reason: "is lower than"},
+	}, small.Validate(testFilterSymbol(), "30000").(*common.OrderFilterError).Violations)
+}
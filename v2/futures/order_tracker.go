@@ -0,0 +1,89 @@
+package futures
+
+import (
+	"context"
+
+	"github.com/Zamzam-Technology/go-binance/v2/common"
+)
+
+// OrderTracker track the orders of the account from the order trade updates
+// of the user data stream, reconciled with the REST orders after a reconnect
+type OrderTracker struct {
+	*common.OrderTracker
+	c *Client
+}
+
+// ApplyOrderTradeUpdate apply an order trade update, see common.OrderTracker.Apply
+func (t *OrderTracker) ApplyOrderTradeUpdate(update *WsOrderTradeUpdate) (bool, error) {
+	u := &common.OrderUpdate{
+		Symbol:           update.Symbol,
+		OrderID:          update.ID,
+		ClientOrderID:    update.ClientOrderID,
+		Side:             string(update.Side),
+		Type:             string(update.Type),
+		Status:           common.OrderStatus(update.Status),
+		Price:            update.OriginalPrice,
+		Quantity:         update.OriginalQty,
+		ExecutedQuantity: update.AccumulatedFilledQty,
+		Time:             update.TradeTime,
+	}
+	if update.ExecutionType == OrderExecutionTypeTrade {
+		u.TradeID = update.TradeID
+		u.LastQuantity = update.LastFilledQty
+		u.LastPrice = update.LastFilledPrice
+		u.Commission = update.Commission
+		u.CommissionAsset = update.CommissionAsset
+	}
+	return t.Apply(u)
+}
+
+// ApplyOrder apply an order returned by the REST API, see common.OrderTracker.Apply
+func (t *OrderTracker) ApplyOrder(order *Order) (bool, error) {
+	return t.Apply(&common.OrderUpdate{
+		Symbol:           order.Symbol,
+		OrderID:          order.OrderID,
+		ClientOrderID:    order.ClientOrderID,
+		Side:             string(order.Side),
+		Type:             string(order.Type),
+		Status:           common.OrderStatus(order.Status),
+		Price:            order.Price,
+		Quantity:         order.OrigQuantity,
+		ExecutedQuantity: order.ExecutedQuantity,
+		CumulativeQuote:  order.CumQuote,
+		Time:             order.UpdateTime,
+	})
+}
+
+// Reconcile apply the open orders of symbol, or of all symbols when symbol is empty, then query
+// the tracked open orders missing from them, which were completed while the stream was down.
+// Stale orders rejected by the tracker are skipped.
+func (t *OrderTracker) Reconcile(ctx context.Context, symbol string, opts ...RequestOption) error {
+	s := t.c.NewListOpenOrdersService()
+	if symbol != "" {
+		s.Symbol(symbol)
+	}
+	orders, err := s.Do(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	open := make(map[string]bool, len(orders))
+	for _, order := range orders {
+		open[order.ClientOrderID] = true
+		if _, err := t.ApplyOrder(order); err != nil && !common.IsOrderTransitionError(err) {
+			return err
+		}
+	}
+	for _, state := range t.OpenOrders() {
+		if open[state.ClientOrderID] || (symbol != "" && state.Symbol != symbol) {
+			continue
+		}
+		order, err := t.c.NewGetOrderService().Symbol(state.Symbol).OrigClientOrderID(state.ClientOrderID).Do(ctx, opts...)
+		if err != nil {
+			return err
+		}
+		if _, err := t.ApplyOrder(order); err != nil && !common.IsOrderTransitionError(err) {
+			return err
+		}
+	}
+	return nil
+}
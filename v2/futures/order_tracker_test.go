@@ -0,0 +1,52 @@
+package futures
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/Zamzam-Technology/go-binance/v2/common"
+	"github.com/stretchr/testify/suite"
+)
+
+type orderTrackerTestSuite struct {
+	baseTestSuite
+}
+
+func TestOrderTracker(t *testing.T) {
+	suite.Run(t, new(orderTrackerTestSuite))
+}
+
+func (s *orderTrackerTestSuite) TestApplyAndReconcile() {
+	tracker := s.client.NewOrderTracker()
+	r := s.r()
+	changed, err := tracker.ApplyOrderTradeUpdate(&WsOrderTradeUpdate{Symbol: "BTCUSDT", ID: 7, ClientOrderID: "a",
+		Side: SideTypeSell, Type: OrderTypeLimit, OriginalQty: "0.002", OriginalPrice: "30000",
+		ExecutionType: OrderExecutionTypeTrade, Status: OrderStatusTypePartiallyFilled, TradeID: 3,
+		LastFilledQty: "0.001", LastFilledPrice: "30000", AccumulatedFilledQty: "0.001",
+		Commission: "0.012", CommissionAsset: "USDT", TradeTime: 100})
+	r.NoError(err)
+	r.True(changed)
+	state, _ := tracker.Order("a")
+	r.Equal("30", state.CumulativeQuote)
+
+	var paths []string
+	responses := [][]byte{
+		[]byte(`[]`),
+		[]byte(`{"symbol": "BTCUSDT", "orderId": 7, "clientOrderId": "a", "status": "FILLED",
+			"origQty": "0.002", "executedQty": "0.002", "cumQuote": "60.02", "updateTime": 200}`),
+	}
+	s.client.Client.do = func(req *http.Request) (*http.Response, error) {
+		data := responses[len(paths)]
+		paths = append(paths, req.URL.Path)
+		return newHTTPResponse(data, http.StatusOK), nil
+	}
+	r.NoError(tracker.Reconcile(newContext(), ""))
+	r.Equal([]string{"/fapi/v1/openOrders", "/fapi/v1/order"}, paths)
+
+	state, _ = tracker.Order("a")
+	r.Equal(common.OrderStatusFilled, state.Status)
+	r.Equal("0.002", state.ExecutedQuantity)
+	r.Equal("30010", state.AveragePrice())
+	r.Equal(map[string]string{"USDT": "0.012"}, state.Commissions)
+	r.Equal(int64(200), state.UpdateTime)
+}
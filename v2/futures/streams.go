@@ -0,0 +1,360 @@
+package futures
+
+import (
+	"time"
+
+	"github.com/Zamzam-Technology/go-binance/v2/common"
+)
+
+// Streams is the websocket surface of the package, implemented by DefaultStreams and FakeStreams
+type Streams interface {
+	WsAggTradeServe(symbol string, handler WsAggTradeHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+	WsMarkPriceServe(symbol string, handler WsMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+	WsMarkPriceServeWithRate(symbol string, rate time.Duration, handler WsMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+	WsAllMarkPriceServe(handler WsAllMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+	WsAllMarkPriceServeWithRate(rate time.Duration, handler WsAllMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+	WsKlineServe(symbol string, interval KlineInterval, handler WsKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+	WsCombinedKlineServe(symbolIntervalPair map[string]KlineInterval, handler WsKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+	WsContinuousKlineServe(pair string, contractType string, interval KlineInterval, handler WsContinuousKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+	WsCombinedContinuousKlineServe(subscribeArgsList []*WsContinuousKlineSubscribeArgs, handler WsContinuousKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+	WsMiniMarketTickerServe(symbol string, handler WsMiniMarketTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+	WsAllMiniMarketTickerServe(handler WsAllMiniMarketTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+	WsMarketTickerServe(symbol string, handler WsMarketTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+	WsAllMarketTickerServe(handler WsAllMarketTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+	WsBookTickerServe(symbol string, handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+	WsAllBookTickerServe(handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+	WsLiquidationOrderServe(symbol string, handler WsLiquidationOrderHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+	WsAllLiquidationOrderServe(handler WsLiquidationOrderHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+	WsPartialDepthServe(symbol string, levels int, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+	WsPartialDepthServeWithRate(symbol string, levels int, rate time.Duration, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+	WsDiffDepthServe(symbol string, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+	WsCombinedDepthServe(symbolLevels map[string]string, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+	WsDiffDepthServeWithRate(symbol string, rate time.Duration, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+	WsBLVTInfoServe(name string, handler WsBLVTInfoHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+	WsBLVTKlineServe(name string, interval KlineInterval, handler WsBLVTKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+	WsCompositiveIndexServe(symbol string, handler WsCompositeIndexHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+	WsContractInfoServe(handler WsContractInfoHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+	WsAssetIndexServe(symbol string, handler WsAssetIndexHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+	WsCombinedAssetIndexServe(symbols []string, handler WsAssetIndexHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+	WsAllAssetIndexServe(handler WsAllAssetIndexHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+	WsUserDataServe(listenKey string, handler WsUserDataHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+}
+
+// DefaultStreams serve the streams with the functions of the package
+type DefaultStreams struct{}
+
+var _ Streams = DefaultStreams{}
+
+// WsAggTradeServe call the WsAggTradeServe function of the package
+func (DefaultStreams) WsAggTradeServe(symbol string, handler WsAggTradeHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return WsAggTradeServe(symbol, handler, errHandler)
+}
+
+// WsMarkPriceServe call the WsMarkPriceServe function of the package
+func (DefaultStreams) WsMarkPriceServe(symbol string, handler WsMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return WsMarkPriceServe(symbol, handler, errHandler)
+}
+
+// WsMarkPriceServeWithRate call the WsMarkPriceServeWithRate function of the package
+func (DefaultStreams) WsMarkPriceServeWithRate(symbol string, rate time.Duration, handler WsMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return WsMarkPriceServeWithRate(symbol, rate, handler, errHandler)
+}
+
+// WsAllMarkPriceServe call the WsAllMarkPriceServe function of the package
+func (DefaultStreams) WsAllMarkPriceServe(handler WsAllMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return WsAllMarkPriceServe(handler, errHandler)
+}
+
+// WsAllMarkPriceServeWithRate call the WsAllMarkPriceServeWithRate function of the package
+func (DefaultStreams) WsAllMarkPriceServeWithRate(rate time.Duration, handler WsAllMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return WsAllMarkPriceServeWithRate(rate, handler, errHandler)
+}
+
+// WsKlineServe call the WsKlineServe function of the package
+func (DefaultStreams) WsKlineServe(symbol string, interval KlineInterval, handler WsKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return WsKlineServe(symbol, interval, handler, errHandler)
+}
+
+// WsCombinedKlineServe call the WsCombinedKlineServe function of the package
+func (DefaultStreams) WsCombinedKlineServe(symbolIntervalPair map[string]KlineInterval, handler WsKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return WsCombinedKlineServe(symbolIntervalPair, handler, errHandler)
+}
+
+// WsContinuousKlineServe call the WsContinuousKlineServe function of the package
+func (DefaultStreams) WsContinuousKlineServe(pair string, contractType string, interval KlineInterval, handler WsContinuousKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return WsContinuousKlineServe(pair, contractType, interval, handler, errHandler)
+}
+
+// WsCombinedContinuousKlineServe call the WsCombinedContinuousKlineServe function of the package
+func (DefaultStreams) WsCombinedContinuousKlineServe(subscribeArgsList []*WsContinuousKlineSubscribeArgs, handler WsContinuousKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return WsCombinedContinuousKlineServe(subscribeArgsList, handler, errHandler)
+}
+
+// WsMiniMarketTickerServe call the WsMiniMarketTickerServe function of the package
+func (DefaultStreams) WsMiniMarketTickerServe(symbol string, handler WsMiniMarketTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return WsMiniMarketTickerServe(symbol, handler, errHandler)
+}
+
+// WsAllMiniMarketTickerServe call the WsAllMiniMarketTickerServe function of the package
+func (DefaultStreams) WsAllMiniMarketTickerServe(handler WsAllMiniMarketTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return WsAllMiniMarketTickerServe(handler, errHandler)
+}
+
+// WsMarketTickerServe call the WsMarketTickerServe function of the package
+func (DefaultStreams) WsMarketTickerServe(symbol string, handler WsMarketTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return WsMarketTickerServe(symbol, handler, errHandler)
+}
+
+// WsAllMarketTickerServe call the WsAllMarketTickerServe function of the package
+func (DefaultStreams) WsAllMarketTickerServe(handler WsAllMarketTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return WsAllMarketTickerServe(handler, errHandler)
+}
+
+// WsBookTickerServe call the WsBookTickerServe function of the package
+func (DefaultStreams) WsBookTickerServe(symbol string, handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return WsBookTickerServe(symbol, handler, errHandler)
+}
+
+// WsAllBookTickerServe call the WsAllBookTickerServe function of the package
+func (DefaultStreams) WsAllBookTickerServe(handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return WsAllBookTickerServe(handler, errHandler)
+}
+
+// WsLiquidationOrderServe call the WsLiquidationOrderServe function of the package
+func (DefaultStreams) WsLiquidationOrderServe(symbol string, handler WsLiquidationOrderHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return WsLiquidationOrderServe(symbol, handler, errHandler)
+}
+
+// WsAllLiquidationOrderServe call the WsAllLiquidationOrderServe function of the package
+func (DefaultStreams) WsAllLiquidationOrderServe(handler WsLiquidationOrderHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return WsAllLiquidationOrderServe(handler, errHandler)
+}
+
+// WsPartialDepthServe call the WsPartialDepthServe function of the package
+func (DefaultStreams) WsPartialDepthServe(symbol string, levels int, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return WsPartialDepthServe(symbol, levels, handler, errHandler)
+}
+
+// WsPartialDepthServeWithRate call the WsPartialDepthServeWithRate function of the package
+func (DefaultStreams) WsPartialDepthServeWithRate(symbol string, levels int, rate time.Duration, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return WsPartialDepthServeWithRate(symbol, levels, rate, handler, errHandler)
+}
+
+// WsDiffDepthServe call the WsDiffDepthServe function of the package
+func (DefaultStreams) WsDiffDepthServe(symbol string, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return WsDiffDepthServe(symbol, handler, errHandler)
+}
+
+// WsCombinedDepthServe call the WsCombinedDepthServe function of the package
+func (DefaultStreams) WsCombinedDepthServe(symbolLevels map[string]string, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return WsCombinedDepthServe(symbolLevels, handler, errHandler)
+}
+
+// WsDiffDepthServeWithRate call the WsDiffDepthServeWithRate function of the package
+func (DefaultStreams) WsDiffDepthServeWithRate(symbol string, rate time.Duration, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return WsDiffDepthServeWithRate(symbol, rate, handler, errHandler)
+}
+
+// WsBLVTInfoServe call the WsBLVTInfoServe function of the package
+func (DefaultStreams) WsBLVTInfoServe(name string, handler WsBLVTInfoHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return WsBLVTInfoServe(name, handler, errHandler)
+}
+
+// WsBLVTKlineServe call the WsBLVTKlineServe function of the package
+func (DefaultStreams) WsBLVTKlineServe(name string, interval KlineInterval, handler WsBLVTKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return WsBLVTKlineServe(name, interval, handler, errHandler)
+}
+
+// WsCompositiveIndexServe call the WsCompositiveIndexServe function of the package
+func (DefaultStreams) WsCompositiveIndexServe(symbol string, handler WsCompositeIndexHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return WsCompositiveIndexServe(symbol, handler, errHandler)
+}
+
+// WsContractInfoServe call the WsContractInfoServe function of the package
+func (DefaultStreams) WsContractInfoServe(handler WsContractInfoHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return WsContractInfoServe(handler, errHandler)
+}
+
+// WsAssetIndexServe call the WsAssetIndexServe function of the package
+func (DefaultStreams) WsAssetIndexServe(symbol string, handler WsAssetIndexHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return WsAssetIndexServe(symbol, handler, errHandler)
+}
+
+// WsCombinedAssetIndexServe call the WsCombinedAssetIndexServe function of the package
+func (DefaultStreams) WsCombinedAssetIndexServe(symbols []string, handler WsAssetIndexHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return WsCombinedAssetIndexServe(symbols, handler, errHandler)
+}
+
+// WsAllAssetIndexServe call the WsAllAssetIndexServe function of the package
+func (DefaultStreams) WsAllAssetIndexServe(handler WsAllAssetIndexHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return WsAllAssetIndexServe(handler, errHandler)
+}
+
+// WsUserDataServe call the WsUserDataServe function of the package
+func (DefaultStreams) WsUserDataServe(listenKey string, handler WsUserDataHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return WsUserDataServe(listenKey, handler, errHandler)
+}
+
+// FakeStreams implement Streams for tests: the handlers of the served streams are kept, and
+// the events given to Send are delivered to the handlers of a method, e.g.
+// Send("WsKlineServe", event).
+type FakeStreams struct {
+	*common.StreamStubs
+}
+
+var _ Streams = (*FakeStreams)(nil)
+
+// NewFakeStreams init fake streams
+func NewFakeStreams() *FakeStreams {
+	return &FakeStreams{StreamStubs: common.NewStreamStubs()}
+}
+
+// WsAggTradeServe keep the handlers of the stream
+func (f *FakeStreams) WsAggTradeServe(symbol string, handler WsAggTradeHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return f.Serve("WsAggTradeServe", handler, errHandler)
+}
+
+// WsMarkPriceServe keep the handlers of the stream
+func (f *FakeStreams) WsMarkPriceServe(symbol string, handler WsMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return f.Serve("WsMarkPriceServe", handler, errHandler)
+}
+
+// WsMarkPriceServeWithRate keep the handlers of the stream
+func (f *FakeStreams) WsMarkPriceServeWithRate(symbol string, rate time.Duration, handler WsMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return f.Serve("WsMarkPriceServeWithRate", handler, errHandler)
+}
+
+// WsAllMarkPriceServe keep the handlers of the stream
+func (f *FakeStreams) WsAllMarkPriceServe(handler WsAllMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return f.Serve("WsAllMarkPriceServe", handler, errHandler)
+}
+
+// WsAllMarkPriceServeWithRate keep the handlers of the stream
+func (f *FakeStreams) WsAllMarkPriceServeWithRate(rate time.Duration, handler WsAllMarkPriceHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return f.Serve("WsAllMarkPriceServeWithRate", handler, errHandler)
+}
+
+// WsKlineServe keep the handlers of the stream
+func (f *FakeStreams) WsKlineServe(symbol string, interval KlineInterval, handler WsKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return f.Serve("WsKlineServe", handler, errHandler)
+}
+
+// WsCombinedKlineServe keep the handlers of the stream
+func (f *FakeStreams) WsCombinedKlineServe(symbolIntervalPair map[string]KlineInterval, handler WsKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return f.Serve("WsCombinedKlineServe", handler, errHandler)
+}
+
+// WsContinuousKlineServe keep the handlers of the stream
+func (f *FakeStreams) WsContinuousKlineServe(pair string, contractType string, interval KlineInterval, handler WsContinuousKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return f.Serve("WsContinuousKlineServe", handler, errHandler)
+}
+
+// WsCombinedContinuousKlineServe keep the handlers of the stream
+func (f *FakeStreams) WsCombinedContinuousKlineServe(subscribeArgsList []*WsContinuousKlineSubscribeArgs, handler WsContinuousKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return f.Serve("WsCombinedContinuousKlineServe", handler, errHandler)
+}
+
+// WsMiniMarketTickerServe keep the handlers of the stream
+func (f *FakeStreams) WsMiniMarketTickerServe(symbol string, handler WsMiniMarketTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return f.Serve("WsMiniMarketTickerServe", handler, errHandler)
+}
+
+// WsAllMiniMarketTickerServe keep the handlers of the stream
+func (f *FakeStreams) WsAllMiniMarketTickerServe(handler WsAllMiniMarketTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return f.Serve("WsAllMiniMarketTickerServe", handler, errHandler)
+}
+
+// WsMarketTickerServe keep the handlers of the stream
+func (f *FakeStreams) WsMarketTickerServe(symbol string, handler WsMarketTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return f.Serve("WsMarketTickerServe", handler, errHandler)
+}
+
+// WsAllMarketTickerServe keep the handlers of the stream
+func (f *FakeStreams) WsAllMarketTickerServe(handler WsAllMarketTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return f.Serve("WsAllMarketTickerServe", handler, errHandler)
+}
+
+// WsBookTickerServe keep the handlers of the stream
+func (f *FakeStreams) WsBookTickerServe(symbol string, handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return f.Serve("WsBookTickerServe", handler, errHandler)
+}
+
+// WsAllBookTickerServe keep the handlers of the stream
+func (f *FakeStreams) WsAllBookTickerServe(handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return f.Serve("WsAllBookTickerServe", handler, errHandler)
+}
+
+// WsLiquidationOrderServe keep the handlers of the stream
+func (f *FakeStreams) WsLiquidationOrderServe(symbol string, handler WsLiquidationOrderHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return f.Serve("WsLiquidationOrderServe", handler, errHandler)
+}
+
+// WsAllLiquidationOrderServe keep the handlers of the stream
+func (f *FakeStreams) WsAllLiquidationOrderServe(handler WsLiquidationOrderHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return f.Serve("WsAllLiquidationOrderServe", handler, errHandler)
+}
+
+// WsPartialDepthServe keep the handlers of the stream
+func (f *FakeStreams) WsPartialDepthServe(symbol string, levels int, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return f.Serve("WsPartialDepthServe", handler, errHandler)
+}
+
+// WsPartialDepthServeWithRate keep the handlers of the stream
+func (f *FakeStreams) WsPartialDepthServeWithRate(symbol string, levels int, rate time.Duration, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return f.Serve("WsPartialDepthServeWithRate", handler, errHandler)
+}
+
+// WsDiffDepthServe keep the handlers of the stream
+func (f *FakeStreams) WsDiffDepthServe(symbol string, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return f.Serve("WsDiffDepthServe", handler, errHandler)
+}
+
+// WsCombinedDepthServe keep the handlers of the stream
+func (f *FakeStreams) WsCombinedDepthServe(symbolLevels map[string]string, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return f.Serve("WsCombinedDepthServe", handler, errHandler)
+}
+
+// WsDiffDepthServeWithRate keep the handlers of the stream
+func (f *FakeStreams) WsDiffDepthServeWithRate(symbol string, rate time.Duration, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return f.Serve("WsDiffDepthServeWithRate", handler, errHandler)
+}
+
+// WsBLVTInfoServe keep the handlers of the stream
+func (f *FakeStreams) WsBLVTInfoServe(name string, handler WsBLVTInfoHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return f.Serve("WsBLVTInfoServe", handler, errHandler)
+}
+
+// WsBLVTKlineServe keep the handlers of the stream
+func (f *FakeStreams) WsBLVTKlineServe(name string, interval KlineInterval, handler WsBLVTKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return f.Serve("WsBLVTKlineServe", handler, errHandler)
+}
+
+// WsCompositiveIndexServe keep the handlers of the stream
+func (f *FakeStreams) WsCompositiveIndexServe(symbol string, handler WsCompositeIndexHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return f.Serve("WsCompositiveIndexServe", handler, errHandler)
+}
+
+// WsContractInfoServe keep the handlers of the stream
+func (f *FakeStreams) WsContractInfoServe(handler WsContractInfoHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return f.Serve("WsContractInfoServe", handler, errHandler)
+}
+
+// WsAssetIndexServe keep the handlers of the stream
+func (f *FakeStreams) WsAssetIndexServe(symbol string, handler WsAssetIndexHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return f.Serve("WsAssetIndexServe", handler, errHandler)
+}
+
+// WsCombinedAssetIndexServe keep the handlers of the stream
+func (f *FakeStreams) WsCombinedAssetIndexServe(symbols []string, handler WsAssetIndexHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return f.Serve("WsCombinedAssetIndexServe", handler, errHandler)
+}
+
+// WsAllAssetIndexServe keep the handlers of the stream
+func (f *FakeStreams) WsAllAssetIndexServe(handler WsAllAssetIndexHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return f.Serve("WsAllAssetIndexServe", handler, errHandler)
+}
+
+// WsUserDataServe keep the handlers of the stream
+func (f *FakeStreams) WsUserDataServe(listenKey string, handler WsUserDataHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return f.Serve("WsUserDataServe", handler, errHandler)
+}
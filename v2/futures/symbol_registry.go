@@ -0,0 +1,262 @@
+package futures
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Zamzam-Technology/go-binance/v2/common"
+)
+
+// SymbolEventType define the type of a symbol change detected by SymbolRegistry
+type SymbolEventType string
+
+// Global enums
+const (
+	SymbolEventTypeListed         SymbolEventType = "LISTED"
+	SymbolEventTypeHalted         SymbolEventType = "HALTED"
+	SymbolEventTypeStatusChanged  SymbolEventType = "STATUS_CHANGED"
+	SymbolEventTypeFiltersChanged SymbolEventType = "FILTERS_CHANGED"
+	SymbolEventTypeDelisted       SymbolEventType = "DELISTED"
+)
+
+// SymbolEvent define a change of a symbol between two refreshes of the exchange info.
+// Previous is nil for a listing and Current is nil for a delisting.
+type SymbolEvent struct {
+	Type     SymbolEventType
+	Symbol   string
+	Previous *Symbol
+	Current  *Symbol
+}
+
+// SymbolEventHandler handle symbol change events
+type SymbolEventHandler func(event *SymbolEvent)
+
+// SymbolRegistry keep a cached exchange info, refreshed when it is older than its TTL,
+// and index its symbols by name and by base and quote asset. It is safe for concurrent use.
+// The returned symbols are shared and must not be modified.
+type SymbolRegistry struct {
+	c       *Client
+	ttl     time.Duration
+	handler SymbolEventHandler
+	now     func() time.Time
+
+	refreshMu sync.Mutex
+	mu        sync.RWMutex
+	snapshot  *symbolSnapshot
+}
+
+type symbolSnapshot struct {
+	info      *ExchangeInfo
+	updatedAt time.Time
+	symbols   map[string]*Symbol
+	assets    map[string][]*Symbol
+	bases     map[string][]*Symbol
+	quotes    map[string][]*Symbol
+}
+
+func newSymbolSnapshot(info *ExchangeInfo, updatedAt time.Time) *symbolSnapshot {
+	s := &symbolSnapshot{
+		info:      info,
+		updatedAt: updatedAt,
+		symbols:   make(map[string]*Symbol, len(info.Symbols)),
+		assets:    make(map[string][]*Symbol),
+		bases:     make(map[string][]*Symbol),
+		quotes:    make(map[string][]*Symbol),
+	}
+	for i := range info.Symbols {
+		symbol := &info.Symbols[i]
+		s.symbols[symbol.Symbol] = symbol
+		key := symbol.BaseAsset + "/" + symbol.QuoteAsset
+		s.assets[key] = append(s.assets[key], symbol)
+		s.bases[symbol.BaseAsset] = append(s.bases[symbol.BaseAsset], symbol)
+		s.quotes[symbol.QuoteAsset] = append(s.quotes[symbol.QuoteAsset], symbol)
+	}
+	return s
+}
+
+// OnEvent set the handler called with the changes found by each refresh
+func (r *SymbolRegistry) OnEvent(handler SymbolEventHandler) *SymbolRegistry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.handler = handler
+	return r
+}
+
+// Refresh load the exchange info and emit the changes since the previous load
+func (r *SymbolRegistry) Refresh(ctx context.Context, opts ...RequestOption) error {
+	r.refreshMu.Lock()
+	events, err := r.refresh(ctx, opts...)
+	r.refreshMu.Unlock()
+	r.emit(events)
+	return err
+}
+
+// refresh load the exchange info and return the changes since the previous load. The caller
+// emits them once refreshMu is released, so that the handler may use the registry.
+func (r *SymbolRegistry) refresh(ctx context.Context, opts ...RequestOption) ([]*SymbolEvent, error) {
+	info, err := r.c.NewExchangeInfoService().Do(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	next := newSymbolSnapshot(info, r.now())
+
+	r.mu.Lock()
+	previous := r.snapshot
+	r.snapshot = next
+	r.mu.Unlock()
+
+	if previous == nil {
+		return nil, nil
+	}
+	return diffSymbols(previous.symbols, next.symbols), nil
+}
+
+func (r *SymbolRegistry) emit(events []*SymbolEvent) {
+	r.mu.RLock()
+	handler := r.handler
+	r.mu.RUnlock()
+	if handler == nil {
+		return
+	}
+	for _, event := range events {
+		handler(event)
+	}
+}
+
+// current return the snapshot, refreshing it first when it is missing or expired
+func (r *SymbolRegistry) current(ctx context.Context) (*symbolSnapshot, error) {
+	r.mu.RLock()
+	snapshot := r.snapshot
+	r.mu.RUnlock()
+	if snapshot != nil && (r.ttl <= 0 || r.now().Sub(snapshot.updatedAt) < r.ttl) {
+		return snapshot, nil
+	}
+
+	r.refreshMu.Lock()
+	// another caller may have refreshed while waiting for the lock
+	r.mu.RLock()
+	latest := r.snapshot
+	r.mu.RUnlock()
+	if latest != snapshot {
+		r.refreshMu.Unlock()
+		return latest, nil
+	}
+	events, err := r.refresh(ctx)
+	r.mu.RLock()
+	latest = r.snapshot
+	r.mu.RUnlock()
+	r.refreshMu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	r.emit(events)
+	return latest, nil
+}
+
+// ExchangeInfo return the cached exchange info
+func (r *SymbolRegistry) ExchangeInfo(ctx context.Context) (*ExchangeInfo, error) {
+	snapshot, err := r.current(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return snapshot.info, nil
+}
+
+// Symbol return the symbol with the given name, or common.ErrSymbolNotFound
+func (r *SymbolRegistry) Symbol(ctx context.Context, name string) (*Symbol, error) {
+	snapshot, err := r.current(ctx)
+	if err != nil {
+		return nil, err
+	}
+	symbol, ok := snapshot.symbols[name]
+	if !ok {
+		return nil, common.ErrSymbolNotFound
+	}
+	return symbol, nil
+}
+
+// SymbolsByAssets return the contracts trading base against quote, a perpetual and
+// quarterly contracts can share the same assets
+func (r *SymbolRegistry) SymbolsByAssets(ctx context.Context, base, quote string) ([]*Symbol, error) {
+	snapshot, err := r.current(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return snapshot.assets[base+"/"+quote], nil
+}
+
+// SymbolsByBaseAsset return the symbols whose base asset is base
+func (r *SymbolRegistry) SymbolsByBaseAsset(ctx context.Context, base string) ([]*Symbol, error) {
+	snapshot, err := r.current(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return snapshot.bases[base], nil
+}
+
+// SymbolsByQuoteAsset return the symbols whose quote asset is quote
+func (r *SymbolRegistry) SymbolsByQuoteAsset(ctx context.Context, quote string) ([]*Symbol, error) {
+	snapshot, err := r.current(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return snapshot.quotes[quote], nil
+}
+
+// Watch refresh the exchange info every TTL until ctx is done, so that change
+// events are emitted without waiting for a lookup
+func (r *SymbolRegistry) Watch(ctx context.Context, errHandler ErrHandler) {
+	if r.ttl <= 0 {
+		return
+	}
+	ticker := time.NewTicker(r.ttl)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if err := r.Refresh(ctx); err != nil && errHandler != nil {
+			errHandler(err)
+		}
+	}
+}
+
+// diffSymbols return the changes from previous to next, sorted by symbol
+func diffSymbols(previous, next map[string]*Symbol) []*SymbolEvent {
+	var events []*SymbolEvent
+	for name, current := range next {
+		old, ok := previous[name]
+		if !ok {
+			events = append(events, &SymbolEvent{Type: SymbolEventTypeListed, Symbol: name, Current: current})
+			continue
+		}
+		if old.Status != current.Status {
+			eventType := SymbolEventTypeStatusChanged
+			if isHaltedStatus(current.Status) {
+				eventType = SymbolEventTypeHalted
+			}
+			events = append(events, &SymbolEvent{Type: eventType, Symbol: name, Previous: old, Current: current})
+		}
+		if !reflect.DeepEqual(old.Filters, current.Filters) {
+			events = append(events, &SymbolEvent{Type: SymbolEventTypeFiltersChanged, Symbol: name, Previous: old, Current: current})
+		}
+	}
+	for name, old := range previous {
+		if _, ok := next[name]; !ok {
+			events = append(events, &SymbolEvent{Type: SymbolEventTypeDelisted, Symbol: name, Previous: old})
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Symbol < events[j].Symbol
+	})
+	return events
+}
+
+func isHaltedStatus(status string) bool {
+	return status == string(SymbolStatusTypeHalt) || status == string(SymbolStatusTypeBreak)
+}
@@ -0,0 +1,51 @@
+package futures
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type symbolRegistryTestSuite struct {
+	baseTestSuite
+}
+
+func TestSymbolRegistry(t *testing.T) {
+	suite.Run(t, new(symbolRegistryTestSuite))
+}
+
+func (s *symbolRegistryTestSuite) TestRefresh() {
+	responses := [][]byte{
+		[]byte(`{"symbols": [
+			{"symbol": "BTCUSDT", "pair": "BTCUSDT", "contractType": "PERPETUAL", "status": "TRADING", "baseAsset": "BTC", "quoteAsset": "USDT"},
+			{"symbol": "BTCUSDT_210625", "pair": "BTCUSDT", "contractType": "CURRENT_QUARTER", "status": "TRADING", "baseAsset": "BTC", "quoteAsset": "USDT"}
+		]}`),
+		[]byte(`{"symbols": [
+			{"symbol": "BTCUSDT", "pair": "BTCUSDT", "contractType": "PERPETUAL", "status": "TRADING", "baseAsset": "BTC", "quoteAsset": "USDT"},
+			{"symbol": "BTCUSDT_210625", "pair": "BTCUSDT", "contractType": "CURRENT_QUARTER", "status": "SETTLING", "baseAsset": "BTC", "quoteAsset": "USDT"}
+		]}`),
+	}
+	calls := 0
+	s.client.Client.do = func(req *http.Request) (*http.Response, error) {
+		data := responses[calls]
+		calls++
+		return newHTTPResponse(data, http.StatusOK), nil
+	}
+	var events []*SymbolEvent
+	registry := s.client.NewSymbolRegistry(time.Hour).OnEvent(func(event *SymbolEvent) {
+		events = append(events, event)
+	})
+	r := s.r()
+
+	symbols, err := registry.SymbolsByAssets(newContext(), "BTC", "USDT")
+	r.NoError(err)
+	r.Len(symbols, 2)
+
+	r.NoError(registry.Refresh(newContext()))
+	r.Len(events, 1)
+	r.Equal(SymbolEventTypeStatusChanged, events[0].Type)
+	r.Equal("BTCUSDT_210625", events[0].Symbol)
+	r.Equal("SETTLING", events[0].Current.Status)
+}
@@ -1,8 +1,10 @@
 package futures
 
 import (
+	"sync"
 	"time"
 
+	"github.com/Zamzam-Technology/go-binance/v2/common"
 	"github.com/gorilla/websocket"
 )
 
@@ -30,6 +32,12 @@ var wsServe = func(cfg *WsConfig, handler WsHandler, errHandler ErrHandler) (don
 	}
 	doneC = make(chan struct{})
 	stopC = make(chan struct{})
+	conn := &wsConn{conn: c}
+	watchdog := WebsocketWatchdog
+	var monitor *common.WsStreamMonitor
+	if watchdog != nil {
+		monitor = common.NewWsStreamMonitor(cfg.Endpoint, watchdog)
+	}
 	go func() {
 		// This function will exit either on error from
 		// websocket.Conn.ReadMessage or when the stopC channel is
@@ -41,22 +49,51 @@ var wsServe = func(cfg *WsConfig, handler WsHandler, errHandler ErrHandler) (don
 		// Wait for the stopC channel to be closed.  We do that in a
 		// separate goroutine because ReadMessage is a blocking
 		// operation.
-		silent := false
 		go func() {
 			select {
 			case <-stopC:
-				silent = true
+				conn.stop()
 			case <-doneC:
 			}
-			c.Close()
+			conn.close()
 		}()
+		if monitor != nil {
+			wsMonitors.Add(monitor)
+			defer wsMonitors.Remove(monitor)
+			go monitor.Watch(doneC, func(stale []common.WsStreamStats) {
+				if watchdog.Reconnect {
+					conn.forceReconnect()
+				}
+			})
+		}
 		for {
-			_, message, err := c.ReadMessage()
+			_, message, err := conn.current().ReadMessage()
 			if err != nil {
-				if !silent {
+				if conn.isStopped() {
+					return
+				}
+				if !conn.takeReconnect() {
 					errHandler(err)
+					return
 				}
-				return
+				// The watchdog closed a stale connection, dial the endpoint again.
+				c, _, err := websocket.DefaultDialer.Dial(cfg.Endpoint, nil)
+				if err != nil {
+					errHandler(err)
+					return
+				}
+				if !conn.replace(c) {
+					c.Close()
+					return
+				}
+				monitor.Reconnected()
+				if WebsocketKeepalive {
+					keepAlive(c, WebsocketTimeout)
+				}
+				continue
+			}
+			if monitor != nil {
+				monitor.Observe(message)
 			}
 			handler(message)
 		}
@@ -64,6 +101,75 @@ var wsServe = func(cfg *WsConfig, handler WsHandler, errHandler ErrHandler) (don
 	return
 }
 
+// wsConn hold the connection of a stream, which is replaced when the watchdog forces a reconnection
+type wsConn struct {
+	mu           sync.Mutex
+	conn         *websocket.Conn
+	stopped      bool
+	reconnecting bool
+}
+
+func (c *wsConn) current() *websocket.Conn {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.conn
+}
+
+func (c *wsConn) stop() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.stopped = true
+}
+
+func (c *wsConn) isStopped() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stopped
+}
+
+func (c *wsConn) close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.conn.Close()
+}
+
+// forceReconnect close the connection so that the read loop dials the endpoint again
+func (c *wsConn) forceReconnect() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.stopped || c.reconnecting {
+		return
+	}
+	c.reconnecting = true
+	c.conn.Close()
+}
+
+func (c *wsConn) takeReconnect() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	reconnect := c.reconnecting && !c.stopped
+	c.reconnecting = false
+	return reconnect
+}
+
+func (c *wsConn) replace(conn *websocket.Conn) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.stopped {
+		return false
+	}
+	c.conn = conn
+	return true
+}
+
+// wsMonitors keep the monitors of the streams served while WebsocketWatchdog is set
+var wsMonitors = common.NewWsMonitorRegistry()
+
+// WsStreamsStats return the health metrics of the running streams monitored by WebsocketWatchdog
+func WsStreamsStats() []common.WsStreamStats {
+	return wsMonitors.Stats()
+}
+
 func keepAlive(c *websocket.Conn, timeout time.Duration) {
 	ticker := time.NewTicker(timeout)
 
@@ -1,11 +1,12 @@
 package futures
 
 import (
-	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
 	"time"
+
+	"github.com/Zamzam-Technology/go-binance/v2/common"
 )
 
 // Endpoints
@@ -21,12 +22,20 @@ var (
 	WebsocketTimeout = time.Second * 60
 	// WebsocketKeepalive enables sending ping/pong messages to check the connection stability
 	WebsocketKeepalive = false
+	// WebsocketWatchdog enables the stale-stream watchdog and the per-stream metrics returned by WsStreamsStats
+	WebsocketWatchdog *common.WsWatchdog
+	// WebsocketBaseURL replace the base endpoint of the WS streams when set, e.g. "ws://127.0.0.1:8080"
+	// for a local server, which then serves the streams under /ws and /stream
+	WebsocketBaseURL string
 	// UseTestnet switch all the WS streams from production to the testnet
 	UseTestnet = false
 )
 
 // getWsEndpoint return the base endpoint of the WS according the UseTestnet flag
 func getWsEndpoint() string {
+	if WebsocketBaseURL != "" {
+		return WebsocketBaseURL + "/ws"
+	}
 	if UseTestnet {
 		return baseWsTestnetUrl
 	}
@@ -35,6 +44,9 @@ func getWsEndpoint() string {
 
 // getCombinedEndpoint return the base endpoint of the combined stream according the UseTestnet flag
 func getCombinedEndpoint() string {
+	if WebsocketBaseURL != "" {
+		return WebsocketBaseURL + "/stream?streams="
+	}
 	if UseTestnet {
 		return baseCombinedTestnetURL
 	}
@@ -64,7 +76,7 @@ func WsAggTradeServe(symbol string, handler WsAggTradeHandler, errHandler ErrHan
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsAggTradeEvent)
-		err := json.Unmarshal(message, &event)
+		err := common.UnmarshalWsMessage(message, &event)
 		if err != nil {
 			errHandler(err)
 			return
@@ -93,7 +105,7 @@ func wsMarkPriceServe(endpoint string, handler WsMarkPriceHandler, errHandler Er
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsMarkPriceEvent)
-		err := json.Unmarshal(message, &event)
+		err := common.UnmarshalWsMessage(message, &event)
 		if err != nil {
 			errHandler(err)
 			return
@@ -134,7 +146,7 @@ func wsAllMarkPriceServe(endpoint string, handler WsAllMarkPriceHandler, errHand
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		var event WsAllMarkPriceEvent
-		err := json.Unmarshal(message, &event)
+		err := common.UnmarshalWsMessage(message, &event)
 		if err != nil {
 			errHandler(err)
 			return
@@ -197,12 +209,12 @@ type WsKline struct {
 type WsKlineHandler func(event *WsKlineEvent)
 
 // WsKlineServe serve websocket kline handler with a symbol and interval like 15m, 30s
-func WsKlineServe(symbol string, interval string, handler WsKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+func WsKlineServe(symbol string, interval KlineInterval, handler WsKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
 	endpoint := fmt.Sprintf("%s/%s@kline_%s", getWsEndpoint(), strings.ToLower(symbol), interval)
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsKlineEvent)
-		err := json.Unmarshal(message, event)
+		err := common.UnmarshalWsMessage(message, event)
 		if err != nil {
 			errHandler(err)
 			return
@@ -213,7 +225,7 @@ func WsKlineServe(symbol string, interval string, handler WsKlineHandler, errHan
 }
 
 // WsCombinedKlineServe is similar to WsKlineServe, but it handles multiple symbols with it interval
-func WsCombinedKlineServe(symbolIntervalPair map[string]string, handler WsKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+func WsCombinedKlineServe(symbolIntervalPair map[string]KlineInterval, handler WsKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
 	endpoint := getCombinedEndpoint()
 	for symbol, interval := range symbolIntervalPair {
 		endpoint += fmt.Sprintf("%s@kline_%s", strings.ToLower(symbol), interval) + "/"
@@ -221,27 +233,88 @@ func WsCombinedKlineServe(symbolIntervalPair map[string]string, handler WsKlineH
 	endpoint = endpoint[:len(endpoint)-1]
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
-		j, err := newJSON(message)
+		event := new(WsKlineEvent)
+		stream, err := common.UnmarshalCombinedWsMessage(message, event)
 		if err != nil {
 			errHandler(err)
 			return
 		}
+		event.Symbol = common.StreamSymbol(stream)
 
-		stream := j.Get("stream").MustString()
-		data := j.Get("data").MustMap()
+		handler(event)
+	}
+	return wsServe(cfg, wsHandler, errHandler)
+}
 
-		symbol := strings.Split(stream, "@")[0]
+// WsContinuousKlineEvent define websocket continuous kline event
+type WsContinuousKlineEvent struct {
+	Event        string            `json:"e"`
+	Time         int64             `json:"E"`
+	Pair         string            `json:"ps"`
+	ContractType ContractType      `json:"ct"`
+	Kline        WsContinuousKline `json:"k"`
+}
 
-		jsonData, _ := json.Marshal(data)
+// WsContinuousKline define websocket continuous kline
+type WsContinuousKline struct {
+	StartTime            int64  `json:"t"`
+	EndTime              int64  `json:"T"`
+	Interval             string `json:"i"`
+	FirstTradeID         int64  `json:"f"`
+	LastTradeID          int64  `json:"L"`
+	Open                 string `json:"o"`
+	Close                string `json:"c"`
+	High                 string `json:"h"`
+	Low                  string `json:"l"`
+	Volume               string `json:"v"`
+	TradeNum             int64  `json:"n"`
+	IsFinal              bool   `json:"x"`
+	QuoteVolume          string `json:"q"`
+	ActiveBuyVolume      string `json:"V"`
+	ActiveBuyQuoteVolume string `json:"Q"`
+}
 
-		event := new(WsKlineEvent)
-		err = json.Unmarshal(jsonData, event)
+// WsContinuousKlineHandler handle websocket continuous kline event
+type WsContinuousKlineHandler func(event *WsContinuousKlineEvent)
+
+// WsContinuousKlineServe serve websocket kline handler with a pair, a contract type and interval like 15m, 30s
+func WsContinuousKlineServe(pair string, contractType string, interval KlineInterval, handler WsContinuousKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s_%s@continuousKline_%s", getWsEndpoint(), strings.ToLower(pair), strings.ToLower(contractType), interval)
+	cfg := newWsConfig(endpoint)
+	wsHandler := func(message []byte) {
+		event := new(WsContinuousKlineEvent)
+		err := common.UnmarshalWsMessage(message, event)
 		if err != nil {
 			errHandler(err)
 			return
 		}
-		event.Symbol = strings.ToUpper(symbol)
+		handler(event)
+	}
+	return wsServe(cfg, wsHandler, errHandler)
+}
 
+// WsContinuousKlineSubscribeArgs define the pair, contract type and interval of a continuous kline stream
+type WsContinuousKlineSubscribeArgs struct {
+	Pair         string
+	ContractType string
+	Interval     KlineInterval
+}
+
+// WsCombinedContinuousKlineServe is similar to WsContinuousKlineServe, but it handles multiple pairs and contract types
+func WsCombinedContinuousKlineServe(subscribeArgsList []*WsContinuousKlineSubscribeArgs, handler WsContinuousKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := getCombinedEndpoint()
+	for _, val := range subscribeArgsList {
+		endpoint += fmt.Sprintf("%s_%s@continuousKline_%s", strings.ToLower(val.Pair), strings.ToLower(val.ContractType), val.Interval) + "/"
+	}
+	endpoint = endpoint[:len(endpoint)-1]
+	cfg := newWsConfig(endpoint)
+	wsHandler := func(message []byte) {
+		event := new(WsContinuousKlineEvent)
+		_, err := common.UnmarshalCombinedWsMessage(message, event)
+		if err != nil {
+			errHandler(err)
+			return
+		}
 		handler(event)
 	}
 	return wsServe(cfg, wsHandler, errHandler)
@@ -269,7 +342,7 @@ func WsMiniMarketTickerServe(symbol string, handler WsMiniMarketTickerHandler, e
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsMiniMarketTickerEvent)
-		err := json.Unmarshal(message, &event)
+		err := common.UnmarshalWsMessage(message, &event)
 		if err != nil {
 			errHandler(err)
 			return
@@ -291,7 +364,7 @@ func WsAllMiniMarketTickerServe(handler WsAllMiniMarketTickerHandler, errHandler
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		var event WsAllMiniMarketTickerEvent
-		err := json.Unmarshal(message, &event)
+		err := common.UnmarshalWsMessage(message, &event)
 		if err != nil {
 			errHandler(err)
 			return
@@ -332,7 +405,7 @@ func WsMarketTickerServe(symbol string, handler WsMarketTickerHandler, errHandle
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsMarketTickerEvent)
-		err := json.Unmarshal(message, &event)
+		err := common.UnmarshalWsMessage(message, &event)
 		if err != nil {
 			errHandler(err)
 			return
@@ -354,7 +427,7 @@ func WsAllMarketTickerServe(handler WsAllMarketTickerHandler, errHandler ErrHand
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		var event WsAllMarketTickerEvent
-		err := json.Unmarshal(message, &event)
+		err := common.UnmarshalWsMessage(message, &event)
 		if err != nil {
 			errHandler(err)
 			return
@@ -386,7 +459,7 @@ func WsBookTickerServe(symbol string, handler WsBookTickerHandler, errHandler Er
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsBookTickerEvent)
-		err := json.Unmarshal(message, &event)
+		err := common.UnmarshalWsMessage(message, &event)
 		if err != nil {
 			errHandler(err)
 			return
@@ -402,7 +475,7 @@ func WsAllBookTickerServe(handler WsBookTickerHandler, errHandler ErrHandler) (d
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsBookTickerEvent)
-		err := json.Unmarshal(message, &event)
+		err := common.UnmarshalWsMessage(message, &event)
 		if err != nil {
 			errHandler(err)
 			return
@@ -443,7 +516,7 @@ func WsLiquidationOrderServe(symbol string, handler WsLiquidationOrderHandler, e
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsLiquidationOrderEvent)
-		err := json.Unmarshal(message, &event)
+		err := common.UnmarshalWsMessage(message, &event)
 		if err != nil {
 			errHandler(err)
 			return
@@ -459,7 +532,7 @@ func WsAllLiquidationOrderServe(handler WsLiquidationOrderHandler, errHandler Er
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsLiquidationOrderEvent)
-		err := json.Unmarshal(message, &event)
+		err := common.UnmarshalWsMessage(message, &event)
 		if err != nil {
 			errHandler(err)
 			return
@@ -517,38 +590,12 @@ func WsCombinedDepthServe(symbolLevels map[string]string, handler WsDepthHandler
 	endpoint = endpoint[:len(endpoint)-1]
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
-		j, err := newJSON(message)
+		event := new(WsDepthEvent)
+		_, err := common.UnmarshalCombinedWsMessage(message, event)
 		if err != nil {
 			errHandler(err)
 			return
 		}
-		event := new(WsDepthEvent)
-		data := j.Get("data").MustMap()
-		event.Event = data["e"].(string)
-		event.Time, _ = data["E"].(json.Number).Int64()
-		event.TransactionTime, _ = data["T"].(json.Number).Int64()
-		event.Symbol = data["s"].(string)
-		event.FirstUpdateID, _ = data["U"].(json.Number).Int64()
-		event.LastUpdateID, _ = data["u"].(json.Number).Int64()
-		event.PrevLastUpdateID, _ = data["pu"].(json.Number).Int64()
-		bidsLen := len(data["b"].([]interface{}))
-		event.Bids = make([]Bid, bidsLen)
-		for i := 0; i < bidsLen; i++ {
-			item := data["b"].([]interface{})[i].([]interface{})
-			event.Bids[i] = Bid{
-				Price:    item[0].(string),
-				Quantity: item[1].(string),
-			}
-		}
-		asksLen := len(data["a"].([]interface{}))
-		event.Asks = make([]Ask, asksLen)
-		for i := 0; i < asksLen; i++ {
-			item := data["a"].([]interface{})[i].([]interface{})
-			event.Asks[i] = Ask{
-				Price:    item[0].(string),
-				Quantity: item[1].(string),
-			}
-		}
 		handler(event)
 	}
 	return wsServe(cfg, wsHandler, errHandler)
@@ -576,37 +623,12 @@ func wsDepthServe(symbol string, levels string, rate *time.Duration, handler WsD
 	endpoint := fmt.Sprintf("%s/%s@depth%s%s", getWsEndpoint(), strings.ToLower(symbol), levels, rateStr)
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
-		j, err := newJSON(message)
+		event := new(WsDepthEvent)
+		err := common.UnmarshalWsMessage(message, event)
 		if err != nil {
 			errHandler(err)
 			return
 		}
-		event := new(WsDepthEvent)
-		event.Event = j.Get("e").MustString()
-		event.Time = j.Get("E").MustInt64()
-		event.TransactionTime = j.Get("T").MustInt64()
-		event.Symbol = j.Get("s").MustString()
-		event.FirstUpdateID = j.Get("U").MustInt64()
-		event.LastUpdateID = j.Get("u").MustInt64()
-		event.PrevLastUpdateID = j.Get("pu").MustInt64()
-		bidsLen := len(j.Get("b").MustArray())
-		event.Bids = make([]Bid, bidsLen)
-		for i := 0; i < bidsLen; i++ {
-			item := j.Get("b").GetIndex(i)
-			event.Bids[i] = Bid{
-				Price:    item.GetIndex(0).MustString(),
-				Quantity: item.GetIndex(1).MustString(),
-			}
-		}
-		asksLen := len(j.Get("a").MustArray())
-		event.Asks = make([]Ask, asksLen)
-		for i := 0; i < asksLen; i++ {
-			item := j.Get("a").GetIndex(i)
-			event.Asks[i] = Ask{
-				Price:    item.GetIndex(0).MustString(),
-				Quantity: item.GetIndex(1).MustString(),
-			}
-		}
 		handler(event)
 	}
 	return wsServe(cfg, wsHandler, errHandler)
@@ -640,7 +662,7 @@ func WsBLVTInfoServe(name string, handler WsBLVTInfoHandler, errHandler ErrHandl
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsBLVTInfoEvent)
-		err := json.Unmarshal(message, &event)
+		err := common.UnmarshalWsMessage(message, &event)
 		if err != nil {
 			errHandler(err)
 			return
@@ -678,12 +700,12 @@ type WsBLVTKline struct {
 type WsBLVTKlineHandler func(event *WsBLVTKlineEvent)
 
 // WsBLVTKlineServe serve BLVT kline stream
-func WsBLVTKlineServe(name string, interval string, handler WsBLVTKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+func WsBLVTKlineServe(name string, interval KlineInterval, handler WsBLVTKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
 	endpoint := fmt.Sprintf("%s/%s@nav_Kline_%s", getWsEndpoint(), strings.ToUpper(name), interval)
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsBLVTKlineEvent)
-		err := json.Unmarshal(message, event)
+		err := common.UnmarshalWsMessage(message, event)
 		if err != nil {
 			errHandler(err)
 			return
@@ -718,7 +740,127 @@ func WsCompositiveIndexServe(symbol string, handler WsCompositeIndexHandler, err
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsCompositeIndexEvent)
-		err := json.Unmarshal(message, event)
+		err := common.UnmarshalWsMessage(message, event)
+		if err != nil {
+			errHandler(err)
+			return
+		}
+		handler(event)
+	}
+	return wsServe(cfg, wsHandler, errHandler)
+}
+
+// WsContractInfoEvent define websocket contract info event
+type WsContractInfoEvent struct {
+	Event        string             `json:"e"`
+	Time         int64              `json:"E"`
+	Symbol       string             `json:"s"`
+	Pair         string             `json:"ps"`
+	ContractType ContractType       `json:"ct"`
+	DeliveryDate int64              `json:"dt"`
+	OnboardDate  int64              `json:"ot"`
+	Status       SymbolStatusType   `json:"cs"`
+	Brackets     []WsBracketSetting `json:"bks"`
+}
+
+// WsBracketSetting define websocket contract info leverage bracket
+type WsBracketSetting struct {
+	Bracket          int64   `json:"bs"`
+	NotionalFloor    float64 `json:"bnf"`
+	NotionalCap      float64 `json:"bnc"`
+	MaintMarginRatio float64 `json:"mmr"`
+	Cum              float64 `json:"cf"`
+	MinLeverage      int64   `json:"mi"`
+	MaxLeverage      int64   `json:"ma"`
+}
+
+// WsContractInfoHandler handle websocket contract info event
+type WsContractInfoHandler func(event *WsContractInfoEvent)
+
+// WsContractInfoServe serve websocket that pushes contract info updates, such as listing, settlement and bracket changes
+func WsContractInfoServe(handler WsContractInfoHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/!contractInfo", getWsEndpoint())
+	cfg := newWsConfig(endpoint)
+	wsHandler := func(message []byte) {
+		event := new(WsContractInfoEvent)
+		err := common.UnmarshalWsMessage(message, event)
+		if err != nil {
+			errHandler(err)
+			return
+		}
+		handler(event)
+	}
+	return wsServe(cfg, wsHandler, errHandler)
+}
+
+// WsAssetIndexEvent define websocket multi-assets mode asset index event
+type WsAssetIndexEvent struct {
+	Event                 string `json:"e"`
+	Time                  int64  `json:"E"`
+	Symbol                string `json:"s"`
+	Index                 string `json:"i"`
+	BidBuffer             string `json:"b"`
+	AskBuffer             string `json:"a"`
+	BidRate               string `json:"B"`
+	AskRate               string `json:"A"`
+	AutoExchangeBidBuffer string `json:"q"`
+	AutoExchangeAskBuffer string `json:"g"`
+	AutoExchangeBidRate   string `json:"Q"`
+	AutoExchangeAskRate   string `json:"G"`
+}
+
+// WsAssetIndexHandler handle websocket that pushes the asset index for a single asset
+type WsAssetIndexHandler func(event *WsAssetIndexEvent)
+
+// WsAssetIndexServe serve websocket that pushes the multi-assets mode asset index for a single asset, like ADAUSD
+func WsAssetIndexServe(symbol string, handler WsAssetIndexHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/%s@assetIndex", getWsEndpoint(), strings.ToLower(symbol))
+	cfg := newWsConfig(endpoint)
+	wsHandler := func(message []byte) {
+		event := new(WsAssetIndexEvent)
+		err := common.UnmarshalWsMessage(message, event)
+		if err != nil {
+			errHandler(err)
+			return
+		}
+		handler(event)
+	}
+	return wsServe(cfg, wsHandler, errHandler)
+}
+
+// WsCombinedAssetIndexServe is similar to WsAssetIndexServe, but it handles multiple assets
+func WsCombinedAssetIndexServe(symbols []string, handler WsAssetIndexHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := getCombinedEndpoint()
+	for _, s := range symbols {
+		endpoint += fmt.Sprintf("%s@assetIndex", strings.ToLower(s)) + "/"
+	}
+	endpoint = endpoint[:len(endpoint)-1]
+	cfg := newWsConfig(endpoint)
+	wsHandler := func(message []byte) {
+		event := new(WsAssetIndexEvent)
+		_, err := common.UnmarshalCombinedWsMessage(message, event)
+		if err != nil {
+			errHandler(err)
+			return
+		}
+		handler(event)
+	}
+	return wsServe(cfg, wsHandler, errHandler)
+}
+
+// WsAllAssetIndexEvent define an array of websocket asset index events.
+type WsAllAssetIndexEvent []*WsAssetIndexEvent
+
+// WsAllAssetIndexHandler handle websocket that pushes the asset index for all assets
+type WsAllAssetIndexHandler func(event WsAllAssetIndexEvent)
+
+// WsAllAssetIndexServe serve websocket that pushes the multi-assets mode asset index for all assets
+func WsAllAssetIndexServe(handler WsAllAssetIndexHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	endpoint := fmt.Sprintf("%s/!assetIndex@arr", getWsEndpoint())
+	cfg := newWsConfig(endpoint)
+	wsHandler := func(message []byte) {
+		var event WsAllAssetIndexEvent
+		err := common.UnmarshalWsMessage(message, &event)
 		if err != nil {
 			errHandler(err)
 			return
@@ -817,7 +959,7 @@ func WsUserDataServe(listenKey string, handler WsUserDataHandler, errHandler Err
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsUserDataEvent)
-		err := json.Unmarshal(message, event)
+		err := common.UnmarshalWsMessage(message, event)
 		if err != nil {
 			errHandler(err)
 			return
@@ -386,7 +386,7 @@ func (s *websocketServiceTestSuite) TestWsCombinedKlineServe() {
 	s.mockWsServe(data, errors.New(fakeErrMsg))
 	defer s.assertWsServe()
 
-	input := map[string]string{
+	input := map[string]KlineInterval{
 		"ETHBTC": "1m",
 	}
 	doneC, stopC, err := WsCombinedKlineServe(input, func(event *WsKlineEvent) {
@@ -422,6 +422,140 @@ func (s *websocketServiceTestSuite) TestWsCombinedKlineServe() {
 	<-doneC
 }
 
+func (s *websocketServiceTestSuite) TestContinuousKlineServe() {
+	data := []byte(`{
+		"e": "continuous_kline",
+		"E": 1607443058651,
+		"ps": "BTCUSDT",
+		"ct": "PERPETUAL",
+		"k": {
+		  "t": 1607443020000,
+		  "T": 1607443079999,
+		  "i": "1m",
+		  "f": 116467658886,
+		  "L": 116468012423,
+		  "o": "18787.00",
+		  "c": "18804.04",
+		  "h": "18804.04",
+		  "l": "18786.54",
+		  "v": "197.664",
+		  "n": 543,
+		  "x": false,
+		  "q": "3715253.19494",
+		  "V": "184.769",
+		  "Q": "3472925.84746",
+		  "B": "0"
+		}
+	  }`)
+	fakeErrMsg := "fake error"
+	s.mockWsServe(data, errors.New(fakeErrMsg))
+	defer s.assertWsServe()
+
+	doneC, stopC, err := WsContinuousKlineServe("BTCUSDT", "PERPETUAL", "1m", func(event *WsContinuousKlineEvent) {
+		s.assertWsContinuousKlineEventEqual(s.expectedContinuousKlineEvent(), event)
+	}, func(err error) {
+		s.r().EqualError(err, fakeErrMsg)
+	})
+	s.r().NoError(err)
+	stopC <- struct{}{}
+	<-doneC
+}
+
+func (s *websocketServiceTestSuite) TestWsCombinedContinuousKlineServe() {
+	data := []byte(`{
+	"stream":"btcusdt_perpetual@continuousKline_1m",
+	"data": {
+		"e": "continuous_kline",
+		"E": 1607443058651,
+		"ps": "BTCUSDT",
+		"ct": "PERPETUAL",
+		"k": {
+		  "t": 1607443020000,
+		  "T": 1607443079999,
+		  "i": "1m",
+		  "f": 116467658886,
+		  "L": 116468012423,
+		  "o": "18787.00",
+		  "c": "18804.04",
+		  "h": "18804.04",
+		  "l": "18786.54",
+		  "v": "197.664",
+		  "n": 543,
+		  "x": false,
+		  "q": "3715253.19494",
+		  "V": "184.769",
+		  "Q": "3472925.84746",
+		  "B": "0"
+		}
+	}}`)
+	fakeErrMsg := "fake error"
+	s.mockWsServe(data, errors.New(fakeErrMsg))
+	defer s.assertWsServe()
+
+	input := []*WsContinuousKlineSubscribeArgs{
+		{Pair: "BTCUSDT", ContractType: "PERPETUAL", Interval: "1m"},
+		{Pair: "BTCUSDT", ContractType: "CURRENT_QUARTER", Interval: "1m"},
+	}
+	doneC, stopC, err := WsCombinedContinuousKlineServe(input, func(event *WsContinuousKlineEvent) {
+		s.assertWsContinuousKlineEventEqual(s.expectedContinuousKlineEvent(), event)
+	}, func(err error) {
+		s.r().EqualError(err, fakeErrMsg)
+	})
+	s.r().NoError(err)
+	stopC <- struct{}{}
+	<-doneC
+}
+
+func (s *websocketServiceTestSuite) expectedContinuousKlineEvent() *WsContinuousKlineEvent {
+	return &WsContinuousKlineEvent{
+		Event:        "continuous_kline",
+		Time:         1607443058651,
+		Pair:         "BTCUSDT",
+		ContractType: ContractTypePerpetual,
+		Kline: WsContinuousKline{
+			StartTime:            1607443020000,
+			EndTime:              1607443079999,
+			Interval:             "1m",
+			FirstTradeID:         116467658886,
+			LastTradeID:          116468012423,
+			Open:                 "18787.00",
+			Close:                "18804.04",
+			High:                 "18804.04",
+			Low:                  "18786.54",
+			Volume:               "197.664",
+			TradeNum:             543,
+			IsFinal:              false,
+			QuoteVolume:          "3715253.19494",
+			ActiveBuyVolume:      "184.769",
+			ActiveBuyQuoteVolume: "3472925.84746",
+		},
+	}
+}
+
+func (s *websocketServiceTestSuite) assertWsContinuousKlineEventEqual(e, a *WsContinuousKlineEvent) {
+	r := s.r()
+	r.Equal(e.Event, a.Event, "Event")
+	r.Equal(e.Time, a.Time, "Time")
+	r.Equal(e.Pair, a.Pair, "Pair")
+	r.Equal(e.ContractType, a.ContractType, "ContractType")
+	ek, ak := e.Kline, a.Kline
+	r.Equal(ek.StartTime, ak.StartTime, "StartTime")
+	r.Equal(ek.EndTime, ak.EndTime, "EndTime")
+	r.Equal(ek.Interval, ak.Interval, "Interval")
+	r.Equal(ek.FirstTradeID, ak.FirstTradeID, "FirstTradeID")
+	r.Equal(ek.LastTradeID, ak.LastTradeID, "LastTradeID")
+	r.Equal(ek.Open, ak.Open, "Open")
+	r.Equal(ek.Close, ak.Close, "Close")
+	r.Equal(ek.High, ak.High, "High")
+	r.Equal(ek.Low, ak.Low, "Low")
+	r.Equal(ek.Volume, ak.Volume, "Volume")
+	r.Equal(ek.TradeNum, ak.TradeNum, "TradeNum")
+	r.Equal(ek.IsFinal, ak.IsFinal, "IsFinal")
+	r.Equal(ek.QuoteVolume, ak.QuoteVolume, "QuoteVolume")
+	r.Equal(ek.ActiveBuyVolume, ak.ActiveBuyVolume, "ActiveBuyVolume")
+	r.Equal(ek.ActiveBuyQuoteVolume, ak.ActiveBuyQuoteVolume, "ActiveBuyQuoteVolume")
+}
+
 func (s *websocketServiceTestSuite) TestMiniMarketTickerServe() {
 	data := []byte(`{
 		"e": "24hrMiniTicker", 
@@ -1266,6 +1400,169 @@ func (s *websocketServiceTestSuite) assertCompositeIndexEvent(e, a *WsCompositeI
 	}
 }
 
+func (s *websocketServiceTestSuite) TestWsContractInfoServe() {
+	data := []byte(`{
+		"e":"contractInfo",
+		"E":1669356423908,
+		"s":"IOTAUSDT",
+		"ps":"IOTAUSDT",
+		"ct":"PERPETUAL",
+		"dt":4133404800000,
+		"ot":1569398400000,
+		"cs":"TRADING",
+		"bks":[
+			{
+				"bs":1,
+				"bnf":0,
+				"bnc":5000,
+				"mmr":0.01,
+				"cf":0,
+				"mi":21,
+				"ma":50
+			}
+		]
+	}`)
+	fakeErrMsg := "fake error"
+	s.mockWsServe(data, errors.New(fakeErrMsg))
+	defer s.assertWsServe()
+
+	doneC, stopC, err := WsContractInfoServe(func(event *WsContractInfoEvent) {
+		e := &WsContractInfoEvent{
+			Event:        "contractInfo",
+			Time:         1669356423908,
+			Symbol:       "IOTAUSDT",
+			Pair:         "IOTAUSDT",
+			ContractType: ContractTypePerpetual,
+			DeliveryDate: 4133404800000,
+			OnboardDate:  1569398400000,
+			Status:       SymbolStatusTypeTrading,
+			Brackets: []WsBracketSetting{
+				{
+					Bracket:          1,
+					NotionalFloor:    0,
+					NotionalCap:      5000,
+					MaintMarginRatio: 0.01,
+					Cum:              0,
+					MinLeverage:      21,
+					MaxLeverage:      50,
+				},
+			},
+		}
+		s.r().Equal(e, event)
+	}, func(err error) {
+		s.r().EqualError(err, fakeErrMsg)
+	})
+	s.r().NoError(err)
+	stopC <- struct{}{}
+	<-doneC
+}
+
+func (s *websocketServiceTestSuite) TestWsAssetIndexServe() {
+	data := []byte(`{
+		"e":"assetIndexUpdate",
+		"E":1686749230000,
+		"s":"ADAUSD",
+		"i":"0.27462452",
+		"b":"0.10000000",
+		"a":"0.10000000",
+		"B":"0.24716207",
+		"A":"0.30208698",
+		"q":"0.05000000",
+		"g":"0.05000000",
+		"Q":"0.26089330",
+		"G":"0.28835575"
+	}`)
+	fakeErrMsg := "fake error"
+	s.mockWsServe(data, errors.New(fakeErrMsg))
+	defer s.assertWsServe()
+
+	doneC, stopC, err := WsAssetIndexServe("ADAUSD", func(event *WsAssetIndexEvent) {
+		s.r().Equal(s.expectedAssetIndexEvent(), event)
+	}, func(err error) {
+		s.r().EqualError(err, fakeErrMsg)
+	})
+	s.r().NoError(err)
+	stopC <- struct{}{}
+	<-doneC
+}
+
+func (s *websocketServiceTestSuite) TestWsCombinedAssetIndexServe() {
+	data := []byte(`{
+	"stream":"adausd@assetIndex",
+	"data": {
+		"e":"assetIndexUpdate",
+		"E":1686749230000,
+		"s":"ADAUSD",
+		"i":"0.27462452",
+		"b":"0.10000000",
+		"a":"0.10000000",
+		"B":"0.24716207",
+		"A":"0.30208698",
+		"q":"0.05000000",
+		"g":"0.05000000",
+		"Q":"0.26089330",
+		"G":"0.28835575"
+	}}`)
+	fakeErrMsg := "fake error"
+	s.mockWsServe(data, errors.New(fakeErrMsg))
+	defer s.assertWsServe()
+
+	doneC, stopC, err := WsCombinedAssetIndexServe([]string{"ADAUSD", "BTCUSD"}, func(event *WsAssetIndexEvent) {
+		s.r().Equal(s.expectedAssetIndexEvent(), event)
+	}, func(err error) {
+		s.r().EqualError(err, fakeErrMsg)
+	})
+	s.r().NoError(err)
+	stopC <- struct{}{}
+	<-doneC
+}
+
+func (s *websocketServiceTestSuite) TestWsAllAssetIndexServe() {
+	data := []byte(`[{
+		"e":"assetIndexUpdate",
+		"E":1686749230000,
+		"s":"ADAUSD",
+		"i":"0.27462452",
+		"b":"0.10000000",
+		"a":"0.10000000",
+		"B":"0.24716207",
+		"A":"0.30208698",
+		"q":"0.05000000",
+		"g":"0.05000000",
+		"Q":"0.26089330",
+		"G":"0.28835575"
+	}]`)
+	fakeErrMsg := "fake error"
+	s.mockWsServe(data, errors.New(fakeErrMsg))
+	defer s.assertWsServe()
+
+	doneC, stopC, err := WsAllAssetIndexServe(func(event WsAllAssetIndexEvent) {
+		s.r().Equal(WsAllAssetIndexEvent{s.expectedAssetIndexEvent()}, event)
+	}, func(err error) {
+		s.r().EqualError(err, fakeErrMsg)
+	})
+	s.r().NoError(err)
+	stopC <- struct{}{}
+	<-doneC
+}
+
+func (s *websocketServiceTestSuite) expectedAssetIndexEvent() *WsAssetIndexEvent {
+	return &WsAssetIndexEvent{
+		Event:                 "assetIndexUpdate",
+		Time:                  1686749230000,
+		Symbol:                "ADAUSD",
+		Index:                 "0.27462452",
+		BidBuffer:             "0.10000000",
+		AskBuffer:             "0.10000000",
+		BidRate:               "0.24716207",
+		AskRate:               "0.30208698",
+		AutoExchangeBidBuffer: "0.05000000",
+		AutoExchangeAskBuffer: "0.05000000",
+		AutoExchangeBidRate:   "0.26089330",
+		AutoExchangeAskRate:   "0.28835575",
+	}
+}
+
 func (s *websocketServiceTestSuite) testWsUserDataServe(data []byte, expectedEvent *WsUserDataEvent) {
 	fakeErrMsg := "fake error"
 	s.mockWsServe(data, errors.New(fakeErrMsg))
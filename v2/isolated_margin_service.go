@@ -0,0 +1,416 @@
+package binance
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// IsolatedMarginTransferService transfer an asset between the spot account and an isolated margin account
+type IsolatedMarginTransferService struct {
+	c         *Client
+	asset     string
+	symbol    string
+	transFrom IsolatedMarginAccountType
+	transTo   IsolatedMarginAccountType
+	amount    string
+}
+
+// Asset set asset being transferred, e.g., USDT
+func (s *IsolatedMarginTransferService) Asset(asset string) *IsolatedMarginTransferService {
+	s.asset = asset
+	return s
+}
+
+// Symbol set the symbol of the isolated margin account, e.g., BTCUSDT
+func (s *IsolatedMarginTransferService) Symbol(symbol string) *IsolatedMarginTransferService {
+	s.symbol = symbol
+	return s
+}
+
+// TransFrom set the account the asset is transferred from
+func (s *IsolatedMarginTransferService) TransFrom(transFrom IsolatedMarginAccountType) *IsolatedMarginTransferService {
+	s.transFrom = transFrom
+	return s
+}
+
+// TransTo set the account the asset is transferred to
+func (s *IsolatedMarginTransferService) TransTo(transTo IsolatedMarginAccountType) *IsolatedMarginTransferService {
+	s.transTo = transTo
+	return s
+}
+
+// Amount the amount to be transferred
+func (s *IsolatedMarginTransferService) Amount(amount string) *IsolatedMarginTransferService {
+	s.amount = amount
+	return s
+}
+
+// Do send request
+func (s *IsolatedMarginTransferService) Do(ctx context.Context, opts ...RequestOption) (res *TransactionResponse, err error) {
+	r := &request{
+		method:   "POST",
+		endpoint: "/sapi/v1/margin/isolated/transfer",
+		secType:  secTypeSigned,
+	}
+	m := params{
+		"asset":     s.asset,
+		"symbol":    s.symbol,
+		"transFrom": s.transFrom,
+		"transTo":   s.transTo,
+		"amount":    s.amount,
+	}
+	r.setFormParams(m)
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(TransactionResponse)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ListIsolatedMarginTransfersService list the transfers of an isolated margin account
+type ListIsolatedMarginTransfersService struct {
+	c         *Client
+	asset     string
+	symbol    string
+	transFrom IsolatedMarginAccountType
+	transTo   IsolatedMarginAccountType
+	startTime *int64
+	endTime   *int64
+	current   *int64
+	size      *int64
+	archived  *bool
+}
+
+// Asset set asset
+func (s *ListIsolatedMarginTransfersService) Asset(asset string) *ListIsolatedMarginTransfersService {
+	s.asset = asset
+	return s
+}
+
+// Symbol set the symbol of the isolated margin account
+func (s *ListIsolatedMarginTransfersService) Symbol(symbol string) *ListIsolatedMarginTransfersService {
+	s.symbol = symbol
+	return s
+}
+
+// TransFrom set the account the asset is transferred from
+func (s *ListIsolatedMarginTransfersService) TransFrom(transFrom IsolatedMarginAccountType) *ListIsolatedMarginTransfersService {
+	s.transFrom = transFrom
+	return s
+}
+
+// TransTo set the account the asset is transferred to
+func (s *ListIsolatedMarginTransfersService) TransTo(transTo IsolatedMarginAccountType) *ListIsolatedMarginTransfersService {
+	s.transTo = transTo
+	return s
+}
+
+// StartTime set start time
+func (s *ListIsolatedMarginTransfersService) StartTime(startTime int64) *ListIsolatedMarginTransfersService {
+	s.startTime = &startTime
+	return s
+}
+
+// EndTime set end time
+func (s *ListIsolatedMarginTransfersService) EndTime(endTime int64) *ListIsolatedMarginTransfersService {
+	s.endTime = &endTime
+	return s
+}
+
+// Current currently querying page. Start from 1. Default:1
+func (s *ListIsolatedMarginTransfersService) Current(current int64) *ListIsolatedMarginTransfersService {
+	s.current = &current
+	return s
+}
+
+// Size default:10 max:100
+func (s *ListIsolatedMarginTransfersService) Size(size int64) *ListIsolatedMarginTransfersService {
+	s.size = &size
+	return s
+}
+
+// Archived set whether to query the transfers older than 6 months
+func (s *ListIsolatedMarginTransfersService) Archived(archived bool) *ListIsolatedMarginTransfersService {
+	s.archived = &archived
+	return s
+}
+
+// Do send request
+func (s *ListIsolatedMarginTransfersService) Do(ctx context.Context, opts ...RequestOption) (res *IsolatedMarginTransferResponse, err error) {
+	r := &request{
+		method:   "GET",
+		endpoint: "/sapi/v1/margin/isolated/transfer",
+		secType:  secTypeSigned,
+	}
+	r.setParam("symbol", s.symbol)
+	if s.asset != "" {
+		r.setParam("asset", s.asset)
+	}
+	if s.transFrom != "" {
+		r.setParam("transFrom", s.transFrom)
+	}
+	if s.transTo != "" {
+		r.setParam("transTo", s.transTo)
+	}
+	if s.startTime != nil {
+		r.setParam("startTime", *s.startTime)
+	}
+	if s.endTime != nil {
+		r.setParam("endTime", *s.endTime)
+	}
+	if s.current != nil {
+		r.setParam("current", *s.current)
+	}
+	if s.size != nil {
+		r.setParam("size", *s.size)
+	}
+	if s.archived != nil {
+		r.setParam("archived", *s.archived)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(IsolatedMarginTransferResponse)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// IsolatedMarginTransferResponse define isolated margin transfer history response
+type IsolatedMarginTransferResponse struct {
+	Rows  []IsolatedMarginTransfer `json:"rows"`
+	Total int64                    `json:"total"`
+}
+
+// IsolatedMarginTransfer define isolated margin transfer
+type IsolatedMarginTransfer struct {
+	Amount    string                    `json:"amount"`
+	Asset     string                    `json:"asset"`
+	Status    MarginTransferStatusType  `json:"status"`
+	Timestamp int64                     `json:"timestamp"`
+	TxID      int64                     `json:"txId"`
+	TransFrom IsolatedMarginAccountType `json:"transFrom"`
+	TransTo   IsolatedMarginAccountType `json:"transTo"`
+}
+
+// EnableIsolatedMarginAccountService enable the isolated margin account of a symbol
+type EnableIsolatedMarginAccountService struct {
+	c      *Client
+	symbol string
+}
+
+// Symbol set symbol
+func (s *EnableIsolatedMarginAccountService) Symbol(symbol string) *EnableIsolatedMarginAccountService {
+	s.symbol = symbol
+	return s
+}
+
+// Do send request
+func (s *EnableIsolatedMarginAccountService) Do(ctx context.Context, opts ...RequestOption) (res *IsolatedMarginAccountStatus, err error) {
+	r := &request{
+		method:   "POST",
+		endpoint: "/sapi/v1/margin/isolated/account",
+		secType:  secTypeSigned,
+	}
+	r.setFormParam("symbol", s.symbol)
+	return callIsolatedMarginAccountStatus(ctx, s.c, r, opts...)
+}
+
+// DisableIsolatedMarginAccountService disable the isolated margin account of a symbol
+type DisableIsolatedMarginAccountService struct {
+	c      *Client
+	symbol string
+}
+
+// Symbol set symbol
+func (s *DisableIsolatedMarginAccountService) Symbol(symbol string) *DisableIsolatedMarginAccountService {
+	s.symbol = symbol
+	return s
+}
+
+// Do send request
+func (s *DisableIsolatedMarginAccountService) Do(ctx context.Context, opts ...RequestOption) (res *IsolatedMarginAccountStatus, err error) {
+	r := &request{
+		method:   "DELETE",
+		endpoint: "/sapi/v1/margin/isolated/account",
+		secType:  secTypeSigned,
+	}
+	r.setParam("symbol", s.symbol)
+	return callIsolatedMarginAccountStatus(ctx, s.c, r, opts...)
+}
+
+func callIsolatedMarginAccountStatus(ctx context.Context, c *Client, r *request, opts ...RequestOption) (res *IsolatedMarginAccountStatus, err error) {
+	data, err := c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(IsolatedMarginAccountStatus)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// IsolatedMarginAccountStatus define the result of enabling or disabling an isolated margin account
+type IsolatedMarginAccountStatus struct {
+	Success bool   `json:"success"`
+	Symbol  string `json:"symbol"`
+}
+
+// GetIsolatedMarginAccountLimitService get the number of enabled isolated margin accounts and its limit
+type GetIsolatedMarginAccountLimitService struct {
+	c *Client
+}
+
+// Do send request
+func (s *GetIsolatedMarginAccountLimitService) Do(ctx context.Context, opts ...RequestOption) (res *IsolatedMarginAccountLimit, err error) {
+	r := &request{
+		method:   "GET",
+		endpoint: "/sapi/v1/margin/isolated/accountLimit",
+		secType:  secTypeSigned,
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(IsolatedMarginAccountLimit)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// IsolatedMarginAccountLimit define isolated margin account limit
+type IsolatedMarginAccountLimit struct {
+	EnabledAccount int64 `json:"enabledAccount"`
+	MaxAccount     int64 `json:"maxAccount"`
+}
+
+// GetIsolatedMarginPairService get isolated margin pair info
+type GetIsolatedMarginPairService struct {
+	c      *Client
+	symbol string
+}
+
+// Symbol set symbol
+func (s *GetIsolatedMarginPairService) Symbol(symbol string) *GetIsolatedMarginPairService {
+	s.symbol = symbol
+	return s
+}
+
+// Do send request
+func (s *GetIsolatedMarginPairService) Do(ctx context.Context, opts ...RequestOption) (res *IsolatedMarginPair, err error) {
+	r := &request{
+		method:   "GET",
+		endpoint: "/sapi/v1/margin/isolated/pair",
+		secType:  secTypeSigned,
+	}
+	r.setParam("symbol", s.symbol)
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(IsolatedMarginPair)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetIsolatedMarginAllPairsService get all isolated margin pairs
+type GetIsolatedMarginAllPairsService struct {
+	c *Client
+}
+
+// Do send request
+func (s *GetIsolatedMarginAllPairsService) Do(ctx context.Context, opts ...RequestOption) (res []*IsolatedMarginPair, err error) {
+	r := &request{
+		method:   "GET",
+		endpoint: "/sapi/v1/margin/isolated/allPairs",
+		secType:  secTypeSigned,
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*IsolatedMarginPair{}, err
+	}
+	res = make([]*IsolatedMarginPair, 0)
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return []*IsolatedMarginPair{}, err
+	}
+	return res, nil
+}
+
+// IsolatedMarginPair define isolated margin pair info
+type IsolatedMarginPair struct {
+	Symbol        string `json:"symbol"`
+	Base          string `json:"base"`
+	Quote         string `json:"quote"`
+	IsMarginTrade bool   `json:"isMarginTrade"`
+	IsBuyAllowed  bool   `json:"isBuyAllowed"`
+	IsSellAllowed bool   `json:"isSellAllowed"`
+}
+
+// GetIsolatedMarginTierService get the tiers of an isolated margin pair
+type GetIsolatedMarginTierService struct {
+	c      *Client
+	symbol string
+	tier   *int
+}
+
+// Symbol set symbol
+func (s *GetIsolatedMarginTierService) Symbol(symbol string) *GetIsolatedMarginTierService {
+	s.symbol = symbol
+	return s
+}
+
+// Tier set tier, all the tiers are returned by default
+func (s *GetIsolatedMarginTierService) Tier(tier int) *GetIsolatedMarginTierService {
+	s.tier = &tier
+	return s
+}
+
+// Do send request
+func (s *GetIsolatedMarginTierService) Do(ctx context.Context, opts ...RequestOption) (res []*IsolatedMarginTier, err error) {
+	r := &request{
+		method:   "GET",
+		endpoint: "/sapi/v1/margin/isolatedMarginTier",
+		secType:  secTypeSigned,
+	}
+	r.setParam("symbol", s.symbol)
+	if s.tier != nil {
+		r.setParam("tier", *s.tier)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*IsolatedMarginTier{}, err
+	}
+	res = make([]*IsolatedMarginTier, 0)
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return []*IsolatedMarginTier{}, err
+	}
+	return res, nil
+}
+
+// IsolatedMarginTier define the leverage and the borrow limits of a tier of an isolated margin pair
+type IsolatedMarginTier struct {
+	Symbol                  string `json:"symbol"`
+	Tier                    int    `json:"tier"`
+	EffectiveMultiple       string `json:"effectiveMultiple"`
+	InitialRiskRatio        string `json:"initialRiskRatio"`
+	LiquidationRiskRatio    string `json:"liquidationRiskRatio"`
+	BaseAssetMaxBorrowable  string `json:"baseAssetMaxBorrowable"`
+	QuoteAssetMaxBorrowable string `json:"quoteAssetMaxBorrowable"`
+}
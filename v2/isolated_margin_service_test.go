@@ -0,0 +1,219 @@
+package binance
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type isolatedMarginTestSuite struct {
+	baseTestSuite
+}
+
+func TestIsolatedMarginService(t *testing.T) {
+	suite.Run(t, new(isolatedMarginTestSuite))
+}
+
+func (s *isolatedMarginTestSuite) TestTransfer() {
+	data := []byte(`{
+		"tranId": 100000001
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setFormParams(params{
+			"asset":     "USDT",
+			"symbol":    "BTCUSDT",
+			"transFrom": IsolatedMarginAccountTypeSpot,
+			"transTo":   IsolatedMarginAccountTypeIsolatedMargin,
+			"amount":    "100",
+		})
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewIsolatedMarginTransferService().Asset("USDT").Symbol("BTCUSDT").
+		TransFrom(IsolatedMarginAccountTypeSpot).TransTo(IsolatedMarginAccountTypeIsolatedMargin).
+		Amount("100").Do(newContext())
+	s.r().NoError(err)
+	s.r().Equal(int64(100000001), res.TranID)
+}
+
+func (s *isolatedMarginTestSuite) TestListTransfers() {
+	data := []byte(`{
+		"rows": [
+			{
+				"amount": "0.10000000",
+				"asset": "BNB",
+				"status": "CONFIRMED",
+				"timestamp": 1566898617000,
+				"txId": 5240372201,
+				"transFrom": "SPOT",
+				"transTo": "ISOLATED_MARGIN"
+			}
+		],
+		"total": 1
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setParams(params{
+			"symbol":    "BNBUSDT",
+			"asset":     "BNB",
+			"transFrom": IsolatedMarginAccountTypeSpot,
+			"startTime": int64(1566898617000),
+			"size":      int64(10),
+			"archived":  true,
+		})
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewListIsolatedMarginTransfersService().Symbol("BNBUSDT").Asset("BNB").
+		TransFrom(IsolatedMarginAccountTypeSpot).StartTime(1566898617000).Size(10).Archived(true).
+		Do(newContext())
+	s.r().NoError(err)
+	s.r().Equal(&IsolatedMarginTransferResponse{
+		Rows: []IsolatedMarginTransfer{
+			{
+				Amount:    "0.10000000",
+				Asset:     "BNB",
+				Status:    MarginTransferStatusTypeConfirmed,
+				Timestamp: 1566898617000,
+				TxID:      5240372201,
+				TransFrom: IsolatedMarginAccountTypeSpot,
+				TransTo:   IsolatedMarginAccountTypeIsolatedMargin,
+			},
+		},
+		Total: 1,
+	}, res)
+}
+
+func (s *isolatedMarginTestSuite) TestEnableAccount() {
+	data := []byte(`{
+		"success": true,
+		"symbol": "BTCUSDT"
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setFormParam("symbol", "BTCUSDT")
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewEnableIsolatedMarginAccountService().Symbol("BTCUSDT").Do(newContext())
+	s.r().NoError(err)
+	s.r().Equal(&IsolatedMarginAccountStatus{Success: true, Symbol: "BTCUSDT"}, res)
+}
+
+func (s *isolatedMarginTestSuite) TestDisableAccount() {
+	data := []byte(`{
+		"success": true,
+		"symbol": "BTCUSDT"
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setParam("symbol", "BTCUSDT")
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewDisableIsolatedMarginAccountService().Symbol("BTCUSDT").Do(newContext())
+	s.r().NoError(err)
+	s.r().Equal(&IsolatedMarginAccountStatus{Success: true, Symbol: "BTCUSDT"}, res)
+}
+
+func (s *isolatedMarginTestSuite) TestGetAccountLimit() {
+	data := []byte(`{
+		"enabledAccount": 5,
+		"maxAccount": 20
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest()
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewGetIsolatedMarginAccountLimitService().Do(newContext())
+	s.r().NoError(err)
+	s.r().Equal(&IsolatedMarginAccountLimit{EnabledAccount: 5, MaxAccount: 20}, res)
+}
+
+func (s *isolatedMarginTestSuite) TestGetPair() {
+	data := []byte(`{
+		"symbol": "BTCUSDT",
+		"base": "BTC",
+		"quote": "USDT",
+		"isMarginTrade": true,
+		"isBuyAllowed": true,
+		"isSellAllowed": true
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setParam("symbol", "BTCUSDT")
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewGetIsolatedMarginPairService().Symbol("BTCUSDT").Do(newContext())
+	s.r().NoError(err)
+	s.r().Equal(&IsolatedMarginPair{
+		Symbol:        "BTCUSDT",
+		Base:          "BTC",
+		Quote:         "USDT",
+		IsMarginTrade: true,
+		IsBuyAllowed:  true,
+		IsSellAllowed: true,
+	}, res)
+}
+
+func (s *isolatedMarginTestSuite) TestGetAllPairs() {
+	data := []byte(`[{
+		"symbol": "BTCUSDT",
+		"base": "BTC",
+		"quote": "USDT",
+		"isMarginTrade": true,
+		"isBuyAllowed": true,
+		"isSellAllowed": false
+	}]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest()
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewGetIsolatedMarginAllPairsService().Do(newContext())
+	s.r().NoError(err)
+	s.r().Equal([]*IsolatedMarginPair{{
+		Symbol:        "BTCUSDT",
+		Base:          "BTC",
+		Quote:         "USDT",
+		IsMarginTrade: true,
+		IsBuyAllowed:  true,
+	}}, res)
+}
+
+func (s *isolatedMarginTestSuite) TestGetTier() {
+	data := []byte(`[{
+		"symbol": "BTCUSDT",
+		"tier": 1,
+		"effectiveMultiple": "10",
+		"initialRiskRatio": "1.111",
+		"liquidationRiskRatio": "1.05",
+		"baseAssetMaxBorrowable": "9",
+		"quoteAssetMaxBorrowable": "70000"
+	}]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setParams(params{
+			"symbol": "BTCUSDT",
+			"tier":   1,
+		})
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewGetIsolatedMarginTierService().Symbol("BTCUSDT").Tier(1).Do(newContext())
+	s.r().NoError(err)
+	s.r().Equal([]*IsolatedMarginTier{{
+		Symbol:                  "BTCUSDT",
+		Tier:                    1,
+		EffectiveMultiple:       "10",
+		InitialRiskRatio:        "1.111",
+		LiquidationRiskRatio:    "1.05",
+		BaseAssetMaxBorrowable:  "9",
+		QuoteAssetMaxBorrowable: "70000",
+	}}, res)
+}
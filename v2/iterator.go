@@ -0,0 +1,245 @@
+package binance
+
+import (
+	"context"
+	"time"
+
+	"github.com/Zamzam-Technology/go-binance/v2/common"
+)
+
+// Time windows accepted by the endpoints between startTime and endTime
+const (
+	tradesWindow    = 24 * time.Hour
+	ordersWindow    = 24 * time.Hour
+	aggTradesWindow = time.Hour
+)
+
+// pageLimit return the page size of an iteration, the service limit or max when unset
+func pageLimit(limit *int, max int) int {
+	if limit != nil && *limit > 0 {
+		return *limit
+	}
+	return max
+}
+
+// TradeV3Iterator iterate over account trades
+type TradeV3Iterator struct {
+	*common.PageIterator
+}
+
+// Trade return the current trade
+func (it *TradeV3Iterator) Trade() *TradeV3 {
+	trade, _ := it.Item().(*TradeV3)
+	return trade
+}
+
+func newTradeV3Iterator(pager *common.IDRangePager, fetch func(ctx context.Context, fromID, startTime, endTime *int64, limit int) ([]*TradeV3, error), budget *common.IteratorBudget) *TradeV3Iterator {
+	pager.Fetch = func(ctx context.Context, fromID, startTime, endTime *int64, limit int) ([]interface{}, error) {
+		trades, err := fetch(ctx, fromID, startTime, endTime, limit)
+		if err != nil {
+			return nil, err
+		}
+		items := make([]interface{}, len(trades))
+		for i, trade := range trades {
+			items[i] = trade
+		}
+		return items, nil
+	}
+	pager.Key = func(item interface{}) (int64, int64) {
+		trade := item.(*TradeV3)
+		return trade.ID, trade.Time
+	}
+	return &TradeV3Iterator{common.NewPageIterator(pager.Pager(), budget)}
+}
+
+// Iterator return an iterator over all the trades from the service fromID or startTime up to
+// its endTime, walking the time range by 24 hours windows. Pages have the service limit, 1000 by default.
+func (s *ListTradesService) Iterator(budget *common.IteratorBudget, opts ...RequestOption) *TradeV3Iterator {
+	pager := &common.IDRangePager{
+		FromID:    s.fromID,
+		StartTime: s.startTime,
+		EndTime:   s.endTime,
+		Window:    tradesWindow,
+		Limit:     pageLimit(s.limit, 1000),
+	}
+	return newTradeV3Iterator(pager, func(ctx context.Context, fromID, startTime, endTime *int64, limit int) ([]*TradeV3, error) {
+		page := *s
+		page.fromID, page.startTime, page.endTime, page.limit = fromID, startTime, endTime, &limit
+		return page.Do(ctx, opts...)
+	}, budget)
+}
+
+// Iterator return an iterator over all the margin trades from the service fromID or startTime up to
+// its endTime, walking the time range by 24 hours windows. Pages have the service limit, 1000 by default.
+func (s *ListMarginTradesService) Iterator(budget *common.IteratorBudget, opts ...RequestOption) *TradeV3Iterator {
+	pager := &common.IDRangePager{
+		FromID:    s.fromID,
+		StartTime: s.startTime,
+		EndTime:   s.endTime,
+		Window:    tradesWindow,
+		Limit:     pageLimit(s.limit, 1000),
+	}
+	return newTradeV3Iterator(pager, func(ctx context.Context, fromID, startTime, endTime *int64, limit int) ([]*TradeV3, error) {
+		page := *s
+		page.fromID, page.startTime, page.endTime, page.limit = fromID, startTime, endTime, &limit
+		return page.Do(ctx, opts...)
+	}, budget)
+}
+
+// OrderIterator iterate over account orders
+type OrderIterator struct {
+	*common.PageIterator
+}
+
+// Order return the current order
+func (it *OrderIterator) Order() *Order {
+	order, _ := it.Item().(*Order)
+	return order
+}
+
+// Iterator return an iterator over all the orders from the service orderID or startTime up to
+// its endTime, walking the time range by 24 hours windows. Pages have the service limit, 1000 by default.
+func (s *ListOrdersService) Iterator(budget *common.IteratorBudget, opts ...RequestOption) *OrderIterator {
+	pager := &common.IDRangePager{
+		FromID:    s.orderID,
+		StartTime: s.startTime,
+		EndTime:   s.endTime,
+		Window:    ordersWindow,
+		Limit:     pageLimit(s.limit, 1000),
+		Fetch: func(ctx context.Context, orderID, startTime, endTime *int64, limit int) ([]interface{}, error) {
+			page := *s
+			page.orderID, page.startTime, page.endTime, page.limit = orderID, startTime, endTime, &limit
+			orders, err := page.Do(ctx, opts...)
+			if err != nil {
+				return nil, err
+			}
+			items := make([]interface{}, len(orders))
+			for i, order := range orders {
+				items[i] = order
+			}
+			return items, nil
+		},
+		Key: func(item interface{}) (int64, int64) {
+			order := item.(*Order)
+			return order.OrderID, order.Time
+		},
+	}
+	return &OrderIterator{common.NewPageIterator(pager.Pager(), budget)}
+}
+
+// AggTradeIterator iterate over aggregate trades
+type AggTradeIterator struct {
+	*common.PageIterator
+}
+
+// AggTrade return the current aggregate trade
+func (it *AggTradeIterator) AggTrade() *AggTrade {
+	trade, _ := it.Item().(*AggTrade)
+	return trade
+}
+
+// Iterator return an iterator over all the aggregate trades from the service fromID or startTime up to
+// its endTime, walking the time range by 1 hour windows. Pages have the service limit, 1000 by default.
+func (s *AggTradesService) Iterator(budget *common.IteratorBudget, opts ...RequestOption) *AggTradeIterator {
+	pager := &common.IDRangePager{
+		FromID:    s.fromID,
+		StartTime: s.startTime,
+		EndTime:   s.endTime,
+		Window:    aggTradesWindow,
+		Limit:     pageLimit(s.limit, 1000),
+		Fetch: func(ctx context.Context, fromID, startTime, endTime *int64, limit int) ([]interface{}, error) {
+			page := *s
+			page.fromID, page.startTime, page.endTime, page.limit = fromID, startTime, endTime, &limit
+			trades, err := page.Do(ctx, opts...)
+			if err != nil {
+				return nil, err
+			}
+			items := make([]interface{}, len(trades))
+			for i, trade := range trades {
+				items[i] = trade
+			}
+			return items, nil
+		},
+		Key: func(item interface{}) (int64, int64) {
+			trade := item.(*AggTrade)
+			return trade.AggTradeID, trade.Timestamp
+		},
+	}
+	return &AggTradeIterator{common.NewPageIterator(pager.Pager(), budget)}
+}
+
+// TradeIterator iterate over market trades
+type TradeIterator struct {
+	*common.PageIterator
+}
+
+// Trade return the current trade
+func (it *TradeIterator) Trade() *Trade {
+	trade, _ := it.Item().(*Trade)
+	return trade
+}
+
+// Iterator return an iterator over all the trades from the service fromID up to the latest one.
+// Pages have the service limit, 1000 by default.
+func (s *HistoricalTradesService) Iterator(budget *common.IteratorBudget, opts ...RequestOption) *TradeIterator {
+	pager := &common.IDRangePager{
+		FromID: s.fromID,
+		Limit:  pageLimit(s.limit, 1000),
+		Fetch: func(ctx context.Context, fromID, startTime, endTime *int64, limit int) ([]interface{}, error) {
+			page := *s
+			page.fromID, page.limit = fromID, &limit
+			trades, err := page.Do(ctx, opts...)
+			if err != nil {
+				return nil, err
+			}
+			items := make([]interface{}, len(trades))
+			for i, trade := range trades {
+				items[i] = trade
+			}
+			return items, nil
+		},
+		Key: func(item interface{}) (int64, int64) {
+			trade := item.(*Trade)
+			return trade.ID, trade.Time
+		},
+	}
+	return &TradeIterator{common.NewPageIterator(pager.Pager(), budget)}
+}
+
+// KlineIterator iterate over klines
+type KlineIterator struct {
+	*common.PageIterator
+}
+
+// Kline return the current kline
+func (it *KlineIterator) Kline() *Kline {
+	kline, _ := it.Item().(*Kline)
+	return kline
+}
+
+// Iterator return an iterator over all the klines from the service startTime, or the first kline, up to its endTime,
+// or up to the latest kline. Pages have the service limit, 1000 by default.
+func (s *KlinesService) Iterator(budget *common.IteratorBudget, opts ...RequestOption) *KlineIterator {
+	pager := &common.TimeRangePager{
+		StartTime: s.startTime,
+		EndTime:   s.endTime,
+		Limit:     pageLimit(s.limit, 1000),
+		Fetch: func(ctx context.Context, startTime int64, endTime *int64, limit int) ([]interface{}, error) {
+			page := *s
+			page.startTime, page.endTime, page.limit = &startTime, endTime, &limit
+			klines, err := page.Do(ctx, opts...)
+			if err != nil {
+				return nil, err
+			}
+			items := make([]interface{}, len(klines))
+			for i, kline := range klines {
+				items[i] = kline
+			}
+			return items, nil
+		},
+		Key: func(item interface{}) int64 {
+			return item.(*Kline).OpenTime
+		},
+	}
+	return &KlineIterator{common.NewPageIterator(pager.Pager(), budget)}
+}
@@ -0,0 +1,93 @@
+package binance
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/Zamzam-Technology/go-binance/v2/common"
+	"github.com/stretchr/testify/suite"
+)
+
+type iteratorTestSuite struct {
+	baseTestSuite
+	responses [][]byte
+	queries   []url.Values
+}
+
+func TestIterator(t *testing.T) {
+	suite.Run(t, new(iteratorTestSuite))
+}
+
+func (s *iteratorTestSuite) SetupTest() {
+	s.baseTestSuite.SetupTest()
+	s.queries = nil
+	s.client.Client.do = func(req *http.Request) (*http.Response, error) {
+		data := s.responses[len(s.queries)]
+		s.queries = append(s.queries, req.URL.Query())
+		return newHTTPResponse(data, http.StatusOK), nil
+	}
+}
+
+func (s *iteratorTestSuite) TestListTradesIterator() {
+	s.responses = [][]byte{
+		[]byte(`[]`),
+		[]byte(`[{"id": 10, "time": 86400500}, {"id": 11, "time": 86400600}]`),
+		[]byte(`[{"id": 12, "time": 86400700}, {"id": 13, "time": 172900000}]`),
+	}
+	it := s.client.NewListTradesService().Symbol("BNBBTC").
+		StartTime(0).EndTime(172800000).Limit(2).Iterator(nil)
+	var ids []int64
+	for it.Next(newContext()) {
+		ids = append(ids, it.Trade().ID)
+	}
+	r := s.r()
+	r.NoError(it.Err())
+	r.Equal([]int64{10, 11, 12}, ids)
+	r.Len(s.queries, 3)
+	r.Equal("0", s.queries[0].Get("startTime"))
+	r.Equal("86399999", s.queries[0].Get("endTime"))
+	r.Equal("86400000", s.queries[1].Get("startTime"))
+	r.Equal("", s.queries[2].Get("startTime"))
+	r.Equal("12", s.queries[2].Get("fromId"))
+	r.Equal("2", s.queries[2].Get("limit"))
+}
+
+func (s *iteratorTestSuite) TestKlinesIterator() {
+	s.responses = [][]byte{
+		[]byte(`[[1000, "1", "1", "1", "1", "1", 1999, "1", 1, "1", "1", "0"],
+			[2000, "1", "1", "1", "1", "1", 2999, "1", 1, "1", "1", "0"]]`),
+		[]byte(`[[3000, "1", "1", "1", "1", "1", 3999, "1", 1, "1", "1", "0"]]`),
+	}
+	it := s.client.NewKlinesService().Symbol("BNBBTC").Interval("1s").
+		StartTime(1000).Limit(2).Iterator(&common.IteratorBudget{MaxRequests: 5})
+	var openTimes []int64
+	for it.Next(newContext()) {
+		openTimes = append(openTimes, it.Kline().OpenTime)
+	}
+	r := s.r()
+	r.NoError(it.Err())
+	r.Equal([]int64{1000, 2000, 3000}, openTimes)
+	r.Len(s.queries, 2)
+	r.Equal("2001", s.queries[1].Get("startTime"))
+	r.Equal("", s.queries[1].Get("endTime"))
+	r.Equal(2, it.Requests())
+}
+
+func (s *iteratorTestSuite) TestListOrdersIteratorBudget() {
+	s.responses = [][]byte{
+		[]byte(`[{"orderId": 1, "time": 1}, {"orderId": 2, "time": 2}]`),
+		[]byte(`[{"orderId": 3, "time": 3}, {"orderId": 4, "time": 4}]`),
+	}
+	it := s.client.NewListOrdersService().Symbol("BNBBTC").OrderID(1).Limit(2).
+		Iterator(&common.IteratorBudget{MaxRequests: 1})
+	count := 0
+	for it.Next(newContext()) {
+		count++
+	}
+	r := s.r()
+	r.Equal(2, count)
+	r.Equal(common.ErrIteratorBudgetExhausted, it.Err())
+	r.Len(s.queries, 1)
+	r.Equal("1", s.queries[0].Get("orderId"))
+}
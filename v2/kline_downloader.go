@@ -0,0 +1,85 @@
+package binance
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Zamzam-Technology/go-binance/v2/common"
+)
+
+// KlineDownloader download the klines of a time range with concurrent requests
+type KlineDownloader struct {
+	c           *Client
+	symbol      string
+	interval    KlineInterval
+	limit       int
+	concurrency int
+}
+
+// KlineDownload define the klines of a time range, sorted by open time, and the ranges with no kline
+type KlineDownload struct {
+	Klines []*Kline
+	Gaps   []common.KlineGap
+}
+
+// Limit set the number of klines per request, 1000 by default
+func (d *KlineDownloader) Limit(limit int) *KlineDownloader {
+	d.limit = limit
+	return d
+}
+
+// Concurrency set the maximum number of concurrent requests, 4 by default
+func (d *KlineDownloader) Concurrency(concurrency int) *KlineDownloader {
+	d.concurrency = concurrency
+	return d
+}
+
+// Download fetch the klines opening in [start, end)
+func (d *KlineDownloader) Download(ctx context.Context, start, end time.Time, opts ...RequestOption) (*KlineDownload, error) {
+	if !d.interval.IsValid() {
+		return nil, fmt.Errorf("invalid kline interval %q", d.interval)
+	}
+	if d.limit <= 0 {
+		return nil, fmt.Errorf("invalid kline limit %d", d.limit)
+	}
+	chunks := common.SplitKlineRange(d.interval, start, end, d.limit)
+	pages := make([][]*Kline, len(chunks))
+	err := common.DownloadKlineChunks(ctx, chunks, d.concurrency, func(ctx context.Context, i int, chunk common.KlineChunk) error {
+		klines, err := d.c.NewKlinesService().Symbol(d.symbol).Interval(d.interval).
+			StartTime(chunk.Start).EndTime(chunk.End-1).Limit(d.limit).Do(ctx, opts...)
+		if err != nil {
+			return err
+		}
+		pages[i] = filterKlines(klines, chunk)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newKlineDownload(d.interval, start, end, pages), nil
+}
+
+// filterKlines keep the klines opening in the chunk
+func filterKlines(klines []*Kline, chunk common.KlineChunk) []*Kline {
+	res := make([]*Kline, 0, len(klines))
+	for _, kline := range klines {
+		if kline.OpenTime >= chunk.Start && kline.OpenTime < chunk.End {
+			res = append(res, kline)
+		}
+	}
+	return res
+}
+
+func newKlineDownload(interval KlineInterval, start, end time.Time, pages [][]*Kline) *KlineDownload {
+	download := &KlineDownload{Klines: []*Kline{}}
+	var openTimes []int64
+	for _, page := range pages {
+		for _, kline := range page {
+			download.Klines = append(download.Klines, kline)
+			openTimes = append(openTimes, kline.OpenTime)
+		}
+	}
+	download.Gaps = common.FindKlineGaps(interval, start, end, openTimes)
+	return download
+}
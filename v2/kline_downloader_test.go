@@ -0,0 +1,70 @@
+package binance
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Zamzam-Technology/go-binance/v2/common"
+	"github.com/stretchr/testify/suite"
+)
+
+type klineDownloaderTestSuite struct {
+	baseTestSuite
+}
+
+func TestKlineDownloader(t *testing.T) {
+	suite.Run(t, new(klineDownloaderTestSuite))
+}
+
+func (s *klineDownloaderTestSuite) TestDownload() {
+	minute := int64(60000)
+	missing := map[int64]bool{3 * minute: true, 4 * minute: true}
+	var mu sync.Mutex
+	var starts []string
+	s.client.Client.do = func(req *http.Request) (*http.Response, error) {
+		query := req.URL.Query()
+		mu.Lock()
+		starts = append(starts, query.Get("startTime"))
+		mu.Unlock()
+		startTime, _ := strconv.ParseInt(query.Get("startTime"), 10, 64)
+		endTime, _ := strconv.ParseInt(query.Get("endTime"), 10, 64)
+		data := "["
+		for open := startTime; open <= endTime; open += minute {
+			if missing[open] {
+				continue
+			}
+			if len(data) > 1 {
+				data += ","
+			}
+			data += fmt.Sprintf(`[%d, "1", "1", "1", "1", "1", %d, "1", 1, "1", "1", "0"]`, open, open+minute-1)
+		}
+		return newHTTPResponse([]byte(data+"]"), http.StatusOK), nil
+	}
+
+	start := time.Unix(0, 0)
+	download, err := s.client.NewKlineDownloader("BNBBTC", KlineInterval1m).Limit(4).Concurrency(2).
+		Download(newContext(), start, start.Add(10*time.Minute))
+	r := s.r()
+	r.NoError(err)
+	r.Len(download.Klines, 8)
+	for i := 1; i < len(download.Klines); i++ {
+		r.True(download.Klines[i-1].OpenTime < download.Klines[i].OpenTime)
+	}
+	r.Equal([]common.KlineGap{{Start: 3 * minute, End: 5 * minute, Count: 2}}, download.Gaps)
+	r.ElementsMatch([]string{"0", "240000", "480000"}, starts)
+	r.Equal(time.Unix(0, 0), download.Klines[0].OpenedAt())
+}
+
+func (s *klineDownloaderTestSuite) TestDownloadInvalidInterval() {
+	_, err := s.client.NewKlineDownloader("BNBBTC", "1H").Download(newContext(), time.Unix(0, 0), time.Unix(600, 0))
+	s.r().Error(err)
+}
+
+func (s *klineDownloaderTestSuite) TestDownloadInvalidLimit() {
+	_, err := s.client.NewKlineDownloader("BNBBTC", KlineInterval1m).Limit(0).Download(newContext(), time.Unix(0, 0), time.Unix(600, 0))
+	s.r().EqualError(err, "invalid kline limit 0")
+}
@@ -49,7 +49,7 @@ func (s *klineServiceTestSuite) TestKlines() {
 	defer s.assertDo()
 
 	symbol := "LTCBTC"
-	interval := "15m"
+	interval := KlineInterval15m
 	limit := 10
 	startTime := int64(1499040000000)
 	endTime := int64(1499040000001)
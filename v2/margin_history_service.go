@@ -0,0 +1,457 @@
+package binance
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// ListMarginInterestsService list the interest history of the margin account
+type ListMarginInterestsService struct {
+	c              *Client
+	asset          string
+	isolatedSymbol string
+	startTime      *int64
+	endTime        *int64
+	current        *int64
+	size           *int64
+	archived       *bool
+}
+
+// Asset set asset
+func (s *ListMarginInterestsService) Asset(asset string) *ListMarginInterestsService {
+	s.asset = asset
+	return s
+}
+
+// IsolatedSymbol set the symbol of the isolated margin account
+func (s *ListMarginInterestsService) IsolatedSymbol(isolatedSymbol string) *ListMarginInterestsService {
+	s.isolatedSymbol = isolatedSymbol
+	return s
+}
+
+// StartTime set start time
+func (s *ListMarginInterestsService) StartTime(startTime int64) *ListMarginInterestsService {
+	s.startTime = &startTime
+	return s
+}
+
+// EndTime set end time
+func (s *ListMarginInterestsService) EndTime(endTime int64) *ListMarginInterestsService {
+	s.endTime = &endTime
+	return s
+}
+
+// Current currently querying page. Start from 1. Default:1
+func (s *ListMarginInterestsService) Current(current int64) *ListMarginInterestsService {
+	s.current = &current
+	return s
+}
+
+// Size default:10 max:100
+func (s *ListMarginInterestsService) Size(size int64) *ListMarginInterestsService {
+	s.size = &size
+	return s
+}
+
+// Archived set whether to query the interests older than 6 months
+func (s *ListMarginInterestsService) Archived(archived bool) *ListMarginInterestsService {
+	s.archived = &archived
+	return s
+}
+
+// Do send request
+func (s *ListMarginInterestsService) Do(ctx context.Context, opts ...RequestOption) (res *MarginInterestResponse, err error) {
+	r := &request{
+		method:   "GET",
+		endpoint: "/sapi/v1/margin/interestHistory",
+		secType:  secTypeSigned,
+	}
+	if s.asset != "" {
+		r.setParam("asset", s.asset)
+	}
+	if s.isolatedSymbol != "" {
+		r.setParam("isolatedSymbol", s.isolatedSymbol)
+	}
+	if s.startTime != nil {
+		r.setParam("startTime", *s.startTime)
+	}
+	if s.endTime != nil {
+		r.setParam("endTime", *s.endTime)
+	}
+	if s.current != nil {
+		r.setParam("current", *s.current)
+	}
+	if s.size != nil {
+		r.setParam("size", *s.size)
+	}
+	if s.archived != nil {
+		r.setParam("archived", *s.archived)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(MarginInterestResponse)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// MarginInterestResponse define margin interest history response
+type MarginInterestResponse struct {
+	Rows  []MarginInterest `json:"rows"`
+	Total int64            `json:"total"`
+}
+
+// MarginInterest define margin interest
+type MarginInterest struct {
+	IsolatedSymbol      string             `json:"isolatedSymbol"`
+	Asset               string             `json:"asset"`
+	Interest            string             `json:"interest"`
+	InterestAccuredTime int64              `json:"interestAccuredTime"`
+	InterestRate        string             `json:"interestRate"`
+	Principal           string             `json:"principal"`
+	Type                MarginInterestType `json:"type"`
+}
+
+// ListMarginForceLiquidationsService list the force liquidation records of the margin account
+type ListMarginForceLiquidationsService struct {
+	c              *Client
+	isolatedSymbol string
+	startTime      *int64
+	endTime        *int64
+	current        *int64
+	size           *int64
+}
+
+// IsolatedSymbol set the symbol of the isolated margin account
+func (s *ListMarginForceLiquidationsService) IsolatedSymbol(isolatedSymbol string) *ListMarginForceLiquidationsService {
+	s.isolatedSymbol = isolatedSymbol
+	return s
+}
+
+// StartTime set start time
+func (s *ListMarginForceLiquidationsService) StartTime(startTime int64) *ListMarginForceLiquidationsService {
+	s.startTime = &startTime
+	return s
+}
+
+// EndTime set end time
+func (s *ListMarginForceLiquidationsService) EndTime(endTime int64) *ListMarginForceLiquidationsService {
+	s.endTime = &endTime
+	return s
+}
+
+// Current currently querying page. Start from 1. Default:1
+func (s *ListMarginForceLiquidationsService) Current(current int64) *ListMarginForceLiquidationsService {
+	s.current = &current
+	return s
+}
+
+// Size default:10 max:100
+func (s *ListMarginForceLiquidationsService) Size(size int64) *ListMarginForceLiquidationsService {
+	s.size = &size
+	return s
+}
+
+// Do send request
+func (s *ListMarginForceLiquidationsService) Do(ctx context.Context, opts ...RequestOption) (res *MarginForceLiquidationResponse, err error) {
+	r := &request{
+		method:   "GET",
+		endpoint: "/sapi/v1/margin/forceLiquidationRec",
+		secType:  secTypeSigned,
+	}
+	if s.isolatedSymbol != "" {
+		r.setParam("isolatedSymbol", s.isolatedSymbol)
+	}
+	if s.startTime != nil {
+		r.setParam("startTime", *s.startTime)
+	}
+	if s.endTime != nil {
+		r.setParam("endTime", *s.endTime)
+	}
+	if s.current != nil {
+		r.setParam("current", *s.current)
+	}
+	if s.size != nil {
+		r.setParam("size", *s.size)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(MarginForceLiquidationResponse)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// MarginForceLiquidationResponse define margin force liquidation record response
+type MarginForceLiquidationResponse struct {
+	Rows  []MarginForceLiquidation `json:"rows"`
+	Total int64                    `json:"total"`
+}
+
+// MarginForceLiquidation define a margin order of a force liquidation
+type MarginForceLiquidation struct {
+	AvgPrice    string          `json:"avgPrice"`
+	ExecutedQty string          `json:"executedQty"`
+	OrderID     int64           `json:"orderId"`
+	Price       string          `json:"price"`
+	Qty         string          `json:"qty"`
+	Side        SideType        `json:"side"`
+	Symbol      string          `json:"symbol"`
+	TimeInForce TimeInForceType `json:"timeInForce"`
+	IsIsolated  bool            `json:"isIsolated"`
+	UpdatedTime int64           `json:"updatedTime"`
+}
+
+// ListMarginInterestRatesService list the daily interest rate history of a margin asset
+type ListMarginInterestRatesService struct {
+	c         *Client
+	asset     string
+	vipLevel  *int
+	startTime *int64
+	endTime   *int64
+	limit     *int
+}
+
+// Asset set asset
+func (s *ListMarginInterestRatesService) Asset(asset string) *ListMarginInterestRatesService {
+	s.asset = asset
+	return s
+}
+
+// VipLevel set the VIP level of the rates, the level of the account by default
+func (s *ListMarginInterestRatesService) VipLevel(vipLevel int) *ListMarginInterestRatesService {
+	s.vipLevel = &vipLevel
+	return s
+}
+
+// StartTime set start time
+func (s *ListMarginInterestRatesService) StartTime(startTime int64) *ListMarginInterestRatesService {
+	s.startTime = &startTime
+	return s
+}
+
+// EndTime set end time
+func (s *ListMarginInterestRatesService) EndTime(endTime int64) *ListMarginInterestRatesService {
+	s.endTime = &endTime
+	return s
+}
+
+// Limit default:20 max:100
+func (s *ListMarginInterestRatesService) Limit(limit int) *ListMarginInterestRatesService {
+	s.limit = &limit
+	return s
+}
+
+// Do send request
+func (s *ListMarginInterestRatesService) Do(ctx context.Context, opts ...RequestOption) (res []*MarginInterestRate, err error) {
+	r := &request{
+		method:   "GET",
+		endpoint: "/sapi/v1/margin/interestRateHistory",
+		secType:  secTypeSigned,
+	}
+	r.setParam("asset", s.asset)
+	if s.vipLevel != nil {
+		r.setParam("vipLevel", *s.vipLevel)
+	}
+	if s.startTime != nil {
+		r.setParam("startTime", *s.startTime)
+	}
+	if s.endTime != nil {
+		r.setParam("endTime", *s.endTime)
+	}
+	if s.limit != nil {
+		r.setParam("limit", *s.limit)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*MarginInterestRate{}, err
+	}
+	res = make([]*MarginInterestRate, 0)
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return []*MarginInterestRate{}, err
+	}
+	return res, nil
+}
+
+// MarginInterestRate define the daily interest rate of a margin asset
+type MarginInterestRate struct {
+	Asset             string `json:"asset"`
+	DailyInterestRate string `json:"dailyInterestRate"`
+	Timestamp         int64  `json:"timestamp"`
+	VipLevel          int    `json:"vipLevel"`
+}
+
+// GetCrossMarginDataService get the cross margin borrow limits and interest rates of a VIP level
+type GetCrossMarginDataService struct {
+	c        *Client
+	vipLevel *int
+	coin     string
+}
+
+// VipLevel set the VIP level, the level of the account by default
+func (s *GetCrossMarginDataService) VipLevel(vipLevel int) *GetCrossMarginDataService {
+	s.vipLevel = &vipLevel
+	return s
+}
+
+// Coin set coin, all the coins by default
+func (s *GetCrossMarginDataService) Coin(coin string) *GetCrossMarginDataService {
+	s.coin = coin
+	return s
+}
+
+// Do send request
+func (s *GetCrossMarginDataService) Do(ctx context.Context, opts ...RequestOption) (res []*CrossMarginData, err error) {
+	r := &request{
+		method:   "GET",
+		endpoint: "/sapi/v1/margin/crossMarginData",
+		secType:  secTypeSigned,
+	}
+	if s.vipLevel != nil {
+		r.setParam("vipLevel", *s.vipLevel)
+	}
+	if s.coin != "" {
+		r.setParam("coin", s.coin)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*CrossMarginData{}, err
+	}
+	res = make([]*CrossMarginData, 0)
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return []*CrossMarginData{}, err
+	}
+	return res, nil
+}
+
+// CrossMarginData define the cross margin borrow limit and interest rates of a coin for a VIP level
+type CrossMarginData struct {
+	VipLevel        int      `json:"vipLevel"`
+	Coin            string   `json:"coin"`
+	TransferIn      bool     `json:"transferIn"`
+	Borrowable      bool     `json:"borrowable"`
+	DailyInterest   string   `json:"dailyInterest"`
+	YearlyInterest  string   `json:"yearlyInterest"`
+	BorrowLimit     string   `json:"borrowLimit"`
+	MarginablePairs []string `json:"marginablePairs"`
+}
+
+// ListMarginTransfersService list the transfers between the spot account and the cross margin account
+type ListMarginTransfersService struct {
+	c            *Client
+	asset        string
+	transferType MarginTransferDirectionType
+	startTime    *int64
+	endTime      *int64
+	current      *int64
+	size         *int64
+	archived     *bool
+}
+
+// Asset set asset
+func (s *ListMarginTransfersService) Asset(asset string) *ListMarginTransfersService {
+	s.asset = asset
+	return s
+}
+
+// Type set the direction of the transfers, ROLL_IN to the margin account or ROLL_OUT from it
+func (s *ListMarginTransfersService) Type(transferType MarginTransferDirectionType) *ListMarginTransfersService {
+	s.transferType = transferType
+	return s
+}
+
+// StartTime set start time
+func (s *ListMarginTransfersService) StartTime(startTime int64) *ListMarginTransfersService {
+	s.startTime = &startTime
+	return s
+}
+
+// EndTime set end time
+func (s *ListMarginTransfersService) EndTime(endTime int64) *ListMarginTransfersService {
+	s.endTime = &endTime
+	return s
+}
+
+// Current currently querying page. Start from 1. Default:1
+func (s *ListMarginTransfersService) Current(current int64) *ListMarginTransfersService {
+	s.current = &current
+	return s
+}
+
+// Size default:10 max:100
+func (s *ListMarginTransfersService) Size(size int64) *ListMarginTransfersService {
+	s.size = &size
+	return s
+}
+
+// Archived set whether to query the transfers older than 6 months
+func (s *ListMarginTransfersService) Archived(archived bool) *ListMarginTransfersService {
+	s.archived = &archived
+	return s
+}
+
+// Do send request
+func (s *ListMarginTransfersService) Do(ctx context.Context, opts ...RequestOption) (res *MarginTransferResponse, err error) {
+	r := &request{
+		method:   "GET",
+		endpoint: "/sapi/v1/margin/transfer",
+		secType:  secTypeSigned,
+	}
+	if s.asset != "" {
+		r.setParam("asset", s.asset)
+	}
+	if s.transferType != "" {
+		r.setParam("type", s.transferType)
+	}
+	if s.startTime != nil {
+		r.setParam("startTime", *s.startTime)
+	}
+	if s.endTime != nil {
+		r.setParam("endTime", *s.endTime)
+	}
+	if s.current != nil {
+		r.setParam("current", *s.current)
+	}
+	if s.size != nil {
+		r.setParam("size", *s.size)
+	}
+	if s.archived != nil {
+		r.setParam("archived", *s.archived)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(MarginTransferResponse)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// MarginTransferResponse define margin transfer history response
+type MarginTransferResponse struct {
+	Rows  []MarginTransfer `json:"rows"`
+	Total int64            `json:"total"`
+}
+
+// MarginTransfer define a transfer between the spot account and the cross margin account
+type MarginTransfer struct {
+	Amount    string                      `json:"amount"`
+	Asset     string                      `json:"asset"`
+	Status    MarginTransferStatusType    `json:"status"`
+	Timestamp int64                       `json:"timestamp"`
+	TxID      int64                       `json:"txId"`
+	Type      MarginTransferDirectionType `json:"type"`
+}
@@ -0,0 +1,217 @@
+package binance
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type marginHistoryServiceTestSuite struct {
+	baseTestSuite
+}
+
+func TestMarginHistoryService(t *testing.T) {
+	suite.Run(t, new(marginHistoryServiceTestSuite))
+}
+
+func (s *marginHistoryServiceTestSuite) TestListInterests() {
+	data := []byte(`{
+		"rows": [
+			{
+				"isolatedSymbol": "BNBUSDT",
+				"asset": "BNB",
+				"interest": "0.02414667",
+				"interestAccuredTime": 1566813600000,
+				"interestRate": "0.01600000",
+				"principal": "36.22000000",
+				"type": "ON_BORROW"
+			}
+		],
+		"total": 1
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setParams(params{
+			"asset":          "BNB",
+			"isolatedSymbol": "BNBUSDT",
+			"startTime":      int64(1566813600000),
+			"current":        int64(2),
+			"size":           int64(10),
+			"archived":       true,
+		})
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewListMarginInterestsService().Asset("BNB").IsolatedSymbol("BNBUSDT").
+		StartTime(1566813600000).Current(2).Size(10).Archived(true).Do(newContext())
+	s.r().NoError(err)
+	s.r().Equal(&MarginInterestResponse{
+		Rows: []MarginInterest{
+			{
+				IsolatedSymbol:      "BNBUSDT",
+				Asset:               "BNB",
+				Interest:            "0.02414667",
+				InterestAccuredTime: 1566813600000,
+				InterestRate:        "0.01600000",
+				Principal:           "36.22000000",
+				Type:                MarginInterestTypeOnBorrow,
+			},
+		},
+		Total: 1,
+	}, res)
+}
+
+func (s *marginHistoryServiceTestSuite) TestListForceLiquidations() {
+	data := []byte(`{
+		"rows": [
+			{
+				"avgPrice": "0.00388359",
+				"executedQty": "31.39000000",
+				"orderId": 180015097,
+				"price": "0.00388110",
+				"qty": "31.39000000",
+				"side": "SELL",
+				"symbol": "BNBBTC",
+				"timeInForce": "GTC",
+				"isIsolated": true,
+				"updatedTime": 1558941374745
+			}
+		],
+		"total": 1
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setParams(params{
+			"isolatedSymbol": "BNBBTC",
+			"endTime":        int64(1558941374745),
+			"size":           int64(100),
+		})
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewListMarginForceLiquidationsService().IsolatedSymbol("BNBBTC").
+		EndTime(1558941374745).Size(100).Do(newContext())
+	s.r().NoError(err)
+	s.r().Equal(&MarginForceLiquidationResponse{
+		Rows: []MarginForceLiquidation{
+			{
+				AvgPrice:    "0.00388359",
+				ExecutedQty: "31.39000000",
+				OrderID:     180015097,
+				Price:       "0.00388110",
+				Qty:         "31.39000000",
+				Side:        SideTypeSell,
+				Symbol:      "BNBBTC",
+				TimeInForce: TimeInForceTypeGTC,
+				IsIsolated:  true,
+				UpdatedTime: 1558941374745,
+			},
+		},
+		Total: 1,
+	}, res)
+}
+
+func (s *marginHistoryServiceTestSuite) TestListInterestRates() {
+	data := []byte(`[
+		{
+			"asset": "BTC",
+			"dailyInterestRate": "0.00025000",
+			"timestamp": 1611544731000,
+			"vipLevel": 1
+		}
+	]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setParams(params{
+			"asset":    "BTC",
+			"vipLevel": 1,
+			"limit":    5,
+		})
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewListMarginInterestRatesService().Asset("BTC").VipLevel(1).Limit(5).Do(newContext())
+	s.r().NoError(err)
+	s.r().Equal([]*MarginInterestRate{{
+		Asset:             "BTC",
+		DailyInterestRate: "0.00025000",
+		Timestamp:         1611544731000,
+		VipLevel:          1,
+	}}, res)
+}
+
+func (s *marginHistoryServiceTestSuite) TestGetCrossMarginData() {
+	data := []byte(`[
+		{
+			"vipLevel": 0,
+			"coin": "BTC",
+			"transferIn": true,
+			"borrowable": true,
+			"dailyInterest": "0.00026125",
+			"yearlyInterest": "0.0953",
+			"borrowLimit": "180",
+			"marginablePairs": ["BNBBTC", "TRXBTC"]
+		}
+	]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setParams(params{
+			"vipLevel": 0,
+			"coin":     "BTC",
+		})
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewGetCrossMarginDataService().VipLevel(0).Coin("BTC").Do(newContext())
+	s.r().NoError(err)
+	s.r().Equal([]*CrossMarginData{{
+		Coin:            "BTC",
+		TransferIn:      true,
+		Borrowable:      true,
+		DailyInterest:   "0.00026125",
+		YearlyInterest:  "0.0953",
+		BorrowLimit:     "180",
+		MarginablePairs: []string{"BNBBTC", "TRXBTC"},
+	}}, res)
+}
+
+func (s *marginHistoryServiceTestSuite) TestListTransfers() {
+	data := []byte(`{
+		"rows": [
+			{
+				"amount": "0.10000000",
+				"asset": "BNB",
+				"status": "CONFIRMED",
+				"timestamp": 1566898617,
+				"txId": 5240372201,
+				"type": "ROLL_IN"
+			}
+		],
+		"total": 1
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setParams(params{
+			"asset": "BNB",
+			"type":  MarginTransferDirectionTypeRollIn,
+		})
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewListMarginTransfersService().Asset("BNB").
+		Type(MarginTransferDirectionTypeRollIn).Do(newContext())
+	s.r().NoError(err)
+	s.r().Equal(&MarginTransferResponse{
+		Rows: []MarginTransfer{
+			{
+				Amount:    "0.10000000",
+				Asset:     "BNB",
+				Status:    MarginTransferStatusTypeConfirmed,
+				Timestamp: 1566898617,
+				TxID:      5240372201,
+				Type:      MarginTransferDirectionTypeRollIn,
+			},
+		},
+		Total: 1,
+	}, res)
+}
@@ -21,6 +21,8 @@ type CreateMarginOrderService struct {
 	sideEffectType   *SideEffectType
 	timeInForce      *TimeInForceType
 	isIsolated       *bool
+
+	selfTradePreventionMode *SelfTradePreventionMode
 }
 
 // Symbol set symbol
@@ -101,6 +103,12 @@ func (s *CreateMarginOrderService) SideEffectType(sideEffectType SideEffectType)
 	return s
 }
 
+// SelfTradePreventionMode set selfTradePreventionMode
+func (s *CreateMarginOrderService) SelfTradePreventionMode(selfTradePreventionMode SelfTradePreventionMode) *CreateMarginOrderService {
+	s.selfTradePreventionMode = &selfTradePreventionMode
+	return s
+}
+
 // Do send request
 func (s *CreateMarginOrderService) Do(ctx context.Context, opts ...RequestOption) (res *CreateOrderResponse, err error) {
 	r := &request{
@@ -147,6 +155,9 @@ func (s *CreateMarginOrderService) Do(ctx context.Context, opts ...RequestOption
 	if s.sideEffectType != nil {
 		m["sideEffectType"] = *s.sideEffectType
 	}
+	if s.selfTradePreventionMode != nil {
+		m["selfTradePreventionMode"] = *s.selfTradePreventionMode
+	}
 	r.setFormParams(m)
 	res = new(CreateOrderResponse)
 	data, err := s.c.callAPI(ctx, r, opts...)
@@ -440,3 +451,507 @@ type CancelMarginOrderResponse struct {
 	Type                     OrderType       `json:"type"`
 	Side                     SideType        `json:"side"`
 }
+
+// CreateMarginOCOService create a margin OCO order
+type CreateMarginOCOService struct {
+	c                    *Client
+	symbol               string
+	isIsolated           *bool
+	listClientOrderID    *string
+	side                 SideType
+	quantity             string
+	limitClientOrderID   *string
+	price                string
+	limitIcebergQty      *string
+	stopClientOrderID    *string
+	stopPrice            string
+	stopLimitPrice       *string
+	stopIcebergQty       *string
+	stopLimitTimeInForce *TimeInForceType
+	newOrderRespType     *NewOrderRespType
+	sideEffectType       *SideEffectType
+
+	selfTradePreventionMode *SelfTradePreventionMode
+}
+
+// Symbol set symbol
+func (s *CreateMarginOCOService) Symbol(symbol string) *CreateMarginOCOService {
+	s.symbol = symbol
+	return s
+}
+
+// IsIsolated sets the order list to isolated margin
+func (s *CreateMarginOCOService) IsIsolated(isIsolated bool) *CreateMarginOCOService {
+	s.isIsolated = &isIsolated
+	return s
+}
+
+// ListClientOrderID set listClientOrderId
+func (s *CreateMarginOCOService) ListClientOrderID(listClientOrderID string) *CreateMarginOCOService {
+	s.listClientOrderID = &listClientOrderID
+	return s
+}
+
+// Side set side
+func (s *CreateMarginOCOService) Side(side SideType) *CreateMarginOCOService {
+	s.side = side
+	return s
+}
+
+// Quantity set quantity
+func (s *CreateMarginOCOService) Quantity(quantity string) *CreateMarginOCOService {
+	s.quantity = quantity
+	return s
+}
+
+// LimitClientOrderID set limitClientOrderId
+func (s *CreateMarginOCOService) LimitClientOrderID(limitClientOrderID string) *CreateMarginOCOService {
+	s.limitClientOrderID = &limitClientOrderID
+	return s
+}
+
+// Price set the price of the limit order
+func (s *CreateMarginOCOService) Price(price string) *CreateMarginOCOService {
+	s.price = price
+	return s
+}
+
+// LimitIcebergQuantity set limitIcebergQty
+func (s *CreateMarginOCOService) LimitIcebergQuantity(limitIcebergQty string) *CreateMarginOCOService {
+	s.limitIcebergQty = &limitIcebergQty
+	return s
+}
+
+// StopClientOrderID set stopClientOrderId
+func (s *CreateMarginOCOService) StopClientOrderID(stopClientOrderID string) *CreateMarginOCOService {
+	s.stopClientOrderID = &stopClientOrderID
+	return s
+}
+
+// StopPrice set stop price
+func (s *CreateMarginOCOService) StopPrice(stopPrice string) *CreateMarginOCOService {
+	s.stopPrice = stopPrice
+	return s
+}
+
+// StopLimitPrice set stop limit price, the stop order is a stop limit order when set
+func (s *CreateMarginOCOService) StopLimitPrice(stopLimitPrice string) *CreateMarginOCOService {
+	s.stopLimitPrice = &stopLimitPrice
+	return s
+}
+
+// StopIcebergQuantity set stopIcebergQty
+func (s *CreateMarginOCOService) StopIcebergQuantity(stopIcebergQty string) *CreateMarginOCOService {
+	s.stopIcebergQty = &stopIcebergQty
+	return s
+}
+
+// StopLimitTimeInForce set stopLimitTimeInForce
+func (s *CreateMarginOCOService) StopLimitTimeInForce(stopLimitTimeInForce TimeInForceType) *CreateMarginOCOService {
+	s.stopLimitTimeInForce = &stopLimitTimeInForce
+	return s
+}
+
+// NewOrderRespType set newOrderRespType
+func (s *CreateMarginOCOService) NewOrderRespType(newOrderRespType NewOrderRespType) *CreateMarginOCOService {
+	s.newOrderRespType = &newOrderRespType
+	return s
+}
+
+// SideEffectType set sideEffectType
+func (s *CreateMarginOCOService) SideEffectType(sideEffectType SideEffectType) *CreateMarginOCOService {
+	s.sideEffectType = &sideEffectType
+	return s
+}
+
+// SelfTradePreventionMode set selfTradePreventionMode
+func (s *CreateMarginOCOService) SelfTradePreventionMode(selfTradePreventionMode SelfTradePreventionMode) *CreateMarginOCOService {
+	s.selfTradePreventionMode = &selfTradePreventionMode
+	return s
+}
+
+// Do send request
+func (s *CreateMarginOCOService) Do(ctx context.Context, opts ...RequestOption) (res *CreateMarginOCOResponse, err error) {
+	r := &request{
+		method:   "POST",
+		endpoint: "/sapi/v1/margin/order/oco",
+		secType:  secTypeSigned,
+	}
+	m := params{
+		"symbol":    s.symbol,
+		"side":      s.side,
+		"quantity":  s.quantity,
+		"price":     s.price,
+		"stopPrice": s.stopPrice,
+	}
+	if s.isIsolated != nil {
+		if *s.isIsolated {
+			m["isIsolated"] = "TRUE"
+		} else {
+			m["isIsolated"] = "FALSE"
+		}
+	}
+	if s.listClientOrderID != nil {
+		m["listClientOrderId"] = *s.listClientOrderID
+	}
+	if s.limitClientOrderID != nil {
+		m["limitClientOrderId"] = *s.limitClientOrderID
+	}
+	if s.limitIcebergQty != nil {
+		m["limitIcebergQty"] = *s.limitIcebergQty
+	}
+	if s.stopClientOrderID != nil {
+		m["stopClientOrderId"] = *s.stopClientOrderID
+	}
+	if s.stopLimitPrice != nil {
+		m["stopLimitPrice"] = *s.stopLimitPrice
+	}
+	if s.stopIcebergQty != nil {
+		m["stopIcebergQty"] = *s.stopIcebergQty
+	}
+	if s.stopLimitTimeInForce != nil {
+		m["stopLimitTimeInForce"] = *s.stopLimitTimeInForce
+	}
+	if s.newOrderRespType != nil {
+		m["newOrderRespType"] = *s.newOrderRespType
+	}
+	if s.sideEffectType != nil {
+		m["sideEffectType"] = *s.sideEffectType
+	}
+	if s.selfTradePreventionMode != nil {
+		m["selfTradePreventionMode"] = *s.selfTradePreventionMode
+	}
+	r.setFormParams(m)
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(CreateMarginOCOResponse)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// CreateMarginOCOResponse define create margin OCO order response
+type CreateMarginOCOResponse struct {
+	CreateOCOResponse
+	MarginBuyBorrowAmount string `json:"marginBuyBorrowAmount"`
+	MarginBuyBorrowAsset  string `json:"marginBuyBorrowAsset"`
+	IsIsolated            bool   `json:"isIsolated"`
+}
+
+// CancelMarginOCOService cancel all active orders of a margin order list
+type CancelMarginOCOService struct {
+	c                 *Client
+	symbol            string
+	isIsolated        bool
+	orderListID       *int64
+	listClientOrderID *string
+	newClientOrderID  *string
+}
+
+// Symbol set symbol
+func (s *CancelMarginOCOService) Symbol(symbol string) *CancelMarginOCOService {
+	s.symbol = symbol
+	return s
+}
+
+// IsIsolated set isIsolated
+func (s *CancelMarginOCOService) IsIsolated(isIsolated bool) *CancelMarginOCOService {
+	s.isIsolated = isIsolated
+	return s
+}
+
+// OrderListID set orderListId
+func (s *CancelMarginOCOService) OrderListID(orderListID int64) *CancelMarginOCOService {
+	s.orderListID = &orderListID
+	return s
+}
+
+// ListClientOrderID set listClientOrderId
+func (s *CancelMarginOCOService) ListClientOrderID(listClientOrderID string) *CancelMarginOCOService {
+	s.listClientOrderID = &listClientOrderID
+	return s
+}
+
+// NewClientOrderID set newClientOrderId
+func (s *CancelMarginOCOService) NewClientOrderID(newClientOrderID string) *CancelMarginOCOService {
+	s.newClientOrderID = &newClientOrderID
+	return s
+}
+
+// Do send request
+func (s *CancelMarginOCOService) Do(ctx context.Context, opts ...RequestOption) (res *CancelMarginOCOResponse, err error) {
+	r := &request{
+		method:   "DELETE",
+		endpoint: "/sapi/v1/margin/orderList",
+		secType:  secTypeSigned,
+	}
+	r.setFormParam("symbol", s.symbol)
+	if s.isIsolated {
+		r.setFormParam("isIsolated", "TRUE")
+	}
+	if s.orderListID != nil {
+		r.setFormParam("orderListId", *s.orderListID)
+	}
+	if s.listClientOrderID != nil {
+		r.setFormParam("listClientOrderId", *s.listClientOrderID)
+	}
+	if s.newClientOrderID != nil {
+		r.setFormParam("newClientOrderId", *s.newClientOrderID)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(CancelMarginOCOResponse)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// CancelMarginOCOResponse define cancel margin OCO order response
+type CancelMarginOCOResponse struct {
+	CancelOCOResponse
+	IsIsolated bool `json:"isIsolated"`
+}
+
+// MarginOCO define a margin order list as returned by the margin order list query endpoints
+type MarginOCO struct {
+	OCO
+	IsIsolated bool `json:"isIsolated"`
+}
+
+// GetMarginOCOService get a margin order list by orderListId or origClientOrderId
+type GetMarginOCOService struct {
+	c                 *Client
+	symbol            string
+	isIsolated        bool
+	orderListID       *int64
+	origClientOrderID *string
+}
+
+// Symbol set symbol, mandatory for isolated margin
+func (s *GetMarginOCOService) Symbol(symbol string) *GetMarginOCOService {
+	s.symbol = symbol
+	return s
+}
+
+// IsIsolated set isIsolated
+func (s *GetMarginOCOService) IsIsolated(isIsolated bool) *GetMarginOCOService {
+	s.isIsolated = isIsolated
+	return s
+}
+
+// OrderListID set orderListId
+func (s *GetMarginOCOService) OrderListID(orderListID int64) *GetMarginOCOService {
+	s.orderListID = &orderListID
+	return s
+}
+
+// OrigClientOrderID set origClientOrderId, the listClientOrderId of the order list
+func (s *GetMarginOCOService) OrigClientOrderID(origClientOrderID string) *GetMarginOCOService {
+	s.origClientOrderID = &origClientOrderID
+	return s
+}
+
+// Do send request
+func (s *GetMarginOCOService) Do(ctx context.Context, opts ...RequestOption) (res *MarginOCO, err error) {
+	r := &request{
+		method:   "GET",
+		endpoint: "/sapi/v1/margin/orderList",
+		secType:  secTypeSigned,
+	}
+	if s.symbol != "" {
+		r.setParam("symbol", s.symbol)
+	}
+	if s.isIsolated {
+		r.setParam("isIsolated", "TRUE")
+	}
+	if s.orderListID != nil {
+		r.setParam("orderListId", *s.orderListID)
+	}
+	if s.origClientOrderID != nil {
+		r.setParam("origClientOrderId", *s.origClientOrderID)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(MarginOCO)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ListMarginOCOService list all margin order lists, from fromId or within a time window
+type ListMarginOCOService struct {
+	c          *Client
+	symbol     string
+	isIsolated bool
+	fromID     *int64
+	startTime  *int64
+	endTime    *int64
+	limit      *int
+}
+
+// Symbol set symbol, mandatory for isolated margin
+func (s *ListMarginOCOService) Symbol(symbol string) *ListMarginOCOService {
+	s.symbol = symbol
+	return s
+}
+
+// IsIsolated set isIsolated
+func (s *ListMarginOCOService) IsIsolated(isIsolated bool) *ListMarginOCOService {
+	s.isIsolated = isIsolated
+	return s
+}
+
+// FromID set fromId, the order lists from this orderListId are returned
+// and startTime and endTime can not be used
+func (s *ListMarginOCOService) FromID(fromID int64) *ListMarginOCOService {
+	s.fromID = &fromID
+	return s
+}
+
+// StartTime set startTime
+func (s *ListMarginOCOService) StartTime(startTime int64) *ListMarginOCOService {
+	s.startTime = &startTime
+	return s
+}
+
+// EndTime set endTime
+func (s *ListMarginOCOService) EndTime(endTime int64) *ListMarginOCOService {
+	s.endTime = &endTime
+	return s
+}
+
+// Limit set limit, default 500 and max 1000
+func (s *ListMarginOCOService) Limit(limit int) *ListMarginOCOService {
+	s.limit = &limit
+	return s
+}
+
+// Do send request
+func (s *ListMarginOCOService) Do(ctx context.Context, opts ...RequestOption) (res []*MarginOCO, err error) {
+	r := &request{
+		method:   "GET",
+		endpoint: "/sapi/v1/margin/allOrderList",
+		secType:  secTypeSigned,
+	}
+	if s.symbol != "" {
+		r.setParam("symbol", s.symbol)
+	}
+	if s.isIsolated {
+		r.setParam("isIsolated", "TRUE")
+	}
+	if s.fromID != nil {
+		r.setParam("fromId", *s.fromID)
+	}
+	if s.startTime != nil {
+		r.setParam("startTime", *s.startTime)
+	}
+	if s.endTime != nil {
+		r.setParam("endTime", *s.endTime)
+	}
+	if s.limit != nil {
+		r.setParam("limit", *s.limit)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*MarginOCO{}, err
+	}
+	res = make([]*MarginOCO, 0)
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return []*MarginOCO{}, err
+	}
+	return res, nil
+}
+
+// ListMarginOpenOCOService list the open margin order lists
+type ListMarginOpenOCOService struct {
+	c          *Client
+	symbol     string
+	isIsolated bool
+}
+
+// Symbol set symbol, mandatory for isolated margin
+func (s *ListMarginOpenOCOService) Symbol(symbol string) *ListMarginOpenOCOService {
+	s.symbol = symbol
+	return s
+}
+
+// IsIsolated set isIsolated
+func (s *ListMarginOpenOCOService) IsIsolated(isIsolated bool) *ListMarginOpenOCOService {
+	s.isIsolated = isIsolated
+	return s
+}
+
+// Do send request
+func (s *ListMarginOpenOCOService) Do(ctx context.Context, opts ...RequestOption) (res []*MarginOCO, err error) {
+	r := &request{
+		method:   "GET",
+		endpoint: "/sapi/v1/margin/openOrderList",
+		secType:  secTypeSigned,
+	}
+	if s.symbol != "" {
+		r.setParam("symbol", s.symbol)
+	}
+	if s.isIsolated {
+		r.setParam("isIsolated", "TRUE")
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*MarginOCO{}, err
+	}
+	res = make([]*MarginOCO, 0)
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return []*MarginOCO{}, err
+	}
+	return res, nil
+}
+
+// CancelMarginOpenOrdersService cancel all active margin orders on a symbol, including the order lists
+type CancelMarginOpenOrdersService struct {
+	c          *Client
+	symbol     string
+	isIsolated bool
+}
+
+// Symbol set symbol
+func (s *CancelMarginOpenOrdersService) Symbol(symbol string) *CancelMarginOpenOrdersService {
+	s.symbol = symbol
+	return s
+}
+
+// IsIsolated set isIsolated
+func (s *CancelMarginOpenOrdersService) IsIsolated(isIsolated bool) *CancelMarginOpenOrdersService {
+	s.isIsolated = isIsolated
+	return s
+}
+
+// Do send request
+func (s *CancelMarginOpenOrdersService) Do(ctx context.Context, opts ...RequestOption) (res *CancelOpenOrdersResponse, err error) {
+	r := &request{
+		method:   "DELETE",
+		endpoint: "/sapi/v1/margin/openOrders",
+		secType:  secTypeSigned,
+	}
+	r.setParam("symbol", s.symbol)
+	if s.isIsolated {
+		r.setParam("isIsolated", "TRUE")
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return &CancelOpenOrdersResponse{}, err
+	}
+	return parseCancelOpenOrdersResponse(data)
+}
@@ -408,6 +408,243 @@ func (s *marginOrderServiceTestSuite) TestListMarginOrders() {
 	}
 }
 
+func (s *marginOrderServiceTestSuite) TestCreateOCO() {
+	data := []byte(`{
+		"orderListId": 0,
+		"contingencyType": "OCO",
+		"listStatusType": "EXEC_STARTED",
+		"listOrderStatus": "EXECUTING",
+		"listClientOrderId": "C3wyj4WVEktd7u9aVBRXcN",
+		"transactionTime": 1574040868128,
+		"symbol": "LTCBTC",
+		"marginBuyBorrowAmount": "5",
+		"marginBuyBorrowAsset": "BTC",
+		"isIsolated": true,
+		"orders": [
+			{
+				"symbol": "LTCBTC",
+				"orderId": 2,
+				"clientOrderId": "pO9ufTiFGg3nw2fOdgeOXa"
+			},
+			{
+				"symbol": "LTCBTC",
+				"orderId": 3,
+				"clientOrderId": "TXOvglzXuaubXAaENpaRCB"
+			}
+		],
+		"orderReports": []
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setFormParams(params{
+			"symbol":               "LTCBTC",
+			"isIsolated":           "TRUE",
+			"side":                 SideTypeBuy,
+			"quantity":             "10",
+			"price":                "3",
+			"stopPrice":            "3.1",
+			"stopLimitPrice":       "3.2",
+			"stopLimitTimeInForce": TimeInForceTypeGTC,
+			"sideEffectType":       SideEffectTypeMarginBuy,
+		})
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewCreateMarginOCOService().Symbol("LTCBTC").IsIsolated(true).
+		Side(SideTypeBuy).Quantity("10").Price("3").StopPrice("3.1").StopLimitPrice("3.2").
+		StopLimitTimeInForce(TimeInForceTypeGTC).SideEffectType(SideEffectTypeMarginBuy).
+		Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal(int64(0), res.OrderListID)
+	r.Equal("C3wyj4WVEktd7u9aVBRXcN", res.ListClientOrderID)
+	r.Equal("5", res.MarginBuyBorrowAmount)
+	r.Equal("BTC", res.MarginBuyBorrowAsset)
+	r.True(res.IsIsolated)
+	r.Len(res.Orders, 2)
+	r.Equal(int64(3), res.Orders[1].OrderID)
+}
+
+func (s *marginOrderServiceTestSuite) TestCancelOCO() {
+	data := []byte(`{
+		"orderListId": 1929,
+		"contingencyType": "OCO",
+		"listStatusType": "ALL_DONE",
+		"listOrderStatus": "ALL_DONE",
+		"listClientOrderId": "2inzWQdDvZLHbbAmAozX2N",
+		"transactionTime": 1585230948299,
+		"symbol": "BTCUSDT",
+		"isIsolated": false,
+		"orders": [
+			{
+				"symbol": "BTCUSDT",
+				"orderId": 20,
+				"clientOrderId": "CwOOIPHSmYywx6jZX77TdL"
+			}
+		],
+		"orderReports": [
+			{
+				"symbol": "BTCUSDT",
+				"origClientOrderId": "CwOOIPHSmYywx6jZX77TdL",
+				"orderId": 20,
+				"orderListId": 1929,
+				"clientOrderId": "pXLV6Hz6mprAcVYpVMTGgx",
+				"status": "CANCELED"
+			}
+		]
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setFormParams(params{
+			"symbol":      "BTCUSDT",
+			"orderListId": int64(1929),
+		})
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewCancelMarginOCOService().Symbol("BTCUSDT").OrderListID(1929).Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal(int64(1929), res.OrderListID)
+	r.False(res.IsIsolated)
+	r.Len(res.OrderReports, 1)
+	r.Equal(OrderStatusTypeCanceled, res.OrderReports[0].Status)
+}
+
+func (s *marginOrderServiceTestSuite) TestGetOCO() {
+	data := []byte(`{
+		"orderListId": 27,
+		"contingencyType": "OCO",
+		"listStatusType": "EXEC_STARTED",
+		"listOrderStatus": "EXECUTING",
+		"listClientOrderId": "h2USkA5YQpaXHPIrkd96xE",
+		"transactionTime": 1565245656253,
+		"symbol": "LTCBTC",
+		"isIsolated": true,
+		"orders": [
+			{
+				"symbol": "LTCBTC",
+				"orderId": 4,
+				"clientOrderId": "qD1gy3kc3Gx0rihm9Y3xwS"
+			}
+		]
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setParams(params{
+			"symbol":      "LTCBTC",
+			"isIsolated":  "TRUE",
+			"orderListId": int64(27),
+		})
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewGetMarginOCOService().Symbol("LTCBTC").IsIsolated(true).OrderListID(27).Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal(int64(27), res.OrderListID)
+	r.True(res.IsIsolated)
+	r.Len(res.Orders, 1)
+}
+
+func (s *marginOrderServiceTestSuite) TestListOCO() {
+	data := []byte(`[{
+		"orderListId": 29,
+		"contingencyType": "OCO",
+		"listStatusType": "EXEC_STARTED",
+		"listOrderStatus": "EXECUTING",
+		"listClientOrderId": "amEEAXryFzFwYF1FeRpUoZ",
+		"transactionTime": 1565245913483,
+		"symbol": "LTCBTC",
+		"isIsolated": false
+	}]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setParams(params{
+			"fromId": int64(29),
+			"limit":  10,
+		})
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewListMarginOCOService().FromID(29).Limit(10).Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Len(res, 1)
+	r.Equal(int64(29), res[0].OrderListID)
+	r.Equal("LTCBTC", res[0].Symbol)
+}
+
+func (s *marginOrderServiceTestSuite) TestListOpenOCO() {
+	data := []byte(`[{
+		"orderListId": 31,
+		"contingencyType": "OCO",
+		"listStatusType": "EXEC_STARTED",
+		"listOrderStatus": "EXECUTING",
+		"listClientOrderId": "wuB13fmulKj3YjdqWEcsnp",
+		"transactionTime": 1565246080644,
+		"symbol": "BTCUSDT",
+		"isIsolated": true
+	}]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setParams(params{
+			"symbol":     "BTCUSDT",
+			"isIsolated": "TRUE",
+		})
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewListMarginOpenOCOService().Symbol("BTCUSDT").IsIsolated(true).Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Len(res, 1)
+	r.Equal(int64(31), res[0].OrderListID)
+	r.True(res[0].IsIsolated)
+}
+
+func (s *marginOrderServiceTestSuite) TestCancelOpenOrders() {
+	data := []byte(`[
+		{
+			"symbol": "BTCUSDT",
+			"isIsolated": true,
+			"origClientOrderId": "E6APeyTJvkMvLMYMqu1KQ4",
+			"orderId": 11,
+			"orderListId": -1,
+			"clientOrderId": "pXLV6Hz6mprAcVYpVMTGgx",
+			"status": "CANCELED",
+			"side": "BUY"
+		},
+		{
+			"orderListId": 1929,
+			"contingencyType": "OCO",
+			"listStatusType": "ALL_DONE",
+			"listOrderStatus": "ALL_DONE",
+			"listClientOrderId": "2inzWQdDvZLHbbAmAozX2N",
+			"transactionTime": 1585230948299,
+			"symbol": "BTCUSDT",
+			"isIsolated": true
+		}
+	]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setParams(params{
+			"symbol":     "BTCUSDT",
+			"isIsolated": "TRUE",
+		})
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewCancelMarginOpenOrdersService().Symbol("BTCUSDT").IsIsolated(true).Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Len(res.Orders, 1)
+	r.Equal(int64(11), res.Orders[0].OrderID)
+	r.Equal(OrderStatusTypeCanceled, res.Orders[0].Status)
+	r.Len(res.OCOOrders, 1)
+	r.Equal(int64(1929), res.OCOOrders[0].OrderListID)
+}
+
 func (s *marginOrderServiceTestSuite) assertMarginAllOrderEqual(e, a *Order) {
 	r := s.r()
 	r.Equal(e.OrderID, a.OrderID, "OrderID")
@@ -100,9 +100,6 @@ func (s *MarginLoanService) Do(ctx context.Context, opts ...RequestOption) (res
 		"amount": s.amount,
 	}
 	r.setFormParams(m)
-	if s.isolatedSymbol != "" {
-		r.setParam("isolatedSymbol", s.isolatedSymbol)
-	}
 	res = new(TransactionResponse)
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
@@ -117,10 +114,11 @@ func (s *MarginLoanService) Do(ctx context.Context, opts ...RequestOption) (res
 
 // MarginRepayService repay loan for margin account
 type MarginRepayService struct {
-	c              *Client
-	asset          string
-	amount         string
-	isolatedSymbol string
+	c          *Client
+	asset      string
+	amount     string
+	isIsolated bool
+	symbol     string
 }
 
 // Asset set asset being transferred, e.g., BTC
@@ -135,9 +133,22 @@ func (s *MarginRepayService) Amount(amount string) *MarginRepayService {
 	return s
 }
 
-// IsolatedSymbol set IsolatedSymbol
+// IsolatedSymbol repay in the isolated margin account of isolatedSymbol, it sets IsIsolated and Symbol.
+//
+// Deprecated: use IsIsolated and Symbol.
 func (s *MarginRepayService) IsolatedSymbol(isolatedSymbol string) *MarginRepayService {
-	s.isolatedSymbol = isolatedSymbol
+	return s.IsIsolated(true).Symbol(isolatedSymbol)
+}
+
+// IsIsolated set isIsolated, the repay is made in the isolated margin account of Symbol
+func (s *MarginRepayService) IsIsolated(isIsolated bool) *MarginRepayService {
+	s.isIsolated = isIsolated
+	return s
+}
+
+// Symbol set symbol, the isolated margin account repaid with IsIsolated
+func (s *MarginRepayService) Symbol(symbol string) *MarginRepayService {
+	s.symbol = symbol
 	return s
 }
 
@@ -152,10 +163,13 @@ func (s *MarginRepayService) Do(ctx context.Context, opts ...RequestOption) (res
 		"asset":  s.asset,
 		"amount": s.amount,
 	}
-	r.setFormParams(m)
-	if s.isolatedSymbol != "" {
-		r.setParam("isolatedSymbol", s.isolatedSymbol)
+	if s.isIsolated {
+		m["isIsolated"] = "TRUE"
 	}
+	if s.symbol != "" {
+		m["symbol"] = s.symbol
+	}
+	r.setFormParams(m)
 	res = new(TransactionResponse)
 	data, err := s.c.callAPI(ctx, r, opts...)
 	if err != nil {
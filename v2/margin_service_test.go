@@ -92,6 +92,49 @@ func (s *marginTestSuite) TestRepay() {
 	s.assertTransactionResponseEqual(e, res)
 }
 
+func (s *marginTestSuite) TestRepayIsolated() {
+	data := []byte(`{
+		"tranId": 100000001
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	asset := "BTC"
+	amount := "1.000"
+	symbol := "BTCUSDT"
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setFormParams(params{
+			"asset":      asset,
+			"amount":     amount,
+			"isIsolated": "TRUE",
+			"symbol":     symbol,
+		})
+		s.assertRequestEqual(e, r)
+	})
+	_, err := s.client.NewMarginRepayService().Asset(asset).
+		Amount(amount).IsIsolated(true).Symbol(symbol).Do(newContext())
+	s.r().NoError(err)
+}
+
+func (s *marginTestSuite) TestRepayIsolatedSymbol() {
+	data := []byte(`{
+		"tranId": 100000001
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setFormParams(params{
+			"asset":      "BTC",
+			"amount":     "1.000",
+			"isIsolated": "TRUE",
+			"symbol":     "BTCUSDT",
+		})
+		s.assertRequestEqual(e, r)
+	})
+	_, err := s.client.NewMarginRepayService().Asset("BTC").
+		Amount("1.000").IsolatedSymbol("BTCUSDT").Do(newContext())
+	s.r().NoError(err)
+}
+
 func (s *marginTestSuite) TestListMarginLoans() {
 	data := []byte(`{
 		"rows": [
@@ -0,0 +1,77 @@
+package binance
+
+import (
+	"github.com/Zamzam-Technology/go-binance/v2/common"
+)
+
+// OrderFilters return the filters of the symbol used to check orders before they are sent
+func (s *Symbol) OrderFilters() *common.OrderFilters {
+	f := &common.OrderFilters{Symbol: s.Symbol}
+	if p := s.PriceFilter(); p != nil {
+		f.Price = &common.PriceRule{MinPrice: p.MinPrice, MaxPrice: p.MaxPrice, TickSize: p.TickSize}
+	}
+	if l := s.LotSizeFilter(); l != nil {
+		f.LotSize = &common.LotSizeRule{MinQuantity: l.MinQuantity, MaxQuantity: l.MaxQuantity, StepSize: l.StepSize}
+	}
+	if l := s.MarketLotSizeFilter(); l != nil {
+		f.MarketLotSize = &common.LotSizeRule{MinQuantity: l.MinQuantity, MaxQuantity: l.MaxQuantity, StepSize: l.StepSize}
+	}
+	if n := s.MinNotionalFilter(); n != nil {
+		f.MinNotional = &common.MinNotionalRule{MinNotional: n.MinNotional, ApplyToMarket: n.ApplyToMarket}
+	}
+	if p := s.PercentPriceFilter(); p != nil {
+		f.PercentPrice = &common.PercentPriceRule{MultiplierUp: p.MultiplierUp, MultiplierDown: p.MultiplierDown}
+	}
+	if i := s.IcebergPartsFilter(); i != nil {
+		f.IcebergParts = int64(i.Limit)
+	}
+	return f
+}
+
+func spotOrderParams(side SideType, orderType OrderType, price, stopPrice, quantity, quoteOrderQty, icebergQuantity *string) *common.OrderParams {
+	return &common.OrderParams{
+		Buy:             side == SideTypeBuy,
+		Market:          orderType == OrderTypeMarket || orderType == OrderTypeStopLoss || orderType == OrderTypeTakeProfit,
+		Price:           price,
+		StopPrice:       stopPrice,
+		Quantity:        quantity,
+		QuoteQuantity:   quoteOrderQty,
+		IcebergQuantity: icebergQuantity,
+	}
+}
+
+func (s *CreateOrderService) orderParams() *common.OrderParams {
+	return spotOrderParams(s.side, s.orderType, s.price, s.stopPrice, s.quantity, s.quoteOrderQty, s.icebergQuantity)
+}
+
+// Normalize round the price and stop price of the order to the tick size of symbol
+// and its quantities to the step size
+func (s *CreateOrderService) Normalize(symbol *Symbol) error {
+	return symbol.OrderFilters().Normalize(s.orderParams())
+}
+
+// Validate check the order against the filters of symbol and return a *common.OrderFilterError
+// with all the violations. averagePrice, as returned by AveragePriceService, is used for the
+// PERCENT_PRICE filter and the notional of market orders, those checks are skipped when it is empty.
+func (s *CreateOrderService) Validate(symbol *Symbol, averagePrice string) error {
+	p := s.orderParams()
+	p.ReferencePrice = averagePrice
+	return symbol.OrderFilters().Validate(p)
+}
+
+func (s *CreateMarginOrderService) orderParams() *common.OrderParams {
+	return spotOrderParams(s.side, s.orderType, s.price, s.stopPrice, s.quantity, s.quoteOrderQty, s.icebergQuantity)
+}
+
+// Normalize round the price and stop price of the order to the tick size of symbol
+// and its quantities to the step size
+func (s *CreateMarginOrderService) Normalize(symbol *Symbol) error {
+	return symbol.OrderFilters().Normalize(s.orderParams())
+}
+
+// Validate check the order against the filters of symbol, see CreateOrderService.Validate
+func (s *CreateMarginOrderService) Validate(symbol *Symbol, averagePrice string) error {
+	p := s.orderParams()
+	p.ReferencePrice = averagePrice
+	return symbol.OrderFilters().Validate(p)
+}
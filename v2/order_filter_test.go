@@ -0,0 +1,61 @@
+package binance
+
+import (
+	"testing"
+
+	"github.com/Zamzam-Technology/go-binance/v2/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func testFilterSymbol() *Symbol {
+	return &Symbol{
+		Symbol: "ETHBTC",
+		Filters: []map[string]interface{}{
+			{"filterType": "PRICE_FILTER", "minPrice": "0.00000100", "maxPrice": "100000.00000000", "tickSize": "0.00000100"},
+			{"filterType": "PERCENT_PRICE", "multiplierUp": "5", "multiplierDown": "0.2", "avgPriceMins": float64(5)},
+			{"filterType": "LOT_SIZE", "minQty": "0.00100000", "maxQty": "100000.00000000", "stepSize": "0.00100000"},
+			{"filterType": "MIN_NOTIONAL", "minNotional": "0.00010000", "applyToMarket": true, "avgPriceMins": float64(5)},
+			{"filterType": "ICEBERG_PARTS", "limit": float64(10)},
+			{"filterType": "MARKET_LOT_SIZE", "minQty": "0.00000000", "maxQty": "2000.00000000", "stepSize": "0.00000000"},
+		},
+	}
+}
+
+func TestCreateOrderServiceNormalize(t *testing.T) {
+	assert := assert.New(t)
+	s := NewClient("", "").NewCreateOrderService().Symbol("ETHBTC").Side(SideTypeSell).
+		Type(OrderTypeLimit).Price("0.0712345").Quantity("1.23456")
+	assert.NoError(s.Normalize(testFilterSymbol()))
+	assert.Equal("0.071235", *s.price)
+	assert.Equal("1.234", *s.quantity)
+	assert.NoError(s.Validate(testFilterSymbol(), "0.07"))
+}
+
+func TestCreateOrderServiceValidate(t *testing.T) {
+	assert := assert.New(t)
+	s := NewClient("", "").NewCreateOrderService().Symbol("ETHBTC").Side(SideTypeBuy).
+		Type(OrderTypeLimit).Price("0.5").Quantity("0.0001")
+	err := s.Validate(testFilterSymbol(), "0.07")
+	assert.True(common.IsOrderFilterError(err))
+	filters := []string{}
+	for _, v := range err.(*common.OrderFilterError).Violations {
+		filters = append(filters, v.Filter)
+	}
+	assert.Equal([]string{"LOT_SIZE", "LOT_SIZE", "PERCENT_PRICE", "MIN_NOTIONAL"}, filters)
+
+	// the percent price is not checked without average price
+	err = s.Quantity("1").Validate(testFilterSymbol(), "")
+	assert.NoError(err)
+}
+
+func TestCreateMarginOrderServiceValidate(t *testing.T) {
+	assert := assert.New(t)
+	s := NewClient("", "").NewCreateMarginOrderService().Symbol("ETHBTC").Side(SideTypeBuy).
+		Type(OrderTypeMarket).Quantity("2500.0004")
+	assert.NoError(s.Normalize(testFilterSymbol()))
+	assert.Equal("2500.000", *s.quantity)
+	err := s.Validate(testFilterSymbol(), "0.07")
+	assert.Equal([]common.OrderFilterViolation{
+		{Filter: "MARKET_LOT_SIZE", Field: "quantity", Value: "2500.000", Limit: "2000.00000000", Reason: "is greater than"},
+	}, err.(*common.OrderFilterError).Violations)
+}
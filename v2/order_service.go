@@ -3,6 +3,8 @@ package binance
 import (
 	"context"
 	"encoding/json"
+
+	"github.com/Zamzam-Technology/go-binance/v2/common"
 )
 
 // CreateOrderService create order
@@ -19,6 +21,11 @@ type CreateOrderService struct {
 	newClientOrderID *string
 	stopPrice        *string
 	icebergQuantity  *string
+	trailingDelta    *int64
+	strategyID       *int64
+	strategyType     *int64
+
+	selfTradePreventionMode *SelfTradePreventionMode
 }
 
 // Symbol set symbol
@@ -87,6 +94,30 @@ func (s *CreateOrderService) NewOrderRespType(newOrderRespType NewOrderRespType)
 	return s
 }
 
+// TrailingDelta set trailingDelta, in BIPS, for trailing stop orders
+func (s *CreateOrderService) TrailingDelta(trailingDelta int64) *CreateOrderService {
+	s.trailingDelta = &trailingDelta
+	return s
+}
+
+// StrategyID set strategyId, an arbitrary id to identify the orders of a strategy
+func (s *CreateOrderService) StrategyID(strategyID int64) *CreateOrderService {
+	s.strategyID = &strategyID
+	return s
+}
+
+// StrategyType set strategyType, an arbitrary value to identify the strategy, at least 1000000
+func (s *CreateOrderService) StrategyType(strategyType int64) *CreateOrderService {
+	s.strategyType = &strategyType
+	return s
+}
+
+// SelfTradePreventionMode set selfTradePreventionMode
+func (s *CreateOrderService) SelfTradePreventionMode(selfTradePreventionMode SelfTradePreventionMode) *CreateOrderService {
+	s.selfTradePreventionMode = &selfTradePreventionMode
+	return s
+}
+
 func (s *CreateOrderService) createOrder(ctx context.Context, endpoint string, opts ...RequestOption) (data []byte, err error) {
 	r := &request{
 		method:   "POST",
@@ -122,6 +153,18 @@ func (s *CreateOrderService) createOrder(ctx context.Context, endpoint string, o
 	if s.newOrderRespType != nil {
 		m["newOrderRespType"] = *s.newOrderRespType
 	}
+	if s.trailingDelta != nil {
+		m["trailingDelta"] = *s.trailingDelta
+	}
+	if s.strategyID != nil {
+		m["strategyId"] = *s.strategyID
+	}
+	if s.strategyType != nil {
+		m["strategyType"] = *s.strategyType
+	}
+	if s.selfTradePreventionMode != nil {
+		m["selfTradePreventionMode"] = *s.selfTradePreventionMode
+	}
 	r.setFormParams(m)
 	data, err = s.c.callAPI(ctx, r, opts...)
 	if err != nil {
@@ -167,6 +210,14 @@ type CreateOrderResponse struct {
 	Type        OrderType       `json:"type"`
 	Side        SideType        `json:"side"`
 
+	WorkingTime             int64                   `json:"workingTime"`
+	SelfTradePreventionMode SelfTradePreventionMode `json:"selfTradePreventionMode"`
+	StrategyID              int64                   `json:"strategyId"`
+	StrategyType            int64                   `json:"strategyType"`
+	TrailingDelta           int64                   `json:"trailingDelta"`
+	WorkingFloor            string                  `json:"workingFloor"` // for SOR orders
+	UsedSor                 bool                    `json:"usedSor"`
+
 	// for order response is set to FULL
 	Fills                 []*Fill `json:"fills"`
 	MarginBuyBorrowAmount string  `json:"marginBuyBorrowAmount"` // for margin
@@ -179,6 +230,10 @@ type Fill struct {
 	Quantity        string `json:"qty"`
 	Commission      string `json:"commission"`
 	CommissionAsset string `json:"commissionAsset"`
+	TradeID         int64  `json:"tradeId"`
+	// for SOR orders, the fills are allocations
+	MatchType string `json:"matchType"`
+	AllocID   int64  `json:"allocId"`
 }
 
 // CreateOCOService create order
@@ -197,6 +252,13 @@ type CreateOCOService struct {
 	stopIcebergQty       *string
 	stopLimitTimeInForce *TimeInForceType
 	newOrderRespType     *NewOrderRespType
+	trailingDelta        *int64
+	limitStrategyID      *int64
+	limitStrategyType    *int64
+	stopStrategyID       *int64
+	stopStrategyType     *int64
+
+	selfTradePreventionMode *SelfTradePreventionMode
 }
 
 // Symbol set symbol
@@ -277,6 +339,42 @@ func (s *CreateOCOService) NewOrderRespType(newOrderRespType NewOrderRespType) *
 	return s
 }
 
+// TrailingDelta set trailingDelta, in BIPS, of the stop loss leg
+func (s *CreateOCOService) TrailingDelta(trailingDelta int64) *CreateOCOService {
+	s.trailingDelta = &trailingDelta
+	return s
+}
+
+// LimitStrategyID set limitStrategyId
+func (s *CreateOCOService) LimitStrategyID(limitStrategyID int64) *CreateOCOService {
+	s.limitStrategyID = &limitStrategyID
+	return s
+}
+
+// LimitStrategyType set limitStrategyType, at least 1000000
+func (s *CreateOCOService) LimitStrategyType(limitStrategyType int64) *CreateOCOService {
+	s.limitStrategyType = &limitStrategyType
+	return s
+}
+
+// StopStrategyID set stopStrategyId
+func (s *CreateOCOService) StopStrategyID(stopStrategyID int64) *CreateOCOService {
+	s.stopStrategyID = &stopStrategyID
+	return s
+}
+
+// StopStrategyType set stopStrategyType, at least 1000000
+func (s *CreateOCOService) StopStrategyType(stopStrategyType int64) *CreateOCOService {
+	s.stopStrategyType = &stopStrategyType
+	return s
+}
+
+// SelfTradePreventionMode set selfTradePreventionMode
+func (s *CreateOCOService) SelfTradePreventionMode(selfTradePreventionMode SelfTradePreventionMode) *CreateOCOService {
+	s.selfTradePreventionMode = &selfTradePreventionMode
+	return s
+}
+
 func (s *CreateOCOService) createOrder(ctx context.Context, endpoint string, opts ...RequestOption) (data []byte, err error) {
 	r := &request{
 		method:   "POST",
@@ -315,6 +413,24 @@ func (s *CreateOCOService) createOrder(ctx context.Context, endpoint string, opt
 	if s.newOrderRespType != nil {
 		m["newOrderRespType"] = *s.newOrderRespType
 	}
+	if s.trailingDelta != nil {
+		m["trailingDelta"] = *s.trailingDelta
+	}
+	if s.limitStrategyID != nil {
+		m["limitStrategyId"] = *s.limitStrategyID
+	}
+	if s.limitStrategyType != nil {
+		m["limitStrategyType"] = *s.limitStrategyType
+	}
+	if s.stopStrategyID != nil {
+		m["stopStrategyId"] = *s.stopStrategyID
+	}
+	if s.stopStrategyType != nil {
+		m["stopStrategyType"] = *s.stopStrategyType
+	}
+	if s.selfTradePreventionMode != nil {
+		m["selfTradePreventionMode"] = *s.selfTradePreventionMode
+	}
 	r.setFormParams(m)
 	data, err = s.c.callAPI(ctx, r, opts...)
 	if err != nil {
@@ -377,6 +493,408 @@ type OCOOrderReport struct {
 	IcebergQuantity          string          `json:"icebergQty"`
 }
 
+// CreateOrderListOCOService create an OCO order list with an above and a below order
+type CreateOrderListOCOService struct {
+	c                       *Client
+	symbol                  string
+	side                    SideType
+	quantity                string
+	listClientOrderID       *string
+	aboveType               OrderType
+	aboveClientOrderID      *string
+	aboveIcebergQuantity    *string
+	abovePrice              *string
+	aboveStopPrice          *string
+	aboveTrailingDelta      *int64
+	aboveTimeInForce        *TimeInForceType
+	aboveStrategyID         *int64
+	aboveStrategyType       *int64
+	belowType               OrderType
+	belowClientOrderID      *string
+	belowIcebergQuantity    *string
+	belowPrice              *string
+	belowStopPrice          *string
+	belowTrailingDelta      *int64
+	belowTimeInForce        *TimeInForceType
+	belowStrategyID         *int64
+	belowStrategyType       *int64
+	newOrderRespType        *NewOrderRespType
+	selfTradePreventionMode *SelfTradePreventionMode
+}
+
+// Symbol set symbol
+func (s *CreateOrderListOCOService) Symbol(symbol string) *CreateOrderListOCOService {
+	s.symbol = symbol
+	return s
+}
+
+// Side set side
+func (s *CreateOrderListOCOService) Side(side SideType) *CreateOrderListOCOService {
+	s.side = side
+	return s
+}
+
+// Quantity set quantity of both legs
+func (s *CreateOrderListOCOService) Quantity(quantity string) *CreateOrderListOCOService {
+	s.quantity = quantity
+	return s
+}
+
+// ListClientOrderID set listClientOrderId
+func (s *CreateOrderListOCOService) ListClientOrderID(listClientOrderID string) *CreateOrderListOCOService {
+	s.listClientOrderID = &listClientOrderID
+	return s
+}
+
+// AboveType set aboveType, STOP_LOSS_LIMIT, STOP_LOSS, LIMIT_MAKER, TAKE_PROFIT or TAKE_PROFIT_LIMIT
+func (s *CreateOrderListOCOService) AboveType(aboveType OrderType) *CreateOrderListOCOService {
+	s.aboveType = aboveType
+	return s
+}
+
+// AboveClientOrderID set aboveClientOrderId
+func (s *CreateOrderListOCOService) AboveClientOrderID(aboveClientOrderID string) *CreateOrderListOCOService {
+	s.aboveClientOrderID = &aboveClientOrderID
+	return s
+}
+
+// AboveIcebergQuantity set aboveIcebergQty
+func (s *CreateOrderListOCOService) AboveIcebergQuantity(aboveIcebergQuantity string) *CreateOrderListOCOService {
+	s.aboveIcebergQuantity = &aboveIcebergQuantity
+	return s
+}
+
+// AbovePrice set abovePrice
+func (s *CreateOrderListOCOService) AbovePrice(abovePrice string) *CreateOrderListOCOService {
+	s.abovePrice = &abovePrice
+	return s
+}
+
+// AboveStopPrice set aboveStopPrice
+func (s *CreateOrderListOCOService) AboveStopPrice(aboveStopPrice string) *CreateOrderListOCOService {
+	s.aboveStopPrice = &aboveStopPrice
+	return s
+}
+
+// AboveTrailingDelta set aboveTrailingDelta
+func (s *CreateOrderListOCOService) AboveTrailingDelta(aboveTrailingDelta int64) *CreateOrderListOCOService {
+	s.aboveTrailingDelta = &aboveTrailingDelta
+	return s
+}
+
+// AboveTimeInForce set aboveTimeInForce
+func (s *CreateOrderListOCOService) AboveTimeInForce(aboveTimeInForce TimeInForceType) *CreateOrderListOCOService {
+	s.aboveTimeInForce = &aboveTimeInForce
+	return s
+}
+
+// BelowType set belowType, STOP_LOSS_LIMIT, STOP_LOSS, TAKE_PROFIT or TAKE_PROFIT_LIMIT
+func (s *CreateOrderListOCOService) BelowType(belowType OrderType) *CreateOrderListOCOService {
+	s.belowType = belowType
+	return s
+}
+
+// BelowClientOrderID set belowClientOrderId
+func (s *CreateOrderListOCOService) BelowClientOrderID(belowClientOrderID string) *CreateOrderListOCOService {
+	s.belowClientOrderID = &belowClientOrderID
+	return s
+}
+
+// BelowIcebergQuantity set belowIcebergQty
+func (s *CreateOrderListOCOService) BelowIcebergQuantity(belowIcebergQuantity string) *CreateOrderListOCOService {
+	s.belowIcebergQuantity = &belowIcebergQuantity
+	return s
+}
+
+// BelowPrice set belowPrice
+func (s *CreateOrderListOCOService) BelowPrice(belowPrice string) *CreateOrderListOCOService {
+	s.belowPrice = &belowPrice
+	return s
+}
+
+// BelowStopPrice set belowStopPrice
+func (s *CreateOrderListOCOService) BelowStopPrice(belowStopPrice string) *CreateOrderListOCOService {
+	s.belowStopPrice = &belowStopPrice
+	return s
+}
+
+// BelowTrailingDelta set belowTrailingDelta
+func (s *CreateOrderListOCOService) BelowTrailingDelta(belowTrailingDelta int64) *CreateOrderListOCOService {
+	s.belowTrailingDelta = &belowTrailingDelta
+	return s
+}
+
+// BelowTimeInForce set belowTimeInForce
+func (s *CreateOrderListOCOService) BelowTimeInForce(belowTimeInForce TimeInForceType) *CreateOrderListOCOService {
+	s.belowTimeInForce = &belowTimeInForce
+	return s
+}
+
+// AboveStrategyID set aboveStrategyId
+func (s *CreateOrderListOCOService) AboveStrategyID(aboveStrategyID int64) *CreateOrderListOCOService {
+	s.aboveStrategyID = &aboveStrategyID
+	return s
+}
+
+// AboveStrategyType set aboveStrategyType, at least 1000000
+func (s *CreateOrderListOCOService) AboveStrategyType(aboveStrategyType int64) *CreateOrderListOCOService {
+	s.aboveStrategyType = &aboveStrategyType
+	return s
+}
+
+// BelowStrategyID set belowStrategyId
+func (s *CreateOrderListOCOService) BelowStrategyID(belowStrategyID int64) *CreateOrderListOCOService {
+	s.belowStrategyID = &belowStrategyID
+	return s
+}
+
+// BelowStrategyType set belowStrategyType, at least 1000000
+func (s *CreateOrderListOCOService) BelowStrategyType(belowStrategyType int64) *CreateOrderListOCOService {
+	s.belowStrategyType = &belowStrategyType
+	return s
+}
+
+// SelfTradePreventionMode set selfTradePreventionMode
+func (s *CreateOrderListOCOService) SelfTradePreventionMode(selfTradePreventionMode SelfTradePreventionMode) *CreateOrderListOCOService {
+	s.selfTradePreventionMode = &selfTradePreventionMode
+	return s
+}
+
+// NewOrderRespType set newOrderRespType
+func (s *CreateOrderListOCOService) NewOrderRespType(newOrderRespType NewOrderRespType) *CreateOrderListOCOService {
+	s.newOrderRespType = &newOrderRespType
+	return s
+}
+
+// Do send request
+func (s *CreateOrderListOCOService) Do(ctx context.Context, opts ...RequestOption) (res *CreateOCOResponse, err error) {
+	r := &request{
+		method:   "POST",
+		endpoint: "/api/v3/orderList/oco",
+		secType:  secTypeSigned,
+	}
+	m := params{
+		"symbol":    s.symbol,
+		"side":      s.side,
+		"quantity":  s.quantity,
+		"aboveType": s.aboveType,
+		"belowType": s.belowType,
+	}
+	if s.listClientOrderID != nil {
+		m["listClientOrderId"] = *s.listClientOrderID
+	}
+	if s.aboveClientOrderID != nil {
+		m["aboveClientOrderId"] = *s.aboveClientOrderID
+	}
+	if s.aboveIcebergQuantity != nil {
+		m["aboveIcebergQty"] = *s.aboveIcebergQuantity
+	}
+	if s.abovePrice != nil {
+		m["abovePrice"] = *s.abovePrice
+	}
+	if s.aboveStopPrice != nil {
+		m["aboveStopPrice"] = *s.aboveStopPrice
+	}
+	if s.aboveTrailingDelta != nil {
+		m["aboveTrailingDelta"] = *s.aboveTrailingDelta
+	}
+	if s.aboveTimeInForce != nil {
+		m["aboveTimeInForce"] = *s.aboveTimeInForce
+	}
+	if s.belowClientOrderID != nil {
+		m["belowClientOrderId"] = *s.belowClientOrderID
+	}
+	if s.belowIcebergQuantity != nil {
+		m["belowIcebergQty"] = *s.belowIcebergQuantity
+	}
+	if s.belowPrice != nil {
+		m["belowPrice"] = *s.belowPrice
+	}
+	if s.belowStopPrice != nil {
+		m["belowStopPrice"] = *s.belowStopPrice
+	}
+	if s.belowTrailingDelta != nil {
+		m["belowTrailingDelta"] = *s.belowTrailingDelta
+	}
+	if s.belowTimeInForce != nil {
+		m["belowTimeInForce"] = *s.belowTimeInForce
+	}
+	if s.aboveStrategyID != nil {
+		m["aboveStrategyId"] = *s.aboveStrategyID
+	}
+	if s.aboveStrategyType != nil {
+		m["aboveStrategyType"] = *s.aboveStrategyType
+	}
+	if s.belowStrategyID != nil {
+		m["belowStrategyId"] = *s.belowStrategyID
+	}
+	if s.belowStrategyType != nil {
+		m["belowStrategyType"] = *s.belowStrategyType
+	}
+	if s.newOrderRespType != nil {
+		m["newOrderRespType"] = *s.newOrderRespType
+	}
+	if s.selfTradePreventionMode != nil {
+		m["selfTradePreventionMode"] = *s.selfTradePreventionMode
+	}
+	r.setFormParams(m)
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(CreateOCOResponse)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// OCO define an order list as returned by the order list query endpoints
+type OCO struct {
+	OrderListID       int64       `json:"orderListId"`
+	ContingencyType   string      `json:"contingencyType"`
+	ListStatusType    string      `json:"listStatusType"`
+	ListOrderStatus   string      `json:"listOrderStatus"`
+	ListClientOrderID string      `json:"listClientOrderId"`
+	TransactionTime   int64       `json:"transactionTime"`
+	Symbol            string      `json:"symbol"`
+	Orders            []*OCOOrder `json:"orders"`
+}
+
+// GetOCOService get an OCO order list by orderListId or origClientOrderId
+type GetOCOService struct {
+	c                 *Client
+	orderListID       *int64
+	origClientOrderID *string
+}
+
+// OrderListID set orderListId
+func (s *GetOCOService) OrderListID(orderListID int64) *GetOCOService {
+	s.orderListID = &orderListID
+	return s
+}
+
+// OrigClientOrderID set origClientOrderId, the listClientOrderId of the order list
+func (s *GetOCOService) OrigClientOrderID(origClientOrderID string) *GetOCOService {
+	s.origClientOrderID = &origClientOrderID
+	return s
+}
+
+// Do send request
+func (s *GetOCOService) Do(ctx context.Context, opts ...RequestOption) (res *OCO, err error) {
+	r := &request{
+		method:   "GET",
+		endpoint: "/api/v3/orderList",
+		secType:  secTypeSigned,
+	}
+	if s.orderListID != nil {
+		r.setParam("orderListId", *s.orderListID)
+	}
+	if s.origClientOrderID != nil {
+		r.setParam("origClientOrderId", *s.origClientOrderID)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(OCO)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ListOCOService list all OCO order lists, from fromId or within a time window
+type ListOCOService struct {
+	c         *Client
+	fromID    *int64
+	startTime *int64
+	endTime   *int64
+	limit     *int
+}
+
+// FromID set fromId, the order lists from this orderListId are returned
+// and startTime and endTime can not be used
+func (s *ListOCOService) FromID(fromID int64) *ListOCOService {
+	s.fromID = &fromID
+	return s
+}
+
+// StartTime set startTime
+func (s *ListOCOService) StartTime(startTime int64) *ListOCOService {
+	s.startTime = &startTime
+	return s
+}
+
+// EndTime set endTime
+func (s *ListOCOService) EndTime(endTime int64) *ListOCOService {
+	s.endTime = &endTime
+	return s
+}
+
+// Limit set limit, default 500 and max 1000
+func (s *ListOCOService) Limit(limit int) *ListOCOService {
+	s.limit = &limit
+	return s
+}
+
+// Do send request
+func (s *ListOCOService) Do(ctx context.Context, opts ...RequestOption) (res []*OCO, err error) {
+	r := &request{
+		method:   "GET",
+		endpoint: "/api/v3/allOrderList",
+		secType:  secTypeSigned,
+	}
+	if s.fromID != nil {
+		r.setParam("fromId", *s.fromID)
+	}
+	if s.startTime != nil {
+		r.setParam("startTime", *s.startTime)
+	}
+	if s.endTime != nil {
+		r.setParam("endTime", *s.endTime)
+	}
+	if s.limit != nil {
+		r.setParam("limit", *s.limit)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*OCO{}, err
+	}
+	res = make([]*OCO, 0)
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return []*OCO{}, err
+	}
+	return res, nil
+}
+
+// ListOpenOCOService list the open OCO order lists
+type ListOpenOCOService struct {
+	c *Client
+}
+
+// Do send request
+func (s *ListOpenOCOService) Do(ctx context.Context, opts ...RequestOption) (res []*OCO, err error) {
+	r := &request{
+		method:   "GET",
+		endpoint: "/api/v3/openOrderList",
+		secType:  secTypeSigned,
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*OCO{}, err
+	}
+	res = make([]*OCO, 0)
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return []*OCO{}, err
+	}
+	return res, nil
+}
+
 // ListOpenOrdersService list opened orders
 type ListOpenOrdersService struct {
 	c      *Client
@@ -482,6 +1000,16 @@ type Order struct {
 	UpdateTime               int64           `json:"updateTime"`
 	IsWorking                bool            `json:"isWorking"`
 	IsIsolated               bool            `json:"isIsolated"`
+	WorkingTime              int64           `json:"workingTime"`
+	StrategyID               int64           `json:"strategyId"`
+	StrategyType             int64           `json:"strategyType"`
+	TrailingDelta            int64           `json:"trailingDelta"`
+	PreventedMatchID         int64           `json:"preventedMatchId"`
+	PreventedQuantity        string          `json:"preventedQuantity"`
+	WorkingFloor             string          `json:"workingFloor"`
+	UsedSor                  bool            `json:"usedSor"`
+
+	SelfTradePreventionMode SelfTradePreventionMode `json:"selfTradePreventionMode"`
 }
 
 // ListOrdersService all account orders; active, canceled, or filled
@@ -618,6 +1146,262 @@ func (s *CancelOrderService) Do(ctx context.Context, opts ...RequestOption) (res
 	return res, nil
 }
 
+// CancelReplaceOrderService cancel an existing order and place a new order on the same symbol
+type CancelReplaceOrderService struct {
+	c                          *Client
+	symbol                     string
+	side                       SideType
+	orderType                  OrderType
+	cancelReplaceMode          CancelReplaceModeType
+	timeInForce                *TimeInForceType
+	quantity                   *string
+	quoteOrderQty              *string
+	price                      *string
+	cancelNewClientOrderID     *string
+	cancelOrigClientOrderID    *string
+	cancelOrderID              *int64
+	newClientOrderID           *string
+	stopPrice                  *string
+	icebergQuantity            *string
+	newOrderRespType           *NewOrderRespType
+	cancelRestrictions         *CancelRestrictionsType
+	orderRateLimitExceededMode *OrderRateLimitExceededModeType
+}
+
+// Symbol set symbol
+func (s *CancelReplaceOrderService) Symbol(symbol string) *CancelReplaceOrderService {
+	s.symbol = symbol
+	return s
+}
+
+// Side set side
+func (s *CancelReplaceOrderService) Side(side SideType) *CancelReplaceOrderService {
+	s.side = side
+	return s
+}
+
+// Type set type
+func (s *CancelReplaceOrderService) Type(orderType OrderType) *CancelReplaceOrderService {
+	s.orderType = orderType
+	return s
+}
+
+// CancelReplaceMode set cancelReplaceMode
+func (s *CancelReplaceOrderService) CancelReplaceMode(cancelReplaceMode CancelReplaceModeType) *CancelReplaceOrderService {
+	s.cancelReplaceMode = cancelReplaceMode
+	return s
+}
+
+// TimeInForce set timeInForce
+func (s *CancelReplaceOrderService) TimeInForce(timeInForce TimeInForceType) *CancelReplaceOrderService {
+	s.timeInForce = &timeInForce
+	return s
+}
+
+// Quantity set quantity
+func (s *CancelReplaceOrderService) Quantity(quantity string) *CancelReplaceOrderService {
+	s.quantity = &quantity
+	return s
+}
+
+// QuoteOrderQty set quoteOrderQty
+func (s *CancelReplaceOrderService) QuoteOrderQty(quoteOrderQty string) *CancelReplaceOrderService {
+	s.quoteOrderQty = &quoteOrderQty
+	return s
+}
+
+// Price set price
+func (s *CancelReplaceOrderService) Price(price string) *CancelReplaceOrderService {
+	s.price = &price
+	return s
+}
+
+// CancelNewClientOrderID set cancelNewClientOrderId, the new id of the canceled order
+func (s *CancelReplaceOrderService) CancelNewClientOrderID(cancelNewClientOrderID string) *CancelReplaceOrderService {
+	s.cancelNewClientOrderID = &cancelNewClientOrderID
+	return s
+}
+
+// CancelOrigClientOrderID set cancelOrigClientOrderId, the client id of the order to cancel
+func (s *CancelReplaceOrderService) CancelOrigClientOrderID(cancelOrigClientOrderID string) *CancelReplaceOrderService {
+	s.cancelOrigClientOrderID = &cancelOrigClientOrderID
+	return s
+}
+
+// CancelOrderID set cancelOrderId, the id of the order to cancel
+func (s *CancelReplaceOrderService) CancelOrderID(cancelOrderID int64) *CancelReplaceOrderService {
+	s.cancelOrderID = &cancelOrderID
+	return s
+}
+
+// NewClientOrderID set newClientOrderID
+func (s *CancelReplaceOrderService) NewClientOrderID(newClientOrderID string) *CancelReplaceOrderService {
+	s.newClientOrderID = &newClientOrderID
+	return s
+}
+
+// StopPrice set stopPrice
+func (s *CancelReplaceOrderService) StopPrice(stopPrice string) *CancelReplaceOrderService {
+	s.stopPrice = &stopPrice
+	return s
+}
+
+// IcebergQuantity set icebergQuantity
+func (s *CancelReplaceOrderService) IcebergQuantity(icebergQuantity string) *CancelReplaceOrderService {
+	s.icebergQuantity = &icebergQuantity
+	return s
+}
+
+// NewOrderRespType set newOrderRespType
+func (s *CancelReplaceOrderService) NewOrderRespType(newOrderRespType NewOrderRespType) *CancelReplaceOrderService {
+	s.newOrderRespType = &newOrderRespType
+	return s
+}
+
+// CancelRestrictions set cancelRestrictions
+func (s *CancelReplaceOrderService) CancelRestrictions(cancelRestrictions CancelRestrictionsType) *CancelReplaceOrderService {
+	s.cancelRestrictions = &cancelRestrictions
+	return s
+}
+
+// OrderRateLimitExceededMode set orderRateLimitExceededMode
+func (s *CancelReplaceOrderService) OrderRateLimitExceededMode(mode OrderRateLimitExceededModeType) *CancelReplaceOrderService {
+	s.orderRateLimitExceededMode = &mode
+	return s
+}
+
+// Do send request. When the cancel or the new order fails, the returned error is the
+// *common.APIError of the request and res holds the result of both steps.
+func (s *CancelReplaceOrderService) Do(ctx context.Context, opts ...RequestOption) (res *CancelReplaceOrderResponse, err error) {
+	r := &request{
+		method:   "POST",
+		endpoint: "/api/v3/order/cancelReplace",
+		secType:  secTypeSigned,
+	}
+	m := params{
+		"symbol":            s.symbol,
+		"side":              s.side,
+		"type":              s.orderType,
+		"cancelReplaceMode": s.cancelReplaceMode,
+	}
+	if s.timeInForce != nil {
+		m["timeInForce"] = *s.timeInForce
+	}
+	if s.quantity != nil {
+		m["quantity"] = *s.quantity
+	}
+	if s.quoteOrderQty != nil {
+		m["quoteOrderQty"] = *s.quoteOrderQty
+	}
+	if s.price != nil {
+		m["price"] = *s.price
+	}
+	if s.cancelNewClientOrderID != nil {
+		m["cancelNewClientOrderId"] = *s.cancelNewClientOrderID
+	}
+	if s.cancelOrigClientOrderID != nil {
+		m["cancelOrigClientOrderId"] = *s.cancelOrigClientOrderID
+	}
+	if s.cancelOrderID != nil {
+		m["cancelOrderId"] = *s.cancelOrderID
+	}
+	if s.newClientOrderID != nil {
+		m["newClientOrderId"] = *s.newClientOrderID
+	}
+	if s.stopPrice != nil {
+		m["stopPrice"] = *s.stopPrice
+	}
+	if s.icebergQuantity != nil {
+		m["icebergQty"] = *s.icebergQuantity
+	}
+	if s.newOrderRespType != nil {
+		m["newOrderRespType"] = *s.newOrderRespType
+	}
+	if s.cancelRestrictions != nil {
+		m["cancelRestrictions"] = *s.cancelRestrictions
+	}
+	if s.orderRateLimitExceededMode != nil {
+		m["orderRateLimitExceededMode"] = *s.orderRateLimitExceededMode
+	}
+	r.setFormParams(m)
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		apiErr, ok := err.(*common.APIError)
+		if !ok || len(apiErr.Data) == 0 {
+			return nil, err
+		}
+		res = new(CancelReplaceOrderResponse)
+		if e := json.Unmarshal(apiErr.Data, res); e != nil {
+			return nil, err
+		}
+		return res, err
+	}
+	res = new(CancelReplaceOrderResponse)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// CancelReplaceOrderResponse define cancel replace order response. The response of a step
+// which failed is decoded in CancelError or NewOrderError instead of its response.
+type CancelReplaceOrderResponse struct {
+	CancelResult     CancelReplaceResultType `json:"cancelResult"`
+	NewOrderResult   CancelReplaceResultType `json:"newOrderResult"`
+	CancelResponse   *CancelOrderResponse    `json:"-"`
+	CancelError      *common.APIError        `json:"-"`
+	NewOrderResponse *CreateOrderResponse    `json:"-"`
+	NewOrderError    *common.APIError        `json:"-"`
+}
+
+// UnmarshalJSON decode the cancel and new order responses or their errors
+func (r *CancelReplaceOrderResponse) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		CancelResult     CancelReplaceResultType `json:"cancelResult"`
+		NewOrderResult   CancelReplaceResultType `json:"newOrderResult"`
+		CancelResponse   json.RawMessage         `json:"cancelResponse"`
+		NewOrderResponse json.RawMessage         `json:"newOrderResponse"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	r.CancelResult = raw.CancelResult
+	r.NewOrderResult = raw.NewOrderResult
+	if isCancelReplaceError(raw.CancelResponse) {
+		r.CancelError = new(common.APIError)
+		if err := json.Unmarshal(raw.CancelResponse, r.CancelError); err != nil {
+			return err
+		}
+	} else if len(raw.CancelResponse) > 0 && string(raw.CancelResponse) != "null" {
+		r.CancelResponse = new(CancelOrderResponse)
+		if err := json.Unmarshal(raw.CancelResponse, r.CancelResponse); err != nil {
+			return err
+		}
+	}
+	if isCancelReplaceError(raw.NewOrderResponse) {
+		r.NewOrderError = new(common.APIError)
+		if err := json.Unmarshal(raw.NewOrderResponse, r.NewOrderError); err != nil {
+			return err
+		}
+	} else if len(raw.NewOrderResponse) > 0 && string(raw.NewOrderResponse) != "null" {
+		r.NewOrderResponse = new(CreateOrderResponse)
+		if err := json.Unmarshal(raw.NewOrderResponse, r.NewOrderResponse); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isCancelReplaceError check if the response of a cancel-replace step is an error
+func isCancelReplaceError(data json.RawMessage) bool {
+	if len(data) == 0 {
+		return false
+	}
+	e := new(common.APIError)
+	return json.Unmarshal(data, e) == nil && e.Code != 0
+}
+
 // CancelOCOService cancel all active orders on the list order.
 type CancelOCOService struct {
 	c                 *Client
@@ -704,8 +1488,13 @@ func (s *CancelOpenOrdersService) Do(ctx context.Context, opts ...RequestOption)
 	if err != nil {
 		return &CancelOpenOrdersResponse{}, err
 	}
+	return parseCancelOpenOrdersResponse(data)
+}
+
+// parseCancelOpenOrdersResponse split the canceled orders and order lists of a cancel open orders response
+func parseCancelOpenOrdersResponse(data []byte) (*CancelOpenOrdersResponse, error) {
 	rawMessages := make([]*json.RawMessage, 0)
-	err = json.Unmarshal(data, &rawMessages)
+	err := json.Unmarshal(data, &rawMessages)
 	if err != nil {
 		return &CancelOpenOrdersResponse{}, err
 	}
@@ -1,8 +1,10 @@
 package binance
 
 import (
+	"net/http"
 	"testing"
 
+	"github.com/Zamzam-Technology/go-binance/v2/common"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -196,6 +198,57 @@ func (s *baseOrderTestSuite) assertFillEqual(e, a *Fill) {
 	r.Equal(e.Quantity, a.Quantity, "Quantity")
 }
 
+func (s *orderServiceTestSuite) TestCreateOrderWithSelfTradePrevention() {
+	data := []byte(`{
+		"symbol": "BTCUSDT",
+		"orderId": 28,
+		"orderListId": -1,
+		"clientOrderId": "6gCrw2kRUAF9CvJDGP16IP",
+		"transactTime": 1507725176595,
+		"price": "0.00000000",
+		"origQty": "10.00000000",
+		"executedQty": "0.00000000",
+		"cummulativeQuoteQty": "0.00000000",
+		"status": "NEW",
+		"timeInForce": "GTC",
+		"type": "STOP_LOSS_LIMIT",
+		"side": "SELL",
+		"workingTime": 1507725176595,
+		"trailingDelta": 100,
+		"strategyId": 37463720,
+		"strategyType": 1000000,
+		"selfTradePreventionMode": "EXPIRE_MAKER"
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setFormParams(params{
+			"symbol":                  "BTCUSDT",
+			"side":                    SideTypeSell,
+			"type":                    OrderTypeStopLossLimit,
+			"timeInForce":             TimeInForceTypeGTC,
+			"quantity":                "10",
+			"price":                   "20000",
+			"trailingDelta":           100,
+			"strategyId":              37463720,
+			"strategyType":            1000000,
+			"selfTradePreventionMode": SelfTradePreventionModeExpireMaker,
+		})
+		s.assertRequestEqual(e, r)
+	})
+	res, err := s.client.NewCreateOrderService().Symbol("BTCUSDT").Side(SideTypeSell).
+		Type(OrderTypeStopLossLimit).TimeInForce(TimeInForceTypeGTC).Quantity("10").Price("20000").
+		TrailingDelta(100).StrategyID(37463720).StrategyType(1000000).
+		SelfTradePreventionMode(SelfTradePreventionModeExpireMaker).Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal(int64(1507725176595), res.WorkingTime)
+	r.Equal(int64(100), res.TrailingDelta)
+	r.Equal(int64(37463720), res.StrategyID)
+	r.Equal(int64(1000000), res.StrategyType)
+	r.Equal(SelfTradePreventionModeExpireMaker, res.SelfTradePreventionMode)
+}
+
 func (s *orderServiceTestSuite) TestCreateOCO() {
 	data := []byte(`{
 		"orderListId": 0,
@@ -388,6 +441,39 @@ func (s *baseOrderTestSuite) assertOCOOrderEqual(e, a *OCOOrder) {
 	r.Equal(e.Symbol, a.Symbol, "Symbol")
 }
 
+func (s *orderServiceTestSuite) TestGetExpiredInMatchOrder() {
+	data := []byte(`{
+		"symbol": "BTCUSDT",
+		"orderId": 2,
+		"clientOrderId": "FaDk4LeRZI1Fr8kuAX4UjV",
+		"price": "1.00000000",
+		"origQty": "10.00000000",
+		"executedQty": "0.00000000",
+		"cummulativeQuoteQty": "0.00000000",
+		"status": "EXPIRED_IN_MATCH",
+		"timeInForce": "GTC",
+		"type": "LIMIT",
+		"side": "BUY",
+		"time": 1684804350068,
+		"updateTime": 1684804350068,
+		"isWorking": true,
+		"workingTime": 1684804350068,
+		"preventedMatchId": 1,
+		"preventedQuantity": "10.00000000",
+		"selfTradePreventionMode": "EXPIRE_TAKER"
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+	res, err := s.client.NewGetOrderService().Symbol("BTCUSDT").OrderID(2).Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal(OrderStatusTypeExpiredInMatch, res.Status)
+	r.Equal(int64(1684804350068), res.WorkingTime)
+	r.Equal(int64(1), res.PreventedMatchID)
+	r.Equal("10.00000000", res.PreventedQuantity)
+	r.Equal(SelfTradePreventionModeExpireTaker, res.SelfTradePreventionMode)
+}
+
 func (s *orderServiceTestSuite) TestListOpenOrders() {
 	data := []byte(`[
         {
@@ -759,6 +845,396 @@ func (s *orderServiceTestSuite) TestCancelOrder() {
 	s.assertCancelOrderResponseEqual(e, res)
 }
 
+func (s *orderServiceTestSuite) TestCancelReplaceOrder() {
+	data := []byte(`{
+		"cancelResult": "SUCCESS",
+		"newOrderResult": "SUCCESS",
+		"cancelResponse": {
+			"symbol": "BTCUSDT",
+			"origClientOrderId": "DnLo3vTAQcjha43lAZhZ0y",
+			"orderId": 9,
+			"orderListId": -1,
+			"clientOrderId": "osxN3JXAtJvKvCqGeMWMVR",
+			"price": "0.01000000",
+			"origQty": "0.000100",
+			"executedQty": "0.00000000",
+			"cummulativeQuoteQty": "0.00000000",
+			"status": "CANCELED",
+			"timeInForce": "GTC",
+			"type": "LIMIT",
+			"side": "SELL"
+		},
+		"newOrderResponse": {
+			"symbol": "BTCUSDT",
+			"orderId": 10,
+			"orderListId": -1,
+			"clientOrderId": "wOceeeOzNORyLiQfw7jd8S",
+			"transactTime": 1652928801803,
+			"price": "0.02000000",
+			"origQty": "0.040000",
+			"executedQty": "0.00000000",
+			"cummulativeQuoteQty": "0.00000000",
+			"status": "NEW",
+			"timeInForce": "GTC",
+			"type": "LIMIT",
+			"side": "BUY",
+			"fills": []
+		}
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setFormParams(params{
+			"symbol":                     "BTCUSDT",
+			"side":                       SideTypeBuy,
+			"type":                       OrderTypeLimit,
+			"cancelReplaceMode":          CancelReplaceModeTypeStopOnFailure,
+			"timeInForce":                TimeInForceTypeGTC,
+			"quantity":                   "0.04",
+			"price":                      "0.02",
+			"cancelOrderId":              9,
+			"cancelRestrictions":         CancelRestrictionsTypeOnlyNew,
+			"orderRateLimitExceededMode": OrderRateLimitExceededModeTypeCancelOnly,
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewCancelReplaceOrderService().Symbol("BTCUSDT").Side(SideTypeBuy).
+		Type(OrderTypeLimit).CancelReplaceMode(CancelReplaceModeTypeStopOnFailure).
+		TimeInForce(TimeInForceTypeGTC).Quantity("0.04").Price("0.02").CancelOrderID(9).
+		CancelRestrictions(CancelRestrictionsTypeOnlyNew).
+		OrderRateLimitExceededMode(OrderRateLimitExceededModeTypeCancelOnly).Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal(CancelReplaceResultTypeSuccess, res.CancelResult)
+	r.Equal(CancelReplaceResultTypeSuccess, res.NewOrderResult)
+	r.Nil(res.CancelError)
+	r.Nil(res.NewOrderError)
+	s.assertCancelOrderResponseEqual(&CancelOrderResponse{
+		Symbol:                   "BTCUSDT",
+		OrigClientOrderID:        "DnLo3vTAQcjha43lAZhZ0y",
+		OrderID:                  9,
+		OrderListID:              -1,
+		ClientOrderID:            "osxN3JXAtJvKvCqGeMWMVR",
+		Price:                    "0.01000000",
+		OrigQuantity:             "0.000100",
+		ExecutedQuantity:         "0.00000000",
+		CummulativeQuoteQuantity: "0.00000000",
+		Status:                   OrderStatusTypeCanceled,
+		TimeInForce:              TimeInForceTypeGTC,
+		Type:                     OrderTypeLimit,
+		Side:                     SideTypeSell,
+	}, res.CancelResponse)
+	r.Equal(int64(10), res.NewOrderResponse.OrderID)
+	r.Equal(OrderStatusTypeNew, res.NewOrderResponse.Status)
+	r.Equal("0.040000", res.NewOrderResponse.OrigQuantity)
+}
+
+func (s *orderServiceTestSuite) TestCancelReplaceOrderPartialFailure() {
+	data := []byte(`{
+		"code": -2021,
+		"msg": "Order cancel-replace partially failed.",
+		"data": {
+			"cancelResult": "SUCCESS",
+			"newOrderResult": "FAILURE",
+			"cancelResponse": {
+				"symbol": "BTCUSDT",
+				"origClientOrderId": "86M8erehfExV8z2RC8Zo8k",
+				"orderId": 3,
+				"orderListId": -1,
+				"clientOrderId": "G1kLo6aDv2KGNTFcjfTSFq",
+				"price": "0.006123",
+				"origQty": "10000.000000",
+				"executedQty": "0.000000",
+				"cummulativeQuoteQty": "0.000000",
+				"status": "CANCELED",
+				"timeInForce": "GTC",
+				"type": "LIMIT_MAKER",
+				"side": "SELL"
+			},
+			"newOrderResponse": {
+				"code": -2010,
+				"msg": "Order would immediately match and take."
+			}
+		}
+	}`)
+	s.mockDo(data, nil, http.StatusConflict)
+	defer s.assertDo()
+
+	res, err := s.client.NewCancelReplaceOrderService().Symbol("BTCUSDT").Side(SideTypeSell).
+		Type(OrderTypeLimitMaker).CancelReplaceMode(CancelReplaceModeTypeAllowFailure).
+		Quantity("10000").Price("0.0061").CancelOrigClientOrderID("86M8erehfExV8z2RC8Zo8k").Do(newContext())
+	r := s.r()
+	r.Error(err)
+	r.True(common.IsAPIError(err))
+	r.Equal(int64(-2021), err.(*common.APIError).Code)
+	r.NotNil(res)
+	r.Equal(CancelReplaceResultTypeSuccess, res.CancelResult)
+	r.Equal(CancelReplaceResultTypeFailure, res.NewOrderResult)
+	r.Equal(int64(3), res.CancelResponse.OrderID)
+	r.Equal(OrderStatusTypeCanceled, res.CancelResponse.Status)
+	r.Nil(res.NewOrderResponse)
+	r.Equal(int64(-2010), res.NewOrderError.Code)
+	r.Equal("Order would immediately match and take.", res.NewOrderError.Message)
+}
+
+func (s *orderServiceTestSuite) TestCancelReplaceOrderCancelFailure() {
+	data := []byte(`{
+		"code": -2022,
+		"msg": "Order cancel-replace failed.",
+		"data": {
+			"cancelResult": "FAILURE",
+			"newOrderResult": "NOT_ATTEMPTED",
+			"cancelResponse": {
+				"code": -2011,
+				"msg": "Unknown order sent."
+			},
+			"newOrderResponse": null
+		}
+	}`)
+	s.mockDo(data, nil, http.StatusBadRequest)
+	defer s.assertDo()
+
+	res, err := s.client.NewCancelReplaceOrderService().Symbol("BTCUSDT").Side(SideTypeSell).
+		Type(OrderTypeLimit).CancelReplaceMode(CancelReplaceModeTypeStopOnFailure).
+		Quantity("1").Price("1").CancelOrderID(1).Do(newContext())
+	r := s.r()
+	r.Error(err)
+	r.Equal(CancelReplaceResultTypeFailure, res.CancelResult)
+	r.Equal(CancelReplaceResultTypeNotAttempted, res.NewOrderResult)
+	r.Equal(int64(-2011), res.CancelError.Code)
+	r.Nil(res.CancelResponse)
+	r.Nil(res.NewOrderResponse)
+	r.Nil(res.NewOrderError)
+}
+
+func (s *orderServiceTestSuite) TestCreateOrderListOCO() {
+	data := []byte(`{
+		"orderListId": 1,
+		"contingencyType": "OCO",
+		"listStatusType": "EXEC_STARTED",
+		"listOrderStatus": "EXECUTING",
+		"listClientOrderId": "lH1YDkuQKWiXVXHPSKYEIp",
+		"transactionTime": 1710485608839,
+		"symbol": "LTCBTC",
+		"orders": [
+			{"symbol": "LTCBTC", "orderId": 10, "clientOrderId": "44nZvqpemY7sVYgPYbvPih"},
+			{"symbol": "LTCBTC", "orderId": 11, "clientOrderId": "NuMp0nVYnciDiFmVqfpBqK"}
+		],
+		"orderReports": [
+			{
+				"symbol": "LTCBTC",
+				"orderId": 10,
+				"orderListId": 1,
+				"clientOrderId": "44nZvqpemY7sVYgPYbvPih",
+				"transactTime": 1710485608839,
+				"price": "1.00000000",
+				"origQty": "5.00000000",
+				"executedQty": "0.00000000",
+				"cummulativeQuoteQty": "0.00000000",
+				"status": "NEW",
+				"timeInForce": "GTC",
+				"type": "STOP_LOSS_LIMIT",
+				"side": "SELL",
+				"stopPrice": "1.00000000"
+			},
+			{
+				"symbol": "LTCBTC",
+				"orderId": 11,
+				"orderListId": 1,
+				"clientOrderId": "NuMp0nVYnciDiFmVqfpBqK",
+				"transactTime": 1710485608839,
+				"price": "3.00000000",
+				"origQty": "5.00000000",
+				"executedQty": "0.00000000",
+				"cummulativeQuoteQty": "0.00000000",
+				"status": "NEW",
+				"timeInForce": "GTC",
+				"type": "LIMIT_MAKER",
+				"side": "SELL"
+			}
+		]
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setFormParams(params{
+			"symbol":             "LTCBTC",
+			"side":               SideTypeSell,
+			"quantity":           "5",
+			"listClientOrderId":  "lH1YDkuQKWiXVXHPSKYEIp",
+			"aboveType":          OrderTypeLimitMaker,
+			"abovePrice":         "3",
+			"belowType":          OrderTypeStopLossLimit,
+			"belowPrice":         "1",
+			"belowStopPrice":     "1",
+			"belowTimeInForce":   TimeInForceTypeGTC,
+			"aboveClientOrderId": "NuMp0nVYnciDiFmVqfpBqK",
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewCreateOrderListOCOService().Symbol("LTCBTC").Side(SideTypeSell).
+		Quantity("5").ListClientOrderID("lH1YDkuQKWiXVXHPSKYEIp").
+		AboveType(OrderTypeLimitMaker).AbovePrice("3").AboveClientOrderID("NuMp0nVYnciDiFmVqfpBqK").
+		BelowType(OrderTypeStopLossLimit).BelowPrice("1").BelowStopPrice("1").BelowTimeInForce(TimeInForceTypeGTC).
+		Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal(int64(1), res.OrderListID)
+	r.Equal("EXEC_STARTED", res.ListStatusType)
+	r.Len(res.Orders, 2)
+	r.Len(res.OrderReports, 2)
+	r.Equal(OrderTypeStopLossLimit, res.OrderReports[0].Type)
+	r.Equal("1.00000000", res.OrderReports[0].StopPrice)
+	r.Equal(OrderTypeLimitMaker, res.OrderReports[1].Type)
+}
+
+func (s *orderServiceTestSuite) TestGetOCO() {
+	data := []byte(`{
+		"orderListId": 27,
+		"contingencyType": "OCO",
+		"listStatusType": "EXEC_STARTED",
+		"listOrderStatus": "EXECUTING",
+		"listClientOrderId": "h2USkA5YQpaXHPIrkd96xE",
+		"transactionTime": 1565245656253,
+		"symbol": "LTCBTC",
+		"orders": [
+			{"symbol": "LTCBTC", "orderId": 4, "clientOrderId": "qD1gy3kc3Gx0rihm9Y3xwS"},
+			{"symbol": "LTCBTC", "orderId": 5, "clientOrderId": "ARzZ9I00CPM8i3NhmU9Ega"}
+		]
+	}`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setParams(params{
+			"orderListId": 27,
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewGetOCOService().OrderListID(27).Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Equal(&OCO{
+		OrderListID:       27,
+		ContingencyType:   "OCO",
+		ListStatusType:    "EXEC_STARTED",
+		ListOrderStatus:   "EXECUTING",
+		ListClientOrderID: "h2USkA5YQpaXHPIrkd96xE",
+		TransactionTime:   1565245656253,
+		Symbol:            "LTCBTC",
+		Orders: []*OCOOrder{
+			{Symbol: "LTCBTC", OrderID: 4, ClientOrderID: "qD1gy3kc3Gx0rihm9Y3xwS"},
+			{Symbol: "LTCBTC", OrderID: 5, ClientOrderID: "ARzZ9I00CPM8i3NhmU9Ega"},
+		},
+	}, res)
+}
+
+func (s *orderServiceTestSuite) TestListOCO() {
+	data := []byte(`[
+		{
+			"orderListId": 29,
+			"contingencyType": "OCO",
+			"listStatusType": "EXEC_STARTED",
+			"listOrderStatus": "EXECUTING",
+			"listClientOrderId": "amEEAXryFzFwYF1FeRpUoZ",
+			"transactionTime": 1565245913483,
+			"symbol": "LTCBTC",
+			"orders": [
+				{"symbol": "LTCBTC", "orderId": 4, "clientOrderId": "oD7aesZqjEGlZrbtRpy5zB"},
+				{"symbol": "LTCBTC", "orderId": 5, "clientOrderId": "Jr1h6xirOxgeJOUuYQS7V3"}
+			]
+		},
+		{
+			"orderListId": 28,
+			"contingencyType": "OCO",
+			"listStatusType": "ALL_DONE",
+			"listOrderStatus": "ALL_DONE",
+			"listClientOrderId": "hG7hFNxJV6cZy3Ze4AUT4d",
+			"transactionTime": 1565245913407,
+			"symbol": "LTCBTC",
+			"orders": [
+				{"symbol": "LTCBTC", "orderId": 2, "clientOrderId": "j6lFOfbmFMRjTYA7rRJ0LP"},
+				{"symbol": "LTCBTC", "orderId": 3, "clientOrderId": "z0KCjOdditiLS5ekAFtK81"}
+			]
+		}
+	]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setParams(params{
+			"startTime": 1565245913000,
+			"endTime":   1565245914000,
+			"limit":     10,
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewListOCOService().StartTime(1565245913000).EndTime(1565245914000).
+		Limit(10).Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Len(res, 2)
+	r.Equal(int64(29), res[0].OrderListID)
+	r.Equal("ALL_DONE", res[1].ListOrderStatus)
+	r.Equal(int64(3), res[1].Orders[1].OrderID)
+}
+
+func (s *orderServiceTestSuite) TestListOCOFromID() {
+	data := []byte(`[]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	s.assertReq(func(r *request) {
+		e := newSignedRequest().setParams(params{
+			"fromId": 28,
+		})
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewListOCOService().FromID(28).Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Len(res, 0)
+}
+
+func (s *orderServiceTestSuite) TestListOpenOCO() {
+	data := []byte(`[
+		{
+			"orderListId": 31,
+			"contingencyType": "OCO",
+			"listStatusType": "EXEC_STARTED",
+			"listOrderStatus": "EXECUTING",
+			"listClientOrderId": "wuB13fmulKj3YjdqWEcsnp",
+			"transactionTime": 1565246080644,
+			"symbol": "LTCBTC",
+			"orders": [
+				{"symbol": "LTCBTC", "orderId": 4, "clientOrderId": "r3EH2N76dHfLoSZWIUw1bT"},
+				{"symbol": "LTCBTC", "orderId": 5, "clientOrderId": "Cv1SnyPD3qhqpbjpYEHbd2"}
+			]
+		}
+	]`)
+	s.mockDo(data, nil)
+	defer s.assertDo()
+
+	s.assertReq(func(r *request) {
+		e := newSignedRequest()
+		s.assertRequestEqual(e, r)
+	})
+
+	res, err := s.client.NewListOpenOCOService().Do(newContext())
+	r := s.r()
+	r.NoError(err)
+	r.Len(res, 1)
+	r.Equal("wuB13fmulKj3YjdqWEcsnp", res[0].ListClientOrderID)
+	r.Len(res[0].Orders, 2)
+}
+
 func (s *orderServiceTestSuite) TestCancelOpenOrders() {
 	data := []byte(`[
 		{
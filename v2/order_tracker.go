@@ -0,0 +1,115 @@
+package binance
+
+import (
+	"context"
+
+	"github.com/Zamzam-Technology/go-binance/v2/common"
+)
+
+// OrderTracker track the spot or margin orders of the account from the execution reports
+// of the user data stream, reconciled with the REST orders after a reconnect
+type OrderTracker struct {
+	*common.OrderTracker
+	c        *Client
+	margin   bool
+	isolated bool
+}
+
+// ApplyOrderUpdate apply an execution report, see common.OrderTracker.Apply
+func (t *OrderTracker) ApplyOrderUpdate(update *WsOrderUpdate) (bool, error) {
+	clientOrderID := update.ClientOrderID
+	if update.ExecutionType == ExecutionTypeCanceled && update.OrigClientOrderID != "" {
+		// the cancel report carries the client order id of the cancel request
+		clientOrderID = update.OrigClientOrderID
+	}
+	u := &common.OrderUpdate{
+		Symbol:           update.Symbol,
+		OrderID:          update.ID,
+		ClientOrderID:    clientOrderID,
+		Side:             string(update.Side),
+		Type:             string(update.Type),
+		Status:           common.OrderStatus(update.Status),
+		Price:            update.Price,
+		Quantity:         update.Quantity,
+		ExecutedQuantity: update.FilledQuantity,
+		CumulativeQuote:  update.FilledQuoteQuantity,
+		Time:             update.TransactionTime,
+	}
+	if update.ExecutionType == ExecutionTypeTrade {
+		u.TradeID = update.TradeID
+		u.LastQuantity = update.LastFilledQuantity
+		u.LastPrice = update.LastFilledPrice
+		u.Commission = update.Commission
+		u.CommissionAsset = update.CommissionAsset
+	}
+	return t.Apply(u)
+}
+
+// ApplyOrder apply an order returned by the REST API, see common.OrderTracker.Apply
+func (t *OrderTracker) ApplyOrder(order *Order) (bool, error) {
+	return t.Apply(&common.OrderUpdate{
+		Symbol:           order.Symbol,
+		OrderID:          order.OrderID,
+		ClientOrderID:    order.ClientOrderID,
+		Side:             string(order.Side),
+		Type:             string(order.Type),
+		Status:           common.OrderStatus(order.Status),
+		Price:            order.Price,
+		Quantity:         order.OrigQuantity,
+		ExecutedQuantity: order.ExecutedQuantity,
+		CumulativeQuote:  order.CummulativeQuoteQuantity,
+		Time:             order.UpdateTime,
+	})
+}
+
+// Reconcile apply the open orders of symbol, or of all symbols when symbol is empty, then query
+// the tracked open orders missing from them, which were completed while the stream was down.
+// Isolated margin trackers need a symbol. Stale orders rejected by the tracker are skipped.
+func (t *OrderTracker) Reconcile(ctx context.Context, symbol string, opts ...RequestOption) error {
+	var orders []*Order
+	var err error
+	if t.margin {
+		s := t.c.NewListMarginOpenOrdersService().IsIsolated(t.isolated)
+		if symbol != "" {
+			s.Symbol(symbol)
+		}
+		orders, err = s.Do(ctx, opts...)
+	} else {
+		s := t.c.NewListOpenOrdersService()
+		if symbol != "" {
+			s.Symbol(symbol)
+		}
+		orders, err = s.Do(ctx, opts...)
+	}
+	if err != nil {
+		return err
+	}
+	open := make(map[string]bool, len(orders))
+	for _, order := range orders {
+		open[order.ClientOrderID] = true
+		if _, err := t.ApplyOrder(order); err != nil && !common.IsOrderTransitionError(err) {
+			return err
+		}
+	}
+	for _, state := range t.OpenOrders() {
+		if open[state.ClientOrderID] || (symbol != "" && state.Symbol != symbol) {
+			continue
+		}
+		order, err := t.getOrder(ctx, state.Symbol, state.ClientOrderID, opts...)
+		if err != nil {
+			return err
+		}
+		if _, err := t.ApplyOrder(order); err != nil && !common.IsOrderTransitionError(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *OrderTracker) getOrder(ctx context.Context, symbol, clientOrderID string, opts ...RequestOption) (*Order, error) {
+	if t.margin {
+		return t.c.NewGetMarginOrderService().IsIsolated(t.isolated).Symbol(symbol).
+			OrigClientOrderID(clientOrderID).Do(ctx, opts...)
+	}
+	return t.c.NewGetOrderService().Symbol(symbol).OrigClientOrderID(clientOrderID).Do(ctx, opts...)
+}
@@ -0,0 +1,94 @@
+package binance
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/Zamzam-Technology/go-binance/v2/common"
+	"github.com/stretchr/testify/suite"
+)
+
+type orderTrackerTestSuite struct {
+	baseTestSuite
+	responses [][]byte
+	paths     []string
+	queries   []url.Values
+}
+
+func TestOrderTracker(t *testing.T) {
+	suite.Run(t, new(orderTrackerTestSuite))
+}
+
+func (s *orderTrackerTestSuite) SetupTest() {
+	s.baseTestSuite.SetupTest()
+	s.paths = nil
+	s.queries = nil
+	s.client.Client.do = func(req *http.Request) (*http.Response, error) {
+		data := s.responses[len(s.queries)]
+		s.paths = append(s.paths, req.URL.Path)
+		s.queries = append(s.queries, req.URL.Query())
+		return newHTTPResponse(data, http.StatusOK), nil
+	}
+}
+
+func (s *orderTrackerTestSuite) TestApplyOrderUpdate() {
+	tracker := s.client.NewOrderTracker()
+	r := s.r()
+	changed, err := tracker.ApplyOrderUpdate(&WsOrderUpdate{Symbol: "BNBUSDT", ID: 1, ClientOrderID: "a",
+		Side: SideTypeBuy, Type: OrderTypeLimit, Quantity: "2", Price: "10",
+		ExecutionType: ExecutionTypeTrade, Status: OrderStatusTypePartiallyFilled, TradeID: 5,
+		LastFilledQuantity: "1", LastFilledPrice: "10", FilledQuantity: "1", FilledQuoteQuantity: "10",
+		Commission: "0.001", CommissionAsset: "BNB", TransactionTime: 100})
+	r.NoError(err)
+	r.True(changed)
+	changed, err = tracker.ApplyOrderUpdate(&WsOrderUpdate{Symbol: "BNBUSDT", ID: 1, ClientOrderID: "cancel", OrigClientOrderID: "a",
+		ExecutionType: ExecutionTypeCanceled, Status: OrderStatusTypeCanceled, TradeID: -1, FilledQuantity: "1", TransactionTime: 200})
+	r.NoError(err)
+	r.True(changed)
+
+	state, ok := tracker.Order("a")
+	r.True(ok)
+	r.Equal(common.OrderStatusCanceled, state.Status)
+	r.Equal("BUY", state.Side)
+	r.Equal("1", state.ExecutedQuantity)
+	r.Equal("10", state.AveragePrice())
+	r.Equal(map[string]string{"BNB": "0.001"}, state.Commissions)
+	r.Equal(1, state.Trades)
+}
+
+func (s *orderTrackerTestSuite) TestReconcile() {
+	tracker := s.client.NewOrderTracker()
+	r := s.r()
+	for _, id := range []string{"a", "b"} {
+		_, err := tracker.ApplyOrderUpdate(&WsOrderUpdate{Symbol: "BNBUSDT", ClientOrderID: id,
+			ExecutionType: ExecutionTypeNew, Status: OrderStatusTypeNew, Quantity: "1"})
+		r.NoError(err)
+	}
+	s.responses = [][]byte{
+		[]byte(`[{"symbol": "BNBUSDT", "orderId": 1, "clientOrderId": "a", "status": "PARTIALLY_FILLED",
+			"origQty": "1", "executedQty": "0.5", "cummulativeQuoteQty": "5", "updateTime": 300}]`),
+		[]byte(`{"symbol": "BNBUSDT", "orderId": 2, "clientOrderId": "b", "status": "FILLED",
+			"origQty": "1", "executedQty": "1", "cummulativeQuoteQty": "11", "updateTime": 400}`),
+	}
+	r.NoError(tracker.Reconcile(newContext(), "BNBUSDT"))
+
+	r.Equal([]string{"/api/v3/openOrders", "/api/v3/order"}, s.paths)
+	r.Equal("BNBUSDT", s.queries[0].Get("symbol"))
+	r.Equal("b", s.queries[1].Get("origClientOrderId"))
+	state, _ := tracker.Order("a")
+	r.Equal(common.OrderStatusPartiallyFilled, state.Status)
+	r.Equal("0.5", state.ExecutedQuantity)
+	state, _ = tracker.Order("b")
+	r.Equal(common.OrderStatusFilled, state.Status)
+	r.Equal("11", state.AveragePrice())
+	r.Len(tracker.OpenOrders(), 1)
+}
+
+func (s *orderTrackerTestSuite) TestReconcileMargin() {
+	tracker := s.client.NewMarginOrderTracker(true)
+	s.responses = [][]byte{[]byte(`[]`)}
+	s.r().NoError(tracker.Reconcile(newContext(), "BNBUSDT"))
+	s.r().Equal([]string{"/sapi/v1/margin/openOrders"}, s.paths)
+	s.r().Equal("TRUE", s.queries[0].Get("isIsolated"))
+}
@@ -0,0 +1,449 @@
+package paper
+
+import (
+	"fmt"
+	"math/big"
+	"net/http"
+	"strconv"
+
+	"github.com/Zamzam-Technology/go-binance/v2/common"
+	"github.com/Zamzam-Technology/go-binance/v2/delivery"
+)
+
+// DeliveryExchange simulate the COIN-M futures order, account, position and user stream
+// endpoints, with cross margin. The quantities are in contracts, whose value is the contract
+// size in quote asset, and the margin and the profit are in the margin asset of the symbols.
+// It is safe for concurrent use.
+type DeliveryExchange struct {
+	*exchange
+	*futuresAccount
+	pairs map[string]string
+}
+
+// NewDeliveryExchange create a paper coin-m futures exchange trading the symbols of info
+func NewDeliveryExchange(info *delivery.ExchangeInfo, cfg *Config) (*DeliveryExchange, error) {
+	if cfg == nil {
+		cfg = &Config{}
+	}
+	account, err := newFuturesAccount(cfg, "0.0002", "0.0005")
+	if err != nil {
+		return nil, err
+	}
+	x := &DeliveryExchange{exchange: newExchange(cfg), futuresAccount: account, pairs: make(map[string]string)}
+	x.engine = newEngine(x, x.millis)
+	x.exchange.flush = x.flushAccount
+	for i := range info.Symbols {
+		s := &info.Symbols[i]
+		if s.ContractSize <= 0 {
+			return nil, fmt.Errorf("invalid contract size %d of %s", s.ContractSize, s.Symbol)
+		}
+		x.symbols[s.Symbol] = &futuresSymbol{
+			filters: s.OrderFilters(),
+			margin:  s.MarginAsset,
+			size:    big.NewRat(int64(s.ContractSize), 1),
+		}
+		x.pairs[s.Symbol] = s.Pair
+	}
+
+	x.handle(http.MethodPost, "/dapi/v1/order", x.createOrder)
+	x.handle(http.MethodGet, "/dapi/v1/order", x.getOrder)
+	x.handle(http.MethodDelete, "/dapi/v1/order", x.cancelOrder)
+	x.handle(http.MethodGet, "/dapi/v1/openOrders", x.listOpenOrders)
+	x.handle(http.MethodDelete, "/dapi/v1/allOpenOrders", x.cancelAllOpenOrders)
+	x.handle(http.MethodGet, "/dapi/v1/allOrders", x.listOrders)
+	x.handle(http.MethodGet, "/dapi/v1/balance", x.getBalance)
+	x.handle(http.MethodGet, "/dapi/v1/account", x.getAccount)
+	x.handle(http.MethodGet, "/dapi/v1/positionRisk", x.getPositionRisk)
+	x.handle(http.MethodGet, "/dapi/v1/positionSide/dual", x.getPositionMode)
+	x.handle(http.MethodPost, "/dapi/v1/positionSide/dual", x.changePositionMode)
+	x.handle(http.MethodPost, "/dapi/v1/leverage", x.changeLeverage)
+	x.handle(http.MethodPost, "/dapi/v1/listenKey", listenKey)
+	x.handle(http.MethodPut, "/dapi/v1/listenKey", emptyResponse)
+	x.handle(http.MethodDelete, "/dapi/v1/listenKey", emptyResponse)
+	return x, nil
+}
+
+// Client return a client whose requests are served by the exchange
+func (x *DeliveryExchange) Client() *delivery.Client {
+	c := delivery.NewClient("paper", "paper")
+	c.HTTPClient = &http.Client{Transport: x}
+	return c
+}
+
+// SubscribeUserData call handler with the events of the user data stream: order trade updates
+// and account updates. Handlers are called outside of the exchange lock, in the order of the
+// events. The returned function removes the subscription.
+func (x *DeliveryExchange) SubscribeUserData(handler delivery.WsUserDataHandler) (unsubscribe func()) {
+	return x.subscribe(func(event interface{}) {
+		handler(event.(*delivery.WsUserDataEvent))
+	})
+}
+
+// OnBook replace the order book of symbol, e.g. with a partial depth event.
+// The resting orders crossed by the book are filled as maker at their price.
+func (x *DeliveryExchange) OnBook(symbol string, bids, asks []common.PriceLevel) {
+	x.update(func() {
+		x.engine.onBook(symbol, bids, asks)
+	})
+}
+
+// OnTrade record a trade of the market, see FuturesExchange.OnTrade
+func (x *DeliveryExchange) OnTrade(symbol, price, quantity string) error {
+	p, err := common.ParseDecimal(price)
+	if err != nil {
+		return err
+	}
+	q, err := common.ParseDecimal(quantity)
+	if err != nil {
+		return err
+	}
+	x.update(func() {
+		x.engine.onTrade(symbol, p, q)
+	})
+	return nil
+}
+
+// OnFunding settle the funding of the perpetual symbol at fundingRate, see FuturesExchange.OnFunding.
+// The fee is in the margin asset of symbol.
+func (x *DeliveryExchange) OnFunding(symbol, fundingRate string) (string, error) {
+	rate, err := common.ParseDecimal(fundingRate)
+	if err != nil {
+		return "", err
+	}
+	if _, ok := x.symbols[symbol]; !ok {
+		return "", fmt.Errorf("unknown symbol %s", symbol)
+	}
+	var fee *big.Rat
+	x.update(func() {
+		fee = x.fund(symbol, rate)
+	})
+	return common.FormatDecimal(fee), nil
+}
+
+// Equity return the margin balance of asset: the wallet balance and the unrealized profit
+func (x *DeliveryExchange) Equity(asset string) string {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	return common.FormatDecimal(x.equity(asset))
+}
+
+// cumBase return the value in margin asset of the executed quantity of an order
+func (x *DeliveryExchange) cumBase(o *order) *big.Rat {
+	s := x.symbols[o.symbol]
+	base := new(big.Rat)
+	for _, t := range x.engine.trades {
+		if t.order == o {
+			base.Add(base, s.value(t.quantity, t.price))
+		}
+	}
+	return base
+}
+
+func (x *DeliveryExchange) createOrder(p params) (interface{}, error) {
+	o, err := x.futuresAccount.createOrder(p)
+	if err != nil {
+		return nil, err
+	}
+	return &delivery.CreateOrderResponse{
+		ClientOrderID:    o.clientOrderID,
+		CumQuantity:      common.FormatDecimal(o.executed),
+		CumBase:          common.FormatDecimal(x.cumBase(o)),
+		ExecutedQuantity: common.FormatDecimal(o.executed),
+		OrderID:          o.id,
+		AvgPrice:         common.FormatDecimal(average(o.quote, o.executed)),
+		OrigQuantity:     common.FormatDecimal(o.quantity),
+		Price:            common.FormatDecimal(o.price),
+		ReduceOnly:       o.reduceOnly,
+		Side:             delivery.SideType(o.side),
+		PositionSide:     delivery.PositionSideType(o.positionSide),
+		Status:           delivery.OrderStatusType(o.status),
+		StopPrice:        common.FormatDecimal(o.stopPrice),
+		ClosePosition:    o.closePosition,
+		Symbol:           o.symbol,
+		Pair:             x.pairs[o.symbol],
+		TimeInForce:      delivery.TimeInForceType(o.timeInForce),
+		Type:             delivery.OrderType(o.orderType),
+		OrigType:         delivery.OrderType(o.orderType),
+		UpdateTime:       o.updateTime,
+		WorkingType:      delivery.WorkingTypeContractPrice,
+	}, nil
+}
+
+func (x *DeliveryExchange) getOrder(p params) (interface{}, error) {
+	o, err := x.futuresAccount.getOrder(p)
+	if err != nil {
+		return nil, err
+	}
+	return x.order(o), nil
+}
+
+func (x *DeliveryExchange) cancelOrder(p params) (interface{}, error) {
+	o, err := x.futuresAccount.cancelOrder(p)
+	if err != nil {
+		return nil, err
+	}
+	return &delivery.CancelOrderResponse{
+		AvgPrice:         common.FormatDecimal(average(o.quote, o.executed)),
+		ClientOrderID:    o.clientOrderID,
+		CumQuantity:      common.FormatDecimal(o.executed),
+		CumBase:          common.FormatDecimal(x.cumBase(o)),
+		ExecutedQuantity: common.FormatDecimal(o.executed),
+		OrderID:          o.id,
+		OrigQuantity:     common.FormatDecimal(o.quantity),
+		OrigType:         delivery.OrderType(o.orderType),
+		Price:            common.FormatDecimal(o.price),
+		ReduceOnly:       o.reduceOnly,
+		Side:             delivery.SideType(o.side),
+		PositionSide:     delivery.PositionSideType(o.positionSide),
+		Status:           delivery.OrderStatusType(o.status),
+		StopPrice:        common.FormatDecimal(o.stopPrice),
+		ClosePosition:    o.closePosition,
+		Symbol:           o.symbol,
+		Pair:             x.pairs[o.symbol],
+		TimeInForce:      delivery.TimeInForceType(o.timeInForce),
+		Type:             delivery.OrderType(o.orderType),
+		UpdateTime:       o.updateTime,
+		WorkingType:      delivery.WorkingTypeContractPrice,
+	}, nil
+}
+
+// listOpenOrders return the open orders of the symbol or the pair of the parameters, or of all
+// symbols when neither is set
+func (x *DeliveryExchange) listOpenOrders(p params) (interface{}, error) {
+	symbol, pair := p.get("symbol"), p.get("pair")
+	if _, ok := x.symbols[symbol]; symbol != "" && !ok {
+		return nil, apiError(-1121, "Invalid symbol.")
+	}
+	res := make([]*delivery.Order, 0)
+	for _, o := range x.engine.openOrders(symbol) {
+		if pair == "" || x.pairs[o.symbol] == pair {
+			res = append(res, x.order(o))
+		}
+	}
+	return res, nil
+}
+
+func (x *DeliveryExchange) listOrders(p params) (interface{}, error) {
+	orders, err := x.futuresAccount.listOrders(p)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*delivery.Order, 0, len(orders))
+	for _, o := range orders {
+		res = append(res, x.order(o))
+	}
+	return res, nil
+}
+
+func (x *DeliveryExchange) getBalance(p params) (interface{}, error) {
+	res := make([]*delivery.Balance, 0, len(x.wallets))
+	for _, asset := range x.assets() {
+		unrealized, _, _ := x.margins(asset)
+		available := common.FormatDecimal(x.available(asset))
+		res = append(res, &delivery.Balance{
+			AccountAlias:       "paper",
+			Asset:              asset,
+			Balance:            common.FormatDecimal(x.wallets[asset]),
+			WithdrawAvailable:  available,
+			CrossWalletBalance: common.FormatDecimal(x.wallets[asset]),
+			CrossUnPnl:         common.FormatDecimal(unrealized),
+			AvailableBalance:   available,
+			UpdateTime:         x.millis(),
+		})
+	}
+	return res, nil
+}
+
+func (x *DeliveryExchange) getAccount(p params) (interface{}, error) {
+	res := &delivery.Account{
+		Assets:      make([]*delivery.AccountAsset, 0, len(x.wallets)),
+		CanDeposit:  true,
+		CanTrade:    true,
+		CanWithdraw: true,
+		Positions:   make([]*delivery.AccountPosition, 0),
+		UpdateTime:  x.millis(),
+	}
+	for _, asset := range x.assets() {
+		wallet := x.wallets[asset]
+		unrealized, positionMargin, orderMargin := x.margins(asset)
+		available := common.FormatDecimal(x.available(asset))
+		res.Assets = append(res.Assets, &delivery.AccountAsset{
+			Asset:                  asset,
+			WalletBalance:          common.FormatDecimal(wallet),
+			UnrealizedProfit:       common.FormatDecimal(unrealized),
+			MarginBalance:          common.FormatDecimal(new(big.Rat).Add(wallet, unrealized)),
+			MaintMargin:            "0",
+			InitialMargin:          common.FormatDecimal(new(big.Rat).Add(positionMargin, orderMargin)),
+			PositionInitialMargin:  common.FormatDecimal(positionMargin),
+			OpenOrderInitialMargin: common.FormatDecimal(orderMargin),
+			MaxWithdrawAmount:      available,
+			CrossWalletBalance:     common.FormatDecimal(wallet),
+			CrossUnPnl:             common.FormatDecimal(unrealized),
+			AvailableBalance:       available,
+		})
+	}
+	for _, pos := range x.sortedPositions("") {
+		orderMargin := new(big.Rat)
+		for _, o := range x.engine.openOrders(pos.symbol) {
+			if o.positionSide == pos.side {
+				orderMargin.Add(orderMargin, o.reserved)
+			}
+		}
+		res.Positions = append(res.Positions, &delivery.AccountPosition{
+			Symbol:                 pos.symbol,
+			InitialMargin:          common.FormatDecimal(new(big.Rat).Add(x.initialMargin(pos), orderMargin)),
+			MaintMargin:            "0",
+			UnrealizedProfit:       common.FormatDecimal(x.unrealized(pos)),
+			PositionInitialMargin:  common.FormatDecimal(x.initialMargin(pos)),
+			OpenOrderInitialMargin: common.FormatDecimal(orderMargin),
+			Leverage:               strconv.Itoa(x.symbolLeverage(pos.symbol)),
+			PositionSide:           pos.side,
+			EntryPrice:             common.FormatDecimal(pos.entry),
+			MaxQty:                 "0",
+		})
+	}
+	return res, nil
+}
+
+// getPositionRisk return the positions of the margin asset or the pair of the parameters
+func (x *DeliveryExchange) getPositionRisk(p params) (interface{}, error) {
+	marginAsset, pair := p.get("marginAsset"), p.get("pair")
+	res := make([]*delivery.PositionRisk, 0)
+	for _, pos := range x.sortedPositions("") {
+		if (marginAsset != "" && x.symbols[pos.symbol].margin != marginAsset) || (pair != "" && x.pairs[pos.symbol] != pair) {
+			continue
+		}
+		res = append(res, &delivery.PositionRisk{
+			Symbol:           pos.symbol,
+			PositionAmt:      common.FormatDecimal(pos.amount),
+			EntryPrice:       common.FormatDecimal(pos.entry),
+			MarkPrice:        common.FormatDecimal(x.mark(pos.symbol)),
+			UnRealizedProfit: common.FormatDecimal(x.unrealized(pos)),
+			LiquidationPrice: "0",
+			Leverage:         strconv.Itoa(x.symbolLeverage(pos.symbol)),
+			MaxQuantity:      "0",
+			MarginType:       "cross",
+			IsolatedMargin:   "0",
+			IsAutoAddMargin:  "false",
+			PositionSide:     pos.side,
+		})
+	}
+	return res, nil
+}
+
+func (x *DeliveryExchange) getPositionMode(p params) (interface{}, error) {
+	return &delivery.PositionMode{DualSidePosition: x.dual}, nil
+}
+
+func (x *DeliveryExchange) changeLeverage(p params) (interface{}, error) {
+	symbol, leverage, err := x.futuresAccount.changeLeverage(p)
+	if err != nil {
+		return nil, err
+	}
+	return &delivery.SymbolLeverage{Leverage: leverage, MaxQuantity: "0", Symbol: symbol}, nil
+}
+
+func (x *DeliveryExchange) report(o *order, executionType string, t *trade) {
+	u := &delivery.WsOrderTradeUpdate{
+		Symbol:               o.symbol,
+		ClientOrderID:        o.clientOrderID,
+		Side:                 delivery.SideType(o.side),
+		Type:                 delivery.OrderType(o.orderType),
+		TimeInForce:          delivery.TimeInForceType(o.timeInForce),
+		OriginalQty:          common.FormatDecimal(o.quantity),
+		OriginalPrice:        common.FormatDecimal(o.price),
+		AveragePrice:         common.FormatDecimal(average(o.quote, o.executed)),
+		StopPrice:            common.FormatDecimal(o.stopPrice),
+		ExecutionType:        delivery.OrderExecutionType(executionType),
+		Status:               delivery.OrderStatusType(o.status),
+		ID:                   o.id,
+		LastFilledQty:        "0",
+		AccumulatedFilledQty: common.FormatDecimal(o.executed),
+		LastFilledPrice:      "0",
+		MarginAsset:          x.symbols[o.symbol].margin,
+		TradeTime:            o.updateTime,
+		BidsNotional:         "0",
+		AsksNotional:         "0",
+		IsReduceOnly:         o.reduceOnly,
+		WorkingType:          delivery.WorkingTypeContractPrice,
+		OriginalType:         delivery.OrderType(o.orderType),
+		PositionSide:         delivery.PositionSideType(o.positionSide),
+		IsClosingPosition:    o.closePosition,
+		RealizedPnL:          "0",
+	}
+	if t != nil {
+		u.LastFilledQty = common.FormatDecimal(t.quantity)
+		u.LastFilledPrice = common.FormatDecimal(t.price)
+		u.Commission = common.FormatDecimal(t.commission)
+		u.CommissionAsset = t.commissionAsset
+		u.TradeTime = t.time
+		u.TradeID = t.id
+		u.IsMaker = t.maker
+		u.RealizedPnL = common.FormatDecimal(t.realizedPnL)
+	}
+	now := x.millis()
+	x.emit(&delivery.WsUserDataEvent{
+		Event:            delivery.UserDataEventTypeOrderTradeUpdate,
+		Time:             now,
+		TransactionTime:  now,
+		OrderTradeUpdate: u,
+	})
+}
+
+// flushAccount emit the balances and the positions changed by the update
+func (x *DeliveryExchange) flushAccount() {
+	reason, assets, positions, ok := x.changes()
+	if !ok {
+		return
+	}
+	u := &delivery.WsAccountUpdate{Reason: delivery.UserDataEventReasonType(reason)}
+	for _, asset := range assets {
+		wallet := common.FormatDecimal(x.wallets[asset])
+		u.Balances = append(u.Balances, delivery.WsBalance{Asset: asset, Balance: wallet, CrossWalletBalance: wallet})
+	}
+	for _, pos := range positions {
+		u.Positions = append(u.Positions, delivery.WsPosition{
+			Symbol:              pos.symbol,
+			Side:                delivery.PositionSideType(pos.side),
+			Amount:              common.FormatDecimal(pos.amount),
+			MarginType:          "cross",
+			IsolatedWallet:      "0",
+			EntryPrice:          common.FormatDecimal(pos.entry),
+			UnrealizedPnL:       common.FormatDecimal(x.unrealized(pos)),
+			AccumulatedRealized: common.FormatDecimal(pos.realized),
+		})
+	}
+	now := x.millis()
+	x.emit(&delivery.WsUserDataEvent{
+		Event:           delivery.UserDataEventTypeAccountUpdate,
+		Time:            now,
+		TransactionTime: now,
+		AccountUpdate:   u,
+	})
+}
+
+func (x *DeliveryExchange) order(o *order) *delivery.Order {
+	return &delivery.Order{
+		AvgPrice:         common.FormatDecimal(average(o.quote, o.executed)),
+		ClientOrderID:    o.clientOrderID,
+		CumBase:          common.FormatDecimal(x.cumBase(o)),
+		ExecutedQuantity: common.FormatDecimal(o.executed),
+		OrderID:          o.id,
+		OrigQuantity:     common.FormatDecimal(o.quantity),
+		OrigType:         delivery.OrderType(o.orderType),
+		Price:            common.FormatDecimal(o.price),
+		ReduceOnly:       o.reduceOnly,
+		Side:             delivery.SideType(o.side),
+		PositionSide:     delivery.PositionSideType(o.positionSide),
+		Status:           delivery.OrderStatusType(o.status),
+		StopPrice:        common.FormatDecimal(o.stopPrice),
+		ClosePosition:    o.closePosition,
+		Symbol:           o.symbol,
+		Pair:             x.pairs[o.symbol],
+		Time:             o.time,
+		TimeInForce:      delivery.TimeInForceType(o.timeInForce),
+		Type:             delivery.OrderType(o.orderType),
+		UpdateTime:       o.updateTime,
+		WorkingType:      delivery.WorkingTypeContractPrice,
+	}
+}
@@ -0,0 +1,128 @@
+package paper
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/Zamzam-Technology/go-binance/v2/common"
+	"github.com/Zamzam-Technology/go-binance/v2/delivery"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const deliveryExchangeInfo = `{
+	"symbols": [{
+		"symbol": "BTCUSD_PERP",
+		"pair": "BTCUSD",
+		"contractType": "PERPETUAL",
+		"contractSize": 100,
+		"baseAsset": "BTC",
+		"quoteAsset": "USD",
+		"marginAsset": "BTC",
+		"filters": [
+			{"filterType": "PRICE_FILTER", "minPrice": "0.1", "maxPrice": "1000000", "tickSize": "0.1"},
+			{"filterType": "LOT_SIZE", "minQty": "1", "maxQty": "1000000", "stepSize": "1"},
+			{"filterType": "MARKET_LOT_SIZE", "minQty": "1", "maxQty": "1000", "stepSize": "1"}
+		]
+	}]
+}`
+
+func newTestDeliveryExchange(t *testing.T) (*DeliveryExchange, *delivery.Client, *[]*delivery.WsUserDataEvent) {
+	info := new(delivery.ExchangeInfo)
+	require.NoError(t, json.Unmarshal([]byte(deliveryExchangeInfo), info))
+	x, err := NewDeliveryExchange(info, &Config{
+		Balances: map[string]string{"BTC": "1"},
+		Now:      func() time.Time { return time.Unix(1600000000, 0) },
+	})
+	require.NoError(t, err)
+	events := new([]*delivery.WsUserDataEvent)
+	x.SubscribeUserData(func(event *delivery.WsUserDataEvent) {
+		*events = append(*events, event)
+	})
+	x.OnBook("BTCUSD_PERP", []common.PriceLevel{{Price: "9999", Quantity: "1000"}}, []common.PriceLevel{{Price: "10000", Quantity: "1000"}})
+	return x, x.Client(), events
+}
+
+func TestDeliveryPosition(t *testing.T) {
+	x, c, events := newTestDeliveryExchange(t)
+	ctx := context.Background()
+
+	res, err := c.NewCreateOrderService().Symbol("BTCUSD_PERP").Side(delivery.SideTypeBuy).
+		Type(delivery.OrderTypeMarket).Quantity("10").Do(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, delivery.OrderStatusTypeFilled, res.Status)
+	assert.Equal(t, "10000", res.AvgPrice)
+	assert.Equal(t, "0.1", res.CumBase)
+	assert.Equal(t, "BTCUSD", res.Pair)
+
+	// the entry price of the contracts is the harmonic mean of the fills
+	x.OnBook("BTCUSD_PERP", []common.PriceLevel{{Price: "12499", Quantity: "1000"}}, []common.PriceLevel{{Price: "12500", Quantity: "1000"}})
+	_, err = c.NewCreateOrderService().Symbol("BTCUSD_PERP").Side(delivery.SideTypeBuy).
+		Type(delivery.OrderTypeMarket).Quantity("10").Do(ctx)
+	require.NoError(t, err)
+	require.NoError(t, x.OnTrade("BTCUSD_PERP", "12500", "1"))
+	risks, err := c.NewGetPositionRiskService().Pair("BTCUSD").Do(ctx)
+	require.NoError(t, err)
+	require.Len(t, risks, 1)
+	assert.Equal(t, "20", risks[0].PositionAmt)
+	assert.Equal(t, "11111.11111111", risks[0].EntryPrice)
+	assert.Equal(t, "0.02", risks[0].UnRealizedProfit)
+	account, err := c.NewGetAccountService().Do(ctx)
+	require.NoError(t, err)
+	require.Len(t, account.Assets, 1)
+	assert.Equal(t, "0.009", account.Assets[0].PositionInitialMargin)
+	assert.Equal(t, "1.01991", account.Assets[0].MarginBalance)
+
+	_, err = c.NewCreateOrderService().Symbol("BTCUSD_PERP").Side(delivery.SideTypeSell).
+		Type(delivery.OrderTypeLimit).TimeInForce(delivery.TimeInForceTypeGTC).
+		Quantity("20").Price("13000").NewClientOrderID("tp").Do(ctx)
+	require.NoError(t, err)
+	require.NoError(t, x.OnTrade("BTCUSD_PERP", "13100", "50"))
+	order, err := c.NewGetOrderService().Symbol("BTCUSD_PERP").OrigClientOrderID("tp").Do(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, delivery.OrderStatusTypeFilled, order.Status)
+	assert.Equal(t, "0.15384615", order.CumBase)
+
+	balances, err := c.NewGetBalanceService().Do(ctx)
+	require.NoError(t, err)
+	require.Len(t, balances, 1)
+	// 0.00005 and 0.00004 of taker commission, 0.00003077 of maker commission
+	assert.Equal(t, "1.02603308", balances[0].Balance)
+
+	last := (*events)[len(*events)-2]
+	require.Equal(t, delivery.UserDataEventTypeOrderTradeUpdate, last.Event)
+	assert.Equal(t, "BTC", last.OrderTradeUpdate.MarginAsset)
+	assert.Equal(t, "0.02615385", last.OrderTradeUpdate.RealizedPnL)
+	assert.True(t, last.OrderTradeUpdate.IsMaker)
+	update := (*events)[len(*events)-1]
+	require.Equal(t, delivery.UserDataEventTypeAccountUpdate, update.Event)
+	assert.Equal(t, delivery.UserDataEventReasonTypeOrder, update.AccountUpdate.Reason)
+	assert.Equal(t, []delivery.WsBalance{{Asset: "BTC", Balance: "1.02603308", CrossWalletBalance: "1.02603308"}}, update.AccountUpdate.Balances)
+	require.Len(t, update.AccountUpdate.Positions, 1)
+	assert.Equal(t, "0", update.AccountUpdate.Positions[0].Amount)
+}
+
+func TestDeliveryOrderErrors(t *testing.T) {
+	_, c, _ := newTestDeliveryExchange(t)
+	ctx := context.Background()
+
+	// 2000 contracts of 100 USD at 9000 are 22.2 BTC, 1.11 BTC of margin at the default leverage
+	_, err := c.NewCreateOrderService().Symbol("BTCUSD_PERP").Side(delivery.SideTypeBuy).Type(delivery.OrderTypeLimit).
+		TimeInForce(delivery.TimeInForceTypeGTC).Quantity("2000").Price("9000").Do(ctx)
+	assert.Equal(t, &common.APIError{Code: -2019, Message: "Margin is insufficient."}, err)
+	_, err = c.NewCreateOrderService().Symbol("BTCUSD_PERP").Side(delivery.SideTypeBuy).Type(delivery.OrderTypeLimit).
+		TimeInForce(delivery.TimeInForceTypeGTC).Quantity("1.5").Price("9000").Do(ctx)
+	assert.Equal(t, &common.APIError{Code: -1013, Message: "Filter failure: LOT_SIZE"}, err)
+
+	res, err := c.NewCreateOrderService().Symbol("BTCUSD_PERP").Side(delivery.SideTypeBuy).Type(delivery.OrderTypeLimit).
+		TimeInForce(delivery.TimeInForceTypeGTC).Quantity("1000").Price("9000").Do(ctx)
+	require.NoError(t, err)
+	orders, err := c.NewListOpenOrdersService().Pair("BTCUSD").Do(ctx)
+	require.NoError(t, err)
+	require.Len(t, orders, 1)
+	canceled, err := c.NewCancelOrderService().Symbol("BTCUSD_PERP").OrderID(res.OrderID).Do(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, delivery.OrderStatusTypeCanceled, canceled.Status)
+}
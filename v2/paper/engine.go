@@ -0,0 +1,592 @@
+package paper
+
+import (
+	"errors"
+	"math/big"
+	"sort"
+
+	"github.com/Zamzam-Technology/go-binance/v2/common"
+)
+
+const (
+	sideBuy  = "BUY"
+	sideSell = "SELL"
+
+	statusNew             = "NEW"
+	statusPartiallyFilled = "PARTIALLY_FILLED"
+	statusFilled          = "FILLED"
+	statusCanceled        = "CANCELED"
+	statusExpired         = "EXPIRED"
+
+	executionNew      = "NEW"
+	executionTrade    = "TRADE"
+	executionCanceled = "CANCELED"
+	executionExpired  = "EXPIRED"
+
+	timeInForceGTC = "GTC"
+	timeInForceIOC = "IOC"
+	timeInForceFOK = "FOK"
+	timeInForceGTX = "GTX"
+)
+
+var (
+	errWouldTake    = errors.New("order would immediately match and take")
+	errWouldTrigger = errors.New("order would immediately trigger")
+	errNoLiquidity  = errors.New("no market price")
+)
+
+type level struct {
+	price    *big.Rat
+	quantity *big.Rat
+}
+
+// book is the latest market data of a symbol, the levels are consumed by the
+// simulated fills until the next update so that an update is not filled twice
+type book struct {
+	bids []level
+	asks []level
+	last *big.Rat
+}
+
+// mark return the last trade price, or the mid price when no trade was seen
+func (b *book) mark() *big.Rat {
+	if b.last != nil {
+		return b.last
+	}
+	if len(b.bids) > 0 && len(b.asks) > 0 {
+		mid := new(big.Rat).Add(b.bids[0].price, b.asks[0].price)
+		return mid.Quo(mid, big.NewRat(2, 1))
+	}
+	if len(b.asks) > 0 {
+		return b.asks[0].price
+	}
+	if len(b.bids) > 0 {
+		return b.bids[0].price
+	}
+	return nil
+}
+
+// opposite return the levels a taker order on side trades against
+func (b *book) opposite(side string) []level {
+	if side == sideBuy {
+		return b.asks
+	}
+	return b.bids
+}
+
+type order struct {
+	id            int64
+	listID        int64
+	clientOrderID string
+	// cancelClientOrderID is the client order id of the cancel request of a spot order
+	cancelClientOrderID string
+	symbol              string
+	side                string
+	orderType           string
+	timeInForce         string
+	price               *big.Rat
+	stopPrice           *big.Rat
+	quantity            *big.Rat
+	// quoteQuantity is the quote amount to spend or receive of a spot market order, whose
+	// quantity is nil until it executes
+	quoteQuantity *big.Rat
+	// step is the lot step size, used to round the quantity of quote quantity orders
+	step     *big.Rat
+	executed *big.Rat
+	quote    *big.Rat
+	status   string
+	market   bool
+	postOnly bool
+	// stop is set while the order waits for its stop price, stopAbove when it triggers on a rise
+	stop          bool
+	stopAbove     bool
+	reduceOnly    bool
+	closePosition bool
+	positionSide  string
+	// reserved is the amount locked or the margin held by the ledger for the order
+	reserved   *big.Rat
+	time       int64
+	updateTime int64
+}
+
+func (o *order) remaining() *big.Rat {
+	return new(big.Rat).Sub(o.quantity, o.executed)
+}
+
+func (o *order) done() bool {
+	return o.status != statusNew && o.status != statusPartiallyFilled
+}
+
+// crosses return true if the order can trade at price
+func (o *order) crosses(price *big.Rat) bool {
+	if o.market || o.price == nil {
+		return true
+	}
+	if o.side == sideBuy {
+		return price.Cmp(o.price) <= 0
+	}
+	return price.Cmp(o.price) >= 0
+}
+
+// triggered return true if the stop price of the order is reached at price
+func (o *order) triggered(price *big.Rat) bool {
+	if o.stopAbove {
+		return price.Cmp(o.stopPrice) >= 0
+	}
+	return price.Cmp(o.stopPrice) <= 0
+}
+
+type trade struct {
+	id              int64
+	order           *order
+	price           *big.Rat
+	quantity        *big.Rat
+	quote           *big.Rat
+	commission      *big.Rat
+	commissionAsset string
+	realizedPnL     *big.Rat
+	maker           bool
+	time            int64
+}
+
+type orderList struct {
+	id            int64
+	clientOrderID string
+	symbol        string
+	orders        []*order
+	time          int64
+}
+
+func (l *orderList) done() bool {
+	for _, o := range l.orders {
+		if !o.done() {
+			return false
+		}
+	}
+	return true
+}
+
+// ledger is the account of a venue, which the engine calls while matching
+type ledger interface {
+	// reserve check the account can afford a new order and lock its funds
+	reserve(o *order) error
+	// prepare is called before the order trades, it returns false to expire the order
+	prepare(o *order) bool
+	// settle update the account with a trade and set its commission
+	settle(t *trade)
+	// release unlock the funds of a done order
+	release(o *order)
+	// report an order update, t is set for trades
+	report(o *order, executionType string, t *trade)
+	// reportList report the status of an order list
+	reportList(l *orderList)
+}
+
+// engine match the orders of a venue against the market data
+type engine struct {
+	ledger      ledger
+	now         func() int64
+	books       map[string]*book
+	orders      []*order
+	byID        map[int64]*order
+	lists       map[int64]*orderList
+	trades      []*trade
+	nextOrderID int64
+	nextTradeID int64
+	nextListID  int64
+}
+
+func newEngine(l ledger, now func() int64) *engine {
+	return &engine{
+		ledger: l,
+		now:    now,
+		books:  make(map[string]*book),
+		byID:   make(map[int64]*order),
+		lists:  make(map[int64]*orderList),
+	}
+}
+
+func (e *engine) book(symbol string) *book {
+	b, ok := e.books[symbol]
+	if !ok {
+		b = &book{}
+		e.books[symbol] = b
+	}
+	return b
+}
+
+// newOrder init an order with the next id
+func (e *engine) newOrder(symbol string) *order {
+	e.nextOrderID++
+	now := e.now()
+	return &order{
+		id:         e.nextOrderID,
+		listID:     -1,
+		symbol:     symbol,
+		executed:   new(big.Rat),
+		quote:      new(big.Rat),
+		reserved:   new(big.Rat),
+		status:     statusNew,
+		time:       now,
+		updateTime: now,
+	}
+}
+
+// check return the errors of a new order which does not depend on the account
+func (e *engine) check(o *order) error {
+	b := e.book(o.symbol)
+	if o.stop {
+		if mark := b.mark(); mark != nil && o.triggered(mark) {
+			return errWouldTrigger
+		}
+	}
+	if o.postOnly {
+		if levels := b.opposite(o.side); len(levels) > 0 && o.crosses(levels[0].price) {
+			return errWouldTake
+		}
+	}
+	if o.market && !o.stop && b.mark() == nil {
+		return errNoLiquidity
+	}
+	return nil
+}
+
+// place add a new order and execute it unless it waits for its stop price
+func (e *engine) place(o *order) error {
+	if err := e.check(o); err != nil {
+		return err
+	}
+	if err := e.ledger.reserve(o); err != nil {
+		return err
+	}
+	e.orders = append(e.orders, o)
+	e.byID[o.id] = o
+	e.ledger.report(o, executionNew, nil)
+	if !o.stop {
+		e.execute(o)
+	}
+	return nil
+}
+
+// placeList add the orders of an order list
+func (e *engine) placeList(l *orderList) error {
+	for _, o := range l.orders {
+		if err := e.check(o); err != nil {
+			return err
+		}
+	}
+	// the list is registered first, so that the ledger can share a reservation between its orders
+	e.lists[l.id] = l
+	for i, o := range l.orders {
+		if err := e.ledger.reserve(o); err != nil {
+			for _, reserved := range l.orders[:i] {
+				e.ledger.release(reserved)
+			}
+			delete(e.lists, l.id)
+			return err
+		}
+	}
+	for _, o := range l.orders {
+		e.orders = append(e.orders, o)
+		e.byID[o.id] = o
+		e.ledger.report(o, executionNew, nil)
+	}
+	e.ledger.reportList(l)
+	for _, o := range l.orders {
+		if !o.stop && !o.done() {
+			e.execute(o)
+		}
+	}
+	return nil
+}
+
+// execute match a taker order against the book, the remaining quantity rests or expires
+func (e *engine) execute(o *order) {
+	b := e.book(o.symbol)
+	levels := b.opposite(o.side)
+	if !e.ledger.prepare(o) {
+		e.finish(o, statusExpired, executionExpired)
+		return
+	}
+	if o.quantity == nil {
+		o.quantity = e.quoteQuantity(o, levels)
+	}
+	if o.quantity.Sign() <= 0 {
+		e.finish(o, statusExpired, executionExpired)
+		return
+	}
+	if o.timeInForce == timeInForceFOK && !e.fillable(o, levels) {
+		e.finish(o, statusExpired, executionExpired)
+		return
+	}
+	for i := range levels {
+		if o.done() || !o.crosses(levels[i].price) {
+			break
+		}
+		quantity := common.MinRat(o.remaining(), levels[i].quantity)
+		if quantity.Sign() <= 0 {
+			continue
+		}
+		levels[i].quantity.Sub(levels[i].quantity, quantity)
+		e.fill(o, levels[i].price, quantity, false)
+	}
+	if o.done() {
+		return
+	}
+	if o.market && len(levels) == 0 {
+		// without a book, e.g. when only trades are replayed, the order trades at the last price
+		if price := b.mark(); price != nil {
+			e.fill(o, price, o.remaining(), false)
+			return
+		}
+	}
+	// the rest of a market order expires once the book is exhausted
+	if o.market || o.timeInForce == timeInForceIOC || o.timeInForce == timeInForceFOK {
+		e.finish(o, statusExpired, executionExpired)
+	}
+}
+
+// quoteQuantity return the quantity of a market order with a quote quantity, from the levels
+// it would trade against or the last price when there are none, rounded down to the step size
+func (e *engine) quoteQuantity(o *order, levels []level) *big.Rat {
+	quantity := new(big.Rat)
+	left := new(big.Rat).Set(o.quoteQuantity)
+	for _, l := range levels {
+		if left.Sign() <= 0 {
+			break
+		}
+		quote := new(big.Rat).Mul(l.price, l.quantity)
+		if quote.Cmp(left) >= 0 {
+			quantity.Add(quantity, new(big.Rat).Quo(left, l.price))
+			left.SetInt64(0)
+			break
+		}
+		quantity.Add(quantity, l.quantity)
+		left.Sub(left, quote)
+	}
+	if price := e.book(o.symbol).mark(); len(levels) == 0 && price != nil {
+		quantity.Add(quantity, left.Quo(left, price))
+	}
+	if o.step != nil && o.step.Sign() > 0 {
+		steps := new(big.Rat).Quo(quantity, o.step)
+		floor := new(big.Int).Quo(steps.Num(), steps.Denom())
+		quantity.Mul(new(big.Rat).SetInt(floor), o.step)
+	}
+	return quantity
+}
+
+// walk return the quote amount the remaining quantity of a market order trades for against
+// the book, or at the last price when there is no book
+func (e *engine) walk(o *order) *big.Rat {
+	b := e.book(o.symbol)
+	levels := b.opposite(o.side)
+	amount := new(big.Rat)
+	left := o.remaining()
+	if len(levels) == 0 {
+		if price := b.mark(); price != nil {
+			amount.Mul(left, price)
+		}
+		return amount
+	}
+	for _, l := range levels {
+		if left.Sign() <= 0 {
+			break
+		}
+		quantity := common.MinRat(left, l.quantity)
+		amount.Add(amount, new(big.Rat).Mul(quantity, l.price))
+		left.Sub(left, quantity)
+	}
+	return amount
+}
+
+// fillable return true if the levels crossing the order hold its quantity
+func (e *engine) fillable(o *order, levels []level) bool {
+	available := new(big.Rat)
+	for _, l := range levels {
+		if !o.crosses(l.price) {
+			break
+		}
+		available.Add(available, l.quantity)
+	}
+	return available.Cmp(o.remaining()) >= 0
+}
+
+// fill record a trade of the order
+func (e *engine) fill(o *order, price, quantity *big.Rat, maker bool) {
+	e.nextTradeID++
+	t := &trade{
+		id:       e.nextTradeID,
+		order:    o,
+		price:    new(big.Rat).Set(price),
+		quantity: new(big.Rat).Set(quantity),
+		quote:    new(big.Rat).Mul(price, quantity),
+		maker:    maker,
+		time:     e.now(),
+	}
+	o.executed.Add(o.executed, quantity)
+	o.quote.Add(o.quote, t.quote)
+	o.updateTime = t.time
+	o.status = statusPartiallyFilled
+	if o.executed.Cmp(o.quantity) >= 0 {
+		o.status = statusFilled
+	}
+	e.ledger.settle(t)
+	e.trades = append(e.trades, t)
+	e.ledger.report(o, executionTrade, t)
+	// the other orders of a list expire once one of them trades
+	e.expireSiblings(o)
+	if o.status == statusFilled {
+		e.ledger.release(o)
+		e.listDone(o)
+	}
+}
+
+// finish end an order with status
+func (e *engine) finish(o *order, status, executionType string) {
+	if o.done() {
+		return
+	}
+	o.status = status
+	o.updateTime = e.now()
+	e.ledger.release(o)
+	e.ledger.report(o, executionType, nil)
+	e.listDone(o)
+}
+
+// cancel cancel an open order, and the other orders of its list
+func (e *engine) cancel(o *order) {
+	if l, ok := e.lists[o.listID]; ok {
+		for _, sibling := range l.orders {
+			if sibling != o {
+				e.finish(sibling, statusCanceled, executionCanceled)
+			}
+		}
+	}
+	e.finish(o, statusCanceled, executionCanceled)
+}
+
+// expireSiblings end the other orders of the list of o, once o traded or triggered
+func (e *engine) expireSiblings(o *order) {
+	l, ok := e.lists[o.listID]
+	if !ok {
+		return
+	}
+	for _, sibling := range l.orders {
+		if sibling != o && !sibling.done() {
+			e.finish(sibling, statusExpired, executionExpired)
+		}
+	}
+}
+
+// listDone report the end of the list of o once all its orders are done
+func (e *engine) listDone(o *order) {
+	if l, ok := e.lists[o.listID]; ok && l.done() {
+		delete(e.lists, l.id)
+		e.ledger.reportList(l)
+	}
+}
+
+// openOrders return the open orders of symbol, or of all symbols when symbol is empty
+func (e *engine) openOrders(symbol string) []*order {
+	var orders []*order
+	for _, o := range e.orders {
+		if !o.done() && (symbol == "" || o.symbol == symbol) {
+			orders = append(orders, o)
+		}
+	}
+	return orders
+}
+
+// findOrder return the order of symbol by id, or by client order id when id is zero
+func (e *engine) findOrder(symbol string, id int64, clientOrderID string) *order {
+	if id != 0 {
+		if o, ok := e.byID[id]; ok && o.symbol == symbol {
+			return o
+		}
+		return nil
+	}
+	// the latest order wins, client order ids may be reused once an order is done
+	for i := len(e.orders) - 1; i >= 0; i-- {
+		if o := e.orders[i]; o.symbol == symbol && o.clientOrderID == clientOrderID {
+			return o
+		}
+	}
+	return nil
+}
+
+// onBook replace the levels of the book of symbol and fill the resting orders it crosses
+func (e *engine) onBook(symbol string, bids, asks []common.PriceLevel) {
+	b := e.book(symbol)
+	b.bids = parseLevels(bids)
+	b.asks = parseLevels(asks)
+	sort.SliceStable(b.bids, func(i, j int) bool { return b.bids[i].price.Cmp(b.bids[j].price) > 0 })
+	sort.SliceStable(b.asks, func(i, j int) bool { return b.asks[i].price.Cmp(b.asks[j].price) < 0 })
+	for _, o := range e.openOrders(symbol) {
+		if o.stop || o.done() {
+			continue
+		}
+		levels := b.opposite(o.side)
+		for i := range levels {
+			if o.done() || !o.crosses(levels[i].price) {
+				break
+			}
+			quantity := common.MinRat(o.remaining(), levels[i].quantity)
+			if quantity.Sign() <= 0 {
+				continue
+			}
+			levels[i].quantity.Sub(levels[i].quantity, quantity)
+			// the book moved through a resting order, which trades at its own price
+			e.fill(o, o.price, quantity, true)
+		}
+	}
+}
+
+// onTrade record a trade of the market: the stop orders reaching their stop price are
+// triggered, and the resting orders the price went through are filled up to the trade quantity
+func (e *engine) onTrade(symbol string, price, quantity *big.Rat) {
+	b := e.book(symbol)
+	b.last = price
+	for _, o := range e.openOrders(symbol) {
+		if !o.stop || o.done() || !o.triggered(price) {
+			continue
+		}
+		o.stop = false
+		e.expireSiblings(o)
+		e.execute(o)
+	}
+	left := new(big.Rat).Set(quantity)
+	for _, o := range e.openOrders(symbol) {
+		if left.Sign() <= 0 {
+			break
+		}
+		if o.stop || o.done() || o.price == nil {
+			continue
+		}
+		through := price.Cmp(o.price) < 0
+		if o.side == sideSell {
+			through = price.Cmp(o.price) > 0
+		}
+		if !through {
+			continue
+		}
+		filled := common.MinRat(o.remaining(), left)
+		left.Sub(left, filled)
+		e.fill(o, o.price, filled, true)
+	}
+}
+
+func parseLevels(levels []common.PriceLevel) []level {
+	parsed := make([]level, 0, len(levels))
+	for _, l := range levels {
+		price, ok := new(big.Rat).SetString(l.Price)
+		if !ok || price.Sign() <= 0 {
+			continue
+		}
+		quantity, ok := new(big.Rat).SetString(l.Quantity)
+		if !ok || quantity.Sign() <= 0 {
+			continue
+		}
+		parsed = append(parsed, level{price: price, quantity: quantity})
+	}
+	return parsed
+}
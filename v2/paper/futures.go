@@ -0,0 +1,466 @@
+package paper
+
+import (
+	"fmt"
+	"math/big"
+	"net/http"
+	"strconv"
+
+	"github.com/Zamzam-Technology/go-binance/v2/common"
+	"github.com/Zamzam-Technology/go-binance/v2/futures"
+)
+
+// FuturesExchange simulate the USDT-M futures order, account, position and user stream
+// endpoints, with cross margin. It is safe for concurrent use.
+type FuturesExchange struct {
+	*exchange
+	*futuresAccount
+}
+
+// NewFuturesExchange create a paper futures exchange trading the symbols of info
+func NewFuturesExchange(info *futures.ExchangeInfo, cfg *Config) (*FuturesExchange, error) {
+	if cfg == nil {
+		cfg = &Config{}
+	}
+	account, err := newFuturesAccount(cfg, "0.0002", "0.0004")
+	if err != nil {
+		return nil, err
+	}
+	x := &FuturesExchange{exchange: newExchange(cfg), futuresAccount: account}
+	x.engine = newEngine(x, x.millis)
+	x.exchange.flush = x.flushAccount
+	for i := range info.Symbols {
+		s := &info.Symbols[i]
+		x.symbols[s.Symbol] = &futuresSymbol{filters: s.OrderFilters(), margin: s.MarginAsset}
+	}
+
+	x.handle(http.MethodPost, "/fapi/v1/order", x.createOrder)
+	x.handle(http.MethodGet, "/fapi/v1/order", x.getOrder)
+	x.handle(http.MethodDelete, "/fapi/v1/order", x.cancelOrder)
+	x.handle(http.MethodGet, "/fapi/v1/openOrders", x.listOpenOrders)
+	x.handle(http.MethodDelete, "/fapi/v1/allOpenOrders", x.cancelAllOpenOrders)
+	x.handle(http.MethodGet, "/fapi/v1/allOrders", x.listOrders)
+	x.handle(http.MethodGet, "/fapi/v1/userTrades", x.listTrades)
+	x.handle(http.MethodGet, "/fapi/v2/balance", x.getBalance)
+	x.handle(http.MethodGet, "/fapi/v1/account", x.getAccount)
+	x.handle(http.MethodGet, "/fapi/v2/positionRisk", x.getPositionRisk)
+	x.handle(http.MethodGet, "/fapi/v1/positionSide/dual", x.getPositionMode)
+	x.handle(http.MethodPost, "/fapi/v1/positionSide/dual", x.changePositionMode)
+	x.handle(http.MethodPost, "/fapi/v1/leverage", x.changeLeverage)
+	x.handle(http.MethodPost, "/fapi/v1/listenKey", listenKey)
+	x.handle(http.MethodPut, "/fapi/v1/listenKey", emptyResponse)
+	x.handle(http.MethodDelete, "/fapi/v1/listenKey", emptyResponse)
+	return x, nil
+}
+
+// Client return a client whose requests are served by the exchange
+func (x *FuturesExchange) Client() *futures.Client {
+	c := futures.NewClient("paper", "paper")
+	c.HTTPClient = &http.Client{Transport: x}
+	return c
+}
+
+// SubscribeUserData call handler with the events of the user data stream: order trade updates
+// and account updates. Handlers are called outside of the exchange lock, in the order of the
+// events. The returned function removes the subscription.
+func (x *FuturesExchange) SubscribeUserData(handler futures.WsUserDataHandler) (unsubscribe func()) {
+	return x.subscribe(func(event interface{}) {
+		handler(event.(*futures.WsUserDataEvent))
+	})
+}
+
+// OnBook replace the order book of symbol, e.g. with a partial depth event.
+// The resting orders crossed by the book are filled as maker at their price.
+func (x *FuturesExchange) OnBook(symbol string, bids, asks []common.PriceLevel) {
+	x.update(func() {
+		x.engine.onBook(symbol, bids, asks)
+	})
+}
+
+// OnTrade record a trade of the market. Its price is the last price, which triggers the stop
+// orders, and the resting orders the price went through are filled as maker at their price,
+// up to the quantity of the trade. A trade at the price of a resting order does not fill it.
+func (x *FuturesExchange) OnTrade(symbol, price, quantity string) error {
+	p, err := common.ParseDecimal(price)
+	if err != nil {
+		return err
+	}
+	q, err := common.ParseDecimal(quantity)
+	if err != nil {
+		return err
+	}
+	x.update(func() {
+		x.engine.onTrade(symbol, p, q)
+	})
+	return nil
+}
+
+// OnFunding settle the funding of symbol at fundingRate, e.g. with the history of FundingRateService.
+// The positions pay their notional at the mark price times the rate, the long positions to the
+// short positions when the rate is positive. It return the funding fee of the account, negative
+// when the account paid it.
+func (x *FuturesExchange) OnFunding(symbol, fundingRate string) (string, error) {
+	rate, err := common.ParseDecimal(fundingRate)
+	if err != nil {
+		return "", err
+	}
+	if _, ok := x.symbols[symbol]; !ok {
+		return "", fmt.Errorf("unknown symbol %s", symbol)
+	}
+	var fee *big.Rat
+	x.update(func() {
+		fee = x.fund(symbol, rate)
+	})
+	return common.FormatDecimal(fee), nil
+}
+
+// Equity return the margin balance of asset: the wallet balance and the unrealized profit
+func (x *FuturesExchange) Equity(asset string) string {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	return common.FormatDecimal(x.equity(asset))
+}
+
+func (x *FuturesExchange) createOrder(p params) (interface{}, error) {
+	o, err := x.futuresAccount.createOrder(p)
+	if err != nil {
+		return nil, err
+	}
+	return &futures.CreateOrderResponse{
+		Symbol:           o.symbol,
+		OrderID:          o.id,
+		ClientOrderID:    o.clientOrderID,
+		Price:            common.FormatDecimal(o.price),
+		OrigQuantity:     common.FormatDecimal(o.quantity),
+		ExecutedQuantity: common.FormatDecimal(o.executed),
+		CumQuote:         common.FormatDecimal(o.quote),
+		ReduceOnly:       o.reduceOnly,
+		Status:           futures.OrderStatusType(o.status),
+		StopPrice:        common.FormatDecimal(o.stopPrice),
+		TimeInForce:      futures.TimeInForceType(o.timeInForce),
+		Type:             futures.OrderType(o.orderType),
+		Side:             futures.SideType(o.side),
+		UpdateTime:       o.updateTime,
+		WorkingType:      futures.WorkingTypeContractPrice,
+		AvgPrice:         common.FormatDecimal(average(o.quote, o.executed)),
+		PositionSide:     futures.PositionSideType(o.positionSide),
+		ClosePosition:    o.closePosition,
+	}, nil
+}
+
+func (x *FuturesExchange) getOrder(p params) (interface{}, error) {
+	o, err := x.futuresAccount.getOrder(p)
+	if err != nil {
+		return nil, err
+	}
+	return futuresOrder(o), nil
+}
+
+func (x *FuturesExchange) cancelOrder(p params) (interface{}, error) {
+	o, err := x.futuresAccount.cancelOrder(p)
+	if err != nil {
+		return nil, err
+	}
+	return &futures.CancelOrderResponse{
+		ClientOrderID:    o.clientOrderID,
+		CumQuantity:      common.FormatDecimal(o.executed),
+		CumQuote:         common.FormatDecimal(o.quote),
+		ExecutedQuantity: common.FormatDecimal(o.executed),
+		OrderID:          o.id,
+		OrigQuantity:     common.FormatDecimal(o.quantity),
+		Price:            common.FormatDecimal(o.price),
+		ReduceOnly:       o.reduceOnly,
+		Side:             futures.SideType(o.side),
+		Status:           futures.OrderStatusType(o.status),
+		StopPrice:        common.FormatDecimal(o.stopPrice),
+		Symbol:           o.symbol,
+		TimeInForce:      futures.TimeInForceType(o.timeInForce),
+		Type:             futures.OrderType(o.orderType),
+		UpdateTime:       o.updateTime,
+		WorkingType:      futures.WorkingTypeContractPrice,
+		OrigType:         o.orderType,
+		PositionSide:     futures.PositionSideType(o.positionSide),
+	}, nil
+}
+
+func (x *FuturesExchange) listOpenOrders(p params) (interface{}, error) {
+	symbol := p.get("symbol")
+	if _, ok := x.symbols[symbol]; symbol != "" && !ok {
+		return nil, apiError(-1121, "Invalid symbol.")
+	}
+	res := make([]*futures.Order, 0)
+	for _, o := range x.engine.openOrders(symbol) {
+		res = append(res, futuresOrder(o))
+	}
+	return res, nil
+}
+
+func (x *FuturesExchange) listOrders(p params) (interface{}, error) {
+	orders, err := x.futuresAccount.listOrders(p)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*futures.Order, 0, len(orders))
+	for _, o := range orders {
+		res = append(res, futuresOrder(o))
+	}
+	return res, nil
+}
+
+func (x *FuturesExchange) listTrades(p params) (interface{}, error) {
+	symbol, _, err := x.symbol(p)
+	if err != nil {
+		return nil, err
+	}
+	orderID, err := p.int64("orderId")
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*futures.AccountTrade, 0)
+	for _, t := range x.engine.trades {
+		if t.order.symbol != symbol || (orderID != 0 && t.order.id != orderID) {
+			continue
+		}
+		res = append(res, &futures.AccountTrade{
+			Buyer:           t.order.side == sideBuy,
+			Commission:      common.FormatDecimal(t.commission),
+			CommissionAsset: t.commissionAsset,
+			ID:              t.id,
+			Maker:           t.maker,
+			OrderID:         t.order.id,
+			Price:           common.FormatDecimal(t.price),
+			Quantity:        common.FormatDecimal(t.quantity),
+			QuoteQuantity:   common.FormatDecimal(t.quote),
+			RealizedPnl:     common.FormatDecimal(t.realizedPnL),
+			Side:            futures.SideType(t.order.side),
+			PositionSide:    futures.PositionSideType(t.order.positionSide),
+			Symbol:          symbol,
+			Time:            t.time,
+		})
+	}
+	return res, nil
+}
+
+func (x *FuturesExchange) getBalance(p params) (interface{}, error) {
+	res := make([]*futures.Balance, 0, len(x.wallets))
+	for _, asset := range x.assets() {
+		unrealized, _, _ := x.margins(asset)
+		available := common.FormatDecimal(x.available(asset))
+		res = append(res, &futures.Balance{
+			AccountAlias:       "paper",
+			Asset:              asset,
+			Balance:            common.FormatDecimal(x.wallets[asset]),
+			CrossWalletBalance: common.FormatDecimal(x.wallets[asset]),
+			CrossUnPnl:         common.FormatDecimal(unrealized),
+			AvailableBalance:   available,
+			MaxWithdrawAmount:  available,
+		})
+	}
+	return res, nil
+}
+
+func (x *FuturesExchange) getAccount(p params) (interface{}, error) {
+	res := &futures.Account{
+		Assets:      make([]*futures.AccountAsset, 0, len(x.wallets)),
+		CanDeposit:  true,
+		CanTrade:    true,
+		CanWithdraw: true,
+		Positions:   make([]*futures.AccountPosition, 0),
+		UpdateTime:  x.millis(),
+	}
+	totalWallet, totalUnrealized, totalPosition, totalOrder := new(big.Rat), new(big.Rat), new(big.Rat), new(big.Rat)
+	for _, asset := range x.assets() {
+		wallet := x.wallets[asset]
+		unrealized, positionMargin, orderMargin := x.margins(asset)
+		initial := new(big.Rat).Add(positionMargin, orderMargin)
+		available := common.FormatDecimal(x.available(asset))
+		res.Assets = append(res.Assets, &futures.AccountAsset{
+			Asset:                  asset,
+			InitialMargin:          common.FormatDecimal(initial),
+			MaintMargin:            "0",
+			MarginBalance:          common.FormatDecimal(new(big.Rat).Add(wallet, unrealized)),
+			MaxWithdrawAmount:      available,
+			OpenOrderInitialMargin: common.FormatDecimal(orderMargin),
+			PositionInitialMargin:  common.FormatDecimal(positionMargin),
+			UnrealizedProfit:       common.FormatDecimal(unrealized),
+			WalletBalance:          common.FormatDecimal(wallet),
+		})
+		totalWallet.Add(totalWallet, wallet)
+		totalUnrealized.Add(totalUnrealized, unrealized)
+		totalPosition.Add(totalPosition, positionMargin)
+		totalOrder.Add(totalOrder, orderMargin)
+	}
+	for _, pos := range x.sortedPositions("") {
+		notional := x.notional(pos)
+		res.Positions = append(res.Positions, &futures.AccountPosition{
+			Leverage:              strconv.Itoa(x.symbolLeverage(pos.symbol)),
+			InitialMargin:         common.FormatDecimal(x.initialMargin(pos)),
+			MaintMargin:           "0",
+			PositionInitialMargin: common.FormatDecimal(x.initialMargin(pos)),
+			Symbol:                pos.symbol,
+			UnrealizedProfit:      common.FormatDecimal(x.unrealized(pos)),
+			EntryPrice:            common.FormatDecimal(pos.entry),
+			PositionSide:          futures.PositionSideType(pos.side),
+			PositionAmt:           common.FormatDecimal(pos.amount),
+			Notional:              common.FormatDecimal(notional),
+			IsolatedWallet:        "0",
+			UpdateTime:            pos.updateTime,
+		})
+	}
+	marginBalance := new(big.Rat).Add(totalWallet, totalUnrealized)
+	initial := new(big.Rat).Add(totalPosition, totalOrder)
+	res.TotalWalletBalance = common.FormatDecimal(totalWallet)
+	res.TotalUnrealizedProfit = common.FormatDecimal(totalUnrealized)
+	res.TotalMarginBalance = common.FormatDecimal(marginBalance)
+	res.TotalPositionInitialMargin = common.FormatDecimal(totalPosition)
+	res.TotalOpenOrderInitialMargin = common.FormatDecimal(totalOrder)
+	res.TotalInitialMargin = common.FormatDecimal(initial)
+	res.TotalMaintMargin = "0"
+	res.MaxWithdrawAmount = common.FormatDecimal(marginBalance.Sub(marginBalance, initial))
+	return res, nil
+}
+
+func (x *FuturesExchange) getPositionRisk(p params) (interface{}, error) {
+	symbol := p.get("symbol")
+	if _, ok := x.symbols[symbol]; symbol != "" && !ok {
+		return nil, apiError(-1121, "Invalid symbol.")
+	}
+	res := make([]*futures.PositionRisk, 0)
+	for _, pos := range x.sortedPositions(symbol) {
+		res = append(res, &futures.PositionRisk{
+			EntryPrice:       common.FormatDecimal(pos.entry),
+			MarginType:       "cross",
+			IsAutoAddMargin:  "false",
+			IsolatedMargin:   "0",
+			Leverage:         strconv.Itoa(x.symbolLeverage(pos.symbol)),
+			LiquidationPrice: "0",
+			MarkPrice:        common.FormatDecimal(x.mark(pos.symbol)),
+			MaxNotionalValue: "0",
+			PositionAmt:      common.FormatDecimal(pos.amount),
+			Symbol:           pos.symbol,
+			UnRealizedProfit: common.FormatDecimal(x.unrealized(pos)),
+			PositionSide:     pos.side,
+			Notional:         common.FormatDecimal(x.notional(pos)),
+			IsolatedWallet:   "0",
+		})
+	}
+	return res, nil
+}
+
+func (x *FuturesExchange) getPositionMode(p params) (interface{}, error) {
+	return &futures.PositionMode{DualSidePosition: x.dual}, nil
+}
+
+func (x *FuturesExchange) changeLeverage(p params) (interface{}, error) {
+	symbol, leverage, err := x.futuresAccount.changeLeverage(p)
+	if err != nil {
+		return nil, err
+	}
+	return &futures.SymbolLeverage{Leverage: leverage, MaxNotionalValue: "0", Symbol: symbol}, nil
+}
+
+func (x *FuturesExchange) report(o *order, executionType string, t *trade) {
+	u := futures.WsOrderTradeUpdate{
+		Symbol:               o.symbol,
+		ClientOrderID:        o.clientOrderID,
+		Side:                 futures.SideType(o.side),
+		Type:                 futures.OrderType(o.orderType),
+		TimeInForce:          futures.TimeInForceType(o.timeInForce),
+		OriginalQty:          common.FormatDecimal(o.quantity),
+		OriginalPrice:        common.FormatDecimal(o.price),
+		AveragePrice:         common.FormatDecimal(average(o.quote, o.executed)),
+		StopPrice:            common.FormatDecimal(o.stopPrice),
+		ExecutionType:        futures.OrderExecutionType(executionType),
+		Status:               futures.OrderStatusType(o.status),
+		ID:                   o.id,
+		LastFilledQty:        "0",
+		AccumulatedFilledQty: common.FormatDecimal(o.executed),
+		LastFilledPrice:      "0",
+		TradeTime:            o.updateTime,
+		BidsNotional:         "0",
+		AsksNotional:         "0",
+		IsReduceOnly:         o.reduceOnly,
+		WorkingType:          futures.WorkingTypeContractPrice,
+		OriginalType:         futures.OrderType(o.orderType),
+		PositionSide:         futures.PositionSideType(o.positionSide),
+		IsClosingPosition:    o.closePosition,
+		RealizedPnL:          "0",
+	}
+	if t != nil {
+		u.LastFilledQty = common.FormatDecimal(t.quantity)
+		u.LastFilledPrice = common.FormatDecimal(t.price)
+		u.Commission = common.FormatDecimal(t.commission)
+		u.CommissionAsset = t.commissionAsset
+		u.TradeTime = t.time
+		u.TradeID = t.id
+		u.IsMaker = t.maker
+		u.RealizedPnL = common.FormatDecimal(t.realizedPnL)
+	}
+	now := x.millis()
+	x.emit(&futures.WsUserDataEvent{
+		Event:            futures.UserDataEventTypeOrderTradeUpdate,
+		Time:             now,
+		TransactionTime:  now,
+		OrderTradeUpdate: u,
+	})
+}
+
+// flushAccount emit the balances and the positions changed by the update
+func (x *FuturesExchange) flushAccount() {
+	reason, assets, positions, ok := x.changes()
+	if !ok {
+		return
+	}
+	u := futures.WsAccountUpdate{Reason: futures.UserDataEventReasonType(reason)}
+	for _, asset := range assets {
+		wallet := common.FormatDecimal(x.wallets[asset])
+		u.Balances = append(u.Balances, futures.WsBalance{Asset: asset, Balance: wallet, CrossWalletBalance: wallet})
+	}
+	for _, pos := range positions {
+		u.Positions = append(u.Positions, futures.WsPosition{
+			Symbol:                    pos.symbol,
+			Side:                      futures.PositionSideType(pos.side),
+			Amount:                    common.FormatDecimal(pos.amount),
+			MarginType:                "cross",
+			IsolatedWallet:            "0",
+			EntryPrice:                common.FormatDecimal(pos.entry),
+			MarkPrice:                 common.FormatDecimal(x.mark(pos.symbol)),
+			UnrealizedPnL:             common.FormatDecimal(x.unrealized(pos)),
+			AccumulatedRealized:       common.FormatDecimal(pos.realized),
+			MaintenanceMarginRequired: "0",
+		})
+	}
+	now := x.millis()
+	x.emit(&futures.WsUserDataEvent{
+		Event:           futures.UserDataEventTypeAccountUpdate,
+		Time:            now,
+		TransactionTime: now,
+		AccountUpdate:   u,
+	})
+}
+
+func futuresOrder(o *order) *futures.Order {
+	return &futures.Order{
+		Symbol:           o.symbol,
+		OrderID:          o.id,
+		ClientOrderID:    o.clientOrderID,
+		Price:            common.FormatDecimal(o.price),
+		ReduceOnly:       o.reduceOnly,
+		OrigQuantity:     common.FormatDecimal(o.quantity),
+		ExecutedQuantity: common.FormatDecimal(o.executed),
+		CumQuantity:      common.FormatDecimal(o.executed),
+		CumQuote:         common.FormatDecimal(o.quote),
+		Status:           futures.OrderStatusType(o.status),
+		TimeInForce:      futures.TimeInForceType(o.timeInForce),
+		Type:             futures.OrderType(o.orderType),
+		Side:             futures.SideType(o.side),
+		StopPrice:        common.FormatDecimal(o.stopPrice),
+		Time:             o.time,
+		UpdateTime:       o.updateTime,
+		WorkingType:      futures.WorkingTypeContractPrice,
+		AvgPrice:         common.FormatDecimal(average(o.quote, o.executed)),
+		OrigType:         o.orderType,
+		PositionSide:     futures.PositionSideType(o.positionSide),
+		ClosePosition:    o.closePosition,
+	}
+}
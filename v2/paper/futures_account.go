@@ -0,0 +1,672 @@
+package paper
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/Zamzam-Technology/go-binance/v2/common"
+	"github.com/Zamzam-Technology/go-binance/v2/futures"
+)
+
+const (
+	positionSideBoth  = "BOTH"
+	positionSideLong  = "LONG"
+	positionSideShort = "SHORT"
+)
+
+type futuresSymbol struct {
+	filters *common.OrderFilters
+	margin  string
+	// size is the value in quote asset of a contract of the coin-m symbols, whose margin and
+	// profit are in the base asset, nil for the usd(s)-m symbols
+	size *big.Rat
+}
+
+// value return the value in the margin asset of quantity contracts at price
+func (s *futuresSymbol) value(quantity, price *big.Rat) *big.Rat {
+	if s.size == nil {
+		return new(big.Rat).Mul(quantity, price)
+	}
+	if price.Sign() == 0 {
+		return new(big.Rat)
+	}
+	v := new(big.Rat).Mul(quantity, s.size)
+	return v.Quo(v, price)
+}
+
+// pnl return the profit in the margin asset of amount contracts, negative for short
+// positions, bought at entry and sold at exit
+func (s *futuresSymbol) pnl(amount, entry, exit *big.Rat) *big.Rat {
+	if s.size == nil {
+		return new(big.Rat).Sub(s.value(amount, exit), s.value(amount, entry))
+	}
+	return new(big.Rat).Sub(s.value(amount, entry), s.value(amount, exit))
+}
+
+// entry return the average price of quantity contracts worth value
+func (s *futuresSymbol) entry(quantity, value *big.Rat) *big.Rat {
+	if s.size == nil {
+		return new(big.Rat).Quo(value, quantity)
+	}
+	e := new(big.Rat).Mul(quantity, s.size)
+	return e.Quo(e, value)
+}
+
+type position struct {
+	symbol string
+	side   string
+	// amount is negative for short positions
+	amount     *big.Rat
+	entry      *big.Rat
+	realized   *big.Rat
+	updateTime int64
+}
+
+// futuresAccount is the cross margin account of the paper futures exchanges: the wallets, the
+// positions and the margin of the orders. It implements the ledger but the reports, which
+// depend on the market.
+type futuresAccount struct {
+	engine    *engine
+	symbols   map[string]*futuresSymbol
+	wallets   map[string]*big.Rat
+	positions map[string]*position
+	leverage  map[string]int
+	// defaultLeverage is the leverage of the symbols not set with the leverage endpoint
+	defaultLeverage int
+	dual            bool
+	maker           *big.Rat
+	taker           *big.Rat
+	// changedAssets and changedPositions are the balances and the positions changed during the current update
+	changedAssets    map[string]bool
+	changedPositions map[string]bool
+	// reason is the reason of the account update of the current update, ORDER by default
+	reason string
+}
+
+func newFuturesAccount(cfg *Config, makerCommission, takerCommission string) (*futuresAccount, error) {
+	a := &futuresAccount{
+		symbols:          make(map[string]*futuresSymbol),
+		wallets:          make(map[string]*big.Rat),
+		positions:        make(map[string]*position),
+		leverage:         make(map[string]int),
+		defaultLeverage:  cfg.Leverage,
+		changedAssets:    make(map[string]bool),
+		changedPositions: make(map[string]bool),
+	}
+	if a.defaultLeverage <= 0 {
+		a.defaultLeverage = 20
+	}
+	var err error
+	if a.maker, err = commissionRate(cfg.MakerCommission, makerCommission); err != nil {
+		return nil, err
+	}
+	if a.taker, err = commissionRate(cfg.TakerCommission, takerCommission); err != nil {
+		return nil, err
+	}
+	for asset, amount := range cfg.Balances {
+		if a.wallets[asset], err = common.ParseDecimal(amount); err != nil {
+			return nil, err
+		}
+	}
+	return a, nil
+}
+
+// fund settle the funding of symbol at rate and return the funding fee of the account
+func (a *futuresAccount) fund(symbol string, rate *big.Rat) *big.Rat {
+	s := a.symbols[symbol]
+	fee := new(big.Rat)
+	for _, pos := range a.sortedPositions(symbol) {
+		if pos.amount.Sign() == 0 {
+			continue
+		}
+		fee.Sub(fee, new(big.Rat).Mul(s.value(pos.amount, a.mark(symbol)), rate))
+		a.changedPositions[positionKey(pos.symbol, pos.side)] = true
+	}
+	if fee.Sign() == 0 {
+		return fee
+	}
+	wallet := a.wallet(s.margin)
+	wallet.Add(wallet, fee)
+	a.changedAssets[s.margin] = true
+	a.reason = string(futures.UserDataEventReasonTypeFundingFee)
+	return fee
+}
+
+// equity return the margin balance of asset: the wallet balance and the unrealized profit
+func (a *futuresAccount) equity(asset string) *big.Rat {
+	unrealized, _, _ := a.margins(asset)
+	return unrealized.Add(unrealized, a.wallet(asset))
+}
+
+func positionKey(symbol, side string) string {
+	return symbol + "/" + side
+}
+
+func (a *futuresAccount) position(symbol, side string) *position {
+	key := positionKey(symbol, side)
+	pos, ok := a.positions[key]
+	if !ok {
+		pos = &position{symbol: symbol, side: side, amount: new(big.Rat), entry: new(big.Rat), realized: new(big.Rat)}
+		a.positions[key] = pos
+	}
+	return pos
+}
+
+func (a *futuresAccount) wallet(asset string) *big.Rat {
+	w, ok := a.wallets[asset]
+	if !ok {
+		w = new(big.Rat)
+		a.wallets[asset] = w
+	}
+	return w
+}
+
+func (a *futuresAccount) symbolLeverage(symbol string) int {
+	if leverage, ok := a.leverage[symbol]; ok {
+		return leverage
+	}
+	return a.defaultLeverage
+}
+
+func (a *futuresAccount) mark(symbol string) *big.Rat {
+	if mark := a.engine.book(symbol).mark(); mark != nil {
+		return mark
+	}
+	return new(big.Rat)
+}
+
+// notional return the value of a position at the mark price, negative for short positions
+func (a *futuresAccount) notional(pos *position) *big.Rat {
+	return a.symbols[pos.symbol].value(pos.amount, a.mark(pos.symbol))
+}
+
+func (a *futuresAccount) unrealized(pos *position) *big.Rat {
+	mark := a.mark(pos.symbol)
+	if pos.amount.Sign() == 0 || mark.Sign() == 0 {
+		return new(big.Rat)
+	}
+	return a.symbols[pos.symbol].pnl(pos.amount, pos.entry, mark)
+}
+
+func (a *futuresAccount) initialMargin(pos *position) *big.Rat {
+	margin := a.symbols[pos.symbol].value(pos.amount, pos.entry)
+	margin.Abs(margin)
+	return margin.Quo(margin, big.NewRat(int64(a.symbolLeverage(pos.symbol)), 1))
+}
+
+// margins return the unrealized profit, the position margin and the open order margin of asset
+func (a *futuresAccount) margins(asset string) (unrealized, positionMargin, orderMargin *big.Rat) {
+	unrealized, positionMargin, orderMargin = new(big.Rat), new(big.Rat), new(big.Rat)
+	for _, pos := range a.positions {
+		if a.symbols[pos.symbol].margin == asset {
+			unrealized.Add(unrealized, a.unrealized(pos))
+			positionMargin.Add(positionMargin, a.initialMargin(pos))
+		}
+	}
+	for _, o := range a.engine.openOrders("") {
+		if a.symbols[o.symbol].margin == asset {
+			orderMargin.Add(orderMargin, o.reserved)
+		}
+	}
+	return unrealized, positionMargin, orderMargin
+}
+
+func (a *futuresAccount) available(asset string) *big.Rat {
+	unrealized, positionMargin, orderMargin := a.margins(asset)
+	available := new(big.Rat).Add(a.wallet(asset), unrealized)
+	available.Sub(available, positionMargin)
+	return available.Sub(available, orderMargin)
+}
+
+func (a *futuresAccount) symbol(p params) (string, *futuresSymbol, error) {
+	symbol, err := p.required("symbol")
+	if err != nil {
+		return "", nil, err
+	}
+	s, ok := a.symbols[symbol]
+	if !ok {
+		return "", nil, apiError(-1121, "Invalid symbol.")
+	}
+	return symbol, s, nil
+}
+
+// reducing return true if the order reduces a position: reduce only and close position
+// orders, and the orders closing a position in hedge mode
+func (a *futuresAccount) reducing(o *order) bool {
+	return o.reduceOnly || o.closePosition ||
+		(o.side == sideBuy && o.positionSide == positionSideShort) ||
+		(o.side == sideSell && o.positionSide == positionSideLong)
+}
+
+// reducible return the quantity of the position an order can reduce
+func (a *futuresAccount) reducible(o *order) *big.Rat {
+	amount := a.position(o.symbol, o.positionSide).amount
+	if (o.side == sideBuy && amount.Sign() < 0) || (o.side == sideSell && amount.Sign() > 0) {
+		return new(big.Rat).Abs(amount)
+	}
+	return new(big.Rat)
+}
+
+// newOrder parse and validate the order of the parameters. The order types and the parameters
+// of the coin-m futures are the ones of the usd(s)-m futures.
+func (a *futuresAccount) newOrder(p params) (*order, error) {
+	symbol, s, err := a.symbol(p)
+	if err != nil {
+		return nil, err
+	}
+	side := p.get("side")
+	if side != sideBuy && side != sideSell {
+		return nil, errMandatory("side")
+	}
+	o := a.engine.newOrder(symbol)
+	o.side = side
+	o.orderType = p.get("type")
+	o.timeInForce = timeInForceGTC
+	o.clientOrderID = p.get("newClientOrderId")
+	if o.clientOrderID == "" {
+		o.clientOrderID = fmt.Sprintf("paper_%d", o.id)
+	}
+	o.positionSide = p.get("positionSide")
+	switch o.positionSide {
+	case "":
+		o.positionSide = positionSideBoth
+	case positionSideBoth, positionSideLong, positionSideShort:
+	default:
+		return nil, errInvalidParam("positionSide")
+	}
+	if (o.positionSide == positionSideBoth) == a.dual {
+		return nil, apiError(-4061, "Order's position side does not match user's setting.")
+	}
+	o.reduceOnly = p.bool("reduceOnly")
+	if o.reduceOnly && a.dual {
+		return nil, apiError(-1106, "Parameter 'reduceonly' sent when not required.")
+	}
+	o.closePosition = p.bool("closePosition")
+
+	limit, stop := false, false
+	switch futures.OrderType(o.orderType) {
+	case futures.OrderTypeLimit:
+		limit = true
+	case futures.OrderTypeMarket:
+		o.market = true
+	case futures.OrderTypeStop, futures.OrderTypeTakeProfit:
+		limit, stop = true, true
+	case futures.OrderTypeStopMarket, futures.OrderTypeTakeProfitMarket:
+		o.market, stop = true, true
+	default:
+		return nil, apiError(-1116, "Invalid orderType.")
+	}
+	if limit {
+		if tif := p.get("timeInForce"); tif != "" || !stop {
+			o.timeInForce = tif
+		}
+		switch o.timeInForce {
+		case timeInForceGTC, timeInForceIOC, timeInForceFOK:
+		case timeInForceGTX:
+			o.postOnly = true
+		default:
+			return nil, errMandatory("timeInForce")
+		}
+	}
+	if o.price, err = p.decimal("price"); err != nil {
+		return nil, err
+	}
+	if limit && o.price == nil {
+		return nil, errMandatory("price")
+	}
+	if !limit && o.price != nil {
+		return nil, apiError(-1106, "Parameter 'price' sent when not required.")
+	}
+	if o.stopPrice, err = p.decimal("stopPrice"); err != nil {
+		return nil, err
+	}
+	if stop {
+		if o.stopPrice == nil {
+			return nil, errMandatory("stopPrice")
+		}
+		o.stop = true
+		takeProfit := o.orderType == string(futures.OrderTypeTakeProfit) || o.orderType == string(futures.OrderTypeTakeProfitMarket)
+		o.stopAbove = (side == sideBuy) != takeProfit
+	}
+	if o.quantity, err = p.decimal("quantity"); err != nil {
+		return nil, err
+	}
+	if o.closePosition {
+		if !o.market || !stop {
+			return nil, apiError(-1106, "Parameter 'closePosition' sent when not required.")
+		}
+		if o.quantity != nil || o.reduceOnly {
+			return nil, apiError(-1106, "Parameter 'quantity' sent when not required.")
+		}
+		// the quantity is the position when the order triggers
+		o.quantity = new(big.Rat)
+	} else if o.quantity == nil {
+		return nil, errMandatory("quantity")
+	}
+
+	filters := *s.filters
+	if a.reducing(o) {
+		filters.MinNotional = nil
+	}
+	op := &common.OrderParams{
+		Buy:       side == sideBuy,
+		Market:    o.market,
+		Price:     optional(p, "price"),
+		StopPrice: optional(p, "stopPrice"),
+		Quantity:  optional(p, "quantity"),
+	}
+	if mark := a.engine.book(symbol).mark(); mark != nil {
+		op.ReferencePrice = common.FormatDecimal(mark)
+	}
+	if err := filters.Validate(op); err != nil {
+		return nil, errFilter(err)
+	}
+	for _, open := range a.engine.openOrders(symbol) {
+		if open.clientOrderID == o.clientOrderID {
+			return nil, apiError(-4015, "Client order id is not valid.")
+		}
+	}
+	return o, nil
+}
+
+func futuresError(err error) error {
+	switch err {
+	case errWouldTake:
+		return apiError(-5022, "Due to the order could not be executed as maker, the Post Only order will be rejected.")
+	case errWouldTrigger:
+		return apiError(-2021, "Order would immediately trigger.")
+	case errNoLiquidity:
+		return apiError(-2010, "No market price for the symbol.")
+	}
+	return err
+}
+
+func (a *futuresAccount) createOrder(p params) (*order, error) {
+	o, err := a.newOrder(p)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.engine.place(o); err != nil {
+		return nil, futuresError(err)
+	}
+	return o, nil
+}
+
+func (a *futuresAccount) findOrder(p params) (*order, error) {
+	symbol, _, err := a.symbol(p)
+	if err != nil {
+		return nil, err
+	}
+	id, err := p.int64("orderId")
+	if err != nil {
+		return nil, err
+	}
+	clientOrderID := p.get("origClientOrderId")
+	if id == 0 && clientOrderID == "" {
+		return nil, errMandatory("orderId")
+	}
+	return a.engine.findOrder(symbol, id, clientOrderID), nil
+}
+
+func (a *futuresAccount) getOrder(p params) (*order, error) {
+	o, err := a.findOrder(p)
+	if err != nil {
+		return nil, err
+	}
+	if o == nil {
+		return nil, apiError(-2013, "Order does not exist.")
+	}
+	return o, nil
+}
+
+func (a *futuresAccount) cancelOrder(p params) (*order, error) {
+	o, err := a.findOrder(p)
+	if err != nil {
+		return nil, err
+	}
+	if o == nil || o.done() {
+		return nil, apiError(-2011, "Unknown order sent.")
+	}
+	a.engine.cancel(o)
+	return o, nil
+}
+
+func (a *futuresAccount) cancelAllOpenOrders(p params) (interface{}, error) {
+	symbol, _, err := a.symbol(p)
+	if err != nil {
+		return nil, err
+	}
+	for _, o := range a.engine.openOrders(symbol) {
+		a.engine.cancel(o)
+	}
+	return map[string]interface{}{"code": 200, "msg": "The operation of cancel all open order is done."}, nil
+}
+
+// listOrders return the orders of symbol from the orderId parameter, at most limit
+func (a *futuresAccount) listOrders(p params) ([]*order, error) {
+	symbol, _, err := a.symbol(p)
+	if err != nil {
+		return nil, err
+	}
+	from, err := p.int64("orderId")
+	if err != nil {
+		return nil, err
+	}
+	limit, err := p.int64("limit")
+	if err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = 500
+	}
+	var res []*order
+	for _, o := range a.engine.orders {
+		if o.symbol == symbol && o.id >= from {
+			res = append(res, o)
+		}
+	}
+	if int64(len(res)) > limit {
+		if from > 0 {
+			res = res[:limit]
+		} else {
+			res = res[int64(len(res))-limit:]
+		}
+	}
+	return res, nil
+}
+
+func (a *futuresAccount) assets() []string {
+	assets := make([]string, 0, len(a.wallets))
+	for asset := range a.wallets {
+		assets = append(assets, asset)
+	}
+	sort.Strings(assets)
+	return assets
+}
+
+// sortedPositions return the positions of symbol, or of all symbols when symbol is empty,
+// with the sides of the position mode
+func (a *futuresAccount) sortedPositions(symbol string) []*position {
+	symbols := make([]string, 0, len(a.symbols))
+	for s := range a.symbols {
+		if symbol == "" || s == symbol {
+			symbols = append(symbols, s)
+		}
+	}
+	sort.Strings(symbols)
+	sides := []string{positionSideBoth}
+	if a.dual {
+		sides = []string{positionSideLong, positionSideShort}
+	}
+	positions := make([]*position, 0, len(symbols)*len(sides))
+	for _, s := range symbols {
+		for _, side := range sides {
+			positions = append(positions, a.position(s, side))
+		}
+	}
+	return positions
+}
+
+func (a *futuresAccount) changePositionMode(p params) (interface{}, error) {
+	dual, err := p.required("dualSidePosition")
+	if err != nil {
+		return nil, err
+	}
+	if p.bool("dualSidePosition") == a.dual {
+		return nil, apiError(-4059, "No need to change position side.")
+	}
+	if len(a.engine.openOrders("")) > 0 {
+		return nil, apiError(-4067, "Position side cannot be changed if there exists open orders.")
+	}
+	for _, pos := range a.positions {
+		if pos.amount.Sign() != 0 {
+			return nil, apiError(-4068, "Position side cannot be changed if there exists position.")
+		}
+	}
+	a.dual = dual == "true"
+	return map[string]interface{}{"code": 200, "msg": "success"}, nil
+}
+
+// changeLeverage set the leverage of the symbol of the parameters
+func (a *futuresAccount) changeLeverage(p params) (string, int, error) {
+	symbol, _, err := a.symbol(p)
+	if err != nil {
+		return "", 0, err
+	}
+	leverage, err := p.int64("leverage")
+	if err != nil {
+		return "", 0, err
+	}
+	if leverage < 1 || leverage > 125 {
+		return "", 0, apiError(-4028, fmt.Sprintf("Leverage %d is not valid", leverage))
+	}
+	a.leverage[symbol] = int(leverage)
+	return symbol, int(leverage), nil
+}
+
+func (a *futuresAccount) reserve(o *order) error {
+	if a.reducing(o) {
+		// stop orders reduce the position they find when they trigger
+		if !o.stop && a.reducible(o).Sign() == 0 {
+			return apiError(-2022, "ReduceOnly Order is rejected.")
+		}
+		return nil
+	}
+	price := o.price
+	if price == nil {
+		price = o.stopPrice
+	}
+	if price == nil {
+		price = a.mark(o.symbol)
+	}
+	s := a.symbols[o.symbol]
+	margin := s.value(o.remaining(), price)
+	margin.Quo(margin, big.NewRat(int64(a.symbolLeverage(o.symbol)), 1))
+	if a.available(s.margin).Cmp(margin) < 0 {
+		return apiError(-2019, "Margin is insufficient.")
+	}
+	o.reserved = margin
+	return nil
+}
+
+func (a *futuresAccount) prepare(o *order) bool {
+	if !a.reducing(o) {
+		return true
+	}
+	reducible := a.reducible(o)
+	if reducible.Sign() == 0 {
+		return false
+	}
+	if o.closePosition || o.remaining().Cmp(reducible) > 0 {
+		o.quantity = reducible.Add(reducible, o.executed)
+	}
+	return true
+}
+
+func (a *futuresAccount) settle(t *trade) {
+	o := t.order
+	s := a.symbols[o.symbol]
+	pos := a.position(o.symbol, o.positionSide)
+	delta := new(big.Rat).Set(t.quantity)
+	if o.side == sideSell {
+		delta.Neg(delta)
+	}
+	t.realizedPnL = new(big.Rat)
+	if pos.amount.Sign() == 0 || pos.amount.Sign() == delta.Sign() {
+		size := new(big.Rat).Abs(pos.amount)
+		value := s.value(size, pos.entry)
+		value.Add(value, s.value(t.quantity, t.price))
+		pos.entry = s.entry(size.Add(size, t.quantity), value)
+	} else {
+		closed := common.MinRat(new(big.Rat).Abs(pos.amount), t.quantity)
+		if pos.amount.Sign() < 0 {
+			closed.Neg(closed)
+		}
+		t.realizedPnL = s.pnl(closed, pos.entry, t.price)
+		before := pos.amount.Sign()
+		if after := new(big.Rat).Add(pos.amount, delta); after.Sign() == 0 {
+			pos.entry = new(big.Rat)
+		} else if after.Sign() != before {
+			pos.entry = new(big.Rat).Set(t.price)
+		}
+	}
+	pos.amount = new(big.Rat).Add(pos.amount, delta)
+	pos.realized.Add(pos.realized, t.realizedPnL)
+	pos.updateTime = t.time
+
+	rate := a.taker
+	if t.maker {
+		rate = a.maker
+	}
+	t.commission = new(big.Rat).Mul(s.value(t.quantity, t.price), rate)
+	t.commissionAsset = s.margin
+	wallet := a.wallet(s.margin)
+	wallet.Add(wallet, t.realizedPnL)
+	wallet.Sub(wallet, t.commission)
+
+	// the margin of the order moves to the position
+	if o.reserved.Sign() > 0 {
+		remaining := o.remaining()
+		before := new(big.Rat).Add(remaining, t.quantity)
+		o.reserved.Mul(o.reserved, remaining)
+		o.reserved.Quo(o.reserved, before)
+	}
+	a.changedAssets[s.margin] = true
+	a.changedPositions[positionKey(pos.symbol, pos.side)] = true
+}
+
+func (a *futuresAccount) release(o *order) {
+	o.reserved = new(big.Rat)
+}
+
+func (a *futuresAccount) reportList(l *orderList) {}
+
+// changes return the reason, the balances and the positions of the account update of the
+// current update, and reset them. ok is false when the update did not change the account.
+func (a *futuresAccount) changes() (reason string, assets []string, positions []*position, ok bool) {
+	if len(a.changedAssets) == 0 && len(a.changedPositions) == 0 {
+		return "", nil, nil, false
+	}
+	reason = string(futures.UserDataEventReasonTypeOrder)
+	if a.reason != "" {
+		reason = a.reason
+	}
+	for _, asset := range a.assets() {
+		if a.changedAssets[asset] {
+			assets = append(assets, asset)
+		}
+	}
+	keys := make([]string, 0, len(a.changedPositions))
+	for key := range a.changedPositions {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		positions = append(positions, a.positions[key])
+	}
+	a.changedAssets = make(map[string]bool)
+	a.changedPositions = make(map[string]bool)
+	a.reason = ""
+	return reason, assets, positions, true
+}
@@ -0,0 +1,212 @@
+package paper
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/Zamzam-Technology/go-binance/v2/common"
+	"github.com/Zamzam-Technology/go-binance/v2/futures"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const futuresExchangeInfo = `{
+	"symbols": [{
+		"symbol": "BTCUSDT",
+		"status": "TRADING",
+		"baseAsset": "BTC",
+		"quoteAsset": "USDT",
+		"marginAsset": "USDT",
+		"filters": [
+			{"filterType": "PRICE_FILTER", "minPrice": "0.1", "maxPrice": "1000000", "tickSize": "0.1"},
+			{"filterType": "LOT_SIZE", "minQty": "0.001", "maxQty": "1000", "stepSize": "0.001"},
+			{"filterType": "MARKET_LOT_SIZE", "minQty": "0.001", "maxQty": "100", "stepSize": "0.001"},
+			{"filterType": "MIN_NOTIONAL", "notional": "5"}
+		]
+	}]
+}`
+
+func newTestFuturesExchange(t *testing.T) (*FuturesExchange, *futures.Client, *[]*futures.WsUserDataEvent) {
+	info := new(futures.ExchangeInfo)
+	require.NoError(t, json.Unmarshal([]byte(futuresExchangeInfo), info))
+	x, err := NewFuturesExchange(info, &Config{
+		Balances: map[string]string{"USDT": "1000"},
+		Now:      func() time.Time { return time.Unix(1600000000, 0) },
+	})
+	require.NoError(t, err)
+	events := new([]*futures.WsUserDataEvent)
+	x.SubscribeUserData(func(event *futures.WsUserDataEvent) {
+		*events = append(*events, event)
+	})
+	x.OnBook("BTCUSDT", []common.PriceLevel{{Price: "99", Quantity: "10"}}, []common.PriceLevel{{Price: "100", Quantity: "10"}})
+	return x, x.Client(), events
+}
+
+func futuresPositions(t *testing.T, c *futures.Client) map[string]*futures.PositionRisk {
+	risks, err := c.NewGetPositionRiskService().Symbol("BTCUSDT").Do(context.Background())
+	require.NoError(t, err)
+	positions := make(map[string]*futures.PositionRisk)
+	for _, risk := range risks {
+		positions[risk.PositionSide] = risk
+	}
+	return positions
+}
+
+func TestFuturesPosition(t *testing.T) {
+	x, c, events := newTestFuturesExchange(t)
+	ctx := context.Background()
+
+	leverage, err := c.NewChangeLeverageService().Symbol("BTCUSDT").Leverage(10).Do(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 10, leverage.Leverage)
+
+	res, err := c.NewCreateOrderService().Symbol("BTCUSDT").Side(futures.SideTypeBuy).
+		Type(futures.OrderTypeMarket).Quantity("2").Do(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, futures.OrderStatusTypeFilled, res.Status)
+	assert.Equal(t, "100", res.AvgPrice)
+
+	require.NoError(t, x.OnTrade("BTCUSDT", "110", "0.1"))
+	position := futuresPositions(t, c)["BOTH"]
+	assert.Equal(t, "2", position.PositionAmt)
+	assert.Equal(t, "100", position.EntryPrice)
+	assert.Equal(t, "20", position.UnRealizedProfit)
+	assert.Equal(t, "10", position.Leverage)
+
+	_, err = c.NewCreateOrderService().Symbol("BTCUSDT").Side(futures.SideTypeSell).
+		Type(futures.OrderTypeLimit).TimeInForce(futures.TimeInForceTypeGTC).
+		Quantity("1").Price("110").NewClientOrderID("tp").Do(ctx)
+	require.NoError(t, err)
+	require.NoError(t, x.OnTrade("BTCUSDT", "111", "5"))
+	order, err := c.NewGetOrderService().Symbol("BTCUSDT").OrigClientOrderID("tp").Do(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, futures.OrderStatusTypeFilled, order.Status)
+
+	// the reduce only order is capped to the position
+	res, err = c.NewCreateOrderService().Symbol("BTCUSDT").Side(futures.SideTypeSell).
+		Type(futures.OrderTypeMarket).Quantity("5").ReduceOnly(true).Do(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "1", res.ExecutedQuantity)
+	assert.Equal(t, "99", res.AvgPrice)
+
+	position = futuresPositions(t, c)["BOTH"]
+	assert.Equal(t, "0", position.PositionAmt)
+	assert.Equal(t, "0", position.EntryPrice)
+	balances, err := c.NewGetBalanceService().Do(ctx)
+	require.NoError(t, err)
+	require.Len(t, balances, 1)
+	// 0.08 and 0.0396 of taker commission, 0.022 of maker commission
+	assert.Equal(t, "1008.8584", balances[0].Balance)
+	assert.Equal(t, "1008.8584", balances[0].AvailableBalance)
+
+	trades, err := c.NewListAccountTradeService().Symbol("BTCUSDT").Do(ctx)
+	require.NoError(t, err)
+	require.Len(t, trades, 3)
+	assert.Equal(t, "10", trades[1].RealizedPnl)
+	assert.True(t, trades[1].Maker)
+	assert.Equal(t, "-1", trades[2].RealizedPnl)
+
+	var types []futures.UserDataEventType
+	for _, event := range *events {
+		types = append(types, event.Event)
+	}
+	orderUpdate := futures.UserDataEventTypeOrderTradeUpdate
+	account := futures.UserDataEventTypeAccountUpdate
+	assert.Equal(t, []futures.UserDataEventType{orderUpdate, orderUpdate, account, orderUpdate, orderUpdate, account, orderUpdate, orderUpdate, account}, types)
+	last := (*events)[7].OrderTradeUpdate
+	assert.Equal(t, futures.OrderExecutionTypeTrade, last.ExecutionType)
+	assert.Equal(t, "-1", last.RealizedPnL)
+	assert.Equal(t, "0.0396", last.Commission)
+	update := (*events)[8].AccountUpdate
+	assert.Equal(t, futures.UserDataEventReasonTypeOrder, update.Reason)
+	assert.Equal(t, []futures.WsBalance{{Asset: "USDT", Balance: "1008.8584", CrossWalletBalance: "1008.8584"}}, update.Balances)
+	require.Len(t, update.Positions, 1)
+	assert.Equal(t, "0", update.Positions[0].Amount)
+	assert.Equal(t, "9", update.Positions[0].AccumulatedRealized)
+}
+
+func TestFuturesHedgeMode(t *testing.T) {
+	x, c, _ := newTestFuturesExchange(t)
+	ctx := context.Background()
+
+	_, err := c.NewCreateOrderService().Symbol("BTCUSDT").Side(futures.SideTypeBuy).Type(futures.OrderTypeMarket).
+		Quantity("1").Do(ctx)
+	require.NoError(t, err)
+	err = c.NewChangePositionModeService().DualSide(true).Do(ctx)
+	assert.Equal(t, &common.APIError{Code: -4068, Message: "Position side cannot be changed if there exists position."}, err)
+	_, err = c.NewCreateOrderService().Symbol("BTCUSDT").Side(futures.SideTypeSell).Type(futures.OrderTypeMarket).
+		Quantity("1").ReduceOnly(true).Do(ctx)
+	require.NoError(t, err)
+
+	require.NoError(t, c.NewChangePositionModeService().DualSide(true).Do(ctx))
+	err = c.NewChangePositionModeService().DualSide(true).Do(ctx)
+	assert.Equal(t, &common.APIError{Code: -4059, Message: "No need to change position side."}, err)
+	mode, err := c.NewGetPositionModeService().Do(ctx)
+	require.NoError(t, err)
+	assert.True(t, mode.DualSidePosition)
+
+	_, err = c.NewCreateOrderService().Symbol("BTCUSDT").Side(futures.SideTypeBuy).Type(futures.OrderTypeMarket).
+		Quantity("1").Do(ctx)
+	assert.Equal(t, &common.APIError{Code: -4061, Message: "Order's position side does not match user's setting."}, err)
+	_, err = c.NewCreateOrderService().Symbol("BTCUSDT").Side(futures.SideTypeBuy).PositionSide(futures.PositionSideTypeLong).
+		Type(futures.OrderTypeMarket).Quantity("1").Do(ctx)
+	require.NoError(t, err)
+	_, err = c.NewCreateOrderService().Symbol("BTCUSDT").Side(futures.SideTypeSell).PositionSide(futures.PositionSideTypeShort).
+		Type(futures.OrderTypeMarket).Quantity("0.5").Do(ctx)
+	require.NoError(t, err)
+	positions := futuresPositions(t, c)
+	assert.Len(t, positions, 2)
+	assert.Equal(t, "1", positions["LONG"].PositionAmt)
+	assert.Equal(t, "-0.5", positions["SHORT"].PositionAmt)
+
+	// the take profit closes the long position when it triggers
+	_, err = c.NewCreateOrderService().Symbol("BTCUSDT").Side(futures.SideTypeSell).PositionSide(futures.PositionSideTypeLong).
+		Type(futures.OrderTypeTakeProfitMarket).StopPrice("105").ClosePosition(true).Do(ctx)
+	require.NoError(t, err)
+	require.NoError(t, x.OnTrade("BTCUSDT", "104.9", "1"))
+	assert.Equal(t, "1", futuresPositions(t, c)["LONG"].PositionAmt)
+	require.NoError(t, x.OnTrade("BTCUSDT", "105", "1"))
+	positions = futuresPositions(t, c)
+	assert.Equal(t, "0", positions["LONG"].PositionAmt)
+	assert.Equal(t, "-0.5", positions["SHORT"].PositionAmt)
+	orders, err := c.NewListOpenOrdersService().Symbol("BTCUSDT").Do(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, orders)
+}
+
+func TestFuturesOrderErrors(t *testing.T) {
+	_, c, _ := newTestFuturesExchange(t)
+	ctx := context.Background()
+
+	_, err := c.NewCreateOrderService().Symbol("BTCUSDT").Side(futures.SideTypeBuy).Type(futures.OrderTypeLimit).
+		TimeInForce(futures.TimeInForceTypeGTC).Quantity("300").Price("99").Do(ctx)
+	assert.Equal(t, &common.APIError{Code: -2019, Message: "Margin is insufficient."}, err)
+	_, err = c.NewCreateOrderService().Symbol("BTCUSDT").Side(futures.SideTypeSell).Type(futures.OrderTypeMarket).
+		Quantity("1").ReduceOnly(true).Do(ctx)
+	assert.Equal(t, &common.APIError{Code: -2022, Message: "ReduceOnly Order is rejected."}, err)
+	_, err = c.NewCreateOrderService().Symbol("BTCUSDT").Side(futures.SideTypeBuy).Type(futures.OrderTypeLimit).
+		TimeInForce(futures.TimeInForceTypeGTX).Quantity("1").Price("100").Do(ctx)
+	assert.Equal(t, int64(-5022), err.(*common.APIError).Code)
+	_, err = c.NewCreateOrderService().Symbol("BTCUSDT").Side(futures.SideTypeBuy).Type(futures.OrderTypeStopMarket).
+		Quantity("1").StopPrice("99").Do(ctx)
+	assert.Equal(t, &common.APIError{Code: -2021, Message: "Order would immediately trigger."}, err)
+	_, err = c.NewCreateOrderService().Symbol("BTCUSDT").Side(futures.SideTypeBuy).Type(futures.OrderTypeLimit).
+		TimeInForce(futures.TimeInForceTypeGTC).Quantity("0.01").Price("99").Do(ctx)
+	assert.Equal(t, &common.APIError{Code: -1013, Message: "Filter failure: MIN_NOTIONAL"}, err)
+	_, err = c.NewCreateOrderService().Symbol("BTCUSDT").Side(futures.SideTypeBuy).Type(futures.OrderTypeMarket).
+		Quantity("101").Do(ctx)
+	assert.Equal(t, &common.APIError{Code: -1013, Message: "Filter failure: MARKET_LOT_SIZE"}, err)
+
+	res, err := c.NewCreateOrderService().Symbol("BTCUSDT").Side(futures.SideTypeBuy).Type(futures.OrderTypeLimit).
+		TimeInForce(futures.TimeInForceTypeGTC).Quantity("1").Price("90").Do(ctx)
+	require.NoError(t, err)
+	err = c.NewChangePositionModeService().DualSide(true).Do(ctx)
+	assert.Equal(t, &common.APIError{Code: -4067, Message: "Position side cannot be changed if there exists open orders."}, err)
+	canceled, err := c.NewCancelOrderService().Symbol("BTCUSDT").OrderID(res.OrderID).Do(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, futures.OrderStatusTypeCanceled, canceled.Status)
+	_, err = c.NewCancelOrderService().Symbol("BTCUSDT").OrderID(res.OrderID).Do(ctx)
+	assert.Equal(t, &common.APIError{Code: -2011, Message: "Unknown order sent."}, err)
+}
@@ -0,0 +1,254 @@
+// Package paper simulate the order and account endpoints of Binance for paper trading.
+//
+// SpotExchange, FuturesExchange and DeliveryExchange implement http.RoundTripper: set one as
+// the transport of the HTTPClient of a binance.Client, a futures.Client or a delivery.Client and
+// the order, account and user stream calls are served by an in-process matching engine, while
+// the market data calls go to the real exchange. The engine is driven by the depth and trade updates given to OnBook
+// and OnTrade, from live streams or from recorded data. It applies the filters of the
+// exchange info and the commission rates of the config, and emits the user data events the
+// real user data stream would send to the handlers of SubscribeUserData.
+package paper
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Zamzam-Technology/go-binance/v2/common"
+)
+
+// Config define the account of a paper exchange
+type Config struct {
+	// Balances is the initial balance by asset, e.g. {"USDT": "10000"}
+	Balances map[string]string
+	// MakerCommission and TakerCommission are the commission rates, e.g. "0.001".
+	// They default to the standard rates of the exchange.
+	MakerCommission string
+	TakerCommission string
+	// Leverage is the initial leverage of the futures symbols, 20 by default
+	Leverage int
+	// Now return the time of the exchange, time.Now by default. Set it to the time
+	// of the recorded data when replaying.
+	Now func() time.Time
+	// Transport serves the requests which are not simulated, http.DefaultTransport by default
+	Transport http.RoundTripper
+}
+
+type route func(p params) (interface{}, error)
+
+// exchange dispatch the requests of a paper exchange to its routes under a lock,
+// and deliver the events collected during a request once the lock is released
+type exchange struct {
+	mu            sync.Mutex
+	routes        map[string]route
+	transport     http.RoundTripper
+	now           func() time.Time
+	events        []interface{}
+	handlers      map[int]func(event interface{})
+	nextHandlerID int
+	// flush is called at the end of each update, to emit the account events
+	flush func()
+}
+
+func newExchange(cfg *Config) *exchange {
+	x := &exchange{
+		routes:    make(map[string]route),
+		transport: http.DefaultTransport,
+		now:       time.Now,
+		handlers:  make(map[int]func(event interface{})),
+		flush:     func() {},
+	}
+	if cfg.Transport != nil {
+		x.transport = cfg.Transport
+	}
+	if cfg.Now != nil {
+		x.now = cfg.Now
+	}
+	return x
+}
+
+func (x *exchange) handle(method, path string, r route) {
+	x.routes[method+" "+path] = r
+}
+
+func (x *exchange) millis() int64 {
+	return x.now().UnixNano() / int64(time.Millisecond)
+}
+
+func (x *exchange) emit(event interface{}) {
+	x.events = append(x.events, event)
+}
+
+// update run fn under the lock, then deliver the events it emitted
+func (x *exchange) update(fn func()) {
+	x.mu.Lock()
+	fn()
+	x.flush()
+	events := x.events
+	x.events = nil
+	var handlers []func(event interface{})
+	for _, handler := range x.handlers {
+		handlers = append(handlers, handler)
+	}
+	x.mu.Unlock()
+	for _, event := range events {
+		for _, handler := range handlers {
+			handler(event)
+		}
+	}
+}
+
+func (x *exchange) subscribe(handler func(event interface{})) (unsubscribe func()) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	id := x.nextHandlerID
+	x.nextHandlerID++
+	x.handlers[id] = handler
+	return func() {
+		x.mu.Lock()
+		defer x.mu.Unlock()
+		delete(x.handlers, id)
+	}
+}
+
+// RoundTrip serve a simulated endpoint, or send the request to the transport of the config
+// when it is a public GET request. Other requests fail with an API error, so that no order
+// reaches the real exchange.
+func (x *exchange) RoundTrip(req *http.Request) (*http.Response, error) {
+	r, ok := x.routes[req.Method+" "+req.URL.Path]
+	if !ok {
+		if req.Method == http.MethodGet && req.URL.Query().Get("signature") == "" {
+			return x.transport.RoundTrip(req)
+		}
+		return newResponse(req, nil, apiError(-1000, fmt.Sprintf("%s %s is not simulated by the paper exchange", req.Method, req.URL.Path)))
+	}
+	p, err := readParams(req)
+	if err != nil {
+		return nil, err
+	}
+	var res interface{}
+	x.update(func() {
+		res, err = r(p)
+	})
+	return newResponse(req, res, err)
+}
+
+func newResponse(req *http.Request, res interface{}, err error) (*http.Response, error) {
+	status := http.StatusOK
+	if err != nil {
+		status = http.StatusBadRequest
+		res = err
+	}
+	data, err := json.Marshal(res)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          ioutil.NopCloser(strings.NewReader(string(data))),
+		ContentLength: int64(len(data)),
+		Request:       req,
+	}, nil
+}
+
+func apiError(code int64, msg string) error {
+	return &common.APIError{Code: code, Message: msg}
+}
+
+func errMandatory(name string) error {
+	return apiError(-1102, fmt.Sprintf("Mandatory parameter '%s' was not sent, was empty/null, or malformed.", name))
+}
+
+func errInvalidParam(name string) error {
+	return apiError(-1100, fmt.Sprintf("Illegal characters found in parameter '%s'.", name))
+}
+
+func errFilter(err error) error {
+	if e, ok := err.(*common.OrderFilterError); ok && len(e.Violations) > 0 {
+		return apiError(-1013, "Filter failure: "+e.Violations[0].Filter)
+	}
+	return apiError(-1013, err.Error())
+}
+
+// params are the parameters of a request, from the query and the form body
+type params url.Values
+
+func readParams(req *http.Request) (params, error) {
+	p := params(req.URL.Query())
+	if req.Body == nil {
+		return p, nil
+	}
+	data, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	form, err := url.ParseQuery(string(data))
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range form {
+		p[key] = append(p[key], values...)
+	}
+	return p, nil
+}
+
+func (p params) get(name string) string {
+	return url.Values(p).Get(name)
+}
+
+func (p params) required(name string) (string, error) {
+	v := p.get(name)
+	if v == "" {
+		return "", errMandatory(name)
+	}
+	return v, nil
+}
+
+func (p params) int64(name string) (int64, error) {
+	v := p.get(name)
+	if v == "" {
+		return 0, nil
+	}
+	i, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return 0, errInvalidParam(name)
+	}
+	return i, nil
+}
+
+// decimal return the positive decimal parameter, or nil when it is not set
+func (p params) decimal(name string) (*big.Rat, error) {
+	v := p.get(name)
+	if v == "" {
+		return nil, nil
+	}
+	r, ok := new(big.Rat).SetString(v)
+	if !ok || r.Sign() <= 0 {
+		return nil, errInvalidParam(name)
+	}
+	return r, nil
+}
+
+func (p params) bool(name string) bool {
+	return strings.EqualFold(p.get(name), "true")
+}
+
+// average return quote / quantity, zero when nothing is executed
+func average(quote, quantity *big.Rat) *big.Rat {
+	if quantity == nil || quantity.Sign() == 0 {
+		return new(big.Rat)
+	}
+	return new(big.Rat).Quo(quote, quantity)
+}
@@ -0,0 +1,999 @@
+package paper
+
+import (
+	"fmt"
+	"math/big"
+	"net/http"
+	"sort"
+
+	"github.com/Zamzam-Technology/go-binance/v2"
+	"github.com/Zamzam-Technology/go-binance/v2/common"
+)
+
+type spotSymbol struct {
+	filters *common.OrderFilters
+	base    string
+	quote   string
+}
+
+type spotBalance struct {
+	free   *big.Rat
+	locked *big.Rat
+}
+
+// SpotExchange simulate the spot order, account and user stream endpoints. It is safe for concurrent use.
+type SpotExchange struct {
+	*exchange
+	engine   *engine
+	symbols  map[string]*spotSymbol
+	balances map[string]*spotBalance
+	maker    *big.Rat
+	taker    *big.Rat
+	// changed are the assets whose balance changed during the current update
+	changed map[string]bool
+}
+
+// NewSpotExchange create a paper spot exchange trading the symbols of info
+func NewSpotExchange(info *binance.ExchangeInfo, cfg *Config) (*SpotExchange, error) {
+	if cfg == nil {
+		cfg = &Config{}
+	}
+	x := &SpotExchange{
+		exchange: newExchange(cfg),
+		symbols:  make(map[string]*spotSymbol),
+		balances: make(map[string]*spotBalance),
+		changed:  make(map[string]bool),
+	}
+	x.engine = newEngine(x, x.millis)
+	x.exchange.flush = x.flushAccount
+	var err error
+	if x.maker, err = commissionRate(cfg.MakerCommission, "0.001"); err != nil {
+		return nil, err
+	}
+	if x.taker, err = commissionRate(cfg.TakerCommission, "0.001"); err != nil {
+		return nil, err
+	}
+	for asset, amount := range cfg.Balances {
+		free, err := common.ParseDecimal(amount)
+		if err != nil {
+			return nil, err
+		}
+		x.balance(asset).free = free
+	}
+	for i := range info.Symbols {
+		s := &info.Symbols[i]
+		x.symbols[s.Symbol] = &spotSymbol{filters: s.OrderFilters(), base: s.BaseAsset, quote: s.QuoteAsset}
+	}
+
+	x.handle(http.MethodPost, "/api/v3/order", x.createOrder)
+	x.handle(http.MethodPost, "/api/v3/order/test", x.testOrder)
+	x.handle(http.MethodGet, "/api/v3/order", x.getOrder)
+	x.handle(http.MethodDelete, "/api/v3/order", x.cancelOrder)
+	x.handle(http.MethodGet, "/api/v3/openOrders", x.listOpenOrders)
+	x.handle(http.MethodDelete, "/api/v3/openOrders", x.cancelOpenOrders)
+	x.handle(http.MethodGet, "/api/v3/allOrders", x.listOrders)
+	x.handle(http.MethodPost, "/api/v3/order/oco", x.createOCO)
+	x.handle(http.MethodPost, "/api/v3/orderList/oco", x.createOrderListOCO)
+	x.handle(http.MethodDelete, "/api/v3/orderList", x.cancelOCO)
+	x.handle(http.MethodGet, "/api/v3/account", x.getAccount)
+	x.handle(http.MethodGet, "/api/v3/myTrades", x.listTrades)
+	x.handle(http.MethodPost, "/api/v3/userDataStream", listenKey)
+	x.handle(http.MethodPut, "/api/v3/userDataStream", emptyResponse)
+	x.handle(http.MethodDelete, "/api/v3/userDataStream", emptyResponse)
+	return x, nil
+}
+
+func commissionRate(rate, defaultRate string) (*big.Rat, error) {
+	if rate == "" {
+		rate = defaultRate
+	}
+	return common.ParseDecimal(rate)
+}
+
+func listenKey(p params) (interface{}, error) {
+	return map[string]string{"listenKey": "paper"}, nil
+}
+
+func emptyResponse(p params) (interface{}, error) {
+	return struct{}{}, nil
+}
+
+// Client return a client whose requests are served by the exchange
+func (x *SpotExchange) Client() *binance.Client {
+	c := binance.NewClient("paper", "paper")
+	c.HTTPClient = &http.Client{Transport: x}
+	return c
+}
+
+// SubscribeUserData call handler with the events of the user data stream: execution reports,
+// order list statuses and account positions. Handlers are called outside of the exchange lock,
+// in the order of the events. The returned function removes the subscription.
+func (x *SpotExchange) SubscribeUserData(handler binance.WsUserDataHandler) (unsubscribe func()) {
+	return x.subscribe(func(event interface{}) {
+		handler(event.(*binance.WsUserDataEvent))
+	})
+}
+
+// OnBook replace the order book of symbol, e.g. with a partial depth event.
+// The resting orders crossed by the book are filled as maker at their price.
+func (x *SpotExchange) OnBook(symbol string, bids, asks []common.PriceLevel) {
+	x.update(func() {
+		x.engine.onBook(symbol, bids, asks)
+	})
+}
+
+// OnTrade record a trade of the market, see FuturesExchange.OnTrade
+func (x *SpotExchange) OnTrade(symbol, price, quantity string) error {
+	p, err := common.ParseDecimal(price)
+	if err != nil {
+		return err
+	}
+	q, err := common.ParseDecimal(quantity)
+	if err != nil {
+		return err
+	}
+	x.update(func() {
+		x.engine.onTrade(symbol, p, q)
+	})
+	return nil
+}
+
+// Equity return the value of the balances in asset, at the market price of their symbols.
+// The balances without a market against asset are not counted.
+func (x *SpotExchange) Equity(asset string) string {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	equity := new(big.Rat)
+	for a, b := range x.balances {
+		amount := new(big.Rat).Add(b.free, b.locked)
+		if amount.Sign() == 0 {
+			continue
+		}
+		if price := x.price(a, asset); price != nil {
+			equity.Add(equity, amount.Mul(amount, price))
+		}
+	}
+	return common.FormatDecimal(equity)
+}
+
+// price return the price of base in quote, nil when there is no market
+func (x *SpotExchange) price(base, quote string) *big.Rat {
+	if base == quote {
+		return big.NewRat(1, 1)
+	}
+	for symbol, s := range x.symbols {
+		mark := x.engine.book(symbol).mark()
+		if mark == nil || mark.Sign() == 0 {
+			continue
+		}
+		if s.base == base && s.quote == quote {
+			return mark
+		}
+		if s.base == quote && s.quote == base {
+			return new(big.Rat).Inv(mark)
+		}
+	}
+	return nil
+}
+
+func (x *SpotExchange) balance(asset string) *spotBalance {
+	b, ok := x.balances[asset]
+	if !ok {
+		b = &spotBalance{free: new(big.Rat), locked: new(big.Rat)}
+		x.balances[asset] = b
+	}
+	return b
+}
+
+func (x *SpotExchange) symbol(p params) (string, *spotSymbol, error) {
+	symbol, err := p.required("symbol")
+	if err != nil {
+		return "", nil, err
+	}
+	s, ok := x.symbols[symbol]
+	if !ok {
+		return "", nil, apiError(-1121, "Invalid symbol.")
+	}
+	return symbol, s, nil
+}
+
+func optional(p params, name string) *string {
+	if v := p.get(name); v != "" {
+		return &v
+	}
+	return nil
+}
+
+// newOrder parse and validate the order of the parameters, names maps the parameters of
+// an order list leg to the parameters of an order
+func (x *SpotExchange) newOrder(p params, orderType binance.OrderType, names map[string]string) (*order, error) {
+	name := func(n string) string {
+		if mapped, ok := names[n]; ok {
+			return mapped
+		}
+		return n
+	}
+	symbol, s, err := x.symbol(p)
+	if err != nil {
+		return nil, err
+	}
+	side := p.get("side")
+	if side != sideBuy && side != sideSell {
+		return nil, errMandatory("side")
+	}
+	o := x.engine.newOrder(symbol)
+	o.side = side
+	o.orderType = string(orderType)
+	o.timeInForce = timeInForceGTC
+	o.clientOrderID = p.get(name("newClientOrderId"))
+	if o.clientOrderID == "" {
+		o.clientOrderID = fmt.Sprintf("paper_%d", o.id)
+	}
+	limit, stop := false, false
+	switch orderType {
+	case binance.OrderTypeLimit:
+		limit = true
+	case binance.OrderTypeMarket:
+		o.market = true
+	case binance.OrderTypeLimitMaker:
+		limit, o.postOnly = true, true
+	case binance.OrderTypeStopLoss, binance.OrderTypeTakeProfit:
+		o.market, stop = true, true
+	case binance.OrderTypeStopLossLimit, binance.OrderTypeTakeProfitLimit:
+		limit, stop = true, true
+	default:
+		return nil, apiError(-1116, "Invalid orderType.")
+	}
+	if limit && orderType != binance.OrderTypeLimitMaker {
+		o.timeInForce = p.get(name("timeInForce"))
+		if o.timeInForce != timeInForceGTC && o.timeInForce != timeInForceIOC && o.timeInForce != timeInForceFOK {
+			return nil, errMandatory(name("timeInForce"))
+		}
+	}
+	if o.price, err = p.decimal(name("price")); err != nil {
+		return nil, err
+	}
+	if limit && o.price == nil {
+		return nil, errMandatory(name("price"))
+	}
+	if !limit && o.price != nil {
+		return nil, apiError(-1106, fmt.Sprintf("Parameter '%s' sent when not required.", name("price")))
+	}
+	if o.stopPrice, err = p.decimal(name("stopPrice")); err != nil {
+		return nil, err
+	}
+	if stop {
+		if o.stopPrice == nil {
+			return nil, errMandatory(name("stopPrice"))
+		}
+		o.stop = true
+		takeProfit := orderType == binance.OrderTypeTakeProfit || orderType == binance.OrderTypeTakeProfitLimit
+		o.stopAbove = (side == sideBuy) != takeProfit
+	}
+	if o.quantity, err = p.decimal("quantity"); err != nil {
+		return nil, err
+	}
+	if o.quoteQuantity, err = p.decimal("quoteOrderQty"); err != nil {
+		return nil, err
+	}
+	if o.quantity == nil && (o.quoteQuantity == nil || orderType != binance.OrderTypeMarket) {
+		return nil, errMandatory("quantity")
+	}
+	if o.quantity != nil {
+		o.quoteQuantity = nil
+	}
+
+	op := &common.OrderParams{
+		Buy:           side == sideBuy,
+		Market:        o.market,
+		Price:         optional(p, name("price")),
+		StopPrice:     optional(p, name("stopPrice")),
+		Quantity:      optional(p, "quantity"),
+		QuoteQuantity: optional(p, "quoteOrderQty"),
+	}
+	if mark := x.engine.book(symbol).mark(); mark != nil {
+		op.ReferencePrice = common.FormatDecimal(mark)
+	}
+	if o.quantity == nil {
+		op.Quantity = nil
+	} else {
+		op.QuoteQuantity = nil
+	}
+	if err := s.filters.Validate(op); err != nil {
+		return nil, errFilter(err)
+	}
+	if lot := s.filters.MarketLotSize; lot != nil && positiveRat(lot.StepSize) != nil {
+		o.step = positiveRat(lot.StepSize)
+	} else if lot := s.filters.LotSize; lot != nil {
+		o.step = positiveRat(lot.StepSize)
+	}
+	for _, open := range x.engine.openOrders(symbol) {
+		if open.clientOrderID == o.clientOrderID {
+			return nil, apiError(-2010, "Duplicate order sent.")
+		}
+	}
+	return o, nil
+}
+
+func positiveRat(value string) *big.Rat {
+	r, ok := new(big.Rat).SetString(value)
+	if !ok || r.Sign() <= 0 {
+		return nil
+	}
+	return r
+}
+
+func spotError(err error) error {
+	switch err {
+	case errWouldTake:
+		return apiError(-2010, "Order would immediately match and take.")
+	case errWouldTrigger:
+		return apiError(-2010, "Stop price would trigger immediately.")
+	case errNoLiquidity:
+		return apiError(-2010, "No market price for the symbol.")
+	}
+	return err
+}
+
+func (x *SpotExchange) createOrder(p params) (interface{}, error) {
+	o, err := x.newOrder(p, binance.OrderType(p.get("type")), nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := x.engine.place(o); err != nil {
+		return nil, spotError(err)
+	}
+	res := &binance.CreateOrderResponse{
+		Symbol:                   o.symbol,
+		OrderID:                  o.id,
+		ClientOrderID:            o.clientOrderID,
+		TransactTime:             o.time,
+		Price:                    common.FormatDecimal(o.price),
+		OrigQuantity:             common.FormatDecimal(o.quantity),
+		ExecutedQuantity:         common.FormatDecimal(o.executed),
+		CummulativeQuoteQuantity: common.FormatDecimal(o.quote),
+		Status:                   binance.OrderStatusType(o.status),
+		TimeInForce:              binance.TimeInForceType(o.timeInForce),
+		Type:                     binance.OrderType(o.orderType),
+		Side:                     binance.SideType(o.side),
+		WorkingTime:              o.time,
+		Fills:                    make([]*binance.Fill, 0),
+	}
+	for _, t := range x.engine.trades {
+		if t.order == o {
+			res.Fills = append(res.Fills, &binance.Fill{
+				Price:           common.FormatDecimal(t.price),
+				Quantity:        common.FormatDecimal(t.quantity),
+				Commission:      common.FormatDecimal(t.commission),
+				CommissionAsset: t.commissionAsset,
+				TradeID:         t.id,
+			})
+		}
+	}
+	return res, nil
+}
+
+func (x *SpotExchange) testOrder(p params) (interface{}, error) {
+	o, err := x.newOrder(p, binance.OrderType(p.get("type")), nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := x.engine.check(o); err != nil {
+		return nil, spotError(err)
+	}
+	return struct{}{}, nil
+}
+
+func (x *SpotExchange) findOrder(p params) (*order, error) {
+	symbol, _, err := x.symbol(p)
+	if err != nil {
+		return nil, err
+	}
+	id, err := p.int64("orderId")
+	if err != nil {
+		return nil, err
+	}
+	clientOrderID := p.get("origClientOrderId")
+	if id == 0 && clientOrderID == "" {
+		return nil, errMandatory("orderId")
+	}
+	return x.engine.findOrder(symbol, id, clientOrderID), nil
+}
+
+func (x *SpotExchange) getOrder(p params) (interface{}, error) {
+	o, err := x.findOrder(p)
+	if err != nil {
+		return nil, err
+	}
+	if o == nil {
+		return nil, apiError(-2013, "Order does not exist.")
+	}
+	return spotOrder(o), nil
+}
+
+func (x *SpotExchange) cancelOrder(p params) (interface{}, error) {
+	o, err := x.findOrder(p)
+	if err != nil {
+		return nil, err
+	}
+	if o == nil || o.done() {
+		return nil, apiError(-2011, "Unknown order sent.")
+	}
+	o.cancelClientOrderID = p.get("newClientOrderId")
+	if o.cancelClientOrderID == "" {
+		o.cancelClientOrderID = fmt.Sprintf("paper_cancel_%d", o.id)
+	}
+	x.engine.cancel(o)
+	return spotCancelOrderResponse(o), nil
+}
+
+func (x *SpotExchange) listOpenOrders(p params) (interface{}, error) {
+	symbol := p.get("symbol")
+	if _, ok := x.symbols[symbol]; symbol != "" && !ok {
+		return nil, apiError(-1121, "Invalid symbol.")
+	}
+	res := make([]*binance.Order, 0)
+	for _, o := range x.engine.openOrders(symbol) {
+		res = append(res, spotOrder(o))
+	}
+	return res, nil
+}
+
+func (x *SpotExchange) cancelOpenOrders(p params) (interface{}, error) {
+	symbol, _, err := x.symbol(p)
+	if err != nil {
+		return nil, err
+	}
+	orders := x.engine.openOrders(symbol)
+	if len(orders) == 0 {
+		return nil, apiError(-2011, "Unknown order sent.")
+	}
+	res := make([]interface{}, 0)
+	lists := make(map[int64]bool)
+	for _, o := range orders {
+		if o.done() {
+			continue
+		}
+		o.cancelClientOrderID = fmt.Sprintf("paper_cancel_%d", o.id)
+		if l, ok := x.engine.lists[o.listID]; ok {
+			if !lists[l.id] {
+				lists[l.id] = true
+				for _, leg := range l.orders {
+					leg.cancelClientOrderID = fmt.Sprintf("paper_cancel_%d", leg.id)
+				}
+				x.engine.cancel(o)
+				res = append(res, spotListResponse(l))
+			}
+			continue
+		}
+		x.engine.cancel(o)
+		res = append(res, spotCancelOrderResponse(o))
+	}
+	return res, nil
+}
+
+func (x *SpotExchange) listOrders(p params) (interface{}, error) {
+	symbol, _, err := x.symbol(p)
+	if err != nil {
+		return nil, err
+	}
+	from, err := p.int64("orderId")
+	if err != nil {
+		return nil, err
+	}
+	limit, err := p.int64("limit")
+	if err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = 500
+	}
+	res := make([]*binance.Order, 0)
+	for _, o := range x.engine.orders {
+		if o.symbol == symbol && o.id >= from {
+			res = append(res, spotOrder(o))
+		}
+	}
+	if int64(len(res)) > limit {
+		if from > 0 {
+			res = res[:limit]
+		} else {
+			res = res[int64(len(res))-limit:]
+		}
+	}
+	return res, nil
+}
+
+func (x *SpotExchange) createOCO(p params) (interface{}, error) {
+	side := p.get("side")
+	limit, err := x.newOrder(p, binance.OrderTypeLimitMaker, map[string]string{"newClientOrderId": "limitClientOrderId"})
+	if err != nil {
+		return nil, err
+	}
+	limit.stopPrice = nil
+	stopType := binance.OrderTypeStopLoss
+	names := map[string]string{
+		"newClientOrderId": "stopClientOrderId",
+		"price":            "stopLimitPrice",
+		"timeInForce":      "stopLimitTimeInForce",
+	}
+	if p.get("stopLimitPrice") != "" {
+		stopType = binance.OrderTypeStopLossLimit
+	}
+	stop, err := x.newOrder(p, stopType, names)
+	if err != nil {
+		return nil, err
+	}
+	if (side == sideSell && limit.price.Cmp(stop.stopPrice) <= 0) || (side == sideBuy && limit.price.Cmp(stop.stopPrice) >= 0) {
+		return nil, apiError(-1106, "The relationship of the prices for the orders is not correct.")
+	}
+	return x.placeOCO(p, stop, limit)
+}
+
+func (x *SpotExchange) createOrderListOCO(p params) (interface{}, error) {
+	leg := func(prefix string) (*order, error) {
+		orderType := binance.OrderType(p.get(prefix + "Type"))
+		if orderType == "" {
+			return nil, errMandatory(prefix + "Type")
+		}
+		if orderType == binance.OrderTypeLimit || orderType == binance.OrderTypeMarket {
+			return nil, apiError(-1116, "Invalid orderType.")
+		}
+		o, err := x.newOrder(p, orderType, map[string]string{
+			"newClientOrderId": prefix + "ClientOrderId",
+			"price":            prefix + "Price",
+			"stopPrice":        prefix + "StopPrice",
+			"timeInForce":      prefix + "TimeInForce",
+		})
+		if err != nil {
+			return nil, err
+		}
+		if !o.stop {
+			o.stopPrice = nil
+		}
+		return o, nil
+	}
+	above, err := leg("above")
+	if err != nil {
+		return nil, err
+	}
+	below, err := leg("below")
+	if err != nil {
+		return nil, err
+	}
+	trigger := func(o *order) *big.Rat {
+		if o.stop {
+			return o.stopPrice
+		}
+		return o.price
+	}
+	if trigger(above).Cmp(trigger(below)) <= 0 {
+		return nil, apiError(-1106, "The relationship of the prices for the orders is not correct.")
+	}
+	return x.placeOCO(p, below, above)
+}
+
+// placeOCO place the two orders of an OCO as one order list
+func (x *SpotExchange) placeOCO(p params, orders ...*order) (interface{}, error) {
+	x.engine.nextListID++
+	l := &orderList{
+		id:            x.engine.nextListID,
+		clientOrderID: p.get("listClientOrderId"),
+		symbol:        orders[0].symbol,
+		orders:        orders,
+		time:          x.millis(),
+	}
+	if l.clientOrderID == "" {
+		l.clientOrderID = fmt.Sprintf("paper_list_%d", l.id)
+	}
+	for _, o := range orders {
+		o.listID = l.id
+	}
+	if err := x.engine.placeList(l); err != nil {
+		return nil, spotError(err)
+	}
+	res := &binance.CreateOCOResponse{
+		OrderListID:       l.id,
+		ContingencyType:   "OCO",
+		ListStatusType:    "EXEC_STARTED",
+		ListOrderStatus:   "EXECUTING",
+		ListClientOrderID: l.clientOrderID,
+		TransactionTime:   l.time,
+		Symbol:            l.symbol,
+	}
+	for _, o := range l.orders {
+		res.Orders = append(res.Orders, &binance.OCOOrder{Symbol: o.symbol, OrderID: o.id, ClientOrderID: o.clientOrderID})
+		res.OrderReports = append(res.OrderReports, spotOrderReport(o))
+	}
+	return res, nil
+}
+
+func (x *SpotExchange) cancelOCO(p params) (interface{}, error) {
+	symbol, _, err := x.symbol(p)
+	if err != nil {
+		return nil, err
+	}
+	id, err := p.int64("orderListId")
+	if err != nil {
+		return nil, err
+	}
+	clientOrderID := p.get("listClientOrderId")
+	if id == 0 && clientOrderID == "" {
+		return nil, errMandatory("orderListId")
+	}
+	for _, l := range x.engine.lists {
+		if l.symbol == symbol && (l.id == id || (id == 0 && l.clientOrderID == clientOrderID)) {
+			for _, o := range l.orders {
+				o.cancelClientOrderID = fmt.Sprintf("paper_cancel_%d", o.id)
+			}
+			x.engine.cancel(l.orders[0])
+			return spotListResponse(l), nil
+		}
+	}
+	return nil, apiError(-2011, "Unknown order list sent.")
+}
+
+func (x *SpotExchange) getAccount(p params) (interface{}, error) {
+	res := &binance.Account{
+		MakerCommission: bps(x.maker),
+		TakerCommission: bps(x.taker),
+		CanTrade:        true,
+		CanWithdraw:     true,
+		CanDeposit:      true,
+		UpdateTime:      uint64(x.millis()),
+		AccountType:     "SPOT",
+		Balances:        make([]binance.Balance, 0, len(x.balances)),
+		Permissions:     []string{"SPOT"},
+		CommissionRates: binance.CommissionRates{
+			Maker:  common.FormatDecimal(x.maker),
+			Taker:  common.FormatDecimal(x.taker),
+			Buyer:  "0",
+			Seller: "0",
+		},
+	}
+	for _, asset := range x.assets() {
+		b := x.balances[asset]
+		res.Balances = append(res.Balances, binance.Balance{
+			Asset:  asset,
+			Free:   common.FormatDecimal(b.free),
+			Locked: common.FormatDecimal(b.locked),
+		})
+	}
+	return res, nil
+}
+
+// bps return the rate in basis points, the unit of the commissions of the account
+func bps(rate *big.Rat) int64 {
+	r := new(big.Rat).Mul(rate, big.NewRat(10000, 1))
+	return new(big.Int).Quo(r.Num(), r.Denom()).Int64()
+}
+
+func (x *SpotExchange) assets() []string {
+	assets := make([]string, 0, len(x.balances))
+	for asset := range x.balances {
+		assets = append(assets, asset)
+	}
+	sort.Strings(assets)
+	return assets
+}
+
+func (x *SpotExchange) listTrades(p params) (interface{}, error) {
+	symbol, _, err := x.symbol(p)
+	if err != nil {
+		return nil, err
+	}
+	orderID, err := p.int64("orderId")
+	if err != nil {
+		return nil, err
+	}
+	res := make([]*binance.TradeV3, 0)
+	for _, t := range x.engine.trades {
+		if t.order.symbol != symbol || (orderID != 0 && t.order.id != orderID) {
+			continue
+		}
+		res = append(res, &binance.TradeV3{
+			ID:              t.id,
+			Symbol:          symbol,
+			OrderID:         t.order.id,
+			Price:           common.FormatDecimal(t.price),
+			Quantity:        common.FormatDecimal(t.quantity),
+			QuoteQuantity:   common.FormatDecimal(t.quote),
+			Commission:      common.FormatDecimal(t.commission),
+			CommissionAsset: t.commissionAsset,
+			Time:            t.time,
+			IsBuyer:         t.order.side == sideBuy,
+			IsMaker:         t.maker,
+			IsBestMatch:     true,
+		})
+	}
+	return res, nil
+}
+
+// cost return the asset an order spends and the amount of its remaining quantity,
+// market buy orders cost what they would trade for against the book
+func (x *SpotExchange) cost(o *order) (string, *big.Rat) {
+	s := x.symbols[o.symbol]
+	b := x.engine.book(o.symbol)
+	price := o.price
+	if price == nil && o.stop {
+		price = o.stopPrice
+	}
+	if price == nil {
+		price = b.mark()
+		if levels := b.opposite(o.side); len(levels) > 0 {
+			price = levels[0].price
+		}
+	}
+	if price == nil {
+		price = new(big.Rat)
+	}
+	if o.side == sideSell {
+		if o.quantity == nil {
+			return s.base, average(o.quoteQuantity, price)
+		}
+		return s.base, o.remaining()
+	}
+	if o.quantity == nil {
+		return s.quote, new(big.Rat).Set(o.quoteQuantity)
+	}
+	if o.market && !o.stop {
+		return s.quote, x.engine.walk(o)
+	}
+	return s.quote, new(big.Rat).Mul(o.remaining(), price)
+}
+
+func (x *SpotExchange) reserve(o *order) error {
+	asset, amount := x.cost(o)
+	if l, ok := x.engine.lists[o.listID]; ok {
+		// the orders of a list share the funds, the list locks the largest cost of its orders
+		for _, sibling := range l.orders {
+			amount.Sub(amount, sibling.reserved)
+		}
+		if amount.Sign() < 0 {
+			amount.SetInt64(0)
+		}
+	}
+	b := x.balance(asset)
+	if b.free.Cmp(amount) < 0 {
+		return apiError(-2010, "Account has insufficient balance for requested action.")
+	}
+	if o.market && !o.stop {
+		return nil
+	}
+	b.free.Sub(b.free, amount)
+	b.locked.Add(b.locked, amount)
+	o.reserved = amount
+	x.changed[asset] = true
+	return nil
+}
+
+func (x *SpotExchange) prepare(o *order) bool {
+	if !o.market {
+		return true
+	}
+	asset, amount := x.cost(o)
+	available := new(big.Rat).Add(x.balance(asset).free, o.reserved)
+	return available.Cmp(amount) >= 0
+}
+
+// pay take amount of asset from the funds locked by the order first
+func (x *SpotExchange) pay(o *order, asset string, amount *big.Rat) {
+	b := x.balance(asset)
+	locked := common.MinRat(o.reserved, amount)
+	o.reserved.Sub(o.reserved, locked)
+	b.locked.Sub(b.locked, locked)
+	b.free.Sub(b.free, new(big.Rat).Sub(amount, locked))
+	x.changed[asset] = true
+}
+
+func (x *SpotExchange) settle(t *trade) {
+	s := x.symbols[t.order.symbol]
+	rate := x.taker
+	if t.maker {
+		rate = x.maker
+	}
+	received, asset := t.quote, s.quote
+	if t.order.side == sideBuy {
+		x.pay(t.order, s.quote, t.quote)
+		received, asset = t.quantity, s.base
+	} else {
+		x.pay(t.order, s.base, t.quantity)
+	}
+	t.commission = new(big.Rat).Mul(received, rate)
+	t.commissionAsset = asset
+	b := x.balance(asset)
+	b.free.Add(b.free, received)
+	b.free.Sub(b.free, t.commission)
+	x.changed[asset] = true
+}
+
+func (x *SpotExchange) release(o *order) {
+	if o.reserved.Sign() == 0 {
+		return
+	}
+	asset, _ := x.cost(o)
+	b := x.balance(asset)
+	b.locked.Sub(b.locked, o.reserved)
+	b.free.Add(b.free, o.reserved)
+	o.reserved = new(big.Rat)
+	x.changed[asset] = true
+}
+
+func (x *SpotExchange) report(o *order, executionType string, t *trade) {
+	u := &binance.WsOrderUpdate{
+		Symbol:              o.symbol,
+		ClientOrderID:       o.clientOrderID,
+		Side:                binance.SideType(o.side),
+		Type:                binance.OrderType(o.orderType),
+		TimeInForce:         binance.TimeInForceType(o.timeInForce),
+		Quantity:            common.FormatDecimal(o.quantity),
+		Price:               common.FormatDecimal(o.price),
+		StopPrice:           common.FormatDecimal(o.stopPrice),
+		IcebergQuantity:     "0",
+		OrderListID:         o.listID,
+		ExecutionType:       binance.ExecutionType(executionType),
+		Status:              binance.OrderStatusType(o.status),
+		RejectReason:        "NONE",
+		ID:                  o.id,
+		LastFilledQuantity:  "0",
+		FilledQuantity:      common.FormatDecimal(o.executed),
+		LastFilledPrice:     "0",
+		Commission:          "0",
+		TransactionTime:     o.updateTime,
+		TradeID:             -1,
+		IsWorking:           !o.stop,
+		CreateTime:          o.time,
+		FilledQuoteQuantity: common.FormatDecimal(o.quote),
+		LastQuoteQuantity:   "0",
+		QuoteOrderQuantity:  common.FormatDecimal(o.quoteQuantity),
+		WorkingTime:         o.time,
+	}
+	if executionType == executionCanceled && o.cancelClientOrderID != "" {
+		u.ClientOrderID = o.cancelClientOrderID
+		u.OrigClientOrderID = o.clientOrderID
+	}
+	if t != nil {
+		u.LastFilledQuantity = common.FormatDecimal(t.quantity)
+		u.LastFilledPrice = common.FormatDecimal(t.price)
+		u.LastQuoteQuantity = common.FormatDecimal(t.quote)
+		u.Commission = common.FormatDecimal(t.commission)
+		u.CommissionAsset = t.commissionAsset
+		u.TradeID = t.id
+		u.IsMaker = t.maker
+		u.TransactionTime = t.time
+	}
+	x.emit(&binance.WsUserDataEvent{
+		Event:       binance.UserDataEventTypeExecutionReport,
+		Time:        x.millis(),
+		OrderUpdate: u,
+	})
+}
+
+func (x *SpotExchange) reportList(l *orderList) {
+	u := &binance.WsOCOUpdate{
+		Symbol:            l.symbol,
+		OrderListID:       l.id,
+		ContingencyType:   "OCO",
+		ListStatusType:    "EXEC_STARTED",
+		ListOrderStatus:   "EXECUTING",
+		RejectReason:      "NONE",
+		ListClientOrderID: l.clientOrderID,
+		TransactionTime:   x.millis(),
+	}
+	if l.done() {
+		u.ListStatusType, u.ListOrderStatus = "ALL_DONE", "ALL_DONE"
+	}
+	for _, o := range l.orders {
+		u.Orders = append(u.Orders, binance.WsOCOOrder{Symbol: o.symbol, OrderID: o.id, ClientOrderID: o.clientOrderID})
+	}
+	x.emit(&binance.WsUserDataEvent{
+		Event:     binance.UserDataEventTypeListStatus,
+		Time:      u.TransactionTime,
+		OCOUpdate: u,
+	})
+}
+
+// flushAccount emit the balances changed by the update
+func (x *SpotExchange) flushAccount() {
+	if len(x.changed) == 0 {
+		return
+	}
+	u := &binance.WsAccountUpdate{LastUpdateTime: x.millis()}
+	for _, asset := range x.assets() {
+		if x.changed[asset] {
+			b := x.balances[asset]
+			u.Balances = append(u.Balances, binance.WsAccountBalance{
+				Asset:  asset,
+				Free:   common.FormatDecimal(b.free),
+				Locked: common.FormatDecimal(b.locked),
+			})
+		}
+	}
+	x.changed = make(map[string]bool)
+	x.emit(&binance.WsUserDataEvent{
+		Event:         binance.UserDataEventTypeOutboundAccountPosition,
+		Time:          u.LastUpdateTime,
+		AccountUpdate: u,
+	})
+}
+
+func spotOrder(o *order) *binance.Order {
+	return &binance.Order{
+		Symbol:                   o.symbol,
+		OrderID:                  o.id,
+		ClientOrderID:            o.clientOrderID,
+		Price:                    common.FormatDecimal(o.price),
+		OrigQuantity:             common.FormatDecimal(o.quantity),
+		ExecutedQuantity:         common.FormatDecimal(o.executed),
+		CummulativeQuoteQuantity: common.FormatDecimal(o.quote),
+		Status:                   binance.OrderStatusType(o.status),
+		TimeInForce:              binance.TimeInForceType(o.timeInForce),
+		Type:                     binance.OrderType(o.orderType),
+		Side:                     binance.SideType(o.side),
+		StopPrice:                common.FormatDecimal(o.stopPrice),
+		IcebergQuantity:          "0",
+		Time:                     o.time,
+		UpdateTime:               o.updateTime,
+		IsWorking:                !o.stop,
+		WorkingTime:              o.time,
+	}
+}
+
+func spotCancelOrderResponse(o *order) *binance.CancelOrderResponse {
+	return &binance.CancelOrderResponse{
+		Symbol:                   o.symbol,
+		OrigClientOrderID:        o.clientOrderID,
+		OrderID:                  o.id,
+		OrderListID:              o.listID,
+		ClientOrderID:            o.cancelClientOrderID,
+		TransactTime:             o.updateTime,
+		Price:                    common.FormatDecimal(o.price),
+		OrigQuantity:             common.FormatDecimal(o.quantity),
+		ExecutedQuantity:         common.FormatDecimal(o.executed),
+		CummulativeQuoteQuantity: common.FormatDecimal(o.quote),
+		Status:                   binance.OrderStatusType(o.status),
+		TimeInForce:              binance.TimeInForceType(o.timeInForce),
+		Type:                     binance.OrderType(o.orderType),
+		Side:                     binance.SideType(o.side),
+	}
+}
+
+func spotOrderReport(o *order) *binance.OCOOrderReport {
+	return &binance.OCOOrderReport{
+		Symbol:                   o.symbol,
+		OrderID:                  o.id,
+		OrderListID:              o.listID,
+		ClientOrderID:            o.clientOrderID,
+		TransactionTime:          o.updateTime,
+		Price:                    common.FormatDecimal(o.price),
+		OrigQuantity:             common.FormatDecimal(o.quantity),
+		ExecutedQuantity:         common.FormatDecimal(o.executed),
+		CummulativeQuoteQuantity: common.FormatDecimal(o.quote),
+		Status:                   binance.OrderStatusType(o.status),
+		TimeInForce:              binance.TimeInForceType(o.timeInForce),
+		Type:                     binance.OrderType(o.orderType),
+		Side:                     binance.SideType(o.side),
+		StopPrice:                common.FormatDecimal(o.stopPrice),
+		IcebergQuantity:          "0",
+	}
+}
+
+func spotListResponse(l *orderList) *binance.CancelOCOResponse {
+	res := &binance.CancelOCOResponse{
+		OrderListID:       l.id,
+		ContingencyType:   "OCO",
+		ListStatusType:    "ALL_DONE",
+		ListOrderStatus:   "ALL_DONE",
+		ListClientOrderID: l.clientOrderID,
+		Symbol:            l.symbol,
+	}
+	for _, o := range l.orders {
+		res.TransactionTime = o.updateTime
+		res.Orders = append(res.Orders, &binance.OCOOrder{Symbol: o.symbol, OrderID: o.id, ClientOrderID: o.clientOrderID})
+		report := spotOrderReport(o)
+		report.OrigClientOrderID = o.clientOrderID
+		report.ClientOrderID = o.cancelClientOrderID
+		res.OrderReports = append(res.OrderReports, report)
+	}
+	return res
+}
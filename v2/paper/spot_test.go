@@ -0,0 +1,299 @@
+package paper
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/Zamzam-Technology/go-binance/v2"
+	"github.com/Zamzam-Technology/go-binance/v2/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const spotExchangeInfo = `{
+	"symbols": [{
+		"symbol": "BTCUSDT",
+		"status": "TRADING",
+		"baseAsset": "BTC",
+		"quoteAsset": "USDT",
+		"filters": [
+			{"filterType": "PRICE_FILTER", "minPrice": "0.01", "maxPrice": "1000000", "tickSize": "0.01"},
+			{"filterType": "LOT_SIZE", "minQty": "0.001", "maxQty": "100", "stepSize": "0.001"},
+			{"filterType": "MIN_NOTIONAL", "minNotional": "10", "applyToMarket": true}
+		]
+	}]
+}`
+
+func newTestSpotExchange(t *testing.T) (*SpotExchange, *binance.Client, *[]*binance.WsUserDataEvent) {
+	info := new(binance.ExchangeInfo)
+	require.NoError(t, json.Unmarshal([]byte(spotExchangeInfo), info))
+	x, err := NewSpotExchange(info, &Config{
+		Balances: map[string]string{"USDT": "10000", "BTC": "1"},
+		Now:      func() time.Time { return time.Unix(1600000000, 0) },
+	})
+	require.NoError(t, err)
+	events := new([]*binance.WsUserDataEvent)
+	x.SubscribeUserData(func(event *binance.WsUserDataEvent) {
+		*events = append(*events, event)
+	})
+	x.OnBook("BTCUSDT",
+		[]common.PriceLevel{{Price: "99", Quantity: "1"}, {Price: "98", Quantity: "2"}},
+		[]common.PriceLevel{{Price: "100", Quantity: "1"}, {Price: "101", Quantity: "2"}})
+	return x, x.Client(), events
+}
+
+func spotBalances(t *testing.T, c *binance.Client) map[string]binance.Balance {
+	account, err := c.NewGetAccountService().Do(context.Background())
+	require.NoError(t, err)
+	balances := make(map[string]binance.Balance)
+	for _, b := range account.Balances {
+		balances[b.Asset] = b
+	}
+	return balances
+}
+
+func eventTypes(events []*binance.WsUserDataEvent) []string {
+	var types []string
+	for _, e := range events {
+		switch {
+		case e.OrderUpdate != nil:
+			types = append(types, string(e.OrderUpdate.ExecutionType)+" "+string(e.OrderUpdate.Status))
+		case e.OCOUpdate != nil:
+			types = append(types, "list "+e.OCOUpdate.ListOrderStatus)
+		default:
+			types = append(types, string(e.Event))
+		}
+	}
+	return types
+}
+
+func TestSpotLimitOrder(t *testing.T) {
+	x, c, events := newTestSpotExchange(t)
+	ctx := context.Background()
+
+	res, err := c.NewCreateOrderService().Symbol("BTCUSDT").Side(binance.SideTypeBuy).
+		Type(binance.OrderTypeLimit).TimeInForce(binance.TimeInForceTypeGTC).
+		Quantity("0.5").Price("99.5").NewClientOrderID("a").Do(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, binance.OrderStatusTypeNew, res.Status)
+	assert.Empty(t, res.Fills)
+	balances := spotBalances(t, c)
+	assert.Equal(t, binance.Balance{Asset: "USDT", Free: "9950.25", Locked: "49.75"}, balances["USDT"])
+
+	// a trade through the price fills the order up to its quantity
+	require.NoError(t, x.OnTrade("BTCUSDT", "99", "0.2"))
+	// a trade at the price does not
+	require.NoError(t, x.OnTrade("BTCUSDT", "99.5", "1"))
+	order, err := c.NewGetOrderService().Symbol("BTCUSDT").OrigClientOrderID("a").Do(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, binance.OrderStatusTypePartiallyFilled, order.Status)
+	assert.Equal(t, "0.2", order.ExecutedQuantity)
+
+	// the book crossing the order fills the rest
+	x.OnBook("BTCUSDT", []common.PriceLevel{{Price: "99", Quantity: "1"}}, []common.PriceLevel{{Price: "99.4", Quantity: "1"}})
+	order, err = c.NewGetOrderService().Symbol("BTCUSDT").OrderID(res.OrderID).Do(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, binance.OrderStatusTypeFilled, order.Status)
+	assert.Equal(t, "0.5", order.ExecutedQuantity)
+	assert.Equal(t, "49.75", order.CummulativeQuoteQuantity)
+
+	balances = spotBalances(t, c)
+	assert.Equal(t, binance.Balance{Asset: "USDT", Free: "9950.25", Locked: "0"}, balances["USDT"])
+	assert.Equal(t, binance.Balance{Asset: "BTC", Free: "1.4995", Locked: "0"}, balances["BTC"])
+	assert.Equal(t, []string{
+		"NEW NEW", string(binance.UserDataEventTypeOutboundAccountPosition),
+		"TRADE PARTIALLY_FILLED", string(binance.UserDataEventTypeOutboundAccountPosition),
+		"TRADE FILLED", string(binance.UserDataEventTypeOutboundAccountPosition),
+	}, eventTypes(*events))
+	trade := (*events)[2].OrderUpdate
+	assert.Equal(t, "0.2", trade.LastFilledQuantity)
+	assert.Equal(t, "99.5", trade.LastFilledPrice)
+	assert.Equal(t, "0.0002", trade.Commission)
+	assert.Equal(t, "BTC", trade.CommissionAsset)
+	assert.True(t, trade.IsMaker)
+
+	trades, err := c.NewListTradesService().Symbol("BTCUSDT").Do(ctx)
+	require.NoError(t, err)
+	assert.Len(t, trades, 2)
+}
+
+func TestSpotMarketOrder(t *testing.T) {
+	_, c, events := newTestSpotExchange(t)
+	ctx := context.Background()
+
+	res, err := c.NewCreateOrderService().Symbol("BTCUSDT").Side(binance.SideTypeBuy).
+		Type(binance.OrderTypeMarket).QuoteOrderQty("150").Do(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, binance.OrderStatusTypeFilled, res.Status)
+	assert.Equal(t, "1.495", res.ExecutedQuantity)
+	assert.Equal(t, "149.995", res.CummulativeQuoteQuantity)
+	require.Len(t, res.Fills, 2)
+	assert.Equal(t, &binance.Fill{Price: "100", Quantity: "1", Commission: "0.001", CommissionAsset: "BTC", TradeID: 1}, res.Fills[0])
+	assert.Equal(t, &binance.Fill{Price: "101", Quantity: "0.495", Commission: "0.000495", CommissionAsset: "BTC", TradeID: 2}, res.Fills[1])
+
+	balances := spotBalances(t, c)
+	assert.Equal(t, "9850.005", balances["USDT"].Free)
+	assert.Equal(t, "2.493505", balances["BTC"].Free)
+
+	res, err = c.NewCreateOrderService().Symbol("BTCUSDT").Side(binance.SideTypeSell).
+		Type(binance.OrderTypeMarket).Quantity("1.2").Do(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "1.2", res.ExecutedQuantity)
+	assert.Equal(t, []*binance.Fill{
+		{Price: "99", Quantity: "1", Commission: "0.099", CommissionAsset: "USDT", TradeID: 3},
+		{Price: "98", Quantity: "0.2", Commission: "0.0196", CommissionAsset: "USDT", TradeID: 4},
+	}, res.Fills)
+
+	_, err = c.NewCreateOrderService().Symbol("BTCUSDT").Side(binance.SideTypeSell).
+		Type(binance.OrderTypeMarket).Quantity("5").Do(ctx)
+	assert.Equal(t, &common.APIError{Code: -2010, Message: "Account has insufficient balance for requested action."}, err)
+	assert.Equal(t, binance.UserDataEventTypeExecutionReport, (*events)[0].Event)
+}
+
+func TestSpotMarketOrderBookExhausted(t *testing.T) {
+	x, c, _ := newTestSpotExchange(t)
+	ctx := context.Background()
+
+	// the cost is walked through the book, not taken at its best price
+	x.OnBook("BTCUSDT", nil, []common.PriceLevel{{Price: "100", Quantity: "1"}, {Price: "20000", Quantity: "1"}})
+	_, err := c.NewCreateOrderService().Symbol("BTCUSDT").Side(binance.SideTypeBuy).
+		Type(binance.OrderTypeMarket).Quantity("2").Do(ctx)
+	assert.Equal(t, &common.APIError{Code: -2010, Message: "Account has insufficient balance for requested action."}, err)
+
+	x.OnBook("BTCUSDT", nil, []common.PriceLevel{{Price: "100", Quantity: "1"}})
+	require.NoError(t, x.OnTrade("BTCUSDT", "100", "0.1"))
+	res, err := c.NewCreateOrderService().Symbol("BTCUSDT").Side(binance.SideTypeBuy).
+		Type(binance.OrderTypeMarket).Quantity("2").Do(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, binance.OrderStatusTypeExpired, res.Status)
+	assert.Equal(t, "1", res.ExecutedQuantity)
+	assert.Equal(t, "100", res.CummulativeQuoteQuantity)
+	assert.Equal(t, binance.Balance{Asset: "USDT", Free: "9900", Locked: "0"}, spotBalances(t, c)["USDT"])
+}
+
+func TestSpotOrderErrors(t *testing.T) {
+	_, c, _ := newTestSpotExchange(t)
+	ctx := context.Background()
+	limit := func(side binance.SideType, quantity, price string) *binance.CreateOrderService {
+		return c.NewCreateOrderService().Symbol("BTCUSDT").Side(side).Type(binance.OrderTypeLimit).
+			TimeInForce(binance.TimeInForceTypeGTC).Quantity(quantity).Price(price)
+	}
+
+	_, err := limit(binance.SideTypeBuy, "1", "99.001").Do(ctx)
+	assert.Equal(t, &common.APIError{Code: -1013, Message: "Filter failure: PRICE_FILTER"}, err)
+	_, err = limit(binance.SideTypeBuy, "0.01", "99").Do(ctx)
+	assert.Equal(t, &common.APIError{Code: -1013, Message: "Filter failure: MIN_NOTIONAL"}, err)
+	_, err = c.NewCreateOrderService().Symbol("ETHUSDT").Side(binance.SideTypeBuy).Type(binance.OrderTypeMarket).
+		Quantity("1").Do(ctx)
+	assert.Equal(t, &common.APIError{Code: -1121, Message: "Invalid symbol."}, err)
+	_, err = c.NewCreateOrderService().Symbol("BTCUSDT").Side(binance.SideTypeSell).Type(binance.OrderTypeLimitMaker).
+		Quantity("0.5").Price("98").Do(ctx)
+	assert.Equal(t, &common.APIError{Code: -2010, Message: "Order would immediately match and take."}, err)
+	_, err = c.NewCreateOrderService().Symbol("BTCUSDT").Side(binance.SideTypeSell).Type(binance.OrderTypeStopLoss).
+		Quantity("0.5").StopPrice("100").Do(ctx)
+	assert.Equal(t, &common.APIError{Code: -2010, Message: "Stop price would trigger immediately."}, err)
+	_, err = limit(binance.SideTypeBuy, "200", "99").Do(ctx)
+	assert.Equal(t, &common.APIError{Code: -1013, Message: "Filter failure: LOT_SIZE"}, err)
+	_, err = limit(binance.SideTypeBuy, "50", "300").Do(ctx)
+	assert.Equal(t, &common.APIError{Code: -2010, Message: "Account has insufficient balance for requested action."}, err)
+
+	_, err = c.NewCancelOrderService().Symbol("BTCUSDT").OrderID(42).Do(ctx)
+	assert.Equal(t, &common.APIError{Code: -2011, Message: "Unknown order sent."}, err)
+	_, err = c.NewGetOrderService().Symbol("BTCUSDT").OrderID(42).Do(ctx)
+	assert.Equal(t, &common.APIError{Code: -2013, Message: "Order does not exist."}, err)
+
+	res, err := limit(binance.SideTypeBuy, "1", "90").NewClientOrderID("a").Do(ctx)
+	require.NoError(t, err)
+	_, err = limit(binance.SideTypeBuy, "1", "90").NewClientOrderID("a").Do(ctx)
+	assert.Equal(t, &common.APIError{Code: -2010, Message: "Duplicate order sent."}, err)
+	canceled, err := c.NewCancelOrderService().Symbol("BTCUSDT").OrderID(res.OrderID).NewClientOrderID("b").Do(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, binance.OrderStatusTypeCanceled, canceled.Status)
+	assert.Equal(t, "a", canceled.OrigClientOrderID)
+	assert.Equal(t, "b", canceled.ClientOrderID)
+	assert.Equal(t, "10000", spotBalances(t, c)["USDT"].Free)
+
+	// orders are never sent to the exchange
+	_, err = c.NewCreateMarginOrderService().Symbol("BTCUSDT").Side(binance.SideTypeBuy).
+		Type(binance.OrderTypeMarket).Quantity("1").Do(ctx)
+	assert.Equal(t, int64(-1000), err.(*common.APIError).Code)
+}
+
+func TestSpotOCO(t *testing.T) {
+	x, c, events := newTestSpotExchange(t)
+	ctx := context.Background()
+	require.NoError(t, x.OnTrade("BTCUSDT", "99.5", "0.1"))
+
+	res, err := c.NewCreateOCOService().Symbol("BTCUSDT").Side(binance.SideTypeSell).Quantity("1").
+		Price("110").StopPrice("90").StopLimitPrice("89").StopLimitTimeInForce(binance.TimeInForceTypeGTC).
+		ListClientOrderID("list").Do(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "EXECUTING", res.ListOrderStatus)
+	require.Len(t, res.OrderReports, 2)
+	assert.Equal(t, binance.OrderTypeStopLossLimit, res.OrderReports[0].Type)
+	assert.Equal(t, binance.OrderTypeLimitMaker, res.OrderReports[1].Type)
+	assert.Equal(t, binance.Balance{Asset: "BTC", Free: "0", Locked: "1"}, spotBalances(t, c)["BTC"])
+
+	x.OnBook("BTCUSDT", []common.PriceLevel{{Price: "89.5", Quantity: "2"}}, []common.PriceLevel{{Price: "90", Quantity: "1"}})
+	require.NoError(t, x.OnTrade("BTCUSDT", "90", "0.1"))
+
+	stop, err := c.NewGetOrderService().Symbol("BTCUSDT").OrderID(res.OrderReports[0].OrderID).Do(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, binance.OrderStatusTypeFilled, stop.Status)
+	assert.Equal(t, "89.5", stop.CummulativeQuoteQuantity)
+	limit, err := c.NewGetOrderService().Symbol("BTCUSDT").OrderID(res.OrderReports[1].OrderID).Do(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, binance.OrderStatusTypeExpired, limit.Status)
+
+	balances := spotBalances(t, c)
+	assert.Equal(t, binance.Balance{Asset: "BTC", Free: "0", Locked: "0"}, balances["BTC"])
+	assert.Equal(t, "10089.4105", balances["USDT"].Free)
+	types := eventTypes(*events)
+	assert.Equal(t, []string{"NEW NEW", "NEW NEW", "list EXECUTING", string(binance.UserDataEventTypeOutboundAccountPosition)}, types[:4])
+	assert.Equal(t, []string{"EXPIRED EXPIRED", "TRADE FILLED", "list ALL_DONE", string(binance.UserDataEventTypeOutboundAccountPosition)}, types[4:])
+
+	_, err = c.NewCreateOCOService().Symbol("BTCUSDT").Side(binance.SideTypeBuy).Quantity("1").
+		Price("80").StopPrice("95").Do(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, binance.Balance{Asset: "USDT", Free: "9994.4105", Locked: "95"}, spotBalances(t, c)["USDT"])
+	canceled, err := c.NewCancelOCOService().Symbol("BTCUSDT").ListClientOrderID("paper_list_2").Do(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "ALL_DONE", canceled.ListOrderStatus)
+	assert.Equal(t, binance.OrderStatusTypeCanceled, canceled.OrderReports[0].Status)
+	assert.Equal(t, binance.OrderStatusTypeCanceled, canceled.OrderReports[1].Status)
+	assert.Equal(t, binance.Balance{Asset: "USDT", Free: "10089.4105", Locked: "0"}, spotBalances(t, c)["USDT"])
+}
+
+func TestSpotOrderListOCO(t *testing.T) {
+	x, c, _ := newTestSpotExchange(t)
+	ctx := context.Background()
+	require.NoError(t, x.OnTrade("BTCUSDT", "99.5", "0.1"))
+
+	res, err := c.NewCreateOrderListOCOService().Symbol("BTCUSDT").Side(binance.SideTypeSell).Quantity("1").
+		AboveType(binance.OrderTypeLimitMaker).AbovePrice("110").AboveClientOrderID("above").
+		BelowType(binance.OrderTypeStopLoss).BelowStopPrice("90").Do(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "EXECUTING", res.ListOrderStatus)
+	require.Len(t, res.OrderReports, 2)
+	assert.Equal(t, binance.OrderTypeStopLoss, res.OrderReports[0].Type)
+	assert.Equal(t, binance.OrderTypeLimitMaker, res.OrderReports[1].Type)
+	assert.Equal(t, "above", res.OrderReports[1].ClientOrderID)
+	assert.Equal(t, binance.Balance{Asset: "BTC", Free: "0", Locked: "1"}, spotBalances(t, c)["BTC"])
+
+	x.OnBook("BTCUSDT", []common.PriceLevel{{Price: "110", Quantity: "2"}}, []common.PriceLevel{{Price: "111", Quantity: "1"}})
+	require.NoError(t, x.OnTrade("BTCUSDT", "110", "0.1"))
+	stop, err := c.NewGetOrderService().Symbol("BTCUSDT").OrderID(res.OrderReports[0].OrderID).Do(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, binance.OrderStatusTypeExpired, stop.Status)
+
+	_, err = c.NewCreateOrderListOCOService().Symbol("BTCUSDT").Side(binance.SideTypeBuy).Quantity("1").
+		AboveType(binance.OrderTypeStopLoss).AboveStopPrice("90").
+		BelowType(binance.OrderTypeLimitMaker).BelowPrice("95").Do(ctx)
+	assert.Equal(t, int64(-1106), err.(*common.APIError).Code)
+	_, err = c.NewCreateOrderListOCOService().Symbol("BTCUSDT").Side(binance.SideTypeBuy).Quantity("1").
+		AboveType(binance.OrderTypeLimit).AbovePrice("120").AboveTimeInForce(binance.TimeInForceTypeGTC).
+		BelowType(binance.OrderTypeLimitMaker).BelowPrice("95").Do(ctx)
+	assert.Equal(t, int64(-1116), err.(*common.APIError).Code)
+}
@@ -0,0 +1,676 @@
+package risk
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Zamzam-Technology/go-binance/v2"
+	"github.com/Zamzam-Technology/go-binance/v2/common"
+)
+
+// MarginThreshold define a margin level threshold of a MarginMonitor
+type MarginThreshold string
+
+// MarginActionType define an action taken by a MarginMonitor to restore the margin level
+type MarginActionType string
+
+// Global enums
+const (
+	MarginThresholdAlert       MarginThreshold = "ALERT"
+	MarginThresholdMarginCall  MarginThreshold = "MARGIN_CALL"
+	MarginThresholdLiquidation MarginThreshold = "LIQUIDATION"
+
+	MarginActionTypeRepay MarginActionType = "REPAY"
+	MarginActionTypeTopUp MarginActionType = "TOP_UP"
+)
+
+// MarginConfig define the accounts, the thresholds and the actions of a MarginMonitor
+type MarginConfig struct {
+	// Quote is the asset the cross margin account is valued in, USDT by default. The price of an
+	// asset is the price index of the asset and Quote, e.g. BTCUSDT. The isolated margin accounts
+	// are valued in the quote asset of their symbol.
+	Quote string
+	// IsolatedSymbols are the symbols of the isolated margin accounts to monitor
+	IsolatedSymbols []string
+	// MarginCallLevel and LiquidationLevel are the margin levels of the margin call and of the
+	// liquidation, 1.3 and 1.1 by default
+	MarginCallLevel  string
+	LiquidationLevel string
+	// AlertLevel is an additional threshold, e.g. above the margin call level, disabled when empty
+	AlertLevel string
+	// OnThreshold is called when the margin level of an account falls to a threshold. It is called
+	// again once the level went back above it.
+	OnThreshold func(threshold MarginThreshold, status *MarginStatus)
+	// ActionLevel is the margin level at which the actions restore TargetLevel, the margin call
+	// level by default. AutoRepay repays the debts with the free balance of the same assets, then
+	// AutoTopUp transfers the missing value from the spot account, in TopUpAsset for the cross
+	// margin account (Quote by default) and in the quote asset of the isolated margin accounts.
+	ActionLevel string
+	TargetLevel string
+	AutoRepay   bool
+	AutoTopUp   bool
+	TopUpAsset  string
+	// OnAction is called for each repay and top up sent to the exchange
+	OnAction func(action *MarginAction)
+	// OnError is called with the errors of Run
+	OnError func(err error)
+}
+
+// MarginStatus define the risk of a margin account at the current prices
+type MarginStatus struct {
+	// Symbol is the symbol of an isolated margin account, empty for the cross margin account
+	Symbol string
+	// Quote is the asset of the values
+	Quote          string
+	TotalAsset     string
+	TotalLiability string
+	// Level is the total asset over the total liability, empty without liability
+	Level string
+	// MarginCallDistance and LiquidationDistance are the fractions of the total asset value which
+	// can be lost before the level reaches the margin call and the liquidation levels, negative
+	// once reached, empty without liability
+	MarginCallDistance  string
+	LiquidationDistance string
+	Assets              []MarginAssetRisk
+	// Unpriced are the assets without price, which are not counted in the values. The actions
+	// are not run for an account with unpriced assets.
+	Unpriced []string
+}
+
+// MarginAssetRisk define the position of an asset in a margin account and the prices at which
+// it triggers the thresholds
+type MarginAssetRisk struct {
+	Asset string
+	// Net is the total balance less the borrowed amount and the interest
+	Net   string
+	Price string
+	// MarginCallPrice and LiquidationPrice are the prices of the asset, the other prices unchanged,
+	// at which the level reaches the margin call and the liquidation levels, empty when no price does
+	MarginCallPrice  string
+	LiquidationPrice string
+}
+
+// MarginAction define a repay or a top up sent by a MarginMonitor
+type MarginAction struct {
+	Type MarginActionType
+	// Symbol is the symbol of an isolated margin account, empty for the cross margin account
+	Symbol string
+	Asset  string
+	Amount string
+}
+
+// marginAsset is the balance and the debt of an asset in a margin account
+type marginAsset struct {
+	free     *big.Rat
+	locked   *big.Rat
+	borrowed *big.Rat
+	interest *big.Rat
+}
+
+func (a *marginAsset) total() *big.Rat {
+	return new(big.Rat).Add(a.free, a.locked)
+}
+
+func (a *marginAsset) debt() *big.Rat {
+	return new(big.Rat).Add(a.borrowed, a.interest)
+}
+
+// marginAccount is the cross margin account, or the isolated margin account of symbol
+type marginAccount struct {
+	symbol string
+	quote  string
+	assets map[string]*marginAsset
+	// fired are the thresholds the level is at or below
+	fired map[MarginThreshold]bool
+}
+
+func newMarginAccount(symbol, quote string) *marginAccount {
+	return &marginAccount{
+		symbol: symbol,
+		quote:  quote,
+		assets: make(map[string]*marginAsset),
+		fired:  make(map[MarginThreshold]bool),
+	}
+}
+
+func (a *marginAccount) asset(asset string) *marginAsset {
+	m, ok := a.assets[asset]
+	if !ok {
+		m = &marginAsset{free: new(big.Rat), locked: new(big.Rat), borrowed: new(big.Rat), interest: new(big.Rat)}
+		a.assets[asset] = m
+	}
+	return m
+}
+
+// MarginMonitor follow the margin level of the cross and isolated margin accounts from the
+// account services, the margin user data streams and the price index, fire the threshold
+// callbacks and repay or top up the accounts whose level is too low. It is safe for concurrent use.
+type MarginMonitor struct {
+	c                *binance.Client
+	config           MarginConfig
+	marginCallLevel  *big.Rat
+	liquidationLevel *big.Rat
+	alertLevel       *big.Rat
+	actionLevel      *big.Rat
+	targetLevel      *big.Rat
+
+	mu       sync.Mutex
+	cross    *marginAccount
+	isolated map[string]*marginAccount
+	// prices are the prices by symbol, e.g. BTCUSDT
+	prices map[string]*big.Rat
+}
+
+// NewMarginMonitor create a margin monitor of the accounts of c
+func NewMarginMonitor(c *binance.Client, config MarginConfig) (*MarginMonitor, error) {
+	if config.Quote == "" {
+		config.Quote = "USDT"
+	}
+	if config.TopUpAsset == "" {
+		config.TopUpAsset = config.Quote
+	}
+	if config.MarginCallLevel == "" {
+		config.MarginCallLevel = "1.3"
+	}
+	if config.LiquidationLevel == "" {
+		config.LiquidationLevel = "1.1"
+	}
+	if config.ActionLevel == "" {
+		config.ActionLevel = config.MarginCallLevel
+	}
+	m := &MarginMonitor{
+		c:        c,
+		config:   config,
+		cross:    newMarginAccount("", config.Quote),
+		isolated: make(map[string]*marginAccount),
+		prices:   make(map[string]*big.Rat),
+	}
+	var err error
+	for _, level := range []struct {
+		name  string
+		value string
+		r     **big.Rat
+	}{
+		{"MarginCallLevel", config.MarginCallLevel, &m.marginCallLevel},
+		{"LiquidationLevel", config.LiquidationLevel, &m.liquidationLevel},
+		{"AlertLevel", config.AlertLevel, &m.alertLevel},
+		{"ActionLevel", config.ActionLevel, &m.actionLevel},
+		{"TargetLevel", config.TargetLevel, &m.targetLevel},
+	} {
+		if *level.r, err = parseLimit(level.name, level.value); err != nil {
+			return nil, err
+		}
+	}
+	if m.liquidationLevel.Cmp(m.marginCallLevel) > 0 {
+		return nil, fmt.Errorf("risk: LiquidationLevel %s above MarginCallLevel %s", config.LiquidationLevel, config.MarginCallLevel)
+	}
+	if config.AutoRepay || config.AutoTopUp {
+		if m.targetLevel == nil || m.targetLevel.Cmp(m.actionLevel) <= 0 || m.targetLevel.Cmp(big.NewRat(1, 1)) <= 0 {
+			return nil, fmt.Errorf("risk: TargetLevel %q must be above ActionLevel %s and 1", config.TargetLevel, config.ActionLevel)
+		}
+	}
+	return m, nil
+}
+
+// Refresh load the balances and the debts of the accounts, and the price index of their assets.
+// The assets without price index are reported in the Unpriced field of their status.
+func (m *MarginMonitor) Refresh(ctx context.Context) error {
+	cross, err := m.c.NewGetMarginAccountService().Do(ctx)
+	if err != nil {
+		return err
+	}
+	crossAccount := newMarginAccount("", m.config.Quote)
+	for _, a := range cross.UserAssets {
+		asset := crossAccount.asset(a.Asset)
+		asset.free = common.DecimalOrZero(a.Free)
+		asset.locked = common.DecimalOrZero(a.Locked)
+		asset.borrowed = common.DecimalOrZero(a.Borrowed)
+		asset.interest = common.DecimalOrZero(a.Interest)
+	}
+	accounts := []*marginAccount{crossAccount}
+	isolated := make(map[string]*marginAccount)
+	if len(m.config.IsolatedSymbols) > 0 {
+		res, err := m.c.NewGetIsolatedMarginAccountService().Symbols(m.config.IsolatedSymbols...).Do(ctx)
+		if err != nil {
+			return err
+		}
+		for _, a := range res.Assets {
+			account := newMarginAccount(a.Symbol, a.QuoteAsset.Asset)
+			for _, u := range []binance.IsolatedUserAsset{a.BaseAsset, a.QuoteAsset} {
+				asset := account.asset(u.Asset)
+				asset.free = common.DecimalOrZero(u.Free)
+				asset.locked = common.DecimalOrZero(u.Locked)
+				asset.borrowed = common.DecimalOrZero(u.Borrowed)
+				asset.interest = common.DecimalOrZero(u.Interest)
+			}
+			isolated[a.Symbol] = account
+			accounts = append(accounts, account)
+		}
+	}
+
+	prices := make(map[string]*big.Rat)
+	for _, account := range accounts {
+		for name, asset := range account.assets {
+			if name == account.quote || (asset.total().Sign() == 0 && asset.debt().Sign() == 0) {
+				continue
+			}
+			symbol := name + account.quote
+			if _, ok := prices[symbol]; ok {
+				continue
+			}
+			index, err := m.c.NewGetMarginPriceIndexService().Symbol(symbol).Do(ctx)
+			if common.IsAPIError(err) {
+				// the exchange has no price index for the symbol
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			prices[symbol] = common.DecimalOrZero(index.Price)
+		}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	// keep the thresholds fired before the refresh
+	crossAccount.fired = m.cross.fired
+	m.cross = crossAccount
+	for symbol, account := range isolated {
+		if previous, ok := m.isolated[symbol]; ok {
+			account.fired = previous.fired
+		}
+		m.isolated[symbol] = account
+	}
+	for symbol, price := range prices {
+		m.prices[symbol] = price
+	}
+	return nil
+}
+
+// SetPrice set the price of symbol, e.g. from the price index or the trades of the symbol
+func (m *MarginMonitor) SetPrice(symbol, price string) error {
+	p, ok := new(big.Rat).SetString(price)
+	if !ok || p.Sign() <= 0 {
+		return fmt.Errorf("risk: invalid price %q", price)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.prices[symbol] = p
+	return nil
+}
+
+// ApplyAccountUpdate update the balances of the cross margin account with an account update of
+// the margin user data stream. The debts are updated by Refresh.
+func (m *MarginMonitor) ApplyAccountUpdate(u *binance.WsAccountUpdate) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.cross.applyUpdate(u)
+}
+
+// ApplyIsolatedAccountUpdate update the balances of the isolated margin account of symbol with an
+// account update of its user data stream. The debts are updated by Refresh.
+func (m *MarginMonitor) ApplyIsolatedAccountUpdate(symbol string, u *binance.WsAccountUpdate) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if account, ok := m.isolated[symbol]; ok {
+		account.applyUpdate(u)
+	}
+}
+
+func (a *marginAccount) applyUpdate(u *binance.WsAccountUpdate) {
+	for _, b := range u.Balances {
+		asset := a.asset(b.Asset)
+		asset.free = common.DecimalOrZero(b.Free)
+		asset.locked = common.DecimalOrZero(b.Locked)
+	}
+}
+
+// Status return the risk of the cross margin account and of the isolated margin accounts, by symbol
+func (m *MarginMonitor) Status() []*MarginStatus {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	statuses := make([]*MarginStatus, 0, len(m.isolated)+1)
+	for _, account := range m.accounts() {
+		statuses = append(statuses, m.status(account, m.value(account)))
+	}
+	return statuses
+}
+
+// Check fire the threshold callbacks and run the actions of the accounts whose level is at or
+// below the action level, and return the status of the accounts before the actions
+func (m *MarginMonitor) Check(ctx context.Context) ([]*MarginStatus, error) {
+	type crossing struct {
+		threshold MarginThreshold
+		status    *MarginStatus
+	}
+	var crossings []crossing
+	var actions []*MarginAction
+	m.mu.Lock()
+	statuses := make([]*MarginStatus, 0, len(m.isolated)+1)
+	for _, account := range m.accounts() {
+		v := m.value(account)
+		status := m.status(account, v)
+		statuses = append(statuses, status)
+		level := v.level()
+		for _, t := range m.thresholds() {
+			below := level != nil && level.Cmp(t.level) <= 0
+			if below && !account.fired[t.threshold] {
+				crossings = append(crossings, crossing{t.threshold, status})
+			}
+			account.fired[t.threshold] = below
+		}
+		if level != nil && level.Cmp(m.actionLevel) <= 0 && len(v.unpriced) == 0 {
+			actions = append(actions, m.actions(account, v)...)
+		}
+	}
+	m.mu.Unlock()
+
+	if m.config.OnThreshold != nil {
+		for _, c := range crossings {
+			m.config.OnThreshold(c.threshold, c.status)
+		}
+	}
+	for _, action := range actions {
+		if err := m.do(ctx, action); err != nil {
+			return statuses, err
+		}
+		m.applyAction(action)
+		if m.config.OnAction != nil {
+			m.config.OnAction(action)
+		}
+	}
+	return statuses, nil
+}
+
+// Run refresh and check the accounts every interval until ctx is done. The errors are passed to
+// OnError, and Run keeps going.
+func (m *MarginMonitor) Run(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		err := m.Refresh(ctx)
+		if err == nil {
+			_, err = m.Check(ctx)
+		}
+		if err != nil && ctx.Err() == nil && m.config.OnError != nil {
+			m.config.OnError(err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+type marginLevel struct {
+	threshold MarginThreshold
+	level     *big.Rat
+}
+
+// thresholds return the enabled thresholds, from the highest level
+func (m *MarginMonitor) thresholds() []marginLevel {
+	var levels []marginLevel
+	if m.alertLevel != nil {
+		levels = append(levels, marginLevel{MarginThresholdAlert, m.alertLevel})
+	}
+	return append(levels,
+		marginLevel{MarginThresholdMarginCall, m.marginCallLevel},
+		marginLevel{MarginThresholdLiquidation, m.liquidationLevel})
+}
+
+// accounts return the cross margin account and the isolated margin accounts by symbol
+func (m *MarginMonitor) accounts() []*marginAccount {
+	symbols := make([]string, 0, len(m.isolated))
+	for symbol := range m.isolated {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+	accounts := []*marginAccount{m.cross}
+	for _, symbol := range symbols {
+		accounts = append(accounts, m.isolated[symbol])
+	}
+	return accounts
+}
+
+// accountValue is the value of a margin account in its quote asset
+type accountValue struct {
+	// assets are the names of the priced assets with a balance or a debt
+	assets    []string
+	unpriced  []string
+	prices    map[string]*big.Rat
+	total     *big.Rat
+	liability *big.Rat
+}
+
+func (v *accountValue) level() *big.Rat {
+	if v.liability.Sign() == 0 {
+		return nil
+	}
+	return new(big.Rat).Quo(v.total, v.liability)
+}
+
+func (m *MarginMonitor) value(account *marginAccount) *accountValue {
+	v := &accountValue{prices: make(map[string]*big.Rat), total: new(big.Rat), liability: new(big.Rat)}
+	for name, asset := range account.assets {
+		total, debt := asset.total(), asset.debt()
+		if total.Sign() == 0 && debt.Sign() == 0 {
+			continue
+		}
+		price := big.NewRat(1, 1)
+		if name != account.quote {
+			p, ok := m.prices[name+account.quote]
+			if !ok {
+				v.unpriced = append(v.unpriced, name)
+				continue
+			}
+			price = p
+		}
+		v.assets = append(v.assets, name)
+		v.prices[name] = price
+		v.total.Add(v.total, total.Mul(total, price))
+		v.liability.Add(v.liability, debt.Mul(debt, price))
+	}
+	sort.Strings(v.assets)
+	sort.Strings(v.unpriced)
+	return v
+}
+
+func (m *MarginMonitor) status(account *marginAccount, v *accountValue) *MarginStatus {
+	status := &MarginStatus{
+		Symbol:         account.symbol,
+		Quote:          account.quote,
+		TotalAsset:     common.FormatDecimal(v.total),
+		TotalLiability: common.FormatDecimal(v.liability),
+		Unpriced:       v.unpriced,
+	}
+	if level := v.level(); level != nil {
+		status.Level = common.FormatDecimal(level)
+		status.MarginCallDistance = common.FormatDecimal(lossToLevel(v, m.marginCallLevel))
+		status.LiquidationDistance = common.FormatDecimal(lossToLevel(v, m.liquidationLevel))
+	}
+	for _, name := range v.assets {
+		asset := account.assets[name]
+		risk := MarginAssetRisk{
+			Asset: name,
+			Net:   common.FormatDecimal(new(big.Rat).Sub(asset.total(), asset.debt())),
+			Price: common.FormatDecimal(v.prices[name]),
+		}
+		if name != account.quote {
+			risk.MarginCallPrice = formatPrice(triggerPrice(account, v, name, m.marginCallLevel))
+			risk.LiquidationPrice = formatPrice(triggerPrice(account, v, name, m.liquidationLevel))
+		}
+		status.Assets = append(status.Assets, risk)
+	}
+	return status
+}
+
+// lossToLevel return the fraction of the total asset value which can be lost before the margin
+// level reaches level: total * (1 - f) = level * liability
+func lossToLevel(v *accountValue, level *big.Rat) *big.Rat {
+	if v.total.Sign() == 0 {
+		return new(big.Rat).SetInt64(-1)
+	}
+	f := new(big.Rat).Mul(level, v.liability)
+	f.Quo(f, v.total)
+	return f.Sub(big.NewRat(1, 1), f)
+}
+
+// triggerPrice return the price of asset, the other prices unchanged, at which the margin level
+// is level, nil when no price does: (total' + t * p) = level * (liability' + d * p), where total'
+// and liability' exclude the asset, t is its total balance and d its debt
+func triggerPrice(account *marginAccount, v *accountValue, name string, level *big.Rat) *big.Rat {
+	asset := account.assets[name]
+	price := v.prices[name]
+	total, debt := asset.total(), asset.debt()
+	otherTotal := new(big.Rat).Sub(v.total, new(big.Rat).Mul(total, price))
+	otherLiability := new(big.Rat).Sub(v.liability, new(big.Rat).Mul(debt, price))
+	denominator := new(big.Rat).Sub(total, new(big.Rat).Mul(level, debt))
+	if denominator.Sign() == 0 {
+		return nil
+	}
+	p := new(big.Rat).Mul(level, otherLiability)
+	p.Sub(p, otherTotal)
+	p.Quo(p, denominator)
+	if p.Sign() <= 0 {
+		return nil
+	}
+	return p
+}
+
+// truncateDecimal round r down to the 8 decimals of the exchange amounts
+func truncateDecimal(r *big.Rat) *big.Rat {
+	scale := big.NewInt(100000000)
+	n := new(big.Int).Mul(r.Num(), scale)
+	n.Quo(n, r.Denom())
+	return new(big.Rat).SetFrac(n, scale)
+}
+
+// roundUpDecimal round r up to the 8 decimals of the exchange amounts
+func roundUpDecimal(r *big.Rat) *big.Rat {
+	scale := big.NewInt(100000000)
+	n, m := new(big.Int).DivMod(new(big.Int).Mul(r.Num(), scale), r.Denom(), new(big.Int))
+	if m.Sign() != 0 {
+		n.Add(n, big.NewInt(1))
+	}
+	return new(big.Rat).SetFrac(n, scale)
+}
+
+func formatPrice(p *big.Rat) string {
+	if p == nil {
+		return ""
+	}
+	return common.FormatDecimal(p)
+}
+
+// actions return the repays and the top up restoring the target level of account, the account
+// is changed by applyAction once they are sent
+func (m *MarginMonitor) actions(account *marginAccount, v *accountValue) []*MarginAction {
+	var actions []*MarginAction
+	one := big.NewRat(1, 1)
+	total, liability := new(big.Rat).Set(v.total), new(big.Rat).Set(v.liability)
+	// missing return the value to repay, (total - x) = target * (liability - x), or to transfer,
+	// (total + x) = target * liability
+	missing := func(repay bool) *big.Rat {
+		x := new(big.Rat).Mul(m.targetLevel, liability)
+		x.Sub(x, total)
+		if repay {
+			x.Quo(x, new(big.Rat).Sub(m.targetLevel, one))
+		}
+		return x
+	}
+	if m.config.AutoRepay {
+		// repay the largest debts first
+		names := make([]string, 0, len(v.assets))
+		for _, name := range v.assets {
+			if account.assets[name].debt().Sign() > 0 {
+				names = append(names, name)
+			}
+		}
+		sort.SliceStable(names, func(i, j int) bool {
+			di := new(big.Rat).Mul(account.assets[names[i]].debt(), v.prices[names[i]])
+			dj := new(big.Rat).Mul(account.assets[names[j]].debt(), v.prices[names[j]])
+			return di.Cmp(dj) > 0
+		})
+		for _, name := range names {
+			need := missing(true)
+			if need.Sign() <= 0 {
+				break
+			}
+			asset, price := account.assets[name], v.prices[name]
+			amount := truncateDecimal(common.MinRat(common.MinRat(asset.free, asset.debt()), new(big.Rat).Quo(need, price)))
+			if amount.Sign() <= 0 {
+				continue
+			}
+			actions = append(actions, &MarginAction{Type: MarginActionTypeRepay, Symbol: account.symbol, Asset: name, Amount: common.FormatDecimal(amount)})
+			paid := new(big.Rat).Mul(amount, price)
+			total.Sub(total, paid)
+			liability.Sub(liability, paid)
+		}
+	}
+	if m.config.AutoTopUp {
+		if need := missing(false); need.Sign() > 0 {
+			name := m.config.TopUpAsset
+			if account.symbol != "" {
+				name = account.quote
+			}
+			price, ok := one, true
+			if name != account.quote {
+				price, ok = m.prices[name+account.quote]
+			}
+			if ok {
+				// rounded up, a top up short of the need would leave the account below the target level
+				amount := roundUpDecimal(new(big.Rat).Quo(need, price))
+				actions = append(actions, &MarginAction{Type: MarginActionTypeTopUp, Symbol: account.symbol, Asset: name, Amount: common.FormatDecimal(amount)})
+			}
+		}
+	}
+	return actions
+}
+
+// applyAction apply an action accepted by the exchange to the balances and debts of its account
+// until the next refresh
+func (m *MarginMonitor) applyAction(action *MarginAction) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	account := m.cross
+	if action.Symbol != "" {
+		var ok bool
+		if account, ok = m.isolated[action.Symbol]; !ok {
+			return
+		}
+	}
+	amount := common.DecimalOrZero(action.Amount)
+	asset := account.asset(action.Asset)
+	if action.Type == MarginActionTypeTopUp {
+		asset.free.Add(asset.free, amount)
+		return
+	}
+	asset.free.Sub(asset.free, amount)
+	// the interest is repaid first
+	fromInterest := common.MinRat(asset.interest, amount)
+	asset.interest.Sub(asset.interest, fromInterest)
+	asset.borrowed.Sub(asset.borrowed, new(big.Rat).Sub(amount, fromInterest))
+}
+
+// do send action to the exchange
+func (m *MarginMonitor) do(ctx context.Context, action *MarginAction) error {
+	var err error
+	switch {
+	case action.Type == MarginActionTypeRepay:
+		_, err = m.c.NewMarginRepayService().Asset(action.Asset).Amount(action.Amount).
+			IsIsolated(action.Symbol != "").Symbol(action.Symbol).Do(ctx)
+	case action.Symbol == "":
+		_, err = m.c.NewMarginTransferService().Asset(action.Asset).Amount(action.Amount).
+			Type(binance.MarginTransferTypeToMargin).Do(ctx)
+	default:
+		_, err = m.c.NewIsolatedMarginTransferService().Asset(action.Asset).Symbol(action.Symbol).
+			TransFrom(binance.IsolatedMarginAccountTypeSpot).TransTo(binance.IsolatedMarginAccountTypeIsolatedMargin).
+			Amount(action.Amount).Do(ctx)
+	}
+	return err
+}
@@ -0,0 +1,231 @@
+package risk
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Zamzam-Technology/go-binance/v2"
+	"github.com/Zamzam-Technology/go-binance/v2/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestMarginClient(t *testing.T, crossAssets, isolatedAssets string) *binance.FakeClient {
+	c := binance.NewFakeClient()
+	require.NoError(t, c.Stub(http.MethodGet, "/sapi/v1/margin/account", `{"userAssets": `+crossAssets+`}`))
+	require.NoError(t, c.Stub(http.MethodGet, "/sapi/v1/margin/isolated/account", `{"assets": `+isolatedAssets+`}`))
+	require.NoError(t, c.Stub(http.MethodGet, "/sapi/v1/margin/priceIndex", `{"symbol": "BTCUSDT", "price": "100000"}`))
+	require.NoError(t, c.Stub(http.MethodPost, "/sapi/v1/margin/transfer", `{"tranId": 1}`))
+	require.NoError(t, c.Stub(http.MethodPost, "/sapi/v1/margin/isolated/transfer", `{"tranId": 2}`))
+	require.NoError(t, c.Stub(http.MethodPost, "/sapi/v1/margin/repay", `{"tranId": 3}`))
+	return c
+}
+
+func TestMarginMonitor(t *testing.T) {
+	c := newTestMarginClient(t, `[
+		{"asset": "USDT", "free": "1500", "locked": "0", "borrowed": "0", "interest": "0"},
+		{"asset": "BTC", "free": "0", "locked": "0", "borrowed": "0.01", "interest": "0"},
+		{"asset": "ETH", "free": "0", "locked": "0", "borrowed": "0", "interest": "0"}
+	]`, `[]`)
+	var thresholds []MarginThreshold
+	var actions []*MarginAction
+	m, err := NewMarginMonitor(c.Client, MarginConfig{
+		AlertLevel:  "1.4",
+		TargetLevel: "1.5",
+		AutoTopUp:   true,
+		OnThreshold: func(threshold MarginThreshold, status *MarginStatus) {
+			thresholds = append(thresholds, threshold)
+		},
+		OnAction: func(action *MarginAction) {
+			actions = append(actions, action)
+		},
+	})
+	require.NoError(t, err)
+	ctx := context.Background()
+	require.NoError(t, m.Refresh(ctx))
+
+	statuses, err := m.Check(ctx)
+	require.NoError(t, err)
+	require.Len(t, statuses, 1)
+	assert.Equal(t, &MarginStatus{
+		Quote:               "USDT",
+		TotalAsset:          "1500",
+		TotalLiability:      "1000",
+		Level:               "1.5",
+		MarginCallDistance:  "0.13333333",
+		LiquidationDistance: "0.26666667",
+		Assets: []MarginAssetRisk{
+			{Asset: "BTC", Net: "-0.01", Price: "100000", MarginCallPrice: "115384.61538462", LiquidationPrice: "136363.63636364"},
+			{Asset: "USDT", Net: "1500", Price: "1"},
+		},
+	}, statuses[0])
+	assert.Empty(t, thresholds)
+	assert.Empty(t, actions)
+
+	// the price rise brings the level to 1.25, below the alert and the margin call levels
+	require.NoError(t, m.SetPrice("BTCUSDT", "120000"))
+	statuses, err = m.Check(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "1.25", statuses[0].Level)
+	assert.Equal(t, []MarginThreshold{MarginThresholdAlert, MarginThresholdMarginCall}, thresholds)
+	assert.Equal(t, []*MarginAction{{Type: MarginActionTypeTopUp, Asset: "USDT", Amount: "300"}}, actions)
+	calls := c.Calls(http.MethodPost, "/sapi/v1/margin/transfer")
+	require.Len(t, calls, 1)
+	assert.Equal(t, "300", calls[0].Params.Get("amount"))
+	assert.Equal(t, "1", calls[0].Params.Get("type"))
+
+	// the top up restored the target level until the next refresh
+	statuses, err = m.Check(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "1.5", statuses[0].Level)
+	assert.Len(t, actions, 1)
+
+	// the user data stream update the balances
+	m.ApplyAccountUpdate(&binance.WsAccountUpdate{Balances: []binance.WsAccountBalance{{Asset: "USDT", Free: "1320", Locked: "0"}}})
+	statuses, err = m.Check(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "1.1", statuses[0].Level)
+	assert.Equal(t, []MarginThreshold{MarginThresholdAlert, MarginThresholdMarginCall, MarginThresholdAlert,
+		MarginThresholdMarginCall, MarginThresholdLiquidation}, thresholds)
+}
+
+func TestMarginMonitorTopUpRounding(t *testing.T) {
+	c := newTestMarginClient(t, `[
+		{"asset": "USDT", "free": "1500", "locked": "0", "borrowed": "0", "interest": "0"},
+		{"asset": "BTC", "free": "0", "locked": "0", "borrowed": "0.01", "interest": "0"}
+	]`, `[]`)
+	m, err := NewMarginMonitor(c.Client, MarginConfig{TargetLevel: "1.5", AutoTopUp: true})
+	require.NoError(t, err)
+	ctx := context.Background()
+	require.NoError(t, m.Refresh(ctx))
+
+	// 300.0000000045 are missing, the transfer is rounded up to the 8 decimals of the exchange
+	require.NoError(t, m.SetPrice("BTCUSDT", "120000.0000003"))
+	_, err = m.Check(ctx)
+	require.NoError(t, err)
+	calls := c.Calls(http.MethodPost, "/sapi/v1/margin/transfer")
+	require.Len(t, calls, 1)
+	assert.Equal(t, "300.00000001", calls[0].Params.Get("amount"))
+}
+
+func TestMarginMonitorIsolated(t *testing.T) {
+	c := newTestMarginClient(t, `[]`, `[{
+		"symbol": "BTCUSDT",
+		"baseAsset": {"asset": "BTC", "free": "0.01", "locked": "0", "borrowed": "0", "interest": "0"},
+		"quoteAsset": {"asset": "USDT", "free": "100", "locked": "0", "borrowed": "890", "interest": "10"}
+	}]`)
+	var actions []*MarginAction
+	m, err := NewMarginMonitor(c.Client, MarginConfig{
+		IsolatedSymbols: []string{"BTCUSDT"},
+		TargetLevel:     "1.5",
+		AutoRepay:       true,
+		AutoTopUp:       true,
+		OnAction: func(action *MarginAction) {
+			actions = append(actions, action)
+		},
+	})
+	require.NoError(t, err)
+	ctx := context.Background()
+	require.NoError(t, m.Refresh(ctx))
+
+	statuses, err := m.Check(ctx)
+	require.NoError(t, err)
+	require.Len(t, statuses, 2)
+	assert.Equal(t, "", statuses[0].Level)
+	assert.Equal(t, &MarginStatus{
+		Symbol:              "BTCUSDT",
+		Quote:               "USDT",
+		TotalAsset:          "1100",
+		TotalLiability:      "900",
+		Level:               "1.22222222",
+		MarginCallDistance:  "-0.06363636",
+		LiquidationDistance: "0.1",
+		Assets: []MarginAssetRisk{
+			{Asset: "BTC", Net: "0.01", Price: "100000", MarginCallPrice: "107000", LiquidationPrice: "89000"},
+			{Asset: "USDT", Net: "-800", Price: "1"},
+		},
+	}, statuses[1])
+
+	// the free USDT repay 100 of the debt, then 200 USDT are transferred to reach 1.5
+	assert.Equal(t, []*MarginAction{
+		{Type: MarginActionTypeRepay, Symbol: "BTCUSDT", Asset: "USDT", Amount: "100"},
+		{Type: MarginActionTypeTopUp, Symbol: "BTCUSDT", Asset: "USDT", Amount: "200"},
+	}, actions)
+	repays := c.Calls(http.MethodPost, "/sapi/v1/margin/repay")
+	require.Len(t, repays, 1)
+	assert.Equal(t, "TRUE", repays[0].Params.Get("isIsolated"))
+	assert.Equal(t, "BTCUSDT", repays[0].Params.Get("symbol"))
+	transfers := c.Calls(http.MethodPost, "/sapi/v1/margin/isolated/transfer")
+	require.Len(t, transfers, 1)
+	assert.Equal(t, "BTCUSDT", transfers[0].Params.Get("symbol"))
+	assert.Equal(t, "200", transfers[0].Params.Get("amount"))
+	assert.Equal(t, "SPOT", transfers[0].Params.Get("transFrom"))
+
+	statuses = m.Status()
+	assert.Equal(t, "1.5", statuses[1].Level)
+}
+
+func TestMarginMonitorActionError(t *testing.T) {
+	c := newTestMarginClient(t, `[]`, `[{
+		"symbol": "BTCUSDT",
+		"baseAsset": {"asset": "BTC", "free": "0.01", "locked": "0", "borrowed": "0", "interest": "0"},
+		"quoteAsset": {"asset": "USDT", "free": "100", "locked": "0", "borrowed": "890", "interest": "10"}
+	}]`)
+	c.StubError(http.MethodPost, "/sapi/v1/margin/isolated/transfer", &common.APIError{Code: -3041, Message: "Balance is not enough"})
+	m, err := NewMarginMonitor(c.Client, MarginConfig{
+		IsolatedSymbols: []string{"BTCUSDT"},
+		TargetLevel:     "1.5",
+		AutoRepay:       true,
+		AutoTopUp:       true,
+	})
+	require.NoError(t, err)
+	ctx := context.Background()
+	require.NoError(t, m.Refresh(ctx))
+
+	_, err = m.Check(ctx)
+	assert.Error(t, err)
+	// the repay is applied, the failed top up is not
+	statuses := m.Status()
+	assert.Equal(t, "1000", statuses[1].TotalAsset)
+	assert.Equal(t, "800", statuses[1].TotalLiability)
+}
+
+func TestNewMarginMonitor(t *testing.T) {
+	c := binance.NewClient("", "")
+	_, err := NewMarginMonitor(c, MarginConfig{MarginCallLevel: "x"})
+	assert.Error(t, err)
+	_, err = NewMarginMonitor(c, MarginConfig{MarginCallLevel: "1.1", LiquidationLevel: "1.2"})
+	assert.Error(t, err)
+	_, err = NewMarginMonitor(c, MarginConfig{AutoRepay: true})
+	assert.Error(t, err)
+	_, err = NewMarginMonitor(c, MarginConfig{AutoTopUp: true, TargetLevel: "1.2"})
+	assert.Error(t, err)
+	_, err = NewMarginMonitor(c, MarginConfig{AutoTopUp: true, TargetLevel: "2"})
+	assert.NoError(t, err)
+
+	m, err := NewMarginMonitor(c, MarginConfig{})
+	require.NoError(t, err)
+	m.ApplyAccountUpdate(&binance.WsAccountUpdate{Balances: []binance.WsAccountBalance{{Asset: "BTC", Free: "1"}}})
+	statuses := m.Status()
+	assert.Equal(t, []string{"BTC"}, statuses[0].Unpriced)
+}
+
+func TestMarginMonitorUnpriced(t *testing.T) {
+	c := newTestMarginClient(t, `[
+		{"asset": "USDT", "free": "1000", "locked": "0", "borrowed": "900", "interest": "0"},
+		{"asset": "XYZ", "free": "10", "locked": "0", "borrowed": "0", "interest": "0"}
+	]`, `[]`)
+	c.StubError(http.MethodGet, "/sapi/v1/margin/priceIndex", &common.APIError{Code: -1121, Message: "Invalid symbol."})
+	m, err := NewMarginMonitor(c.Client, MarginConfig{TargetLevel: "1.5", AutoTopUp: true})
+	require.NoError(t, err)
+	ctx := context.Background()
+	require.NoError(t, m.Refresh(ctx))
+
+	statuses, err := m.Check(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "1000", statuses[0].TotalAsset)
+	assert.Equal(t, []string{"XYZ"}, statuses[0].Unpriced)
+	// the level of the account is not reliable, no top up is sent
+	assert.Empty(t, c.Calls(http.MethodPost, "/sapi/v1/margin/transfer"))
+}
@@ -0,0 +1,602 @@
+// Package risk check the orders of a strategy against pre-trade limits before they reach the exchange.
+//
+// An Engine wraps the HTTP transport of the spot, margin, futures and delivery clients: the new
+// orders which break a limit are rejected with a *LimitError, without being sent, and the kill
+// switch cancels the open orders and blocks the new ones with ErrKilled. The positions, the open
+// orders and the daily profit are followed from the order updates of the user data streams.
+//
+// A MarginMonitor follows the margin level of the margin accounts, and repays or tops them up
+// before the margin call.
+package risk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/Zamzam-Technology/go-binance/v2/common"
+)
+
+// Market define the market of an order
+type Market string
+
+// Limit define a limit of the engine
+type Limit string
+
+// Global enums
+const (
+	MarketSpot     Market = "SPOT"
+	MarketMargin   Market = "MARGIN"
+	MarketFutures  Market = "FUTURES"
+	MarketDelivery Market = "DELIVERY"
+
+	PositionSideBoth  = "BOTH"
+	PositionSideLong  = "LONG"
+	PositionSideShort = "SHORT"
+
+	LimitOrderNotional Limit = "ORDER_NOTIONAL"
+	LimitPosition      Limit = "POSITION"
+	LimitOpenOrders    Limit = "OPEN_ORDERS"
+	LimitOrderRate     Limit = "ORDER_RATE"
+	LimitPriceBand     Limit = "PRICE_BAND"
+	LimitDailyLoss     Limit = "DAILY_LOSS"
+)
+
+var (
+	// ErrKilled is returned for the new orders while the kill switch is engaged
+	ErrKilled = errors.New("risk: kill switch engaged")
+	// ErrNoReferencePrice is returned for an order whose notional or price band can not be checked
+	// because neither the order nor SetPrice give a price for its symbol
+	ErrNoReferencePrice = errors.New("risk: no reference price")
+)
+
+// LimitError is returned for an order which breaks a limit
+type LimitError struct {
+	Limit  Limit
+	Market Market
+	Symbol string
+	// Value is the value of the order or of the account which breaks the limit Max
+	Value string
+	Max   string
+}
+
+// Error return the error message
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("risk: %s order on %s breaks the %s limit: %s over %s", e.Market, e.Symbol, e.Limit, e.Value, e.Max)
+}
+
+// Limits define the limits of an engine. The zero value of a limit disables it.
+type Limits struct {
+	// MaxOrderNotional is the maximum quantity times price of an order, in the quote asset.
+	// The notional of a delivery order is its number of contracts times the contract size in USD.
+	MaxOrderNotional string
+	// MaxPosition is the maximum absolute net position by symbol once the order is filled, in base
+	// asset or in contracts for delivery. In hedge mode the net position is the long position plus
+	// the short position. The orders which reduce the position, including the reduceOnly and
+	// closePosition futures orders and the SELL LONG and BUY SHORT orders in hedge mode, are always allowed.
+	MaxPosition map[string]string
+	// MaxOpenOrders is the maximum number of open orders over all the markets
+	MaxOpenOrders int
+	// MaxOrdersPerSecond is the maximum number of orders sent during the last second
+	MaxOrdersPerSecond int
+	// MaxPriceDeviation is the maximum relative deviation of the order prices from the reference
+	// price of SetPrice, e.g. "0.05" for 5%
+	MaxPriceDeviation string
+	// MaxDailyLoss is the maximum loss since 00:00 UTC, in the quote asset: the realized profit of
+	// the day, less the futures commissions, and the change of the unrealized profit at the reference
+	// prices. Once it is reached, only the orders which reduce a position are allowed.
+	MaxDailyLoss string
+}
+
+// positionKey identify a position: the net position of a symbol in a market, or one side of a
+// futures position in hedge mode
+type positionKey struct {
+	market Market
+	symbol string
+	// side is LONG or SHORT in hedge mode, empty for the net position
+	side string
+}
+
+func newPositionKey(market Market, symbol, positionSide string) positionKey {
+	if positionSide == PositionSideBoth {
+		positionSide = ""
+	}
+	return positionKey{market: market, symbol: symbol, side: positionSide}
+}
+
+// position is the position of a symbol in a market, negative when short
+type position struct {
+	amount *big.Rat
+	entry  *big.Rat
+}
+
+// fill update the position with a trade, and return the profit it realized
+func (p *position) fill(side string, quantity, price *big.Rat) *big.Rat {
+	delta := new(big.Rat).Set(quantity)
+	if side == sideSell {
+		delta.Neg(delta)
+	}
+	realized := new(big.Rat)
+	if p.amount.Sign() == 0 || p.amount.Sign() == delta.Sign() {
+		size := new(big.Rat).Abs(p.amount)
+		cost := new(big.Rat).Mul(size, p.entry)
+		cost.Add(cost, new(big.Rat).Mul(quantity, price))
+		p.entry = cost.Quo(cost, size.Add(size, quantity))
+	} else {
+		closed := common.MinRat(new(big.Rat).Abs(p.amount), quantity)
+		realized.Sub(price, p.entry)
+		realized.Mul(realized, closed)
+		if p.amount.Sign() < 0 {
+			realized.Neg(realized)
+		}
+		after := new(big.Rat).Add(p.amount, delta)
+		if after.Sign() == 0 {
+			p.entry = new(big.Rat)
+		} else if after.Sign() != p.amount.Sign() {
+			p.entry = new(big.Rat).Set(price)
+		}
+	}
+	p.amount.Add(p.amount, delta)
+	return realized
+}
+
+// order is a new order sent to the exchange
+type order struct {
+	market Market
+	symbol string
+	side   string
+	// positionSide is LONG or SHORT for a futures order in hedge mode
+	positionSide string
+	quantity     *big.Rat
+	// price is the limit price, or the stop price of a stop market order
+	price *big.Rat
+	// quoteQuantity is the quoteOrderQty of a spot market order
+	quoteQuantity *big.Rat
+	// prices are the prices checked against the price band
+	prices []*big.Rat
+	// contractSize is the size of a delivery contract in USD
+	contractSize *big.Rat
+	// reduceOnly is set for the reduce only and close position futures orders
+	reduceOnly bool
+	// delta is the change of the position reserved by a pending order, nil when it reduces the position
+	delta *big.Rat
+}
+
+// update is an order update of the user data stream
+type update struct {
+	market       Market
+	symbol       string
+	id           int64
+	side         string
+	positionSide string
+	final        bool
+	// quantity and price are set for a trade
+	quantity *big.Rat
+	price    *big.Rat
+	// realized and commission are the reported profit and commission of a futures trade, in the quote asset
+	realized   *big.Rat
+	commission *big.Rat
+}
+
+// Engine check the new orders against the limits, and hold the kill switch. It is safe for concurrent use.
+type Engine struct {
+	mu                 sync.Mutex
+	maxOrderNotional   *big.Rat
+	maxPosition        map[string]*big.Rat
+	maxOpenOrders      int
+	maxOrdersPerSecond int
+	maxPriceDeviation  *big.Rat
+	maxDailyLoss       *big.Rat
+	now                func() time.Time
+	prices             map[string]*big.Rat
+	positions          map[positionKey]*position
+	// orders are the orders seen by the engine, by market, symbol and id, true while they are open
+	orders map[string]bool
+	// pending are the orders checked and not answered by the exchange yet, they hold an open order
+	// and their quantity counts in the position
+	pending map[*order]bool
+	sent    []time.Time
+	killed  bool
+	// day is the current UTC day, realized the profit realized during the day, and startUnrealized
+	// the unrealized profit at its start
+	day             int64
+	realized        *big.Rat
+	startUnrealized *big.Rat
+	// cancelers cancel the open orders of the wrapped clients
+	cancelers []func(ctx context.Context) error
+}
+
+// NewEngine create an engine enforcing limits
+func NewEngine(limits Limits) (*Engine, error) {
+	e := &Engine{
+		maxPosition:        make(map[string]*big.Rat),
+		maxOpenOrders:      limits.MaxOpenOrders,
+		maxOrdersPerSecond: limits.MaxOrdersPerSecond,
+		now:                time.Now,
+		prices:             make(map[string]*big.Rat),
+		positions:          make(map[positionKey]*position),
+		orders:             make(map[string]bool),
+		pending:            make(map[*order]bool),
+		realized:           new(big.Rat),
+		startUnrealized:    new(big.Rat),
+	}
+	var err error
+	if e.maxOrderNotional, err = parseLimit("MaxOrderNotional", limits.MaxOrderNotional); err != nil {
+		return nil, err
+	}
+	if e.maxPriceDeviation, err = parseLimit("MaxPriceDeviation", limits.MaxPriceDeviation); err != nil {
+		return nil, err
+	}
+	if e.maxDailyLoss, err = parseLimit("MaxDailyLoss", limits.MaxDailyLoss); err != nil {
+		return nil, err
+	}
+	for symbol, max := range limits.MaxPosition {
+		if e.maxPosition[symbol], err = parseLimit("MaxPosition", max); err != nil {
+			return nil, err
+		}
+	}
+	return e, nil
+}
+
+func parseLimit(name, value string) (*big.Rat, error) {
+	if value == "" {
+		return nil, nil
+	}
+	r, ok := new(big.Rat).SetString(value)
+	if !ok || r.Sign() < 0 {
+		return nil, fmt.Errorf("risk: invalid %s %q", name, value)
+	}
+	return r, nil
+}
+
+// SetPrice set the reference price of symbol, e.g. the mark price or the last price
+func (e *Engine) SetPrice(symbol, price string) error {
+	p, ok := new(big.Rat).SetString(price)
+	if !ok || p.Sign() <= 0 {
+		return fmt.Errorf("risk: invalid price %q", price)
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.rollDay()
+	e.prices[symbol] = p
+	return nil
+}
+
+// SetPosition set the net position of symbol in market, negative when short, e.g. from the
+// balances or the position risk when the engine starts
+func (e *Engine) SetPosition(market Market, symbol, amount, entryPrice string) error {
+	a, ok := new(big.Rat).SetString(amount)
+	if !ok {
+		return fmt.Errorf("risk: invalid amount %q", amount)
+	}
+	entry, ok := new(big.Rat).SetString(entryPrice)
+	if !ok {
+		return fmt.Errorf("risk: invalid entry price %q", entryPrice)
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.rollDay()
+	pos := e.position(newPositionKey(market, symbol, ""))
+	pos.amount, pos.entry = a, entry
+	return nil
+}
+
+// SetHedgePosition set the LONG or SHORT position of symbol in market in hedge mode, negative
+// for SHORT as in the position risk, e.g. when the engine starts
+func (e *Engine) SetHedgePosition(market Market, symbol, positionSide, amount, entryPrice string) error {
+	if positionSide != PositionSideLong && positionSide != PositionSideShort {
+		return fmt.Errorf("risk: invalid position side %q", positionSide)
+	}
+	a, ok := new(big.Rat).SetString(amount)
+	if !ok {
+		return fmt.Errorf("risk: invalid amount %q", amount)
+	}
+	entry, ok := new(big.Rat).SetString(entryPrice)
+	if !ok {
+		return fmt.Errorf("risk: invalid entry price %q", entryPrice)
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.rollDay()
+	pos := e.position(newPositionKey(market, symbol, positionSide))
+	pos.amount, pos.entry = a, entry
+	return nil
+}
+
+// Position return the net position of symbol in market, the sum of the LONG and SHORT positions in hedge mode
+func (e *Engine) Position(market Market, symbol string) string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return common.FormatDecimal(e.netPosition(market, symbol))
+}
+
+// DailyProfit return the profit since 00:00 UTC, see Limits.MaxDailyLoss
+func (e *Engine) DailyProfit() string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.rollDay()
+	return common.FormatDecimal(e.dailyProfit())
+}
+
+// OpenOrders return the number of open orders seen by the engine
+func (e *Engine) OpenOrders() int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.openOrders()
+}
+
+// Killed return whether the kill switch is engaged
+func (e *Engine) Killed() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.killed
+}
+
+// Kill engage the kill switch: the new orders are rejected with ErrKilled, and the open orders of
+// the wrapped clients are canceled. Kill return the first error of the cancellations, the switch
+// stays engaged and Kill may be called again.
+func (e *Engine) Kill(ctx context.Context) error {
+	e.mu.Lock()
+	e.killed = true
+	cancelers := e.cancelers
+	e.mu.Unlock()
+	var first error
+	for _, cancel := range cancelers {
+		if err := cancel(ctx); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
+
+// Resume release the kill switch
+func (e *Engine) Resume() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.killed = false
+}
+
+func orderKey(market Market, symbol string, id int64) string {
+	return fmt.Sprintf("%s/%s/%d", market, symbol, id)
+}
+
+func (e *Engine) position(key positionKey) *position {
+	pos, ok := e.positions[key]
+	if !ok {
+		pos = &position{amount: new(big.Rat), entry: new(big.Rat)}
+		e.positions[key] = pos
+	}
+	return pos
+}
+
+// netPosition return the sum of the positions of symbol in market
+func (e *Engine) netPosition(market Market, symbol string) *big.Rat {
+	net := new(big.Rat)
+	for key, pos := range e.positions {
+		if key.market == market && key.symbol == symbol {
+			net.Add(net, pos.amount)
+		}
+	}
+	return net
+}
+
+func (e *Engine) openOrders() int {
+	n := 0
+	for _, open := range e.orders {
+		if open {
+			n++
+		}
+	}
+	return n
+}
+
+// unrealized return the unrealized profit of the positions at the reference prices. The positions
+// of delivery, whose profit is in the margin coin, are not counted.
+func (e *Engine) unrealized() *big.Rat {
+	total := new(big.Rat)
+	for key, pos := range e.positions {
+		price, ok := e.prices[key.symbol]
+		if key.market == MarketDelivery || !ok || pos.amount.Sign() == 0 {
+			continue
+		}
+		pnl := new(big.Rat).Sub(price, pos.entry)
+		total.Add(total, pnl.Mul(pnl, pos.amount))
+	}
+	return total
+}
+
+func (e *Engine) dailyProfit() *big.Rat {
+	profit := new(big.Rat).Add(e.realized, e.unrealized())
+	return profit.Sub(profit, e.startUnrealized)
+}
+
+// rollDay start a new day at 00:00 UTC, and forget the orders which are done
+func (e *Engine) rollDay() {
+	day := e.now().Unix() / 86400
+	if day == e.day {
+		return
+	}
+	e.day = day
+	e.realized = new(big.Rat)
+	e.startUnrealized = e.unrealized()
+	for key, open := range e.orders {
+		if !open {
+			delete(e.orders, key)
+		}
+	}
+}
+
+// reducing return whether the order only reduces the position of its symbol
+func (e *Engine) reducing(o *order, quantity *big.Rat) bool {
+	switch o.positionSide {
+	case PositionSideLong:
+		// in hedge mode an order closes its side of the position, or is rejected by the exchange
+		return o.side == sideSell
+	case PositionSideShort:
+		return o.side == sideBuy
+	}
+	if o.reduceOnly {
+		// the exchange rejects the part of the order which would increase the position
+		return true
+	}
+	pos := e.position(newPositionKey(o.market, o.symbol, ""))
+	if pos.amount.Sign() == 0 || quantity == nil {
+		return false
+	}
+	if (o.side == sideBuy) == (pos.amount.Sign() > 0) {
+		return false
+	}
+	return quantity.Cmp(new(big.Rat).Abs(pos.amount)) <= 0
+}
+
+// check return the error of an order which breaks a limit, or record it as sent and pending
+func (e *Engine) check(o *order) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.rollDay()
+	if e.killed {
+		return ErrKilled
+	}
+	now := e.now()
+	limitError := func(limit Limit, value, max *big.Rat) error {
+		return &LimitError{Limit: limit, Market: o.market, Symbol: o.symbol, Value: common.FormatDecimal(value), Max: common.FormatDecimal(max)}
+	}
+
+	if e.maxOrdersPerSecond > 0 {
+		sent := e.sent[:0]
+		for _, t := range e.sent {
+			if now.Sub(t) < time.Second {
+				sent = append(sent, t)
+			}
+		}
+		e.sent = sent
+		if len(e.sent) >= e.maxOrdersPerSecond {
+			return limitError(LimitOrderRate, big.NewRat(int64(len(e.sent)+1), 1), big.NewRat(int64(e.maxOrdersPerSecond), 1))
+		}
+	}
+	if e.maxOpenOrders > 0 {
+		if n := e.openOrders() + len(e.pending); n >= e.maxOpenOrders {
+			return limitError(LimitOpenOrders, big.NewRat(int64(n+1), 1), big.NewRat(int64(e.maxOpenOrders), 1))
+		}
+	}
+
+	reference, hasReference := e.prices[o.symbol]
+	price := o.price
+	if price == nil && hasReference {
+		price = reference
+	}
+	quantity := o.quantity
+	if quantity == nil && o.quoteQuantity != nil && price != nil {
+		quantity = new(big.Rat).Quo(o.quoteQuantity, price)
+	}
+
+	if e.maxPriceDeviation != nil && len(o.prices) > 0 {
+		if !hasReference {
+			return ErrNoReferencePrice
+		}
+		for _, p := range o.prices {
+			deviation := new(big.Rat).Sub(p, reference)
+			deviation.Abs(deviation)
+			deviation.Quo(deviation, reference)
+			if deviation.Cmp(e.maxPriceDeviation) > 0 {
+				return limitError(LimitPriceBand, deviation, e.maxPriceDeviation)
+			}
+		}
+	}
+
+	if e.maxOrderNotional != nil {
+		var notional *big.Rat
+		switch {
+		case o.contractSize != nil && quantity != nil:
+			notional = new(big.Rat).Mul(quantity, o.contractSize)
+		case o.quoteQuantity != nil:
+			notional = o.quoteQuantity
+		case quantity != nil && price != nil:
+			notional = new(big.Rat).Mul(quantity, price)
+		case quantity != nil:
+			return ErrNoReferencePrice
+		}
+		if notional != nil && notional.Cmp(e.maxOrderNotional) > 0 {
+			return limitError(LimitOrderNotional, notional, e.maxOrderNotional)
+		}
+	}
+
+	reducing := e.reducing(o, quantity)
+	if quantity != nil && !reducing {
+		o.delta = new(big.Rat).Set(quantity)
+		if o.side == sideSell {
+			o.delta.Neg(o.delta)
+		}
+	}
+	if max, ok := e.maxPosition[o.symbol]; ok && o.delta != nil {
+		after := e.netPosition(o.market, o.symbol)
+		for pending := range e.pending {
+			if pending.market == o.market && pending.symbol == o.symbol && pending.delta != nil {
+				after.Add(after, pending.delta)
+			}
+		}
+		after.Add(after, o.delta)
+		after.Abs(after)
+		if after.Cmp(max) > 0 {
+			return limitError(LimitPosition, after, max)
+		}
+	}
+
+	if e.maxDailyLoss != nil && !reducing {
+		loss := e.dailyProfit()
+		loss.Neg(loss)
+		if loss.Cmp(e.maxDailyLoss) >= 0 {
+			return limitError(LimitDailyLoss, loss, e.maxDailyLoss)
+		}
+	}
+
+	if e.maxOrdersPerSecond > 0 {
+		e.sent = append(e.sent, now)
+	}
+	e.pending[o] = true
+	return nil
+}
+
+// release forget a pending order once the exchange answered
+func (e *Engine) release(o *order) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	delete(e.pending, o)
+}
+
+// placed record an order accepted by the exchange, in place of its pending order
+func (e *Engine) placed(o *order, id int64, open bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	delete(e.pending, o)
+	key := orderKey(o.market, o.symbol, id)
+	// the order update of the user data stream may come first
+	if _, ok := e.orders[key]; !ok {
+		e.orders[key] = open
+	}
+}
+
+func (e *Engine) apply(u *update) {
+	if u.id == 0 {
+		// not an order update, e.g. the empty order update of another event
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.rollDay()
+	e.orders[orderKey(u.market, u.symbol, u.id)] = !u.final
+	if u.quantity == nil || u.quantity.Sign() == 0 {
+		return
+	}
+	realized := e.position(newPositionKey(u.market, u.symbol, u.positionSide)).fill(u.side, u.quantity, u.price)
+	if u.realized != nil {
+		realized = u.realized
+	}
+	e.realized.Add(e.realized, realized)
+	if u.commission != nil {
+		e.realized.Sub(e.realized, u.commission)
+	}
+}
@@ -0,0 +1,356 @@
+package risk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Zamzam-Technology/go-binance/v2"
+	"github.com/Zamzam-Technology/go-binance/v2/common"
+	"github.com/Zamzam-Technology/go-binance/v2/delivery"
+	"github.com/Zamzam-Technology/go-binance/v2/futures"
+	"github.com/Zamzam-Technology/go-binance/v2/paper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const exchangeInfo = `{
+	"symbols": [{
+		"symbol": "BTCUSDT",
+		"status": "TRADING",
+		"baseAsset": "BTC",
+		"quoteAsset": "USDT",
+		"marginAsset": "USDT",
+		"filters": [
+			{"filterType": "PRICE_FILTER", "minPrice": "0.01", "maxPrice": "1000000", "tickSize": "0.01"},
+			{"filterType": "LOT_SIZE", "minQty": "0.001", "maxQty": "100", "stepSize": "0.001"}
+		]
+	}]
+}`
+
+func newTestEngine(t *testing.T, limits Limits) (*Engine, *time.Time) {
+	e, err := NewEngine(limits)
+	require.NoError(t, err)
+	now := time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+	e.now = func() time.Time { return now }
+	return e, &now
+}
+
+func newTestSpotClient(t *testing.T, e *Engine) *binance.Client {
+	info := new(binance.ExchangeInfo)
+	require.NoError(t, json.Unmarshal([]byte(exchangeInfo), info))
+	x, err := paper.NewSpotExchange(info, &paper.Config{Balances: map[string]string{"USDT": "100000", "BTC": "10"}})
+	require.NoError(t, err)
+	x.SubscribeUserData(func(event *binance.WsUserDataEvent) {
+		if event.OrderUpdate != nil {
+			e.ApplySpotUpdate(event.OrderUpdate)
+		}
+	})
+	x.OnBook("BTCUSDT", []common.PriceLevel{{Price: "99", Quantity: "10"}}, []common.PriceLevel{{Price: "100", Quantity: "10"}})
+	c := x.Client()
+	e.Wrap(c)
+	return c
+}
+
+func limitBuy(c *binance.Client, quantity, price string) error {
+	_, err := c.NewCreateOrderService().Symbol("BTCUSDT").Side(binance.SideTypeBuy).
+		Type(binance.OrderTypeLimit).TimeInForce(binance.TimeInForceTypeGTC).
+		Quantity(quantity).Price(price).Do(context.Background())
+	return err
+}
+
+func assertLimitError(t *testing.T, err error, limit Limit, value, max string) {
+	var limitErr *LimitError
+	if assert.True(t, errors.As(err, &limitErr), "%v", err) {
+		assert.Equal(t, limit, limitErr.Limit)
+		assert.Equal(t, "BTCUSDT", limitErr.Symbol)
+		assert.Equal(t, value, limitErr.Value)
+		assert.Equal(t, max, limitErr.Max)
+	}
+}
+
+func TestOrderLimits(t *testing.T) {
+	e, _ := newTestEngine(t, Limits{MaxOrderNotional: "1000", MaxPriceDeviation: "0.05", MaxOpenOrders: 2})
+	c := newTestSpotClient(t, e)
+
+	// the price band needs a reference price
+	assert.True(t, errors.Is(limitBuy(c, "1", "98"), ErrNoReferencePrice))
+	require.NoError(t, e.SetPrice("BTCUSDT", "100"))
+
+	assertLimitError(t, limitBuy(c, "20", "99"), LimitOrderNotional, "1980", "1000")
+	assertLimitError(t, limitBuy(c, "1", "90"), LimitPriceBand, "0.1", "0.05")
+	_, err := c.NewCreateOrderService().Symbol("BTCUSDT").Side(binance.SideTypeBuy).
+		Type(binance.OrderTypeMarket).Quantity("11").Do(context.Background())
+	assertLimitError(t, err, LimitOrderNotional, "1100", "1000")
+
+	require.NoError(t, limitBuy(c, "1", "98"))
+	require.NoError(t, limitBuy(c, "1", "97"))
+	assert.Equal(t, 2, e.OpenOrders())
+	assertLimitError(t, limitBuy(c, "1", "96"), LimitOpenOrders, "3", "2")
+
+	require.NoError(t, e.Kill(context.Background()))
+	assert.True(t, e.Killed())
+	assert.Equal(t, 0, e.OpenOrders())
+	orders, err := c.NewListOpenOrdersService().Do(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, orders)
+	assert.True(t, errors.Is(limitBuy(c, "1", "98"), ErrKilled))
+
+	e.Resume()
+	assert.NoError(t, limitBuy(c, "1", "98"))
+}
+
+func TestOrderRate(t *testing.T) {
+	e, now := newTestEngine(t, Limits{MaxOrdersPerSecond: 2})
+	c := newTestSpotClient(t, e)
+	require.NoError(t, limitBuy(c, "1", "98"))
+	*now = now.Add(500 * time.Millisecond)
+	require.NoError(t, limitBuy(c, "1", "98"))
+	assertLimitError(t, limitBuy(c, "1", "98"), LimitOrderRate, "3", "2")
+	*now = now.Add(600 * time.Millisecond)
+	assert.NoError(t, limitBuy(c, "1", "98"))
+}
+
+func TestPositionAndDailyLoss(t *testing.T) {
+	e, now := newTestEngine(t, Limits{MaxPosition: map[string]string{"BTCUSDT": "2"}, MaxDailyLoss: "50"})
+	info := new(futures.ExchangeInfo)
+	require.NoError(t, json.Unmarshal([]byte(exchangeInfo), info))
+	x, err := paper.NewFuturesExchange(info, &paper.Config{Balances: map[string]string{"USDT": "10000"}})
+	require.NoError(t, err)
+	x.SubscribeUserData(func(event *futures.WsUserDataEvent) {
+		if event.Event == futures.UserDataEventTypeOrderTradeUpdate {
+			e.ApplyFuturesUpdate(&event.OrderTradeUpdate)
+		}
+	})
+	x.OnBook("BTCUSDT", []common.PriceLevel{{Price: "99", Quantity: "10"}}, []common.PriceLevel{{Price: "100", Quantity: "10"}})
+	c := x.Client()
+	e.WrapFutures(c)
+	market := func(side futures.SideType, quantity string) error {
+		_, err := c.NewCreateOrderService().Symbol("BTCUSDT").Side(side).
+			Type(futures.OrderTypeMarket).Quantity(quantity).Do(context.Background())
+		return err
+	}
+	require.NoError(t, e.SetPrice("BTCUSDT", "100"))
+
+	require.NoError(t, market(futures.SideTypeBuy, "2"))
+	assert.Equal(t, "2", e.Position(MarketFutures, "BTCUSDT"))
+	assertLimitError(t, market(futures.SideTypeBuy, "1"), LimitPosition, "3", "2")
+	require.NoError(t, market(futures.SideTypeSell, "1"))
+	assert.Equal(t, "1", e.Position(MarketFutures, "BTCUSDT"))
+	// bought 2 at 100 with 0.08 of commission, sold 1 at 99 with 0.0396 of commission
+	assert.Equal(t, "-1.1196", e.DailyProfit())
+
+	require.NoError(t, e.SetPrice("BTCUSDT", "50"))
+	assert.Equal(t, "-51.1196", e.DailyProfit())
+	assertLimitError(t, market(futures.SideTypeBuy, "0.1"), LimitDailyLoss, "51.1196", "50")
+	// the orders reducing the position are allowed
+	require.NoError(t, market(futures.SideTypeSell, "0.5"))
+
+	// the unrealized loss of the previous day is not counted
+	*now = now.Add(24 * time.Hour)
+	assert.Equal(t, "0", e.DailyProfit())
+	assert.NoError(t, market(futures.SideTypeBuy, "0.1"))
+}
+
+type futuresTransport struct {
+	orders int
+}
+
+func (t *futuresTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.orders++
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       ioutil.NopCloser(strings.NewReader(`{"symbol": "BTCUSDT", "orderId": 1, "status": "NEW"}`)),
+		Request:    req,
+	}, nil
+}
+
+func TestReduceOnlyOrders(t *testing.T) {
+	e, _ := newTestEngine(t, Limits{MaxPosition: map[string]string{"BTCUSDT": "2"}, MaxDailyLoss: "50"})
+	stub := new(futuresTransport)
+	c := futures.NewClient("key", "secret")
+	c.HTTPClient = &http.Client{Transport: stub}
+	e.WrapFutures(c)
+	require.NoError(t, e.SetPrice("BTCUSDT", "100"))
+	require.NoError(t, e.SetPosition(MarketFutures, "BTCUSDT", "1", "100"))
+	require.NoError(t, e.SetPrice("BTCUSDT", "40"))
+	ctx := context.Background()
+
+	_, err := c.NewCreateOrderService().Symbol("BTCUSDT").Side(futures.SideTypeBuy).
+		Type(futures.OrderTypeMarket).Quantity("0.1").Do(ctx)
+	assertLimitError(t, err, LimitDailyLoss, "60", "50")
+	_, err = c.NewCreateOrderService().Symbol("BTCUSDT").Side(futures.SideTypeSell).
+		Type(futures.OrderTypeStopMarket).StopPrice("39").ClosePosition(true).Do(ctx)
+	assert.NoError(t, err)
+	// larger than the position, the exchange reduces it to the position
+	_, err = c.NewCreateOrderService().Symbol("BTCUSDT").Side(futures.SideTypeSell).
+		Type(futures.OrderTypeMarket).Quantity("4").ReduceOnly(true).Do(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, stub.orders)
+}
+
+func TestHedgeModeOrders(t *testing.T) {
+	e, _ := newTestEngine(t, Limits{MaxPosition: map[string]string{"BTCUSDT": "2"}})
+	stub := new(futuresTransport)
+	c := futures.NewClient("key", "secret")
+	c.HTTPClient = &http.Client{Transport: stub}
+	e.WrapFutures(c)
+	require.NoError(t, e.SetHedgePosition(MarketFutures, "BTCUSDT", PositionSideLong, "2", "100"))
+	assert.Error(t, e.SetHedgePosition(MarketFutures, "BTCUSDT", PositionSideBoth, "2", "100"))
+	order := func(side futures.SideType, positionSide futures.PositionSideType, quantity string) error {
+		_, err := c.NewCreateOrderService().Symbol("BTCUSDT").Side(side).PositionSide(positionSide).
+			Type(futures.OrderTypeMarket).Quantity(quantity).Do(context.Background())
+		return err
+	}
+
+	assertLimitError(t, order(futures.SideTypeBuy, futures.PositionSideTypeLong, "1"), LimitPosition, "3", "2")
+	// a SELL LONG order closes the long position, it never opens a short one
+	assert.NoError(t, order(futures.SideTypeSell, futures.PositionSideTypeLong, "3"))
+	// the short position counts against the net position
+	assert.NoError(t, order(futures.SideTypeSell, futures.PositionSideTypeShort, "3"))
+	assertLimitError(t, order(futures.SideTypeSell, futures.PositionSideTypeShort, "5"), LimitPosition, "3", "2")
+	assert.Equal(t, 2, stub.orders)
+
+	e.ApplyFuturesUpdate(&futures.WsOrderTradeUpdate{ID: 2, Symbol: "BTCUSDT", Side: futures.SideTypeSell,
+		PositionSide: futures.PositionSideTypeShort, Status: futures.OrderStatusTypeFilled,
+		ExecutionType: futures.OrderExecutionTypeTrade, LastFilledQty: "3", LastFilledPrice: "100"})
+	assert.Equal(t, "-1", e.Position(MarketFutures, "BTCUSDT"))
+	// the BUY SHORT order reduces the short position, the long position is unchanged
+	assert.NoError(t, order(futures.SideTypeBuy, futures.PositionSideTypeShort, "3"))
+	e.ApplyFuturesUpdate(&futures.WsOrderTradeUpdate{ID: 3, Symbol: "BTCUSDT", Side: futures.SideTypeBuy,
+		PositionSide: futures.PositionSideTypeShort, Status: futures.OrderStatusTypeFilled,
+		ExecutionType: futures.OrderExecutionTypeTrade, LastFilledQty: "3", LastFilledPrice: "90", RealizedPnL: "30"})
+	assert.Equal(t, "2", e.Position(MarketFutures, "BTCUSDT"))
+	assert.Equal(t, "30", e.DailyProfit())
+}
+
+// blockingTransport answer the orders once released, with the status of the response
+type blockingTransport struct {
+	status  int
+	arrived chan struct{}
+	answer  chan struct{}
+}
+
+func (t *blockingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.arrived <- struct{}{}
+	<-t.answer
+	return &http.Response{
+		StatusCode: t.status,
+		Body:       ioutil.NopCloser(strings.NewReader(`{"code": -2019, "msg": "Margin is insufficient."}`)),
+		Request:    req,
+	}, nil
+}
+
+func TestPendingOrders(t *testing.T) {
+	tests := []struct {
+		limits Limits
+		limit  Limit
+		value  string
+		max    string
+	}{
+		{Limits{MaxOpenOrders: 1}, LimitOpenOrders, "2", "1"},
+		{Limits{MaxPosition: map[string]string{"BTCUSDT": "2"}}, LimitPosition, "3", "2"},
+	}
+	for _, tt := range tests {
+		e, _ := newTestEngine(t, tt.limits)
+		stub := &blockingTransport{status: http.StatusBadRequest, arrived: make(chan struct{}), answer: make(chan struct{})}
+		c := futures.NewClient("key", "secret")
+		c.HTTPClient = &http.Client{Transport: stub}
+		e.WrapFutures(c)
+		order := func(quantity string) error {
+			_, err := c.NewCreateOrderService().Symbol("BTCUSDT").Side(futures.SideTypeBuy).
+				Type(futures.OrderTypeMarket).Quantity(quantity).Do(context.Background())
+			return err
+		}
+
+		done := make(chan error)
+		go func() {
+			done <- order("2")
+		}()
+		<-stub.arrived
+		// the first order holds an open order and its position until the exchange answers
+		assertLimitError(t, order("1"), tt.limit, tt.value, tt.max)
+		close(stub.answer)
+		assert.True(t, common.IsAPIError(<-done))
+
+		// the rejected order released them
+		go func() {
+			<-stub.arrived
+		}()
+		assert.True(t, common.IsAPIError(order("2")))
+		assert.Equal(t, 0, e.OpenOrders())
+		assert.Empty(t, e.pending)
+	}
+}
+
+type deliveryTransport struct {
+	orders int
+}
+
+func (t *deliveryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body := `{"symbols": [{"symbol": "BTCUSD_PERP", "contractSize": 100}]}`
+	if req.URL.Path == "/dapi/v1/order" {
+		t.orders++
+		body = `{"symbol": "BTCUSD_PERP", "orderId": 1, "status": "NEW"}`
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       ioutil.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}, nil
+}
+
+func TestDeliveryNotional(t *testing.T) {
+	e, _ := newTestEngine(t, Limits{MaxOrderNotional: "1000"})
+	stub := new(deliveryTransport)
+	c := delivery.NewClient("key", "secret")
+	c.HTTPClient = &http.Client{Transport: stub}
+	e.WrapDelivery(c)
+	order := func(quantity string) error {
+		_, err := c.NewCreateOrderService().Symbol("BTCUSD_PERP").Side(delivery.SideTypeBuy).
+			Type(delivery.OrderTypeMarket).Quantity(quantity).Do(context.Background())
+		return err
+	}
+	var limitErr *LimitError
+	require.True(t, errors.As(order("11"), &limitErr))
+	assert.Equal(t, "1100", limitErr.Value)
+	assert.NoError(t, order("10"))
+	assert.Equal(t, 1, stub.orders)
+	assert.Equal(t, 1, e.OpenOrders())
+}
+
+func TestNewEngine(t *testing.T) {
+	_, err := NewEngine(Limits{MaxOrderNotional: "x"})
+	assert.Error(t, err)
+	_, err = NewEngine(Limits{MaxPosition: map[string]string{"BTCUSDT": "-1"}})
+	assert.Error(t, err)
+}
+
+func TestKillMarginOrders(t *testing.T) {
+	e, _ := newTestEngine(t, Limits{})
+	c := binance.NewFakeClient()
+	require.NoError(t, c.Stub(http.MethodGet, "/api/v3/openOrders", `[]`))
+	require.NoError(t, c.Stub(http.MethodGet, "/sapi/v1/margin/openOrders", `[{"symbol": "BTCUSDT", "orderId": 1}]`))
+	require.NoError(t, c.Stub(http.MethodGet, "/sapi/v1/margin/isolated/account", `{"assets": [{"symbol": "ETHUSDT"}]}`))
+	require.NoError(t, c.Stub(http.MethodDelete, "/sapi/v1/margin/openOrders", `[]`))
+	e.Wrap(c.Client)
+
+	// no margin order was seen, the margin orders are canceled anyway
+	require.NoError(t, e.Kill(context.Background()))
+	lists := c.Calls(http.MethodGet, "/sapi/v1/margin/openOrders")
+	require.Len(t, lists, 2)
+	assert.Equal(t, "", lists[0].Params.Get("isIsolated"))
+	assert.Equal(t, "ETHUSDT", lists[1].Params.Get("symbol"))
+	assert.Equal(t, "TRUE", lists[1].Params.Get("isIsolated"))
+	cancels := c.Calls(http.MethodDelete, "/sapi/v1/margin/openOrders")
+	require.Len(t, cancels, 2)
+	assert.Equal(t, "BTCUSDT", cancels[0].Params.Get("symbol"))
+	assert.Equal(t, "", cancels[0].Params.Get("isIsolated"))
+	assert.Equal(t, "ETHUSDT", cancels[1].Params.Get("symbol"))
+	assert.Equal(t, "TRUE", cancels[1].Params.Get("isIsolated"))
+}
@@ -0,0 +1,383 @@
+package risk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Zamzam-Technology/go-binance/v2"
+	"github.com/Zamzam-Technology/go-binance/v2/common"
+	"github.com/Zamzam-Technology/go-binance/v2/delivery"
+	"github.com/Zamzam-Technology/go-binance/v2/futures"
+)
+
+const (
+	sideBuy  = "BUY"
+	sideSell = "SELL"
+)
+
+// orderEndpoints are the endpoints placing new orders, by market
+var orderEndpoints = map[string]Market{
+	"/api/v3/order":               MarketSpot,
+	"/api/v3/order/oco":           MarketSpot,
+	"/api/v3/orderList/oco":       MarketSpot,
+	"/api/v3/sor/order":           MarketSpot,
+	"/api/v3/order/cancelReplace": MarketSpot,
+	"/sapi/v1/margin/order":       MarketMargin,
+	"/sapi/v1/margin/order/oco":   MarketMargin,
+	"/fapi/v1/order":              MarketFutures,
+	"/dapi/v1/order":              MarketDelivery,
+}
+
+// priceParams are the order parameters checked against the price band
+var priceParams = []string{"price", "stopPrice", "abovePrice", "belowPrice", "aboveStopPrice", "belowStopPrice"}
+
+// transport check the new orders sent through next
+type transport struct {
+	e    *Engine
+	next http.RoundTripper
+	// contractSize return the contract size of a delivery symbol
+	contractSize func(ctx context.Context, symbol string) (*big.Rat, error)
+}
+
+func (e *Engine) wrap(c *http.Client) (*http.Client, *transport) {
+	t := &transport{e: e, next: c.Transport}
+	if t.next == nil {
+		t.next = http.DefaultTransport
+	}
+	// copy the client, which may be http.DefaultClient
+	wrapped := *c
+	wrapped.Transport = t
+	return &wrapped, t
+}
+
+// Wrap check the spot and margin orders sent by c. Kill cancels the spot open orders, the cross
+// margin open orders and the isolated margin open orders of c.
+func (e *Engine) Wrap(c *binance.Client) {
+	c.HTTPClient, _ = e.wrap(c.HTTPClient)
+	e.addCanceler(func(ctx context.Context) error {
+		orders, err := c.NewListOpenOrdersService().Do(ctx)
+		if err != nil {
+			return err
+		}
+		symbols := make([]string, 0, len(orders))
+		for _, o := range orders {
+			symbols = append(symbols, o.Symbol)
+		}
+		for _, symbol := range uniqueSymbols(symbols) {
+			if _, err := c.NewCancelOpenOrdersService().Symbol(symbol).Do(ctx); err != nil {
+				return err
+			}
+		}
+		if err := cancelMarginOrders(ctx, c); err != nil && e.usedMargin() {
+			// the errors are ignored until a margin order is seen, the account may have no margin
+			return err
+		}
+		return nil
+	})
+}
+
+// cancelMarginOrders cancel the cross margin open orders, then the open orders of each isolated margin account
+func cancelMarginOrders(ctx context.Context, c *binance.Client) error {
+	orders, err := c.NewListMarginOpenOrdersService().Do(ctx)
+	if err != nil {
+		return err
+	}
+	symbols := make([]string, 0, len(orders))
+	for _, o := range orders {
+		symbols = append(symbols, o.Symbol)
+	}
+	for _, symbol := range uniqueSymbols(symbols) {
+		if _, err := c.NewCancelMarginOpenOrdersService().Symbol(symbol).Do(ctx); err != nil {
+			return err
+		}
+	}
+	account, err := c.NewGetIsolatedMarginAccountService().Do(ctx)
+	if err != nil {
+		return err
+	}
+	for _, asset := range account.Assets {
+		orders, err := c.NewListMarginOpenOrdersService().Symbol(asset.Symbol).IsIsolated(true).Do(ctx)
+		if err != nil {
+			return err
+		}
+		if len(orders) == 0 {
+			continue
+		}
+		if _, err := c.NewCancelMarginOpenOrdersService().Symbol(asset.Symbol).IsIsolated(true).Do(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WrapFutures check the orders sent by c. Kill cancels the open orders of c.
+func (e *Engine) WrapFutures(c *futures.Client) {
+	c.HTTPClient, _ = e.wrap(c.HTTPClient)
+	e.addCanceler(func(ctx context.Context) error {
+		orders, err := c.NewListOpenOrdersService().Do(ctx)
+		if err != nil {
+			return err
+		}
+		symbols := make([]string, 0, len(orders))
+		for _, o := range orders {
+			symbols = append(symbols, o.Symbol)
+		}
+		for _, symbol := range uniqueSymbols(symbols) {
+			if err := c.NewCancelAllOpenOrdersService().Symbol(symbol).Do(ctx); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// WrapDelivery check the orders sent by c. The contract sizes are read from the exchange info
+// with c. Kill cancels the open orders of c.
+func (e *Engine) WrapDelivery(c *delivery.Client) {
+	var t *transport
+	c.HTTPClient, t = e.wrap(c.HTTPClient)
+	var mu sync.Mutex
+	sizes := make(map[string]*big.Rat)
+	t.contractSize = func(ctx context.Context, symbol string) (*big.Rat, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if size, ok := sizes[symbol]; ok {
+			return size, nil
+		}
+		info, err := c.NewExchangeInfoService().Do(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range info.Symbols {
+			sizes[s.Symbol] = big.NewRat(int64(s.ContractSize), 1)
+		}
+		return sizes[symbol], nil
+	}
+	e.addCanceler(func(ctx context.Context) error {
+		orders, err := c.NewListOpenOrdersService().Do(ctx)
+		if err != nil {
+			return err
+		}
+		symbols := make([]string, 0, len(orders))
+		for _, o := range orders {
+			symbols = append(symbols, o.Symbol)
+		}
+		for _, symbol := range uniqueSymbols(symbols) {
+			if err := c.NewCancelAllOpenOrdersService().Symbol(symbol).Do(ctx); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (e *Engine) addCanceler(cancel func(ctx context.Context) error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.cancelers = append(e.cancelers, cancel)
+}
+
+// usedMargin return whether the engine has seen margin orders
+func (e *Engine) usedMargin() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	prefix := string(MarketMargin) + "/"
+	for key := range e.orders {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func uniqueSymbols(symbols []string) []string {
+	seen := make(map[string]bool)
+	unique := make([]string, 0, len(symbols))
+	for _, symbol := range symbols {
+		if !seen[symbol] {
+			seen[symbol] = true
+			unique = append(unique, symbol)
+		}
+	}
+	sort.Strings(unique)
+	return unique
+}
+
+// RoundTrip check a new order before sending it, and record it once accepted
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	market, ok := orderEndpoints[req.URL.Path]
+	if !ok || req.Method != http.MethodPost {
+		return t.next.RoundTrip(req)
+	}
+	p, err := readParams(req)
+	if err != nil {
+		return nil, err
+	}
+	o, err := t.order(req.Context(), market, p)
+	if err != nil {
+		return nil, err
+	}
+	if err := t.e.check(o); err != nil {
+		return nil, err
+	}
+	// the order holds its open order and its position until the exchange answers
+	defer t.e.release(o)
+	res, err := t.next.RoundTrip(req)
+	if err != nil || res.StatusCode >= 400 {
+		return res, err
+	}
+	data, err := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = ioutil.NopCloser(bytes.NewReader(data))
+	var placed struct {
+		OrderID int64  `json:"orderId"`
+		Status  string `json:"status"`
+	}
+	// the order lists have no order id, their orders are followed from the user data stream
+	if json.Unmarshal(data, &placed) == nil && placed.OrderID != 0 {
+		open := placed.Status == "" || placed.Status == "NEW" || placed.Status == "PARTIALLY_FILLED"
+		t.e.placed(o, placed.OrderID, open)
+	}
+	return res, nil
+}
+
+func (t *transport) order(ctx context.Context, market Market, p url.Values) (*order, error) {
+	o := &order{market: market, symbol: p.Get("symbol"), side: p.Get("side"), positionSide: p.Get("positionSide")}
+	if o.positionSide == PositionSideBoth {
+		o.positionSide = ""
+	}
+	o.reduceOnly = strings.EqualFold(p.Get("reduceOnly"), "true") || strings.EqualFold(p.Get("closePosition"), "true")
+	var err error
+	if o.quantity, err = decimalParam(p, "quantity"); err != nil {
+		return nil, err
+	}
+	if o.quoteQuantity, err = decimalParam(p, "quoteOrderQty"); err != nil {
+		return nil, err
+	}
+	for _, name := range priceParams {
+		price, err := decimalParam(p, name)
+		if err != nil {
+			return nil, err
+		}
+		if price == nil {
+			continue
+		}
+		o.prices = append(o.prices, price)
+		// the notional of an order list is taken at its highest price
+		if o.price == nil || price.Cmp(o.price) > 0 {
+			o.price = price
+		}
+	}
+	if t.contractSize != nil {
+		if o.contractSize, err = t.contractSize(ctx, o.symbol); err != nil {
+			return nil, err
+		}
+	}
+	return o, nil
+}
+
+// readParams return the parameters of the query and the form body, and restore the body
+func readParams(req *http.Request) (url.Values, error) {
+	p := req.URL.Query()
+	if req.Body == nil {
+		return p, nil
+	}
+	data, err := ioutil.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = ioutil.NopCloser(bytes.NewReader(data))
+	form, err := url.ParseQuery(string(data))
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range form {
+		p[key] = append(p[key], values...)
+	}
+	return p, nil
+}
+
+func decimalParam(p url.Values, name string) (*big.Rat, error) {
+	v := p.Get(name)
+	if v == "" {
+		return nil, nil
+	}
+	r, ok := new(big.Rat).SetString(v)
+	if !ok {
+		return nil, fmt.Errorf("risk: invalid %s %q", name, v)
+	}
+	return r, nil
+}
+
+// ApplySpotUpdate update the open orders, the position and the daily profit with an execution report of the spot user data stream
+func (e *Engine) ApplySpotUpdate(u *binance.WsOrderUpdate) {
+	e.apply(spotUpdate(MarketSpot, u))
+}
+
+// ApplyMarginUpdate update the open orders, the position and the daily profit with an execution report of the margin user data stream
+func (e *Engine) ApplyMarginUpdate(u *binance.WsOrderUpdate) {
+	e.apply(spotUpdate(MarketMargin, u))
+}
+
+// ApplyFuturesUpdate update the open orders, the position and the daily profit with an order update of the futures user data stream
+func (e *Engine) ApplyFuturesUpdate(u *futures.WsOrderTradeUpdate) {
+	up := &update{market: MarketFutures, symbol: u.Symbol, id: u.ID, side: string(u.Side), positionSide: string(u.PositionSide)}
+	switch u.Status {
+	case futures.OrderStatusTypeFilled, futures.OrderStatusTypeCanceled, futures.OrderStatusTypeRejected,
+		futures.OrderStatusTypeExpired:
+		up.final = true
+	}
+	if u.ExecutionType == futures.OrderExecutionTypeTrade {
+		up.quantity = common.DecimalOrZero(u.LastFilledQty)
+		up.price = common.DecimalOrZero(u.LastFilledPrice)
+		up.realized = common.DecimalOrZero(u.RealizedPnL)
+		up.commission = common.DecimalOrZero(u.Commission)
+	}
+	e.apply(up)
+}
+
+// ApplyDeliveryUpdate update the open orders, the position and the daily profit with an order update
+// of the delivery user data stream. The profit and the commission in the margin coin are converted
+// at the price of the trade.
+func (e *Engine) ApplyDeliveryUpdate(u *delivery.WsOrderTradeUpdate) {
+	up := &update{market: MarketDelivery, symbol: u.Symbol, id: u.ID, side: string(u.Side), positionSide: string(u.PositionSide)}
+	switch u.Status {
+	case delivery.OrderStatusTypeFilled, delivery.OrderStatusTypeCanceled, delivery.OrderStatusTypeRejected,
+		delivery.OrderStatusTypeExpired:
+		up.final = true
+	}
+	if u.ExecutionType == delivery.OrderExecutionTypeTrade {
+		up.quantity = common.DecimalOrZero(u.LastFilledQty)
+		up.price = common.DecimalOrZero(u.LastFilledPrice)
+		up.realized = new(big.Rat).Mul(common.DecimalOrZero(u.RealizedPnL), up.price)
+		up.commission = new(big.Rat).Mul(common.DecimalOrZero(u.Commission), up.price)
+	}
+	e.apply(up)
+}
+
+func spotUpdate(market Market, u *binance.WsOrderUpdate) *update {
+	up := &update{market: market, symbol: u.Symbol, id: u.ID, side: string(u.Side)}
+	switch u.Status {
+	case binance.OrderStatusTypeFilled, binance.OrderStatusTypeCanceled, binance.OrderStatusTypeRejected,
+		binance.OrderStatusTypeExpired, binance.OrderStatusTypeExpiredInMatch:
+		up.final = true
+	}
+	if u.ExecutionType == binance.ExecutionTypeTrade {
+		up.quantity = common.DecimalOrZero(u.LastFilledQuantity)
+		up.price = common.DecimalOrZero(u.LastFilledPrice)
+	}
+	return up
+}
@@ -0,0 +1,260 @@
+package binance
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// CreateSOROrderService create an order using smart order routing (SOR)
+type CreateSOROrderService struct {
+	c                       *Client
+	symbol                  string
+	side                    SideType
+	orderType               OrderType
+	timeInForce             *TimeInForceType
+	quantity                string
+	price                   *string
+	newClientOrderID        *string
+	strategyID              *int64
+	strategyType            *int64
+	icebergQuantity         *string
+	newOrderRespType        *NewOrderRespType
+	selfTradePreventionMode *SelfTradePreventionMode
+}
+
+// Symbol set symbol
+func (s *CreateSOROrderService) Symbol(symbol string) *CreateSOROrderService {
+	s.symbol = symbol
+	return s
+}
+
+// Side set side
+func (s *CreateSOROrderService) Side(side SideType) *CreateSOROrderService {
+	s.side = side
+	return s
+}
+
+// Type set type, only LIMIT and MARKET orders are supported
+func (s *CreateSOROrderService) Type(orderType OrderType) *CreateSOROrderService {
+	s.orderType = orderType
+	return s
+}
+
+// TimeInForce set timeInForce
+func (s *CreateSOROrderService) TimeInForce(timeInForce TimeInForceType) *CreateSOROrderService {
+	s.timeInForce = &timeInForce
+	return s
+}
+
+// Quantity set quantity
+func (s *CreateSOROrderService) Quantity(quantity string) *CreateSOROrderService {
+	s.quantity = quantity
+	return s
+}
+
+// Price set price
+func (s *CreateSOROrderService) Price(price string) *CreateSOROrderService {
+	s.price = &price
+	return s
+}
+
+// NewClientOrderID set newClientOrderID
+func (s *CreateSOROrderService) NewClientOrderID(newClientOrderID string) *CreateSOROrderService {
+	s.newClientOrderID = &newClientOrderID
+	return s
+}
+
+// StrategyID set strategyId
+func (s *CreateSOROrderService) StrategyID(strategyID int64) *CreateSOROrderService {
+	s.strategyID = &strategyID
+	return s
+}
+
+// StrategyType set strategyType, at least 1000000
+func (s *CreateSOROrderService) StrategyType(strategyType int64) *CreateSOROrderService {
+	s.strategyType = &strategyType
+	return s
+}
+
+// IcebergQuantity set icebergQuantity
+func (s *CreateSOROrderService) IcebergQuantity(icebergQuantity string) *CreateSOROrderService {
+	s.icebergQuantity = &icebergQuantity
+	return s
+}
+
+// NewOrderRespType set newOrderRespType
+func (s *CreateSOROrderService) NewOrderRespType(newOrderRespType NewOrderRespType) *CreateSOROrderService {
+	s.newOrderRespType = &newOrderRespType
+	return s
+}
+
+// SelfTradePreventionMode set selfTradePreventionMode
+func (s *CreateSOROrderService) SelfTradePreventionMode(selfTradePreventionMode SelfTradePreventionMode) *CreateSOROrderService {
+	s.selfTradePreventionMode = &selfTradePreventionMode
+	return s
+}
+
+func (s *CreateSOROrderService) createOrder(ctx context.Context, endpoint string, opts ...RequestOption) (data []byte, err error) {
+	r := &request{
+		method:   "POST",
+		endpoint: endpoint,
+		secType:  secTypeSigned,
+	}
+	m := params{
+		"symbol":   s.symbol,
+		"side":     s.side,
+		"type":     s.orderType,
+		"quantity": s.quantity,
+	}
+	if s.timeInForce != nil {
+		m["timeInForce"] = *s.timeInForce
+	}
+	if s.price != nil {
+		m["price"] = *s.price
+	}
+	if s.newClientOrderID != nil {
+		m["newClientOrderId"] = *s.newClientOrderID
+	}
+	if s.strategyID != nil {
+		m["strategyId"] = *s.strategyID
+	}
+	if s.strategyType != nil {
+		m["strategyType"] = *s.strategyType
+	}
+	if s.icebergQuantity != nil {
+		m["icebergQty"] = *s.icebergQuantity
+	}
+	if s.newOrderRespType != nil {
+		m["newOrderRespType"] = *s.newOrderRespType
+	}
+	if s.selfTradePreventionMode != nil {
+		m["selfTradePreventionMode"] = *s.selfTradePreventionMode
+	}
+	r.setFormParams(m)
+	data, err = s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []byte{}, err
+	}
+	return data, nil
+}
+
+// Do send request
+func (s *CreateSOROrderService) Do(ctx context.Context, opts ...RequestOption) (res *CreateOrderResponse, err error) {
+	data, err := s.createOrder(ctx, "/api/v3/sor/order", opts...)
+	if err != nil {
+		return nil, err
+	}
+	res = new(CreateOrderResponse)
+	err = json.Unmarshal(data, res)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Test send test api to check if the request is valid
+func (s *CreateSOROrderService) Test(ctx context.Context, opts ...RequestOption) (err error) {
+	_, err = s.createOrder(ctx, "/api/v3/sor/order/test", opts...)
+	return err
+}
+
+// ListAllocationsService list the allocations resulting from SOR order placement
+type ListAllocationsService struct {
+	c                *Client
+	symbol           string
+	startTime        *int64
+	endTime          *int64
+	fromAllocationID *int64
+	limit            *int
+	orderID          *int64
+}
+
+// Symbol set symbol
+func (s *ListAllocationsService) Symbol(symbol string) *ListAllocationsService {
+	s.symbol = symbol
+	return s
+}
+
+// StartTime set startTime
+func (s *ListAllocationsService) StartTime(startTime int64) *ListAllocationsService {
+	s.startTime = &startTime
+	return s
+}
+
+// EndTime set endTime
+func (s *ListAllocationsService) EndTime(endTime int64) *ListAllocationsService {
+	s.endTime = &endTime
+	return s
+}
+
+// FromAllocationID set fromAllocationId
+func (s *ListAllocationsService) FromAllocationID(fromAllocationID int64) *ListAllocationsService {
+	s.fromAllocationID = &fromAllocationID
+	return s
+}
+
+// Limit set limit
+func (s *ListAllocationsService) Limit(limit int) *ListAllocationsService {
+	s.limit = &limit
+	return s
+}
+
+// OrderID set orderId
+func (s *ListAllocationsService) OrderID(orderID int64) *ListAllocationsService {
+	s.orderID = &orderID
+	return s
+}
+
+// Do send request
+func (s *ListAllocationsService) Do(ctx context.Context, opts ...RequestOption) (res []*Allocation, err error) {
+	r := &request{
+		method:   "GET",
+		endpoint: "/api/v3/myAllocations",
+		secType:  secTypeSigned,
+	}
+	r.setParam("symbol", s.symbol)
+	if s.startTime != nil {
+		r.setParam("startTime", *s.startTime)
+	}
+	if s.endTime != nil {
+		r.setParam("endTime", *s.endTime)
+	}
+	if s.fromAllocationID != nil {
+		r.setParam("fromAllocationId", *s.fromAllocationID)
+	}
+	if s.limit != nil {
+		r.setParam("limit", *s.limit)
+	}
+	if s.orderID != nil {
+		r.setParam("orderId", *s.orderID)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*Allocation{}, err
+	}
+	res = make([]*Allocation, 0)
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return []*Allocation{}, err
+	}
+	return res, nil
+}
+
+// Allocation define a fill of a SOR order, the counterpart of a trade for orders
+// which are not routed
+type Allocation struct {
+	Symbol          string `json:"symbol"`
+	AllocationID    int64  `json:"allocationId"`
+	AllocationType  string `json:"allocationType"`
+	OrderID         int64  `json:"orderId"`
+	OrderListID     int64  `json:"orderListId"`
+	Price           string `json:"price"`
+	Quantity        string `json:"qty"`
+	QuoteQuantity   string `json:"quoteQty"`
+	Commission      string `json:"commission"`
+	CommissionAsset string `json:"commissionAsset"`
+	Time            int64  `json:"time"`
+	IsBuyer         bool   `json:"isBuyer"`
+	IsMaker         bool   `json:"isMaker"`
+	IsAllocator     bool   `json:"isAllocator"`
+}
@@ -0,0 +1,226 @@
+package binance
+
+import (
+	"github.com/Zamzam-Technology/go-binance/v2/common"
+)
+
+// Streams is the websocket surface of the package, implemented by DefaultStreams and FakeStreams
+type Streams interface {
+	WsPartialDepthServe(symbol string, levels string, handler WsPartialDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+	WsPartialDepthServe100Ms(symbol string, levels string, handler WsPartialDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+	WsCombinedPartialDepthServe(symbolLevels map[string]string, handler WsPartialDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+	WsDepthServe(symbol string, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+	WsDepthServe100Ms(symbol string, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+	WsCombinedKlineServe(symbolIntervalPair map[string]KlineInterval, handler WsKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+	WsKlineServe(symbol string, interval KlineInterval, handler WsKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+	WsAggTradeServe(symbol string, handler WsAggTradeHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+	WsCombinedAggTradeServe(symbols []string, handler WsAggTradeHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+	WsTradeServe(symbol string, handler WsTradeHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+	WsUserDataServe(listenKey string, handler WsHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+	WsUserDataEventServe(listenKey string, handler WsUserDataHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+	WsCombinedMarketStatServe(symbols []string, handler WsMarketStatHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+	WsMarketStatServe(symbol string, handler WsMarketStatHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+	WsAllMarketsStatServe(handler WsAllMarketsStatHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+	WsAllMiniMarketsStatServe(handler WsAllMiniMarketsStatServeHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+	WsBookTickerServe(symbol string, handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+	WsAllBookTickerServe(handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error)
+}
+
+// DefaultStreams serve the streams with the functions of the package
+type DefaultStreams struct{}
+
+var _ Streams = DefaultStreams{}
+
+// WsPartialDepthServe call the WsPartialDepthServe function of the package
+func (DefaultStreams) WsPartialDepthServe(symbol string, levels string, handler WsPartialDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return WsPartialDepthServe(symbol, levels, handler, errHandler)
+}
+
+// WsPartialDepthServe100Ms call the WsPartialDepthServe100Ms function of the package
+func (DefaultStreams) WsPartialDepthServe100Ms(symbol string, levels string, handler WsPartialDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return WsPartialDepthServe100Ms(symbol, levels, handler, errHandler)
+}
+
+// WsCombinedPartialDepthServe call the WsCombinedPartialDepthServe function of the package
+func (DefaultStreams) WsCombinedPartialDepthServe(symbolLevels map[string]string, handler WsPartialDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return WsCombinedPartialDepthServe(symbolLevels, handler, errHandler)
+}
+
+// WsDepthServe call the WsDepthServe function of the package
+func (DefaultStreams) WsDepthServe(symbol string, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return WsDepthServe(symbol, handler, errHandler)
+}
+
+// WsDepthServe100Ms call the WsDepthServe100Ms function of the package
+func (DefaultStreams) WsDepthServe100Ms(symbol string, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return WsDepthServe100Ms(symbol, handler, errHandler)
+}
+
+// WsCombinedKlineServe call the WsCombinedKlineServe function of the package
+func (DefaultStreams) WsCombinedKlineServe(symbolIntervalPair map[string]KlineInterval, handler WsKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return WsCombinedKlineServe(symbolIntervalPair, handler, errHandler)
+}
+
+// WsKlineServe call the WsKlineServe function of the package
+func (DefaultStreams) WsKlineServe(symbol string, interval KlineInterval, handler WsKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return WsKlineServe(symbol, interval, handler, errHandler)
+}
+
+// WsAggTradeServe call the WsAggTradeServe function of the package
+func (DefaultStreams) WsAggTradeServe(symbol string, handler WsAggTradeHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return WsAggTradeServe(symbol, handler, errHandler)
+}
+
+// WsCombinedAggTradeServe call the WsCombinedAggTradeServe function of the package
+func (DefaultStreams) WsCombinedAggTradeServe(symbols []string, handler WsAggTradeHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return WsCombinedAggTradeServe(symbols, handler, errHandler)
+}
+
+// WsTradeServe call the WsTradeServe function of the package
+func (DefaultStreams) WsTradeServe(symbol string, handler WsTradeHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return WsTradeServe(symbol, handler, errHandler)
+}
+
+// WsUserDataServe call the WsUserDataServe function of the package
+func (DefaultStreams) WsUserDataServe(listenKey string, handler WsHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return WsUserDataServe(listenKey, handler, errHandler)
+}
+
+// WsUserDataEventServe call the WsUserDataEventServe function of the package
+func (DefaultStreams) WsUserDataEventServe(listenKey string, handler WsUserDataHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return WsUserDataEventServe(listenKey, handler, errHandler)
+}
+
+// WsCombinedMarketStatServe call the WsCombinedMarketStatServe function of the package
+func (DefaultStreams) WsCombinedMarketStatServe(symbols []string, handler WsMarketStatHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return WsCombinedMarketStatServe(symbols, handler, errHandler)
+}
+
+// WsMarketStatServe call the WsMarketStatServe function of the package
+func (DefaultStreams) WsMarketStatServe(symbol string, handler WsMarketStatHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return WsMarketStatServe(symbol, handler, errHandler)
+}
+
+// WsAllMarketsStatServe call the WsAllMarketsStatServe function of the package
+func (DefaultStreams) WsAllMarketsStatServe(handler WsAllMarketsStatHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return WsAllMarketsStatServe(handler, errHandler)
+}
+
+// WsAllMiniMarketsStatServe call the WsAllMiniMarketsStatServe function of the package
+func (DefaultStreams) WsAllMiniMarketsStatServe(handler WsAllMiniMarketsStatServeHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return WsAllMiniMarketsStatServe(handler, errHandler)
+}
+
+// WsBookTickerServe call the WsBookTickerServe function of the package
+func (DefaultStreams) WsBookTickerServe(symbol string, handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return WsBookTickerServe(symbol, handler, errHandler)
+}
+
+// WsAllBookTickerServe call the WsAllBookTickerServe function of the package
+func (DefaultStreams) WsAllBookTickerServe(handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return WsAllBookTickerServe(handler, errHandler)
+}
+
+// FakeStreams implement Streams for tests: the handlers of the served streams are kept, and
+// the events given to Send are delivered to the handlers of a method, e.g.
+// Send("WsKlineServe", event).
+type FakeStreams struct {
+	*common.StreamStubs
+}
+
+var _ Streams = (*FakeStreams)(nil)
+
+// NewFakeStreams init fake streams
+func NewFakeStreams() *FakeStreams {
+	return &FakeStreams{StreamStubs: common.NewStreamStubs()}
+}
+
+// WsPartialDepthServe keep the handlers of the stream
+func (f *FakeStreams) WsPartialDepthServe(symbol string, levels string, handler WsPartialDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return f.Serve("WsPartialDepthServe", handler, errHandler)
+}
+
+// WsPartialDepthServe100Ms keep the handlers of the stream
+func (f *FakeStreams) WsPartialDepthServe100Ms(symbol string, levels string, handler WsPartialDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return f.Serve("WsPartialDepthServe100Ms", handler, errHandler)
+}
+
+// WsCombinedPartialDepthServe keep the handlers of the stream
+func (f *FakeStreams) WsCombinedPartialDepthServe(symbolLevels map[string]string, handler WsPartialDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return f.Serve("WsCombinedPartialDepthServe", handler, errHandler)
+}
+
+// WsDepthServe keep the handlers of the stream
+func (f *FakeStreams) WsDepthServe(symbol string, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return f.Serve("WsDepthServe", handler, errHandler)
+}
+
+// WsDepthServe100Ms keep the handlers of the stream
+func (f *FakeStreams) WsDepthServe100Ms(symbol string, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return f.Serve("WsDepthServe100Ms", handler, errHandler)
+}
+
+// WsCombinedKlineServe keep the handlers of the stream
+func (f *FakeStreams) WsCombinedKlineServe(symbolIntervalPair map[string]KlineInterval, handler WsKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return f.Serve("WsCombinedKlineServe", handler, errHandler)
+}
+
+// WsKlineServe keep the handlers of the stream
+func (f *FakeStreams) WsKlineServe(symbol string, interval KlineInterval, handler WsKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return f.Serve("WsKlineServe", handler, errHandler)
+}
+
+// WsAggTradeServe keep the handlers of the stream
+func (f *FakeStreams) WsAggTradeServe(symbol string, handler WsAggTradeHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return f.Serve("WsAggTradeServe", handler, errHandler)
+}
+
+// WsCombinedAggTradeServe keep the handlers of the stream
+func (f *FakeStreams) WsCombinedAggTradeServe(symbols []string, handler WsAggTradeHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return f.Serve("WsCombinedAggTradeServe", handler, errHandler)
+}
+
+// WsTradeServe keep the handlers of the stream
+func (f *FakeStreams) WsTradeServe(symbol string, handler WsTradeHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return f.Serve("WsTradeServe", handler, errHandler)
+}
+
+// WsUserDataServe keep the handlers of the stream
+func (f *FakeStreams) WsUserDataServe(listenKey string, handler WsHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return f.Serve("WsUserDataServe", handler, errHandler)
+}
+
+// WsUserDataEventServe keep the handlers of the stream
+func (f *FakeStreams) WsUserDataEventServe(listenKey string, handler WsUserDataHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return f.Serve("WsUserDataEventServe", handler, errHandler)
+}
+
+// WsCombinedMarketStatServe keep the handlers of the stream
+func (f *FakeStreams) WsCombinedMarketStatServe(symbols []string, handler WsMarketStatHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return f.Serve("WsCombinedMarketStatServe", handler, errHandler)
+}
+
+// WsMarketStatServe keep the handlers of the stream
+func (f *FakeStreams) WsMarketStatServe(symbol string, handler WsMarketStatHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return f.Serve("WsMarketStatServe", handler, errHandler)
+}
+
+// WsAllMarketsStatServe keep the handlers of the stream
+func (f *FakeStreams) WsAllMarketsStatServe(handler WsAllMarketsStatHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return f.Serve("WsAllMarketsStatServe", handler, errHandler)
+}
+
+// WsAllMiniMarketsStatServe keep the handlers of the stream
+func (f *FakeStreams) WsAllMiniMarketsStatServe(handler WsAllMiniMarketsStatServeHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return f.Serve("WsAllMiniMarketsStatServe", handler, errHandler)
+}
+
+// WsBookTickerServe keep the handlers of the stream
+func (f *FakeStreams) WsBookTickerServe(symbol string, handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return f.Serve("WsBookTickerServe", handler, errHandler)
+}
+
+// WsAllBookTickerServe keep the handlers of the stream
+func (f *FakeStreams) WsAllBookTickerServe(handler WsBookTickerHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	return f.Serve("WsAllBookTickerServe", handler, errHandler)
+}
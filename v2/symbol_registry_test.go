@@ -0,0 +1,165 @@
+package binance
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Zamzam-Technology/go-binance/v2/common"
+	"github.com/stretchr/testify/suite"
+)
+
+type symbolRegistryTestSuite struct {
+	baseTestSuite
+	responses [][]byte
+	calls     int
+}
+
+func TestSymbolRegistry(t *testing.T) {
+	suite.Run(t, new(symbolRegistryTestSuite))
+}
+
+func (s *symbolRegistryTestSuite) SetupTest() {
+	s.baseTestSuite.SetupTest()
+	s.calls = 0
+	s.client.Client.do = func(req *http.Request) (*http.Response, error) {
+		data := s.responses[s.calls]
+		s.calls++
+		return newHTTPResponse(data, http.StatusOK), nil
+	}
+}
+
+func (s *symbolRegistryTestSuite) TestLookup() {
+	s.responses = [][]byte{[]byte(`{"symbols": [
+		{"symbol": "ETHBTC", "status": "TRADING", "baseAsset": "ETH", "quoteAsset": "BTC"},
+		{"symbol": "LTCBTC", "status": "TRADING", "baseAsset": "LTC", "quoteAsset": "BTC"},
+		{"symbol": "ETHUSDT", "status": "TRADING", "baseAsset": "ETH", "quoteAsset": "USDT"}
+	]}`)}
+	registry := s.client.NewSymbolRegistry(0)
+	r := s.r()
+
+	symbol, err := registry.Symbol(newContext(), "LTCBTC")
+	r.NoError(err)
+	r.Equal("LTC", symbol.BaseAsset)
+
+	symbol, err = registry.SymbolByAssets(newContext(), "ETH", "USDT")
+	r.NoError(err)
+	r.Equal("ETHUSDT", symbol.Symbol)
+
+	symbols, err := registry.SymbolsByBaseAsset(newContext(), "ETH")
+	r.NoError(err)
+	r.Len(symbols, 2)
+
+	symbols, err = registry.SymbolsByQuoteAsset(newContext(), "BTC")
+	r.NoError(err)
+	r.Len(symbols, 2)
+
+	_, err = registry.Symbol(newContext(), "BNBBTC")
+	r.Equal(common.ErrSymbolNotFound, err)
+	r.Equal(1, s.calls, "exchange info loaded once")
+}
+
+func (s *symbolRegistryTestSuite) TestRefreshEvents() {
+	s.responses = [][]byte{
+		[]byte(`{"symbols": [
+			{"symbol": "ETHBTC", "status": "TRADING", "filters": [{"filterType": "PRICE_FILTER", "tickSize": "0.00000100"}]},
+			{"symbol": "LTCBTC", "status": "TRADING"},
+			{"symbol": "XRPBTC", "status": "TRADING"},
+			{"symbol": "BCCBTC", "status": "TRADING"}
+		]}`),
+		[]byte(`{"symbols": [
+			{"symbol": "ETHBTC", "status": "TRADING", "filters": [{"filterType": "PRICE_FILTER", "tickSize": "0.00000010"}]},
+			{"symbol": "LTCBTC", "status": "HALT"},
+			{"symbol": "XRPBTC", "status": "TRADING"},
+			{"symbol": "BNBBTC", "status": "PRE_TRADING"}
+		]}`),
+		[]byte(`{"symbols": [
+			{"symbol": "ETHBTC", "status": "TRADING", "filters": [{"filterType": "PRICE_FILTER", "tickSize": "0.00000010"}]},
+			{"symbol": "LTCBTC", "status": "TRADING"},
+			{"symbol": "XRPBTC", "status": "TRADING"},
+			{"symbol": "BNBBTC", "status": "TRADING"}
+		]}`),
+	}
+	now := time.Unix(1600000000, 0)
+	var events []*SymbolEvent
+	registry := s.client.NewSymbolRegistry(time.Minute).OnEvent(func(event *SymbolEvent) {
+		events = append(events, event)
+	})
+	registry.now = func() time.Time { return now }
+	r := s.r()
+
+	_, err := registry.Symbol(newContext(), "ETHBTC")
+	r.NoError(err)
+	r.Empty(events, "no event on first load")
+
+	now = now.Add(30 * time.Second)
+	_, err = registry.Symbol(newContext(), "ETHBTC")
+	r.NoError(err)
+	r.Equal(1, s.calls, "cached until ttl")
+
+	now = now.Add(31 * time.Second)
+	symbol, err := registry.Symbol(newContext(), "ETHBTC")
+	r.NoError(err)
+	r.Equal("0.00000010", symbol.PriceFilter().TickSize)
+	r.Len(events, 4)
+	r.Equal(SymbolEventTypeDelisted, events[0].Type)
+	r.Equal("BCCBTC", events[0].Symbol)
+	r.Nil(events[0].Current)
+	r.Equal(SymbolEventTypeListed, events[1].Type)
+	r.Equal("BNBBTC", events[1].Symbol)
+	r.Nil(events[1].Previous)
+	r.Equal(SymbolEventTypeFiltersChanged, events[2].Type)
+	r.Equal("ETHBTC", events[2].Symbol)
+	r.Equal("0.00000100", events[2].Previous.PriceFilter().TickSize)
+	r.Equal(SymbolEventTypeHalted, events[3].Type)
+	r.Equal("LTCBTC", events[3].Symbol)
+
+	events = nil
+	r.NoError(registry.Refresh(newContext()))
+	r.Len(events, 2)
+	r.Equal(SymbolEventTypeStatusChanged, events[0].Type)
+	r.Equal("BNBBTC", events[0].Symbol)
+	r.Equal(SymbolEventTypeStatusChanged, events[1].Type)
+	r.Equal("HALT", events[1].Previous.Status)
+	r.Equal("TRADING", events[1].Current.Status)
+}
+
+func (s *symbolRegistryTestSuite) TestHandlerUsesRegistry() {
+	s.responses = [][]byte{
+		[]byte(`{"symbols": [{"symbol": "ETHBTC", "status": "TRADING"}]}`),
+		[]byte(`{"symbols": [{"symbol": "ETHBTC", "status": "HALT"}]}`),
+		[]byte(`{"symbols": [{"symbol": "ETHBTC", "status": "TRADING"}]}`),
+	}
+	var registry *SymbolRegistry
+	var statuses []string
+	registry = s.client.NewSymbolRegistry(0).OnEvent(func(event *SymbolEvent) {
+		symbol, err := registry.Symbol(newContext(), event.Symbol)
+		s.r().NoError(err)
+		statuses = append(statuses, symbol.Status)
+		if len(statuses) == 1 {
+			s.r().NoError(registry.Refresh(newContext()))
+		}
+	})
+	r := s.r()
+	r.NoError(registry.Refresh(newContext()))
+	r.NoError(registry.Refresh(newContext()))
+	r.Equal([]string{"HALT", "TRADING"}, statuses)
+	r.Equal(3, s.calls)
+}
+
+func (s *symbolRegistryTestSuite) TestOnEventDuringRefresh() {
+	s.responses = [][]byte{
+		[]byte(`{"symbols": [{"symbol": "ETHBTC", "status": "TRADING"}]}`),
+		[]byte(`{"symbols": [{"symbol": "ETHBTC", "status": "HALT"}]}`),
+	}
+	registry := s.client.NewSymbolRegistry(0)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		registry.OnEvent(func(event *SymbolEvent) {})
+	}()
+	r := s.r()
+	r.NoError(registry.Refresh(newContext()))
+	r.NoError(registry.Refresh(newContext()))
+	<-done
+}
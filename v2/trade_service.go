@@ -77,6 +77,92 @@ func (s *ListTradesService) Do(ctx context.Context, opts ...RequestOption) (res
 	return res, nil
 }
 
+// ListPreventedMatchesService list the orders expired because of self-trade prevention
+type ListPreventedMatchesService struct {
+	c                    *Client
+	symbol               string
+	preventedMatchID     *int64
+	orderID              *int64
+	fromPreventedMatchID *int64
+	limit                *int
+}
+
+// Symbol set symbol
+func (s *ListPreventedMatchesService) Symbol(symbol string) *ListPreventedMatchesService {
+	s.symbol = symbol
+	return s
+}
+
+// PreventedMatchID set preventedMatchId
+func (s *ListPreventedMatchesService) PreventedMatchID(preventedMatchID int64) *ListPreventedMatchesService {
+	s.preventedMatchID = &preventedMatchID
+	return s
+}
+
+// OrderID set orderId
+func (s *ListPreventedMatchesService) OrderID(orderID int64) *ListPreventedMatchesService {
+	s.orderID = &orderID
+	return s
+}
+
+// FromPreventedMatchID set fromPreventedMatchId, used with orderId
+func (s *ListPreventedMatchesService) FromPreventedMatchID(fromPreventedMatchID int64) *ListPreventedMatchesService {
+	s.fromPreventedMatchID = &fromPreventedMatchID
+	return s
+}
+
+// Limit set limit
+func (s *ListPreventedMatchesService) Limit(limit int) *ListPreventedMatchesService {
+	s.limit = &limit
+	return s
+}
+
+// Do send request
+func (s *ListPreventedMatchesService) Do(ctx context.Context, opts ...RequestOption) (res []*PreventedMatch, err error) {
+	r := &request{
+		method:   "GET",
+		endpoint: "/api/v3/myPreventedMatches",
+		secType:  secTypeSigned,
+	}
+	r.setParam("symbol", s.symbol)
+	if s.preventedMatchID != nil {
+		r.setParam("preventedMatchId", *s.preventedMatchID)
+	}
+	if s.orderID != nil {
+		r.setParam("orderId", *s.orderID)
+	}
+	if s.fromPreventedMatchID != nil {
+		r.setParam("fromPreventedMatchId", *s.fromPreventedMatchID)
+	}
+	if s.limit != nil {
+		r.setParam("limit", *s.limit)
+	}
+	data, err := s.c.callAPI(ctx, r, opts...)
+	if err != nil {
+		return []*PreventedMatch{}, err
+	}
+	res = make([]*PreventedMatch, 0)
+	err = json.Unmarshal(data, &res)
+	if err != nil {
+		return []*PreventedMatch{}, err
+	}
+	return res, nil
+}
+
+// PreventedMatch define a match prevented by self-trade prevention
+type PreventedMatch struct {
+	Symbol                  string                  `json:"symbol"`
+	PreventedMatchID        int64                   `json:"preventedMatchId"`
+	TakerOrderID            int64                   `json:"takerOrderId"`
+	MakerSymbol             string                  `json:"makerSymbol"`
+	MakerOrderID            int64                   `json:"makerOrderId"`
+	TradeGroupID            int64                   `json:"tradeGroupId"`
+	SelfTradePreventionMode SelfTradePreventionMode `json:"selfTradePreventionMode"`
+	Price                   string                  `json:"price"`
+	MakerPreventedQuantity  string                  `json:"makerPreventedQuantity"`
+	TransactTime            int64                   `json:"transactTime"`
+}
+
 // HistoricalTradesService trades
 type HistoricalTradesService struct {
 	c      *Client
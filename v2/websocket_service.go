@@ -1,10 +1,11 @@
 package binance
 
 import (
-	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
+
+	"github.com/Zamzam-Technology/go-binance/v2/common"
 )
 
 // Endpoints
@@ -20,10 +21,18 @@ var (
 	WebsocketTimeout = time.Second * 60
 	// WebsocketKeepalive enables sending ping/pong messages to check the connection stability
 	WebsocketKeepalive = false
+	// WebsocketWatchdog enables the stale-stream watchdog and the per-stream metrics returned by WsStreamsStats
+	WebsocketWatchdog *common.WsWatchdog
+	// WebsocketBaseURL replace the base endpoint of the WS streams when set, e.g. "ws://127.0.0.1:8080"
+	// for a local server, which then serves the streams under /ws and /stream
+	WebsocketBaseURL string
 )
 
 // getWsEndpoint return the base endpoint of the WS according the UseTestnet flag
 func getWsEndpoint() string {
+	if WebsocketBaseURL != "" {
+		return WebsocketBaseURL + "/ws"
+	}
 	if UseTestnet {
 		return baseWsTestnetURL
 	}
@@ -32,6 +41,9 @@ func getWsEndpoint() string {
 
 // getCombinedEndpoint return the base endpoint of the combined stream according the UseTestnet flag
 func getCombinedEndpoint() string {
+	if WebsocketBaseURL != "" {
+		return WebsocketBaseURL + "/stream?streams="
+	}
 	if UseTestnet {
 		return baseCombinedTestnetURL
 	}
@@ -65,32 +77,13 @@ func WsPartialDepthServe100Ms(symbol string, levels string, handler WsPartialDep
 func wsPartialDepthServe(endpoint string, symbol string, handler WsPartialDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
-		j, err := newJSON(message)
+		event := new(WsPartialDepthEvent)
+		err := common.UnmarshalWsMessage(message, event)
 		if err != nil {
 			errHandler(err)
 			return
 		}
-		event := new(WsPartialDepthEvent)
 		event.Symbol = symbol
-		event.LastUpdateID = j.Get("lastUpdateId").MustInt64()
-		bidsLen := len(j.Get("bids").MustArray())
-		event.Bids = make([]Bid, bidsLen)
-		for i := 0; i < bidsLen; i++ {
-			item := j.Get("bids").GetIndex(i)
-			event.Bids[i] = Bid{
-				Price:    item.GetIndex(0).MustString(),
-				Quantity: item.GetIndex(1).MustString(),
-			}
-		}
-		asksLen := len(j.Get("asks").MustArray())
-		event.Asks = make([]Ask, asksLen)
-		for i := 0; i < asksLen; i++ {
-			item := j.Get("asks").GetIndex(i)
-			event.Asks[i] = Ask{
-				Price:    item.GetIndex(0).MustString(),
-				Quantity: item.GetIndex(1).MustString(),
-			}
-		}
 		handler(event)
 	}
 	return wsServe(cfg, wsHandler, errHandler)
@@ -105,36 +98,13 @@ func WsCombinedPartialDepthServe(symbolLevels map[string]string, handler WsParti
 	endpoint = endpoint[:len(endpoint)-1]
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
-		j, err := newJSON(message)
+		event := new(WsPartialDepthEvent)
+		stream, err := common.UnmarshalCombinedWsMessage(message, event)
 		if err != nil {
 			errHandler(err)
 			return
 		}
-		event := new(WsPartialDepthEvent)
-		stream := j.Get("stream").MustString()
-		symbol := strings.Split(stream, "@")[0]
-		event.Symbol = strings.ToUpper(symbol)
-		data := j.Get("data").MustMap()
-		event.LastUpdateID, _ = data["lastUpdateId"].(json.Number).Int64()
-		bidsLen := len(data["bids"].([]interface{}))
-		event.Bids = make([]Bid, bidsLen)
-		for i := 0; i < bidsLen; i++ {
-			item := data["bids"].([]interface{})[i].([]interface{})
-			event.Bids[i] = Bid{
-				Price:    item[0].(string),
-				Quantity: item[1].(string),
-			}
-		}
-		asksLen := len(data["asks"].([]interface{}))
-		event.Asks = make([]Ask, asksLen)
-		for i := 0; i < asksLen; i++ {
-
-			item := data["asks"].([]interface{})[i].([]interface{})
-			event.Asks[i] = Ask{
-				Price:    item[0].(string),
-				Quantity: item[1].(string),
-			}
-		}
+		event.Symbol = common.StreamSymbol(stream)
 		handler(event)
 	}
 	return wsServe(cfg, wsHandler, errHandler)
@@ -159,35 +129,12 @@ func WsDepthServe100Ms(symbol string, handler WsDepthHandler, errHandler ErrHand
 func wsDepthServe(endpoint string, handler WsDepthHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
-		j, err := newJSON(message)
+		event := new(WsDepthEvent)
+		err := common.UnmarshalWsMessage(message, event)
 		if err != nil {
 			errHandler(err)
 			return
 		}
-		event := new(WsDepthEvent)
-		event.Event = j.Get("e").MustString()
-		event.Time = j.Get("E").MustInt64()
-		event.Symbol = j.Get("s").MustString()
-		event.LastUpdateID = j.Get("u").MustInt64()
-		event.FirstUpdateID = j.Get("U").MustInt64()
-		bidsLen := len(j.Get("b").MustArray())
-		event.Bids = make([]Bid, bidsLen)
-		for i := 0; i < bidsLen; i++ {
-			item := j.Get("b").GetIndex(i)
-			event.Bids[i] = Bid{
-				Price:    item.GetIndex(0).MustString(),
-				Quantity: item.GetIndex(1).MustString(),
-			}
-		}
-		asksLen := len(j.Get("a").MustArray())
-		event.Asks = make([]Ask, asksLen)
-		for i := 0; i < asksLen; i++ {
-			item := j.Get("a").GetIndex(i)
-			event.Asks[i] = Ask{
-				Price:    item.GetIndex(0).MustString(),
-				Quantity: item.GetIndex(1).MustString(),
-			}
-		}
 		handler(event)
 	}
 	return wsServe(cfg, wsHandler, errHandler)
@@ -208,7 +155,7 @@ type WsDepthEvent struct {
 type WsKlineHandler func(event *WsKlineEvent)
 
 // WsCombinedKlineServe is similar to WsKlineServe, but it handles multiple symbols with it interval
-func WsCombinedKlineServe(symbolIntervalPair map[string]string, handler WsKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+func WsCombinedKlineServe(symbolIntervalPair map[string]KlineInterval, handler WsKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
 	endpoint := getCombinedEndpoint()
 	for symbol, interval := range symbolIntervalPair {
 		endpoint += fmt.Sprintf("%s@kline_%s", strings.ToLower(symbol), interval) + "/"
@@ -216,26 +163,13 @@ func WsCombinedKlineServe(symbolIntervalPair map[string]string, handler WsKlineH
 	endpoint = endpoint[:len(endpoint)-1]
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
-		j, err := newJSON(message)
-		if err != nil {
-			errHandler(err)
-			return
-		}
-
-		stream := j.Get("stream").MustString()
-		data := j.Get("data").MustMap()
-
-		symbol := strings.Split(stream, "@")[0]
-
-		jsonData, _ := json.Marshal(data)
-
 		event := new(WsKlineEvent)
-		err = json.Unmarshal(jsonData, event)
+		stream, err := common.UnmarshalCombinedWsMessage(message, event)
 		if err != nil {
 			errHandler(err)
 			return
 		}
-		event.Symbol = strings.ToUpper(symbol)
+		event.Symbol = common.StreamSymbol(stream)
 
 		handler(event)
 	}
@@ -243,12 +177,12 @@ func WsCombinedKlineServe(symbolIntervalPair map[string]string, handler WsKlineH
 }
 
 // WsKlineServe serve websocket kline handler with a symbol and interval like 15m, 30s
-func WsKlineServe(symbol string, interval string, handler WsKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+func WsKlineServe(symbol string, interval KlineInterval, handler WsKlineHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
 	endpoint := fmt.Sprintf("%s/%s@kline_%s", getWsEndpoint(), strings.ToLower(symbol), interval)
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsKlineEvent)
-		err := json.Unmarshal(message, event)
+		err := common.UnmarshalWsMessage(message, event)
 		if err != nil {
 			errHandler(err)
 			return
@@ -295,7 +229,7 @@ func WsAggTradeServe(symbol string, handler WsAggTradeHandler, errHandler ErrHan
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsAggTradeEvent)
-		err := json.Unmarshal(message, event)
+		err := common.UnmarshalWsMessage(message, event)
 		if err != nil {
 			errHandler(err)
 			return
@@ -314,27 +248,13 @@ func WsCombinedAggTradeServe(symbols []string, handler WsAggTradeHandler, errHan
 	endpoint = endpoint[:len(endpoint)-1]
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
-		j, err := newJSON(message)
-		if err != nil {
-			errHandler(err)
-			return
-		}
-
-		stream := j.Get("stream").MustString()
-		data := j.Get("data").MustMap()
-
-		symbol := strings.Split(stream, "@")[0]
-
-		jsonData, _ := json.Marshal(data)
-
 		event := new(WsAggTradeEvent)
-		err = json.Unmarshal(jsonData, event)
+		stream, err := common.UnmarshalCombinedWsMessage(message, event)
 		if err != nil {
 			errHandler(err)
 			return
 		}
-
-		event.Symbol = strings.ToUpper(symbol)
+		event.Symbol = common.StreamSymbol(stream)
 
 		handler(event)
 	}
@@ -365,7 +285,7 @@ func WsTradeServe(symbol string, handler WsTradeHandler, errHandler ErrHandler)
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsTradeEvent)
-		err := json.Unmarshal(message, event)
+		err := common.UnmarshalWsMessage(message, event)
 		if err != nil {
 			errHandler(err)
 			return
@@ -397,6 +317,141 @@ func WsUserDataServe(listenKey string, handler WsHandler, errHandler ErrHandler)
 	return wsServe(cfg, handler, errHandler)
 }
 
+// WsUserDataEvent define user data event, only the field matching Event is set
+type WsUserDataEvent struct {
+	Event         UserDataEventType
+	Time          int64
+	AccountUpdate *WsAccountUpdate
+	BalanceUpdate *WsBalanceUpdate
+	OrderUpdate   *WsOrderUpdate
+	OCOUpdate     *WsOCOUpdate
+}
+
+// WsAccountUpdate define the balances changed by an account update
+type WsAccountUpdate struct {
+	LastUpdateTime int64              `json:"u"`
+	Balances       []WsAccountBalance `json:"B"`
+}
+
+// WsAccountBalance define the balance of an asset
+type WsAccountBalance struct {
+	Asset  string `json:"a"`
+	Free   string `json:"f"`
+	Locked string `json:"l"`
+}
+
+// WsBalanceUpdate define a deposit, withdrawal or transfer
+type WsBalanceUpdate struct {
+	Asset           string `json:"a"`
+	Change          string `json:"d"`
+	TransactionTime int64  `json:"T"`
+}
+
+// WsOrderUpdate define an execution report of an order
+type WsOrderUpdate struct {
+	Symbol                  string                  `json:"s"`
+	ClientOrderID           string                  `json:"c"`
+	Side                    SideType                `json:"S"`
+	Type                    OrderType               `json:"o"`
+	TimeInForce             TimeInForceType         `json:"f"`
+	Quantity                string                  `json:"q"`
+	Price                   string                  `json:"p"`
+	StopPrice               string                  `json:"P"`
+	IcebergQuantity         string                  `json:"F"`
+	OrderListID             int64                   `json:"g"`
+	OrigClientOrderID       string                  `json:"C"`
+	ExecutionType           ExecutionType           `json:"x"`
+	Status                  OrderStatusType         `json:"X"`
+	RejectReason            string                  `json:"r"`
+	ID                      int64                   `json:"i"`
+	LastFilledQuantity      string                  `json:"l"`
+	FilledQuantity          string                  `json:"z"`
+	LastFilledPrice         string                  `json:"L"`
+	Commission              string                  `json:"n"`
+	CommissionAsset         string                  `json:"N"`
+	TransactionTime         int64                   `json:"T"`
+	TradeID                 int64                   `json:"t"`
+	Placeholder             int64                   `json:"I"` // add this field to avoid case insensitive unmarshaling
+	IsWorking               bool                    `json:"w"`
+	IsMaker                 bool                    `json:"m"`
+	Placeholder2            bool                    `json:"M"` // add this field to avoid case insensitive unmarshaling
+	CreateTime              int64                   `json:"O"`
+	FilledQuoteQuantity     string                  `json:"Z"`
+	LastQuoteQuantity       string                  `json:"Y"`
+	QuoteOrderQuantity      string                  `json:"Q"`
+	WorkingTime             int64                   `json:"W"`
+	SelfTradePreventionMode SelfTradePreventionMode `json:"V"`
+	PreventedMatchID        int64                   `json:"v"`
+}
+
+// WsOCOUpdate define the status of an order list
+type WsOCOUpdate struct {
+	Symbol            string       `json:"s"`
+	OrderListID       int64        `json:"g"`
+	ContingencyType   string       `json:"c"`
+	ListStatusType    string       `json:"l"`
+	ListOrderStatus   string       `json:"L"`
+	RejectReason      string       `json:"r"`
+	ListClientOrderID string       `json:"C"`
+	TransactionTime   int64        `json:"T"`
+	Orders            []WsOCOOrder `json:"O"`
+}
+
+// WsOCOOrder define an order of an order list
+type WsOCOOrder struct {
+	Symbol        string `json:"s"`
+	OrderID       int64  `json:"i"`
+	ClientOrderID string `json:"c"`
+}
+
+// ParseWsUserDataEvent decode a message of the user data stream. An error frame is returned
+// as an *common.APIError and a malformed message as a *common.WsDecodeError.
+func ParseWsUserDataEvent(message []byte) (*WsUserDataEvent, error) {
+	var header struct {
+		Event UserDataEventType `json:"e"`
+		Time  int64             `json:"E"`
+	}
+	if err := common.UnmarshalWsMessage(message, &header); err != nil {
+		return nil, err
+	}
+	event := &WsUserDataEvent{Event: header.Event, Time: header.Time}
+	var err error
+	switch header.Event {
+	case UserDataEventTypeOutboundAccountPosition:
+		event.AccountUpdate = new(WsAccountUpdate)
+		err = common.UnmarshalWsMessage(message, event.AccountUpdate)
+	case UserDataEventTypeBalanceUpdate:
+		event.BalanceUpdate = new(WsBalanceUpdate)
+		err = common.UnmarshalWsMessage(message, event.BalanceUpdate)
+	case UserDataEventTypeExecutionReport:
+		event.OrderUpdate = new(WsOrderUpdate)
+		err = common.UnmarshalWsMessage(message, event.OrderUpdate)
+	case UserDataEventTypeListStatus:
+		event.OCOUpdate = new(WsOCOUpdate)
+		err = common.UnmarshalWsMessage(message, event.OCOUpdate)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// WsUserDataHandler handle typed user data events
+type WsUserDataHandler func(event *WsUserDataEvent)
+
+// WsUserDataEventServe is similar to WsUserDataServe, but it decodes the events
+func WsUserDataEventServe(listenKey string, handler WsUserDataHandler, errHandler ErrHandler) (doneC, stopC chan struct{}, err error) {
+	wsHandler := func(message []byte) {
+		event, err := ParseWsUserDataEvent(message)
+		if err != nil {
+			errHandler(err)
+			return
+		}
+		handler(event)
+	}
+	return WsUserDataServe(listenKey, wsHandler, errHandler)
+}
+
 // WsMarketStatHandler handle websocket that push single market statistics for 24hr
 type WsMarketStatHandler func(event *WsMarketStatEvent)
 
@@ -410,27 +465,13 @@ func WsCombinedMarketStatServe(symbols []string, handler WsMarketStatHandler, er
 	cfg := newWsConfig(endpoint)
 
 	wsHandler := func(message []byte) {
-		j, err := newJSON(message)
-		if err != nil {
-			errHandler(err)
-			return
-		}
-
-		stream := j.Get("stream").MustString()
-		data := j.Get("data").MustMap()
-
-		symbol := strings.Split(stream, "@")[0]
-
-		jsonData, _ := json.Marshal(data)
-
 		event := new(WsMarketStatEvent)
-		err = json.Unmarshal(jsonData, event)
+		stream, err := common.UnmarshalCombinedWsMessage(message, event)
 		if err != nil {
 			errHandler(err)
 			return
 		}
-
-		event.Symbol = strings.ToUpper(symbol)
+		event.Symbol = common.StreamSymbol(stream)
 
 		handler(event)
 	}
@@ -443,7 +484,7 @@ func WsMarketStatServe(symbol string, handler WsMarketStatHandler, errHandler Er
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		var event WsMarketStatEvent
-		err := json.Unmarshal(message, &event)
+		err := common.UnmarshalWsMessage(message, &event)
 		if err != nil {
 			errHandler(err)
 			return
@@ -462,7 +503,7 @@ func WsAllMarketsStatServe(handler WsAllMarketsStatHandler, errHandler ErrHandle
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		var event WsAllMarketsStatEvent
-		err := json.Unmarshal(message, &event)
+		err := common.UnmarshalWsMessage(message, &event)
 		if err != nil {
 			errHandler(err)
 			return
@@ -511,7 +552,7 @@ func WsAllMiniMarketsStatServe(handler WsAllMiniMarketsStatServeHandler, errHand
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		var event WsAllMiniMarketsStatEvent
-		err := json.Unmarshal(message, &event)
+		err := common.UnmarshalWsMessage(message, &event)
 		if err != nil {
 			errHandler(err)
 			return
@@ -556,7 +597,7 @@ func WsBookTickerServe(symbol string, handler WsBookTickerHandler, errHandler Er
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsBookTickerEvent)
-		err := json.Unmarshal(message, &event)
+		err := common.UnmarshalWsMessage(message, &event)
 		if err != nil {
 			errHandler(err)
 			return
@@ -572,7 +613,7 @@ func WsAllBookTickerServe(handler WsBookTickerHandler, errHandler ErrHandler) (d
 	cfg := newWsConfig(endpoint)
 	wsHandler := func(message []byte) {
 		event := new(WsBookTickerEvent)
-		err := json.Unmarshal(message, &event)
+		err := common.UnmarshalWsMessage(message, &event)
 		if err != nil {
 			errHandler(err)
 			return
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/Zamzam-Technology/go-binance/v2/common"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -220,6 +221,46 @@ func (s *websocketServiceTestSuite) assertWsPartialDepthEventEqual(e, a *WsParti
 	}
 }
 
+func (s *websocketServiceTestSuite) TestCombinedPartialDepthServeMalformed() {
+	data := []byte(`{"stream":"ethusdt@depth5","data":{"code":-1,"bids":"oops"}}`)
+	s.mockWsServe(data, nil)
+	defer s.assertWsServe()
+
+	handled := false
+	reported := false
+	doneC, stopC, err := WsCombinedPartialDepthServe(map[string]string{"ETHUSDT": "5"}, func(event *WsPartialDepthEvent) {
+		handled = true
+	}, func(err error) {
+		reported = true
+		s.r().True(common.IsWsDecodeError(err))
+		s.r().Equal(data, err.(*common.WsDecodeError).Payload)
+	})
+	s.r().NoError(err)
+	s.r().False(handled)
+	s.r().True(reported)
+	stopC <- struct{}{}
+	<-doneC
+}
+
+func (s *websocketServiceTestSuite) TestServeErrorFrame() {
+	data := []byte(`{"code":2,"msg":"Invalid request"}`)
+	s.mockWsServe(data, nil)
+	defer s.assertWsServe()
+
+	handled := false
+	var reported error
+	doneC, stopC, err := WsDepthServe("ETHBTC", func(event *WsDepthEvent) {
+		handled = true
+	}, func(err error) {
+		reported = err
+	})
+	s.r().NoError(err)
+	s.r().False(handled)
+	s.r().Equal(&common.APIError{Code: 2, Message: "Invalid request"}, reported)
+	stopC <- struct{}{}
+	<-doneC
+}
+
 func (s *websocketServiceTestSuite) TestDepthServe() {
 	data := []byte(`{
         "e": "depthUpdate",
@@ -540,7 +581,7 @@ func (s *websocketServiceTestSuite) TestWsCombinedKlineServe() {
 	s.mockWsServe(data, errors.New(fakeErrMsg))
 	defer s.assertWsServe()
 
-	input := map[string]string{
+	input := map[string]KlineInterval{
 		"ETHBTC": "1m",
 	}
 	doneC, stopC, err := WsCombinedKlineServe(input, func(event *WsKlineEvent) {
@@ -1181,3 +1222,80 @@ func (s *websocketServiceTestSuite) assertWsBookTickerEvent(e, a *WsBookTickerEv
 	r.Equal(e.BestAskPrice, a.BestAskPrice, "BestAskPrice")
 	r.Equal(e.BestAskQty, a.BestAskQty, "BestAskQty")
 }
+
+func (s *websocketServiceTestSuite) TestParseWsUserDataEvent() {
+	event, err := ParseWsUserDataEvent([]byte(`{
+        "e": "executionReport",
+        "E": 1499405658658,
+        "s": "ETHBTC",
+        "c": "mUvoqJxFIILMdfAW5iGSOW",
+        "S": "BUY",
+        "o": "LIMIT",
+        "f": "GTC",
+        "q": "1.00000000",
+        "p": "0.10264410",
+        "P": "0.00000000",
+        "F": "0.00000000",
+        "g": -1,
+        "C": "",
+        "x": "TRADE",
+        "X": "PARTIALLY_FILLED",
+        "r": "NONE",
+        "i": 4293153,
+        "l": "0.40000000",
+        "z": "0.40000000",
+        "L": "0.10264400",
+        "n": "0.00004000",
+        "N": "ETH",
+        "T": 1499405658657,
+        "t": 12,
+        "I": 8641984,
+        "w": false,
+        "m": true,
+        "M": false,
+        "O": 1499405658657,
+        "Z": "0.04105760",
+        "Y": "0.04105760",
+        "Q": "0.00000000",
+        "W": 1499405658657,
+        "V": "NONE"
+    }`))
+	r := s.r()
+	r.NoError(err)
+	r.Equal(UserDataEventTypeExecutionReport, event.Event)
+	r.Equal(int64(1499405658658), event.Time)
+	r.Nil(event.AccountUpdate)
+	update := event.OrderUpdate
+	r.NotNil(update)
+	r.Equal("mUvoqJxFIILMdfAW5iGSOW", update.ClientOrderID)
+	r.Equal("", update.OrigClientOrderID)
+	r.Equal(ExecutionTypeTrade, update.ExecutionType)
+	r.Equal(OrderStatusTypePartiallyFilled, update.Status)
+	r.Equal(int64(4293153), update.ID)
+	r.Equal("0.40000000", update.LastFilledQuantity)
+	r.Equal("0.10264400", update.LastFilledPrice)
+	r.Equal("0.00004000", update.Commission)
+	r.Equal(int64(12), update.TradeID)
+	r.True(update.IsMaker)
+
+	event, err = ParseWsUserDataEvent([]byte(`{
+        "e": "outboundAccountPosition",
+        "E": 1564034571105,
+        "u": 1564034571073,
+        "B": [{"a": "ETH", "f": "10000.000000", "l": "0.000000"}]
+    }`))
+	r.NoError(err)
+	r.Equal(&WsAccountUpdate{
+		LastUpdateTime: 1564034571073,
+		Balances:       []WsAccountBalance{{Asset: "ETH", Free: "10000.000000", Locked: "0.000000"}},
+	}, event.AccountUpdate)
+
+	event, err = ParseWsUserDataEvent([]byte(`{"e": "balanceUpdate", "E": 1573200697110, "a": "BTC", "d": "100.00000000", "T": 1573200697068}`))
+	r.NoError(err)
+	r.Equal(&WsBalanceUpdate{Asset: "BTC", Change: "100.00000000", TransactionTime: 1573200697068}, event.BalanceUpdate)
+
+	_, err = ParseWsUserDataEvent([]byte(`{"e": "executionReport", "E": 1499405658658, "p": 1}`))
+	r.True(common.IsWsDecodeError(err))
+	_, err = ParseWsUserDataEvent([]byte(`{"code": -1003, "msg": "Too many requests."}`))
+	r.True(common.IsAPIError(err))
+}
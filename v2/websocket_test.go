@@ -0,0 +1,83 @@
+package binance
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Zamzam-Technology/go-binance/v2/common"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWsServeWatchdogReconnect(t *testing.T) {
+	assert := assert.New(t)
+
+	var connections int32
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer c.Close()
+		atomic.AddInt32(&connections, 1)
+		// send a single message, then go silent while keeping the connection open
+		c.WriteMessage(websocket.TextMessage, []byte(`{"e":"depthUpdate","E":1}`))
+		for {
+			if _, _, err := c.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	defer server.Close()
+
+	staleC := make(chan common.WsStreamStats, 10)
+	WebsocketWatchdog = &common.WsWatchdog{
+		ExpectedInterval: func(stream string) time.Duration {
+			return 20 * time.Millisecond
+		},
+		StaleHandler: func(stats common.WsStreamStats) {
+			staleC <- stats
+		},
+		Reconnect: true,
+	}
+	defer func() {
+		WebsocketWatchdog = nil
+	}()
+
+	var messages int32
+	endpoint := "ws" + strings.TrimPrefix(server.URL, "http") + "/ws/btcusdt@depth"
+	doneC, stopC, err := wsServe(newWsConfig(endpoint), func(message []byte) {
+		atomic.AddInt32(&messages, 1)
+	}, func(err error) {
+		t.Errorf("unexpected error: %v", err)
+	})
+	assert.NoError(err)
+
+	select {
+	case stats := <-staleC:
+		assert.Equal("btcusdt@depth", stats.Stream)
+		assert.Equal(int64(1), stats.MessageCount)
+	case <-time.After(5 * time.Second):
+		t.Fatal("stale stream not reported")
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for atomic.LoadInt32(&messages) < 2 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.True(atomic.LoadInt32(&connections) >= 2, "connections")
+	assert.True(atomic.LoadInt32(&messages) >= 2, "messages")
+
+	stats := WsStreamsStats()
+	assert.Len(stats, 1)
+	assert.True(stats[0].ReconnectCount >= 1)
+
+	close(stopC)
+	<-doneC
+	assert.Empty(WsStreamsStats())
+}